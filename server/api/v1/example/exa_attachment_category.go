@@ -1,10 +1,10 @@
 package example
 
 import (
-	"server/global"
 	common "server/model/common/request"
 	"server/model/common/response"
 	"server/model/example"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -19,9 +19,9 @@ type AttachmentCategoryApi struct{}
 // @Success   200   {object}  response.Response{data=example.ExaAttachmentCategory,msg=string}  "媒体库分类列表"
 // @Router    /attachmentCategory/getCategoryList [get]
 func (a *AttachmentCategoryApi) GetCategoryList(c *gin.Context) {
-	res, err := attachmentCategoryService.GetCategoryList()
+	res, err := attachmentCategoryService.GetCategoryList(c.Request.Context())
 	if err != nil {
-		global.GVA_LOG.Error("获取分类列表失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取分类列表失败!", zap.Error(err))
 		response.FailWithMessage("获取分类列表失败", c)
 		return
 	}
@@ -39,13 +39,13 @@ func (a *AttachmentCategoryApi) GetCategoryList(c *gin.Context) {
 func (a *AttachmentCategoryApi) AddCategory(c *gin.Context) {
 	var req example.ExaAttachmentCategory
 	if err := c.ShouldBindJSON(&req); err != nil {
-		global.GVA_LOG.Error("参数错误!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("参数错误!", zap.Error(err))
 		response.FailWithMessage("参数错误", c)
 		return
 	}
 
-	if err := attachmentCategoryService.AddCategory(&req); err != nil {
-		global.GVA_LOG.Error("创建/更新失败!", zap.Error(err))
+	if err := attachmentCategoryService.AddCategory(c.Request.Context(), &req); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("创建/更新失败!", zap.Error(err))
 		response.FailWithMessage("创建/更新失败："+err.Error(), c)
 		return
 	}
@@ -73,7 +73,7 @@ func (a *AttachmentCategoryApi) DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	if err := attachmentCategoryService.DeleteCategory(&req.ID); err != nil {
+	if err := attachmentCategoryService.DeleteCategory(c.Request.Context(), &req.ID); err != nil {
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -8,10 +8,10 @@ import (
 
 	"server/model/example"
 
-	"server/global"
 	"server/model/common/response"
 	exampleRes "server/model/example/response"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -33,13 +33,13 @@ func (b *FileUploadAndDownloadApi) BreakpointContinue(c *gin.Context) {
 	chunkTotal, _ := strconv.Atoi(c.Request.FormValue("chunkTotal"))
 	_, FileHeader, err := c.Request.FormFile("file")
 	if err != nil {
-		global.GVA_LOG.Error("接收文件失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("接收文件失败!", zap.Error(err))
 		response.FailWithMessage("接收文件失败", c)
 		return
 	}
 	f, err := FileHeader.Open()
 	if err != nil {
-		global.GVA_LOG.Error("文件读取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("文件读取失败!", zap.Error(err))
 		response.FailWithMessage("文件读取失败", c)
 		return
 	}
@@ -51,25 +51,25 @@ func (b *FileUploadAndDownloadApi) BreakpointContinue(c *gin.Context) {
 	}(f)
 	cen, _ := io.ReadAll(f)
 	if !utils.CheckMd5(cen, chunkMd5) {
-		global.GVA_LOG.Error("检查md5失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("检查md5失败!", zap.Error(err))
 		response.FailWithMessage("检查md5失败", c)
 		return
 	}
-	file, err := fileUploadAndDownloadService.FindOrCreateFile(fileMd5, fileName, chunkTotal)
+	file, err := fileUploadAndDownloadService.FindOrCreateFile(c.Request.Context(), fileMd5, fileName, chunkTotal)
 	if err != nil {
-		global.GVA_LOG.Error("查找或创建记录失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("查找或创建记录失败!", zap.Error(err))
 		response.FailWithMessage("查找或创建记录失败", c)
 		return
 	}
 	pathC, err := utils.BreakPointContinue(cen, fileName, chunkNumber, chunkTotal, fileMd5)
 	if err != nil {
-		global.GVA_LOG.Error("断点续传失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("断点续传失败!", zap.Error(err))
 		response.FailWithMessage("断点续传失败", c)
 		return
 	}
 
-	if err = fileUploadAndDownloadService.CreateFileChunk(file.ID, pathC, chunkNumber); err != nil {
-		global.GVA_LOG.Error("创建文件记录失败!", zap.Error(err))
+	if err = fileUploadAndDownloadService.CreateFileChunk(c.Request.Context(), file.ID, pathC, chunkNumber); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("创建文件记录失败!", zap.Error(err))
 		response.FailWithMessage("创建文件记录失败", c)
 		return
 	}
@@ -89,9 +89,9 @@ func (b *FileUploadAndDownloadApi) FindFile(c *gin.Context) {
 	fileMd5 := c.Query("fileMd5")
 	fileName := c.Query("fileName")
 	chunkTotal, _ := strconv.Atoi(c.Query("chunkTotal"))
-	file, err := fileUploadAndDownloadService.FindOrCreateFile(fileMd5, fileName, chunkTotal)
+	file, err := fileUploadAndDownloadService.FindOrCreateFile(c.Request.Context(), fileMd5, fileName, chunkTotal)
 	if err != nil {
-		global.GVA_LOG.Error("查找失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("查找失败!", zap.Error(err))
 		response.FailWithMessage("查找失败", c)
 	} else {
 		response.OkWithDetailed(exampleRes.FileResponse{File: file}, "查找成功", c)
@@ -112,7 +112,7 @@ func (b *FileUploadAndDownloadApi) BreakpointContinueFinish(c *gin.Context) {
 	fileName := c.Query("fileName")
 	filePath, err := utils.MakeFile(fileName, fileMd5)
 	if err != nil {
-		global.GVA_LOG.Error("文件创建失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("文件创建失败!", zap.Error(err))
 		response.FailWithDetailed(exampleRes.FilePathResponse{FilePath: filePath}, "文件创建失败", c)
 	} else {
 		response.OkWithDetailed(exampleRes.FilePathResponse{FilePath: filePath}, "文件创建成功", c)
@@ -137,12 +137,12 @@ func (b *FileUploadAndDownloadApi) RemoveChunk(c *gin.Context) {
 	}
 	err = utils.RemoveChunk(file.FileMd5)
 	if err != nil {
-		global.GVA_LOG.Error("缓存切片删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("缓存切片删除失败!", zap.Error(err))
 		return
 	}
-	err = fileUploadAndDownloadService.DeleteFileChunk(file.FileMd5, file.FilePath)
+	err = fileUploadAndDownloadService.DeleteFileChunk(c.Request.Context(), file.FileMd5, file.FilePath)
 	if err != nil {
-		global.GVA_LOG.Error(err.Error(), zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error(err.Error(), zap.Error(err))
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
@@ -1,12 +1,12 @@
 package example
 
 import (
-	"server/global"
 	"server/model/common/request"
 	"server/model/common/response"
 	"server/model/example"
 	exampleRes "server/model/example/response"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -36,9 +36,9 @@ func (e *CustomerApi) CreateExaCustomer(c *gin.Context) {
 	}
 	customer.SysUserID = utils.GetUserID(c)
 	customer.SysUserAuthorityID = utils.GetUserAuthorityId(c)
-	err = customerService.CreateExaCustomer(customer)
+	err = customerService.CreateExaCustomer(c.Request.Context(), customer)
 	if err != nil {
-		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败", c)
 		return
 	}
@@ -66,9 +66,9 @@ func (e *CustomerApi) DeleteExaCustomer(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = customerService.DeleteExaCustomer(customer)
+	err = customerService.DeleteExaCustomer(c.Request.Context(), customer)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -101,9 +101,9 @@ func (e *CustomerApi) UpdateExaCustomer(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = customerService.UpdateExaCustomer(&customer)
+	err = customerService.UpdateExaCustomer(c.Request.Context(), &customer)
 	if err != nil {
-		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败", c)
 		return
 	}
@@ -131,9 +131,9 @@ func (e *CustomerApi) GetExaCustomer(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	data, err := customerService.GetExaCustomer(customer.ID)
+	data, err := customerService.GetExaCustomer(c.Request.Context(), customer.ID)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -161,9 +161,9 @@ func (e *CustomerApi) GetExaCustomerList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	customerList, total, err := customerService.GetCustomerInfoList(utils.GetUserAuthorityId(c), pageInfo)
+	customerList, total, err := customerService.GetCustomerInfoList(c.Request.Context(), utils.GetUserAuthorityId(c), pageInfo)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败"+err.Error(), c)
 		return
 	}
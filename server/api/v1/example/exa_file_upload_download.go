@@ -1,11 +1,11 @@
 package example
 
 import (
-	"server/global"
 	"server/model/common/response"
 	"server/model/example"
 	"server/model/example/request"
 	exampleRes "server/model/example/response"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"strconv"
@@ -28,13 +28,13 @@ func (b *FileUploadAndDownloadApi) UploadFile(c *gin.Context) {
 	_, header, err := c.Request.FormFile("file")
 	classId, _ := strconv.Atoi(c.DefaultPostForm("classId", "0"))
 	if err != nil {
-		global.GVA_LOG.Error("接收文件失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("接收文件失败!", zap.Error(err))
 		response.FailWithMessage("接收文件失败", c)
 		return
 	}
-	file, err = fileUploadAndDownloadService.UploadFile(header, noSave, classId) // 文件上传后拿到文件路径
+	file, err = fileUploadAndDownloadService.UploadFile(c.Request.Context(), header, noSave, classId) // 文件上传后拿到文件路径
 	if err != nil {
-		global.GVA_LOG.Error("上传文件失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("上传文件失败!", zap.Error(err))
 		response.FailWithMessage("上传文件失败", c)
 		return
 	}
@@ -49,9 +49,9 @@ func (b *FileUploadAndDownloadApi) EditFileName(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = fileUploadAndDownloadService.EditFileName(file)
+	err = fileUploadAndDownloadService.EditFileName(c.Request.Context(), file)
 	if err != nil {
-		global.GVA_LOG.Error("编辑失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("编辑失败!", zap.Error(err))
 		response.FailWithMessage("编辑失败", c)
 		return
 	}
@@ -73,8 +73,8 @@ func (b *FileUploadAndDownloadApi) DeleteFile(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if err := fileUploadAndDownloadService.DeleteFile(file); err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+	if err := fileUploadAndDownloadService.DeleteFile(c.Request.Context(), file); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -97,9 +97,9 @@ func (b *FileUploadAndDownloadApi) GetFileList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	list, total, err := fileUploadAndDownloadService.GetFileRecordInfoList(pageInfo)
+	list, total, err := fileUploadAndDownloadService.GetFileRecordInfoList(c.Request.Context(), pageInfo)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -126,8 +126,8 @@ func (b *FileUploadAndDownloadApi) ImportURL(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if err := fileUploadAndDownloadService.ImportURL(&file); err != nil {
-		global.GVA_LOG.Error("导入URL失败!", zap.Error(err))
+	if err := fileUploadAndDownloadService.ImportURL(c.Request.Context(), &file); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("导入URL失败!", zap.Error(err))
 		response.FailWithMessage("导入URL失败", c)
 		return
 	}
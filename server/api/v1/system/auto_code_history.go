@@ -1,10 +1,11 @@
 package system
 
 import (
-	"server/global"
 	common "server/model/common/request"
 	"server/model/common/response"
 	request "server/model/system/request"
+	systemRes "server/model/system/response"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -53,7 +54,7 @@ func (a *AutoCodeHistoryApi) Delete(c *gin.Context) {
 	}
 	err = autoCodeHistoryService.Delete(c.Request.Context(), info)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -67,7 +68,7 @@ func (a *AutoCodeHistoryApi) Delete(c *gin.Context) {
 // @accept    application/json
 // @Produce   application/json
 // @Param     data  body      request.SysAutoHistoryRollBack             true  "请求参数"
-// @Success   200   {object}  response.Response{msg=string}  "回滚自动生成代码"
+// @Success   200   {object}  response.Response{data=systemRes.AutoCodeInjection,msg=string}  "回滚自动生成代码, preview 为 true 时只返回注入代码回滚的 diff 与冲突"
 // @Router    /autoCode/rollback [post]
 func (a *AutoCodeHistoryApi) RollBack(c *gin.Context) {
 	var info request.SysAutoHistoryRollBack
@@ -76,12 +77,17 @@ func (a *AutoCodeHistoryApi) RollBack(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = autoCodeHistoryService.RollBack(c.Request.Context(), info)
+	var result *systemRes.AutoCodeInjection
+	result, err = autoCodeHistoryService.RollBack(c.Request.Context(), info)
 	if err != nil {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	response.OkWithMessage("回滚成功", c)
+	if info.Preview {
+		response.OkWithDetailed(result, "预览成功", c)
+		return
+	}
+	response.OkWithDetailed(result, "回滚成功", c)
 }
 
 // GetList
@@ -102,7 +108,7 @@ func (a *AutoCodeHistoryApi) GetList(c *gin.Context) {
 	}
 	list, total, err := autoCodeHistoryService.GetList(c.Request.Context(), info)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
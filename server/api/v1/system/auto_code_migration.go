@@ -0,0 +1,92 @@
+package system
+
+import (
+	"server/model/common/response"
+	request "server/model/system/request"
+	"server/utils/ctxlog"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AutoCodeMigrationApi struct{}
+
+// GetList
+// @Tags      AutoCode
+// @Summary   分页获取代码生成器迁移
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.SysAutoCodeMigrationSearch                      true  "生成记录ID, 页码, 每页大小"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取迁移,返回包括列表,总数,页码,每页数量"
+// @Router    /autoCode/getMigrations [get]
+func (a *AutoCodeMigrationApi) GetList(c *gin.Context) {
+	var info request.SysAutoCodeMigrationSearch
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := autoCodeMigrationService.GetList(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     info.Page,
+		PageSize: info.PageSize,
+	}, "获取成功", c)
+}
+
+// Apply
+// @Tags      AutoCode
+// @Summary   执行迁移
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAutoCodeMigrationVersion  true  "版本号"
+// @Success   200   {object}  response.Response{msg=string}        "执行迁移"
+// @Router    /autoCode/applyMigration [post]
+func (a *AutoCodeMigrationApi) Apply(c *gin.Context) {
+	var info request.SysAutoCodeMigrationVersion
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = autoCodeMigrationService.Apply(c.Request.Context(), info.Version)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("执行迁移失败!", zap.Error(err))
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	response.OkWithMessage("执行成功", c)
+}
+
+// Rollback
+// @Tags      AutoCode
+// @Summary   回滚迁移
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAutoCodeMigrationVersion  true  "版本号"
+// @Success   200   {object}  response.Response{msg=string}        "回滚迁移"
+// @Router    /autoCode/rollbackMigration [post]
+func (a *AutoCodeMigrationApi) Rollback(c *gin.Context) {
+	var info request.SysAutoCodeMigrationVersion
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = autoCodeMigrationService.Rollback(c.Request.Context(), info.Version)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("回滚迁移失败!", zap.Error(err))
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	response.OkWithMessage("回滚成功", c)
+}
@@ -1,11 +1,11 @@
 package system
 
 import (
-	"server/global"
 	common "server/model/common/request"
 	"server/model/common/response"
 	"server/model/system/request"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"strings"
@@ -35,7 +35,7 @@ func (a *AutoCodePackageApi) Create(c *gin.Context) {
 	} // PackageName可能导致路径穿越的问题 / 和 \ 都要防止
 	err := autoCodePackageService.Create(c.Request.Context(), &info)
 	if err != nil {
-		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败", c)
 		return
 	}
@@ -56,7 +56,7 @@ func (a *AutoCodePackageApi) Delete(c *gin.Context) {
 	_ = c.ShouldBindJSON(&info)
 	err := autoCodePackageService.Delete(c.Request.Context(), info)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -74,7 +74,7 @@ func (a *AutoCodePackageApi) Delete(c *gin.Context) {
 func (a *AutoCodePackageApi) All(c *gin.Context) {
 	data, err := autoCodePackageService.All(c.Request.Context())
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -92,7 +92,7 @@ func (a *AutoCodePackageApi) All(c *gin.Context) {
 func (a *AutoCodePackageApi) Templates(c *gin.Context) {
 	data, err := autoCodePackageService.Templates(c.Request.Context())
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
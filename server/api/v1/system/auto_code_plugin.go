@@ -2,9 +2,9 @@ package system
 
 import (
 	"fmt"
-	"server/global"
 	"server/model/common/response"
 	"server/model/system/request"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -63,7 +63,7 @@ func (a *AutoCodePluginApi) Packaged(c *gin.Context) {
 	plugName := c.Query("plugName")
 	zipPath, err := autoCodePluginService.PubPlug(plugName)
 	if err != nil {
-		global.GVA_LOG.Error("打包失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("打包失败!", zap.Error(err))
 		response.FailWithMessage("打包失败"+err.Error(), c)
 		return
 	}
@@ -85,9 +85,9 @@ func (a *AutoCodePluginApi) InitMenu(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = autoCodePluginService.InitMenu(menuInfo)
+	err = autoCodePluginService.InitMenu(c.Request.Context(), menuInfo)
 	if err != nil {
-		global.GVA_LOG.Error("创建初始化Menu失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建初始化Menu失败!", zap.Error(err))
 		response.FailWithMessage("创建初始化Menu失败"+err.Error(), c)
 		return
 	}
@@ -109,9 +109,9 @@ func (a *AutoCodePluginApi) InitAPI(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = autoCodePluginService.InitAPI(apiInfo)
+	err = autoCodePluginService.InitAPI(c.Request.Context(), apiInfo)
 	if err != nil {
-		global.GVA_LOG.Error("创建初始化API失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建初始化API失败!", zap.Error(err))
 		response.FailWithMessage("创建初始化API失败"+err.Error(), c)
 		return
 	}
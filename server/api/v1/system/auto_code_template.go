@@ -1,10 +1,11 @@
 package system
 
 import (
-	"server/global"
 	"server/model/common/response"
 	"server/model/system/request"
+	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -40,13 +41,50 @@ func (a *AutoCodeTemplateApi) Preview(c *gin.Context) {
 	info.PackageT = utils.FirstUpper(info.Package)
 	autoCode, err := autoCodeTemplateService.Preview(c.Request.Context(), info)
 	if err != nil {
-		global.GVA_LOG.Error(err.Error(), zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error(err.Error(), zap.Error(err))
 		response.FailWithMessage("预览失败:"+err.Error(), c)
 	} else {
 		response.OkWithDetailed(gin.H{"autoCode": autoCode}, "预览成功", c)
 	}
 }
 
+// PreviewInjection
+// @Tags      AutoCodeTemplate
+// @Summary   预览注入代码的 diff 及冲突
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.AutoCode                                                  true  "预览注入代码"
+// @Success   200   {object}  response.Response{data=systemRes.AutoCodeInjection,msg=string}  "返回各被注入文件的unified diff及预检冲突, 不写入文件"
+// @Router    /autoCode/previewInjection [post]
+func (a *AutoCodeTemplateApi) PreviewInjection(c *gin.Context) {
+	var info request.AutoCode
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = utils.Verify(info, utils.AutoCodeVerify)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = info.Pretreatment()
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	info.PackageT = utils.FirstUpper(info.Package)
+	var result *systemRes.AutoCodeInjection
+	result, err = autoCodeTemplateService.Injections(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("预览注入失败!", zap.Error(err))
+		response.FailWithMessage("预览失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(result, "预览成功", c)
+}
+
 // Create
 // @Tags      AutoCodeTemplate
 // @Summary   自动代码模板
@@ -75,13 +113,53 @@ func (a *AutoCodeTemplateApi) Create(c *gin.Context) {
 	}
 	err = autoCodeTemplateService.Create(c.Request.Context(), info)
 	if err != nil {
-		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建失败!", zap.Error(err))
 		response.FailWithMessage(err.Error(), c)
 	} else {
 		response.OkWithMessage("创建成功", c)
 	}
 }
 
+// Regenerate
+// @Tags      AutoCodeTemplate
+// @Summary   重新生成已创建的模块并三方合并手工修改
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.AutoCodeRegenerate                                         true  "重新生成的结构体信息, preview为true时只预览"
+// @Success   200   {object}  response.Response{data=systemRes.AutoCodeRegenerate,msg=string}  "返回每个文件的合并结果及字段变更生成的迁移"
+// @Router    /autoCode/regenerate [post]
+func (a *AutoCodeTemplateApi) Regenerate(c *gin.Context) {
+	var info request.AutoCodeRegenerate
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = utils.Verify(info.AutoCode, utils.AutoCodeVerify)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = info.Pretreatment()
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var result *systemRes.AutoCodeRegenerate
+	result, err = autoCodeTemplateService.Regenerate(c.Request.Context(), info.AutoCode, info.Preview, info.ConfirmDrop)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("重新生成失败!", zap.Error(err))
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if info.Preview {
+		response.OkWithDetailed(result, "预览成功", c)
+		return
+	}
+	response.OkWithDetailed(result, "重新生成成功", c)
+}
+
 // AddFunc
 // @Tags      AddFunc
 // @Summary   增加方法
@@ -104,12 +182,12 @@ func (a *AutoCodeTemplateApi) AddFunc(c *gin.Context) {
 		info.FuncName = "填充funcName"
 		info.Method = "填充method"
 		info.Description = "填充description"
-		tempMap, err = autoCodeTemplateService.GetApiAndServer(info)
+		tempMap, err = autoCodeTemplateService.GetApiAndServer(c.Request.Context(), info)
 	} else {
-		err = autoCodeTemplateService.AddFunc(info)
+		err = autoCodeTemplateService.AddFunc(c.Request.Context(), info)
 	}
 	if err != nil {
-		global.GVA_LOG.Error("注入失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("注入失败!", zap.Error(err))
 		response.FailWithMessage("注入失败", c)
 	} else {
 		if info.IsPreview {
@@ -0,0 +1,114 @@
+package system
+
+import (
+	common "server/model/common/request"
+	"server/model/common/response"
+	"server/model/system/request"
+	systemRes "server/model/system/response"
+	"server/utils/ctxlog"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AutoCodeTemplateSetApi struct{}
+
+// Create
+// @Tags      AutoCodeTemplateSet
+// @Summary   注册自定义模板集
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAutoCodeTemplateSetCreate  true  "模板集名称, 适用的包模版, 来源及清单"
+// @Success   200   {object}  response.Response{msg=string}         "注册模板集"
+// @Router    /autoCode/createTemplateSet [post]
+func (a *AutoCodeTemplateSetApi) Create(c *gin.Context) {
+	var info request.SysAutoCodeTemplateSetCreate
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = autoCodeTemplateSetService.Create(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("注册失败!", zap.Error(err))
+		response.FailWithMessage("注册失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("注册成功", c)
+}
+
+// Delete
+// @Tags      AutoCodeTemplateSet
+// @Summary   删除自定义模板集
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      common.GetById                 true  "模板集ID"
+// @Success   200   {object}  response.Response{msg=string}  "删除模板集"
+// @Router    /autoCode/delTemplateSet [post]
+func (a *AutoCodeTemplateSetApi) Delete(c *gin.Context) {
+	var info common.GetById
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = autoCodeTemplateSetService.Delete(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败", c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// All
+// @Tags      AutoCodeTemplateSet
+// @Summary   获取自定义模板集
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=[]system.SysAutoCodeTemplateSet,msg=string}  "获取模板集"
+// @Router    /autoCode/getTemplateSets [get]
+func (a *AutoCodeTemplateSetApi) All(c *gin.Context) {
+	data, err := autoCodeTemplateSetService.All(c.Request.Context())
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(data, "获取成功", c)
+}
+
+// Validate
+// @Tags      AutoCodeTemplateSet
+// @Summary   以示例结构体校验模板集
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.AutoCodeTemplateSetValidate                                   true  "模板集名称"
+// @Success   200   {object}  response.Response{data=[]systemRes.AutoCodeTemplateCheck,msg=string}  "返回每个模板的渲染结果"
+// @Router    /autoCode/validateTemplateSet [post]
+func (a *AutoCodeTemplateSetApi) Validate(c *gin.Context) {
+	var info request.AutoCodeTemplateSetValidate
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var checks []systemRes.AutoCodeTemplateCheck
+	checks, err = autoCodeTemplateSetService.Validate(c.Request.Context(), info.Name)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("校验失败!", zap.Error(err))
+		response.FailWithMessage("校验失败:"+err.Error(), c)
+		return
+	}
+	for _, check := range checks {
+		if check.Error != "" {
+			response.FailWithDetailed(checks, "模板集存在错误", c)
+			return
+		}
+	}
+	response.OkWithDetailed(checks, "校验通过", c)
+}
@@ -21,31 +21,37 @@ type ApiGroup struct {
 	AutoCodePackageApi
 	AutoCodeHistoryApi
 	AutoCodeTemplateApi
+	AutoCodeMigrationApi
+	AutoCodeTemplateSetApi
 	SysParamsApi
 	SysVersionApi
 	AreaApi
 }
 
 var (
-	apiService              = service.ServiceGroupApp.SystemServiceGroup.ApiService
-	jwtService              = service.ServiceGroupApp.SystemServiceGroup.JwtService
-	menuService             = service.ServiceGroupApp.SystemServiceGroup.MenuService
-	userService             = service.ServiceGroupApp.SystemServiceGroup.UserService
-	initDBService           = service.ServiceGroupApp.SystemServiceGroup.InitDBService
-	casbinService           = service.ServiceGroupApp.SystemServiceGroup.CasbinService
-	baseMenuService         = service.ServiceGroupApp.SystemServiceGroup.BaseMenuService
-	authorityService        = service.ServiceGroupApp.SystemServiceGroup.AuthorityService
-	dictionaryService       = service.ServiceGroupApp.SystemServiceGroup.DictionaryService
-	authorityBtnService     = service.ServiceGroupApp.SystemServiceGroup.AuthorityBtnService
-	systemConfigService     = service.ServiceGroupApp.SystemServiceGroup.SystemConfigService
-	sysParamsService        = service.ServiceGroupApp.SystemServiceGroup.SysParamsService
-	operationRecordService  = service.ServiceGroupApp.SystemServiceGroup.OperationRecordService
-	dictionaryDetailService = service.ServiceGroupApp.SystemServiceGroup.DictionaryDetailService
-	autoCodeService         = service.ServiceGroupApp.SystemServiceGroup.AutoCodeService
-	autoCodePluginService   = service.ServiceGroupApp.SystemServiceGroup.AutoCodePlugin
-	autoCodePackageService  = service.ServiceGroupApp.SystemServiceGroup.AutoCodePackage
-	autoCodeHistoryService  = service.ServiceGroupApp.SystemServiceGroup.AutoCodeHistory
-	autoCodeTemplateService = service.ServiceGroupApp.SystemServiceGroup.AutoCodeTemplate
-	sysVersionService       = service.ServiceGroupApp.SystemServiceGroup.SysVersionService
-	areaService             = service.ServiceGroupApp.SystemServiceGroup.AreaService
+	apiService                 = service.ServiceGroupApp.SystemServiceGroup.ApiService
+	jwtService                 = service.ServiceGroupApp.SystemServiceGroup.JwtService
+	menuService                = service.ServiceGroupApp.SystemServiceGroup.MenuService
+	userService                = service.ServiceGroupApp.SystemServiceGroup.UserService
+	initDBService              = service.ServiceGroupApp.SystemServiceGroup.InitDBService
+	casbinService              = service.ServiceGroupApp.SystemServiceGroup.CasbinService
+	baseMenuService            = service.ServiceGroupApp.SystemServiceGroup.BaseMenuService
+	authorityService           = service.ServiceGroupApp.SystemServiceGroup.AuthorityService
+	dictionaryService          = service.ServiceGroupApp.SystemServiceGroup.DictionaryService
+	authorityBtnService        = service.ServiceGroupApp.SystemServiceGroup.AuthorityBtnService
+	systemConfigService        = service.ServiceGroupApp.SystemServiceGroup.SystemConfigService
+	sysParamsService           = service.ServiceGroupApp.SystemServiceGroup.SysParamsService
+	operationRecordService     = service.ServiceGroupApp.SystemServiceGroup.OperationRecordService
+	auditLogService            = service.ServiceGroupApp.SystemServiceGroup.AuditLogService
+	changeHistoryService       = service.ServiceGroupApp.SystemServiceGroup.ChangeHistoryService
+	dictionaryDetailService    = service.ServiceGroupApp.SystemServiceGroup.DictionaryDetailService
+	autoCodeService            = service.ServiceGroupApp.SystemServiceGroup.AutoCodeService
+	autoCodePluginService      = service.ServiceGroupApp.SystemServiceGroup.AutoCodePlugin
+	autoCodePackageService     = service.ServiceGroupApp.SystemServiceGroup.AutoCodePackage
+	autoCodeHistoryService     = service.ServiceGroupApp.SystemServiceGroup.AutoCodeHistory
+	autoCodeTemplateService    = service.ServiceGroupApp.SystemServiceGroup.AutoCodeTemplate
+	autoCodeMigrationService   = service.ServiceGroupApp.SystemServiceGroup.AutoCodeMigration
+	autoCodeTemplateSetService = service.ServiceGroupApp.SystemServiceGroup.AutoCodeTemplateSet
+	sysVersionService          = service.ServiceGroupApp.SystemServiceGroup.SysVersionService
+	areaService                = service.ServiceGroupApp.SystemServiceGroup.AreaService
 )
@@ -1,13 +1,16 @@
 package system
 
 import (
-	"server/global"
+	"fmt"
+	"net/http"
+
 	"server/model/common/request"
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
 	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -36,9 +39,9 @@ func (s *SystemApiApi) CreateApi(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = apiService.CreateApi(api)
+	err = apiService.CreateApi(c.Request.Context(), api)
 	if err != nil {
-		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败", c)
 		return
 	}
@@ -54,9 +57,9 @@ func (s *SystemApiApi) CreateApi(c *gin.Context) {
 // @Success   200   {object}  response.Response{msg=string}  "同步API"
 // @Router    /api/syncApi [get]
 func (s *SystemApiApi) SyncApi(c *gin.Context) {
-	newApis, deleteApis, ignoreApis, err := apiService.SyncApi()
+	newApis, deleteApis, ignoreApis, err := apiService.SyncApi(c.Request.Context())
 	if err != nil {
-		global.GVA_LOG.Error("同步失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("同步失败!", zap.Error(err))
 		response.FailWithMessage("同步失败", c)
 		return
 	}
@@ -76,9 +79,9 @@ func (s *SystemApiApi) SyncApi(c *gin.Context) {
 // @Success   200   {object}  response.Response{msg=string}  "获取API分组"
 // @Router    /api/getApiGroups [get]
 func (s *SystemApiApi) GetApiGroups(c *gin.Context) {
-	groups, apiGroupMap, err := apiService.GetApiGroups()
+	groups, apiGroupMap, err := apiService.GetApiGroups(c.Request.Context())
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -103,9 +106,9 @@ func (s *SystemApiApi) IgnoreApi(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = apiService.IgnoreApi(ignoreApi)
+	err = apiService.IgnoreApi(c.Request.Context(), ignoreApi)
 	if err != nil {
-		global.GVA_LOG.Error("忽略失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("忽略失败!", zap.Error(err))
 		response.FailWithMessage("忽略失败", c)
 		return
 	}
@@ -127,9 +130,9 @@ func (s *SystemApiApi) EnterSyncApi(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = apiService.EnterSyncApi(syncApi)
+	err = apiService.EnterSyncApi(c.Request.Context(), syncApi)
 	if err != nil {
-		global.GVA_LOG.Error("忽略失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("忽略失败!", zap.Error(err))
 		response.FailWithMessage("忽略失败", c)
 		return
 	}
@@ -157,9 +160,9 @@ func (s *SystemApiApi) DeleteApi(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = apiService.DeleteApi(api)
+	err = apiService.DeleteApi(c.Request.Context(), api)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -187,9 +190,9 @@ func (s *SystemApiApi) GetApiList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	list, total, err := apiService.GetAPIInfoList(pageInfo.SysApi, pageInfo.PageInfo, pageInfo.OrderKey, pageInfo.Desc)
+	list, total, err := apiService.GetAPIInfoList(c.Request.Context(), pageInfo.SysApi, pageInfo.PageInfo, pageInfo.OrderKey, pageInfo.Desc)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -222,9 +225,9 @@ func (s *SystemApiApi) GetApiById(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	api, err := apiService.GetApiById(idInfo.ID)
+	api, err := apiService.GetApiById(c.Request.Context(), idInfo.ID)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -252,9 +255,9 @@ func (s *SystemApiApi) UpdateApi(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = apiService.UpdateApi(api)
+	err = apiService.UpdateApi(c.Request.Context(), api)
 	if err != nil {
-		global.GVA_LOG.Error("修改失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("修改失败!", zap.Error(err))
 		response.FailWithMessage("修改失败", c)
 		return
 	}
@@ -271,9 +274,9 @@ func (s *SystemApiApi) UpdateApi(c *gin.Context) {
 // @Router    /api/getAllApis [post]
 func (s *SystemApiApi) GetAllApis(c *gin.Context) {
 	authorityID := utils.GetUserAuthorityId(c)
-	apis, err := apiService.GetAllApis(authorityID)
+	apis, err := apiService.GetAllApis(c.Request.Context(), authorityID)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -296,9 +299,9 @@ func (s *SystemApiApi) DeleteApisByIds(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = apiService.DeleteApisByIds(ids)
+	err = apiService.DeleteApisByIds(c.Request.Context(), ids)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -315,9 +318,68 @@ func (s *SystemApiApi) DeleteApisByIds(c *gin.Context) {
 func (s *SystemApiApi) FreshCasbin(c *gin.Context) {
 	err := casbinService.FreshCasbin()
 	if err != nil {
-		global.GVA_LOG.Error("刷新失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("刷新失败!", zap.Error(err))
 		response.FailWithMessage("刷新失败", c)
 		return
 	}
 	response.OkWithMessage("刷新成功", c)
 }
+
+// GetOpenApi
+// @Tags      SysApi
+// @Summary   获取由路由表生成的OpenAPI 3.1文档
+// @Produce   application/json
+// @Success   200   {object}  openapi.Document  "OpenAPI文档"
+// @Router    /api/openapi.json [get]
+func (s *SystemApiApi) GetOpenApi(c *gin.Context) {
+	document, err := apiService.GetOpenApi()
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("生成OpenAPI文档失败!", zap.Error(err))
+		response.FailWithMessage("生成OpenAPI文档失败", c)
+		return
+	}
+	c.JSON(http.StatusOK, document)
+}
+
+// CheckOpenApi
+// @Tags      SysApi
+// @Summary   检查路由与sys_apis、casbin策略及swagger注释的一致性
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Success   200   {object}  response.Response{data=systemRes.SysOpenApiCheck,msg=string}  "检查结果"
+// @Router    /api/checkOpenApi [get]
+func (s *SystemApiApi) CheckOpenApi(c *gin.Context) {
+	result, err := apiService.CheckOpenApi(c.Request.Context())
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("检查失败!", zap.Error(err))
+		response.FailWithMessage("检查失败", c)
+		return
+	}
+	response.OkWithDetailed(result, "检查完成", c)
+}
+
+// GetSdk
+// @Tags      SysApi
+// @Summary   生成go或TypeScript接口客户端
+// @Security  ApiKeyAuth
+// @Produce   application/octet-stream
+// @Param     data  query     systemReq.SdkGenerate  true  "客户端语言, go包名"
+// @Success   200   {file}    file                   "客户端源码"
+// @Router    /api/getSdk [get]
+func (s *SystemApiApi) GetSdk(c *gin.Context) {
+	var info systemReq.SdkGenerate
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	filename, content, err := apiService.GenerateSdk(info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("生成客户端失败!", zap.Error(err))
+		response.FailWithMessage("生成客户端失败:"+err.Error(), c)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "application/octet-stream", content)
+}
@@ -1,13 +1,13 @@
 package system
 
 import (
-	"server/global"
 	"server/model/common/request"
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
 	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -42,9 +42,9 @@ func (areaApi *AreaApi) CreateArea(c *gin.Context) {
 		return
 	}
 
-	err = areaService.CreateArea(area)
+	err = areaService.CreateArea(c.Request.Context(), area)
 	if err != nil {
-		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败："+err.Error(), c)
 		return
 	}
@@ -72,9 +72,9 @@ func (areaApi *AreaApi) DeleteArea(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = areaService.DeleteArea(area)
+	err = areaService.DeleteArea(c.Request.Context(), area)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败："+err.Error(), c)
 		return
 	}
@@ -97,9 +97,9 @@ func (areaApi *AreaApi) DeleteAreasByIds(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = areaService.DeleteAreasByIds(ids)
+	err = areaService.DeleteAreasByIds(c.Request.Context(), ids)
 	if err != nil {
-		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("批量删除失败!", zap.Error(err))
 		response.FailWithMessage("批量删除失败："+err.Error(), c)
 		return
 	}
@@ -136,9 +136,9 @@ func (areaApi *AreaApi) UpdateArea(c *gin.Context) {
 		return
 	}
 
-	err = areaService.UpdateArea(area)
+	err = areaService.UpdateArea(c.Request.Context(), area)
 	if err != nil {
-		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败："+err.Error(), c)
 		return
 	}
@@ -166,9 +166,9 @@ func (areaApi *AreaApi) GetAreaList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	list, total, err := areaService.GetAreaList(pageInfo)
+	list, total, err := areaService.GetAreaList(c.Request.Context(), pageInfo)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败："+err.Error(), c)
 		return
 	}
@@ -201,9 +201,9 @@ func (areaApi *AreaApi) GetAreaById(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	area, err := areaService.GetAreaById(uint(idInfo.ID))
+	area, err := areaService.GetAreaById(c.Request.Context(), uint(idInfo.ID))
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败："+err.Error(), c)
 		return
 	}
@@ -234,9 +234,9 @@ func (areaApi *AreaApi) GetAreaByAreaId(c *gin.Context) {
 		areaIdInt = id
 	}
 
-	area, err := areaService.GetAreaByAreaId(areaIdInt)
+	area, err := areaService.GetAreaByAreaId(c.Request.Context(), areaIdInt)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败："+err.Error(), c)
 		return
 	}
@@ -259,9 +259,9 @@ func (areaApi *AreaApi) GetAreaTree(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	tree, err := areaService.GetAreaTree(req)
+	tree, err := areaService.GetAreaTree(c.Request.Context(), req)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败："+err.Error(), c)
 		return
 	}
@@ -292,9 +292,9 @@ func (areaApi *AreaApi) GetAreasByParentId(c *gin.Context) {
 		parentIdInt = id
 	}
 
-	areas, err := areaService.GetAreasByParentId(parentIdInt)
+	areas, err := areaService.GetAreasByParentId(c.Request.Context(), parentIdInt)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败："+err.Error(), c)
 		return
 	}
@@ -323,9 +323,9 @@ func (areaApi *AreaApi) ImportAreaData(c *gin.Context) {
 		return
 	}
 
-	result, err := areaService.ImportAreaData(req)
+	result, err := areaService.ImportAreaData(c.Request.Context(), req)
 	if err != nil {
-		global.GVA_LOG.Error("导入失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("导入失败!", zap.Error(err))
 		response.FailWithMessage("导入失败："+err.Error(), c)
 		return
 	}
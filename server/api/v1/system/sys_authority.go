@@ -6,6 +6,7 @@ import (
 	"server/model/system"
 	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -36,18 +37,18 @@ func (a *AuthorityApi) CreateAuthority(c *gin.Context) {
 		return
 	}
 
-	if *authority.ParentId == 0 && global.GVA_CONFIG.System.UseStrictAuth {
+	if *authority.ParentId == 0 && global.GVA_CONFIG.Load().System.UseStrictAuth {
 		authority.ParentId = utils.Pointer(utils.GetUserAuthorityId(c))
 	}
 
-	if authBack, err = authorityService.CreateAuthority(authority); err != nil {
-		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+	if authBack, err = authorityService.CreateAuthority(c.Request.Context(), authority); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败"+err.Error(), c)
 		return
 	}
 	err = casbinService.FreshCasbin()
 	if err != nil {
-		global.GVA_LOG.Error("创建成功，权限刷新失败。", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("创建成功，权限刷新失败。", zap.Error(err))
 		response.FailWithMessage("创建成功，权限刷新失败。"+err.Error(), c)
 		return
 	}
@@ -81,9 +82,9 @@ func (a *AuthorityApi) CopyAuthority(c *gin.Context) {
 		return
 	}
 	adminAuthorityID := utils.GetUserAuthorityId(c)
-	authBack, err := authorityService.CopyAuthority(adminAuthorityID, copyInfo)
+	authBack, err := authorityService.CopyAuthority(c.Request.Context(), adminAuthorityID, copyInfo)
 	if err != nil {
-		global.GVA_LOG.Error("拷贝失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("拷贝失败!", zap.Error(err))
 		response.FailWithMessage("拷贝失败"+err.Error(), c)
 		return
 	}
@@ -111,8 +112,8 @@ func (a *AuthorityApi) DeleteAuthority(c *gin.Context) {
 		return
 	}
 	// 删除角色之前需要判断是否有用户正在使用此角色
-	if err = authorityService.DeleteAuthority(&authority); err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+	if err = authorityService.DeleteAuthority(c.Request.Context(), &authority); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败"+err.Error(), c)
 		return
 	}
@@ -141,9 +142,9 @@ func (a *AuthorityApi) UpdateAuthority(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	authority, err := authorityService.UpdateAuthority(auth)
+	authority, err := authorityService.UpdateAuthority(c.Request.Context(), auth)
 	if err != nil {
-		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败"+err.Error(), c)
 		return
 	}
@@ -161,9 +162,9 @@ func (a *AuthorityApi) UpdateAuthority(c *gin.Context) {
 // @Router    /authority/getAuthorityList [post]
 func (a *AuthorityApi) GetAuthorityList(c *gin.Context) {
 	authorityID := utils.GetUserAuthorityId(c)
-	list, err := authorityService.GetAuthorityInfoList(authorityID)
+	list, err := authorityService.GetAuthorityInfoList(c.Request.Context(), authorityID)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败"+err.Error(), c)
 		return
 	}
@@ -192,9 +193,9 @@ func (a *AuthorityApi) SetDataAuthority(c *gin.Context) {
 		return
 	}
 	adminAuthorityID := utils.GetUserAuthorityId(c)
-	err = authorityService.SetDataAuthority(adminAuthorityID, auth)
+	err = authorityService.SetDataAuthority(c.Request.Context(), adminAuthorityID, auth)
 	if err != nil {
-		global.GVA_LOG.Error("设置失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("设置失败!", zap.Error(err))
 		response.FailWithMessage("设置失败"+err.Error(), c)
 		return
 	}
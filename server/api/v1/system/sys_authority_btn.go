@@ -1,9 +1,9 @@
 package system
 
 import (
-	"server/global"
 	"server/model/common/response"
 	"server/model/system/request"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -26,9 +26,9 @@ func (a *AuthorityBtnApi) GetAuthorityBtn(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	res, err := authorityBtnService.GetAuthorityBtn(req)
+	res, err := authorityBtnService.GetAuthorityBtn(c.Request.Context(), req)
 	if err != nil {
-		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("查询失败!", zap.Error(err))
 		response.FailWithMessage("查询失败", c)
 		return
 	}
@@ -51,9 +51,9 @@ func (a *AuthorityBtnApi) SetAuthorityBtn(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = authorityBtnService.SetAuthorityBtn(req)
+	err = authorityBtnService.SetAuthorityBtn(c.Request.Context(), req)
 	if err != nil {
-		global.GVA_LOG.Error("分配失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("分配失败!", zap.Error(err))
 		response.FailWithMessage("分配失败", c)
 		return
 	}
@@ -70,9 +70,9 @@ func (a *AuthorityBtnApi) SetAuthorityBtn(c *gin.Context) {
 // @Router    /authorityBtn/canRemoveAuthorityBtn [post]
 func (a *AuthorityBtnApi) CanRemoveAuthorityBtn(c *gin.Context) {
 	id := c.Query("id")
-	err := authorityBtnService.CanRemoveAuthorityBtn(id)
+	err := authorityBtnService.CanRemoveAuthorityBtn(c.Request.Context(), id)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
@@ -9,7 +9,9 @@ import (
 
 	"server/global"
 	"server/model/common/response"
+	systemReq "server/model/system/request"
 	"server/utils/request"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -26,9 +28,9 @@ type AutoCodeApi struct{}
 // @Router    /autoCode/getDB [get]
 func (autoApi *AutoCodeApi) GetDB(c *gin.Context) {
 	businessDB := c.Query("businessDB")
-	dbs, err := autoCodeService.Database(businessDB).GetDB(businessDB)
+	dbs, err := autoCodeService.Database(businessDB).GetDB(c.Request.Context(), businessDB)
 	var dbList []map[string]interface{}
-	for _, db := range global.GVA_CONFIG.DBList {
+	for _, db := range global.GVA_CONFIG.Load().DBList {
 		var item = make(map[string]interface{})
 		item["aliasName"] = db.AliasName
 		item["dbName"] = db.Dbname
@@ -37,7 +39,7 @@ func (autoApi *AutoCodeApi) GetDB(c *gin.Context) {
 		dbList = append(dbList, item)
 	}
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 	} else {
 		response.OkWithDetailed(gin.H{"dbs": dbs, "dbList": dbList}, "获取成功", c)
@@ -58,7 +60,7 @@ func (autoApi *AutoCodeApi) GetTables(c *gin.Context) {
 	if dbName == "" {
 		dbName = *global.GVA_ACTIVE_DBNAME
 		if businessDB != "" {
-			for _, db := range global.GVA_CONFIG.DBList {
+			for _, db := range global.GVA_CONFIG.Load().DBList {
 				if db.AliasName == businessDB {
 					dbName = db.Dbname
 				}
@@ -66,9 +68,9 @@ func (autoApi *AutoCodeApi) GetTables(c *gin.Context) {
 		}
 	}
 
-	tables, err := autoCodeService.Database(businessDB).GetTables(businessDB, dbName)
+	tables, err := autoCodeService.Database(businessDB).GetTables(c.Request.Context(), businessDB, dbName)
 	if err != nil {
-		global.GVA_LOG.Error("查询table失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("查询table失败!", zap.Error(err))
 		response.FailWithMessage("查询table失败", c)
 	} else {
 		response.OkWithDetailed(gin.H{"tables": tables}, "获取成功", c)
@@ -89,7 +91,7 @@ func (autoApi *AutoCodeApi) GetColumn(c *gin.Context) {
 	if dbName == "" {
 		dbName = *global.GVA_ACTIVE_DBNAME
 		if businessDB != "" {
-			for _, db := range global.GVA_CONFIG.DBList {
+			for _, db := range global.GVA_CONFIG.Load().DBList {
 				if db.AliasName == businessDB {
 					dbName = db.Dbname
 				}
@@ -97,15 +99,40 @@ func (autoApi *AutoCodeApi) GetColumn(c *gin.Context) {
 		}
 	}
 	tableName := c.Query("tableName")
-	columns, err := autoCodeService.Database(businessDB).GetColumn(businessDB, tableName, dbName)
+	columns, err := autoCodeService.Database(businessDB).GetColumn(c.Request.Context(), businessDB, tableName, dbName)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 	} else {
 		response.OkWithDetailed(gin.H{"columns": columns}, "获取成功", c)
 	}
 }
 
+// Spec
+// @Tags      AutoCode
+// @Summary   由已有数据表或建表语句生成代码生成器参数
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.AutoCodeSpec                                  true  "业务数据库和表名, 或CREATE TABLE语句"
+// @Success   200   {object}  response.Response{data=systemReq.AutoCode,msg=string}  "返回可直接预览或创建的AutoCode"
+// @Router    /autoCode/getSpec [post]
+func (autoApi *AutoCodeApi) Spec(c *gin.Context) {
+	var info systemReq.AutoCodeSpec
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	spec, err := autoCodeService.Spec(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("生成失败!", zap.Error(err))
+		response.FailWithMessage("生成失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(spec, "生成成功", c)
+}
+
 func (autoApi *AutoCodeApi) LLMAuto(c *gin.Context) {
 	var llm common.JSONMap
 	err := c.ShouldBindJSON(&llm)
@@ -113,12 +140,12 @@ func (autoApi *AutoCodeApi) LLMAuto(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if global.GVA_CONFIG.AutoCode.AiPath == "" {
+	if global.GVA_CONFIG.Load().AutoCode.AiPath == "" {
 		response.FailWithMessage("请先前往插件市场个人中心获取AiPath并填入config.yaml中", c)
 		return
 	}
 
-	path := strings.ReplaceAll(global.GVA_CONFIG.AutoCode.AiPath, "{FUNC}", fmt.Sprintf("api/chat/%s", llm["mode"]))
+	path := strings.ReplaceAll(global.GVA_CONFIG.Load().AutoCode.AiPath, "{FUNC}", fmt.Sprintf("api/chat/%s", llm["mode"]))
 	res, err := request.HttpRequest(
 		path,
 		"POST",
@@ -127,7 +154,7 @@ func (autoApi *AutoCodeApi) LLMAuto(c *gin.Context) {
 		llm,
 	)
 	if err != nil {
-		global.GVA_LOG.Error("大模型生成失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("大模型生成失败!", zap.Error(err))
 		response.FailWithMessage("大模型生成失败"+err.Error(), c)
 		return
 	}
@@ -135,19 +162,19 @@ func (autoApi *AutoCodeApi) LLMAuto(c *gin.Context) {
 	b, err := io.ReadAll(res.Body)
 	defer res.Body.Close()
 	if err != nil {
-		global.GVA_LOG.Error("大模型生成失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("大模型生成失败!", zap.Error(err))
 		response.FailWithMessage("大模型生成失败"+err.Error(), c)
 		return
 	}
 	err = json.Unmarshal(b, &resStruct)
 	if err != nil {
-		global.GVA_LOG.Error("大模型生成失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("大模型生成失败!", zap.Error(err))
 		response.FailWithMessage("大模型生成失败"+err.Error(), c)
 		return
 	}
 
 	if resStruct.Code == 7 {
-		global.GVA_LOG.Error("大模型生成失败!"+resStruct.Msg, zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("大模型生成失败!"+resStruct.Msg, zap.Error(err))
 		response.FailWithMessage("大模型生成失败"+resStruct.Msg, c)
 		return
 	}
@@ -1,11 +1,11 @@
 package system
 
 import (
-	"server/global"
 	"server/model/common/response"
 	"server/model/system/request"
 	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -34,9 +34,9 @@ func (cas *CasbinApi) UpdateCasbin(c *gin.Context) {
 		return
 	}
 	adminAuthorityID := utils.GetUserAuthorityId(c)
-	err = casbinService.UpdateCasbin(adminAuthorityID, cmr.AuthorityId, cmr.CasbinInfos)
+	err = casbinService.UpdateCasbin(c.Request.Context(), adminAuthorityID, cmr.AuthorityId, cmr.CasbinInfos)
 	if err != nil {
-		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败", c)
 		return
 	}
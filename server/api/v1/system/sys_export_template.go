@@ -7,13 +7,13 @@ import (
 	"sync"
 	"time"
 
-	"server/global"
 	"server/model/common/request"
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
 	"server/service"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -73,8 +73,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) CreateSysExportTemplate(c *gin
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if err := sysExportTemplateService.CreateSysExportTemplate(&sysExportTemplate); err != nil {
-		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+	if err := sysExportTemplateService.CreateSysExportTemplate(c.Request.Context(), &sysExportTemplate); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败", c)
 	} else {
 		response.OkWithMessage("创建成功", c)
@@ -97,8 +97,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) DeleteSysExportTemplate(c *gin
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if err := sysExportTemplateService.DeleteSysExportTemplate(sysExportTemplate); err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+	if err := sysExportTemplateService.DeleteSysExportTemplate(c.Request.Context(), sysExportTemplate); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 	} else {
 		response.OkWithMessage("删除成功", c)
@@ -121,8 +121,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) DeleteSysExportTemplateByIds(c
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if err := sysExportTemplateService.DeleteSysExportTemplateByIds(IDS); err != nil {
-		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
+	if err := sysExportTemplateService.DeleteSysExportTemplateByIds(c.Request.Context(), IDS); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("批量删除失败!", zap.Error(err))
 		response.FailWithMessage("批量删除失败", c)
 	} else {
 		response.OkWithMessage("批量删除成功", c)
@@ -152,8 +152,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) UpdateSysExportTemplate(c *gin
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if err := sysExportTemplateService.UpdateSysExportTemplate(sysExportTemplate); err != nil {
-		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+	if err := sysExportTemplateService.UpdateSysExportTemplate(c.Request.Context(), sysExportTemplate); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败", c)
 	} else {
 		response.OkWithMessage("更新成功", c)
@@ -176,8 +176,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) FindSysExportTemplate(c *gin.C
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if resysExportTemplate, err := sysExportTemplateService.GetSysExportTemplate(sysExportTemplate.ID); err != nil {
-		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+	if resysExportTemplate, err := sysExportTemplateService.GetSysExportTemplate(c.Request.Context(), sysExportTemplate.ID); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("查询失败!", zap.Error(err))
 		response.FailWithMessage("查询失败", c)
 	} else {
 		response.OkWithData(gin.H{"resysExportTemplate": resysExportTemplate}, c)
@@ -200,8 +200,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) GetSysExportTemplateList(c *gi
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	if list, total, err := sysExportTemplateService.GetSysExportTemplateInfoList(pageInfo); err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+	if list, total, err := sysExportTemplateService.GetSysExportTemplateInfoList(c.Request.Context(), pageInfo); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 	} else {
 		response.OkWithDetailed(response.PageResult{
@@ -270,7 +270,7 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportExcelByToken(c *gin.Cont
 	tokenMutex.RUnlock()
 
 	if !exists || time.Now().After(expiry) {
-		global.GVA_LOG.Error("导出token无效或已过期!")
+		ctxlog.Logger(c.Request.Context()).Error("导出token无效或已过期!")
 		response.FailWithMessage("导出token无效或已过期", c)
 		return
 	}
@@ -278,7 +278,7 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportExcelByToken(c *gin.Cont
 	// 从token获取参数
 	exportParams, ok := exportParamsRaw.(map[string]interface{})
 	if !ok {
-		global.GVA_LOG.Error("解析导出参数失败!")
+		ctxlog.Logger(c.Request.Context()).Error("解析导出参数失败!")
 		response.FailWithMessage("解析导出参数失败", c)
 		return
 	}
@@ -294,8 +294,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportExcelByToken(c *gin.Cont
 	tokenMutex.Unlock()
 
 	// 导出
-	if file, name, err := sysExportTemplateService.ExportExcel(templateID, queryParams); err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+	if file, name, err := sysExportTemplateService.ExportExcel(c.Request.Context(), templateID, queryParams); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 	} else {
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", name+utils.RandomString(6)+".xlsx"))
@@ -359,7 +359,7 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportTemplateByToken(c *gin.C
 	tokenMutex.RUnlock()
 
 	if !exists || time.Now().After(expiry) {
-		global.GVA_LOG.Error("导出token无效或已过期!")
+		ctxlog.Logger(c.Request.Context()).Error("导出token无效或已过期!")
 		response.FailWithMessage("导出token无效或已过期", c)
 		return
 	}
@@ -367,7 +367,7 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportTemplateByToken(c *gin.C
 	// 从token获取参数
 	exportParams, ok := exportParamsRaw.(map[string]interface{})
 	if !ok {
-		global.GVA_LOG.Error("解析导出参数失败!")
+		ctxlog.Logger(c.Request.Context()).Error("解析导出参数失败!")
 		response.FailWithMessage("解析导出参数失败", c)
 		return
 	}
@@ -375,7 +375,7 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportTemplateByToken(c *gin.C
 	// 检查是否为模板导出
 	isTemplate, _ := exportParams["isTemplate"].(bool)
 	if !isTemplate {
-		global.GVA_LOG.Error("token类型错误!")
+		ctxlog.Logger(c.Request.Context()).Error("token类型错误!")
 		response.FailWithMessage("token类型错误", c)
 		return
 	}
@@ -390,8 +390,8 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportTemplateByToken(c *gin.C
 	tokenMutex.Unlock()
 
 	// 导出模板
-	if file, name, err := sysExportTemplateService.ExportTemplate(templateID); err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+	if file, name, err := sysExportTemplateService.ExportTemplate(c.Request.Context(), templateID); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 	} else {
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", name+"模板.xlsx"))
@@ -415,12 +415,12 @@ func (sysExportTemplateApi *SysExportTemplateApi) ImportExcel(c *gin.Context) {
 	}
 	file, err := c.FormFile("file")
 	if err != nil {
-		global.GVA_LOG.Error("文件获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("文件获取失败!", zap.Error(err))
 		response.FailWithMessage("文件获取失败", c)
 		return
 	}
-	if err := sysExportTemplateService.ImportExcel(templateID, file); err != nil {
-		global.GVA_LOG.Error(err.Error(), zap.Error(err))
+	if err := sysExportTemplateService.ImportExcel(c.Request.Context(), templateID, file); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error(err.Error(), zap.Error(err))
 		response.FailWithMessage(err.Error(), c)
 	} else {
 		response.OkWithMessage("导入成功", c)
@@ -4,6 +4,7 @@ import (
 	"server/global"
 	"server/model/common/response"
 	"server/model/system/request"
+	"server/utils/ctxlog"
 	"go.uber.org/zap"
 
 	"github.com/gin-gonic/gin"
@@ -20,18 +21,18 @@ type DBApi struct{}
 // @Router   /init/initdb [post]
 func (i *DBApi) InitDB(c *gin.Context) {
 	if global.GVA_DB != nil {
-		global.GVA_LOG.Error("已存在数据库配置!")
+		ctxlog.Logger(c.Request.Context()).Error("已存在数据库配置!")
 		response.FailWithMessage("已存在数据库配置", c)
 		return
 	}
 	var dbInfo request.InitDB
 	if err := c.ShouldBindJSON(&dbInfo); err != nil {
-		global.GVA_LOG.Error("参数校验不通过!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("参数校验不通过!", zap.Error(err))
 		response.FailWithMessage("参数校验不通过", c)
 		return
 	}
 	if err := initDBService.InitDB(dbInfo); err != nil {
-		global.GVA_LOG.Error("自动创建数据库失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("自动创建数据库失败!", zap.Error(err))
 		response.FailWithMessage("自动创建数据库失败，请查看后台日志，检查后在进行初始化", c)
 		return
 	}
@@ -54,6 +55,6 @@ func (i *DBApi) CheckDB(c *gin.Context) {
 		message = "数据库无需初始化"
 		needInit = false
 	}
-	global.GVA_LOG.Info(message)
+	ctxlog.Logger(c.Request.Context()).Info(message)
 	response.OkWithDetailed(gin.H{"needInit": needInit}, message, c)
 }
@@ -1,10 +1,10 @@
 package system
 
 import (
-	"server/global"
 	"server/model/common/response"
 	"server/model/system"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -22,9 +22,9 @@ type JwtApi struct{}
 func (j *JwtApi) JsonInBlacklist(c *gin.Context) {
 	token := utils.GetToken(c)
 	jwt := system.JwtBlacklist{Jwt: token}
-	err := jwtService.JsonInBlacklist(jwt)
+	err := jwtService.JsonInBlacklist(c.Request.Context(), jwt)
 	if err != nil {
-		global.GVA_LOG.Error("jwt作废失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("jwt作废失败!", zap.Error(err))
 		response.FailWithMessage("jwt作废失败", c)
 		return
 	}
@@ -1,13 +1,13 @@
 package system
 
 import (
-	"server/global"
 	"server/model/common/request"
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
 	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -24,9 +24,9 @@ type AuthorityMenuApi struct{}
 // @Success   200   {object}  response.Response{data=systemRes.SysMenusResponse,msg=string}  "获取用户动态路由,返回包括系统菜单详情列表"
 // @Router    /menu/getMenu [post]
 func (a *AuthorityMenuApi) GetMenu(c *gin.Context) {
-	menus, err := menuService.GetMenuTree(utils.GetUserAuthorityId(c))
+	menus, err := menuService.GetMenuTree(c.Request.Context(), utils.GetUserAuthorityId(c))
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -46,9 +46,9 @@ func (a *AuthorityMenuApi) GetMenu(c *gin.Context) {
 // @Router    /menu/getBaseMenuTree [post]
 func (a *AuthorityMenuApi) GetBaseMenuTree(c *gin.Context) {
 	authority := utils.GetUserAuthorityId(c)
-	menus, err := menuService.GetBaseMenuTree(authority)
+	menus, err := menuService.GetBaseMenuTree(c.Request.Context(), authority)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -76,8 +76,8 @@ func (a *AuthorityMenuApi) AddMenuAuthority(c *gin.Context) {
 		return
 	}
 	adminAuthorityID := utils.GetUserAuthorityId(c)
-	if err := menuService.AddMenuAuthority(authorityMenu.Menus, adminAuthorityID, authorityMenu.AuthorityId); err != nil {
-		global.GVA_LOG.Error("添加失败!", zap.Error(err))
+	if err := menuService.AddMenuAuthority(c.Request.Context(), authorityMenu.Menus, adminAuthorityID, authorityMenu.AuthorityId); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("添加失败!", zap.Error(err))
 		response.FailWithMessage("添加失败", c)
 	} else {
 		response.OkWithMessage("添加成功", c)
@@ -105,9 +105,9 @@ func (a *AuthorityMenuApi) GetMenuAuthority(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	menus, err := menuService.GetMenuAuthority(&param)
+	menus, err := menuService.GetMenuAuthority(c.Request.Context(), &param)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithDetailed(systemRes.SysMenusResponse{Menus: menus}, "获取失败", c)
 		return
 	}
@@ -140,9 +140,9 @@ func (a *AuthorityMenuApi) AddBaseMenu(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = menuService.AddBaseMenu(menu)
+	err = menuService.AddBaseMenu(c.Request.Context(), menu)
 	if err != nil {
-		global.GVA_LOG.Error("添加失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("添加失败!", zap.Error(err))
 		response.FailWithMessage("添加失败："+err.Error(), c)
 		return
 	}
@@ -170,9 +170,9 @@ func (a *AuthorityMenuApi) DeleteBaseMenu(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = baseMenuService.DeleteBaseMenu(menu.ID)
+	err = baseMenuService.DeleteBaseMenu(c.Request.Context(), menu.ID)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败:"+err.Error(), c)
 		return
 	}
@@ -205,9 +205,9 @@ func (a *AuthorityMenuApi) UpdateBaseMenu(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = baseMenuService.UpdateBaseMenu(menu)
+	err = baseMenuService.UpdateBaseMenu(c.Request.Context(), menu)
 	if err != nil {
-		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败", c)
 		return
 	}
@@ -235,9 +235,9 @@ func (a *AuthorityMenuApi) GetBaseMenuById(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	menu, err := baseMenuService.GetBaseMenuById(idInfo.ID)
+	menu, err := baseMenuService.GetBaseMenuById(c.Request.Context(), idInfo.ID)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -255,9 +255,9 @@ func (a *AuthorityMenuApi) GetBaseMenuById(c *gin.Context) {
 // @Router    /menu/getMenuList [post]
 func (a *AuthorityMenuApi) GetMenuList(c *gin.Context) {
 	authorityID := utils.GetUserAuthorityId(c)
-	menuList, err := menuService.GetInfoList(authorityID)
+	menuList, err := menuService.GetInfoList(c.Request.Context(), authorityID)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -1,12 +1,18 @@
 package system
 
 import (
-	"server/global"
+	"errors"
+	"fmt"
+	"time"
+
 	"server/model/common/request"
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+	systemService "server/service/system"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -29,9 +35,13 @@ func (s *OperationRecordApi) DeleteSysOperationRecord(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = operationRecordService.DeleteSysOperationRecord(sysOperationRecord)
+	err = operationRecordService.DeleteSysOperationRecord(c.Request.Context(), sysOperationRecord)
+	if errors.Is(err, systemService.ErrAuditProtected) {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
 		return
 	}
@@ -54,9 +64,13 @@ func (s *OperationRecordApi) DeleteSysOperationRecordByIds(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = operationRecordService.DeleteSysOperationRecordByIds(IDS)
+	err = operationRecordService.DeleteSysOperationRecordByIds(c.Request.Context(), IDS)
+	if errors.Is(err, systemService.ErrAuditProtected) {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
 	if err != nil {
-		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("批量删除失败!", zap.Error(err))
 		response.FailWithMessage("批量删除失败", c)
 		return
 	}
@@ -84,9 +98,9 @@ func (s *OperationRecordApi) FindSysOperationRecord(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	reSysOperationRecord, err := operationRecordService.GetSysOperationRecord(sysOperationRecord.ID)
+	reSysOperationRecord, err := operationRecordService.GetSysOperationRecord(c.Request.Context(), sysOperationRecord.ID)
 	if err != nil {
-		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("查询失败!", zap.Error(err))
 		response.FailWithMessage("查询失败", c)
 		return
 	}
@@ -109,9 +123,199 @@ func (s *OperationRecordApi) GetSysOperationRecordList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	list, total, err := operationRecordService.GetSysOperationRecordInfoList(pageInfo)
+	list, total, err := operationRecordService.GetSysOperationRecordInfoList(c.Request.Context(), pageInfo)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetOperationRecordWriterStats
+// @Tags      SysOperationRecord
+// @Summary   获取操作记录写入器状态
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=systemService.OperationRecordWriterStats,msg=string}  "获取操作记录写入器状态,返回包括排队数,已写入,丢弃,失败数"
+// @Router    /sysOperationRecord/getOperationRecordWriterStats [get]
+func (s *OperationRecordApi) GetOperationRecordWriterStats(c *gin.Context) {
+	if systemService.OperationRecordWriterApp == nil {
+		response.FailWithMessage("操作记录写入器未初始化", c)
+		return
+	}
+	response.OkWithDetailed(systemService.OperationRecordWriterApp.Stats(), "获取成功", c)
+}
+
+// GetSysOperationRecordStats
+// @Tags      SysOperationRecord
+// @Summary   按时间粒度统计操作记录
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.SysOperationRecordStats                                            true  "时间范围, 时间粒度, 分组维度"
+// @Success   200   {object}  response.Response{data=[]systemRes.OperationRecordBucketStat,msg=string}  "按时间粒度统计操作记录"
+// @Router    /sysOperationRecord/getSysOperationRecordStats [get]
+func (s *OperationRecordApi) GetSysOperationRecordStats(c *gin.Context) {
+	var info systemReq.SysOperationRecordStats
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var list []systemRes.OperationRecordBucketStat
+	list, err = operationRecordService.GetSysOperationRecordStats(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetTopErrorPaths
+// @Tags      SysOperationRecord
+// @Summary   获取出错最多的路径
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.SysOperationRecordTop                                             true  "筛选条件, 返回条数"
+// @Success   200   {object}  response.Response{data=[]systemRes.OperationRecordErrorPath,msg=string}  "获取出错最多的路径"
+// @Router    /sysOperationRecord/getTopErrorPaths [get]
+func (s *OperationRecordApi) GetTopErrorPaths(c *gin.Context) {
+	var info systemReq.SysOperationRecordTop
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var list []systemRes.OperationRecordErrorPath
+	list, err = operationRecordService.GetTopErrorPaths(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetSlowestPaths
+// @Tags      SysOperationRecord
+// @Summary   获取耗时分位最高的路径
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.SysOperationRecordTop                                           true  "筛选条件, 返回条数"
+// @Success   200   {object}  response.Response{data=[]systemRes.OperationRecordLatency,msg=string}  "获取耗时分位最高的路径,耗时单位毫秒"
+// @Router    /sysOperationRecord/getSlowestPaths [get]
+func (s *OperationRecordApi) GetSlowestPaths(c *gin.Context) {
+	var info systemReq.SysOperationRecordTop
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var list []systemRes.OperationRecordLatency
+	list, err = operationRecordService.GetSlowestPaths(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// ExportSysOperationRecord
+// @Tags      SysOperationRecord
+// @Summary   导出操作记录
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/octet-stream
+// @Param     data  query     request.SysOperationRecordExport  true  "筛选条件, 导出格式"
+// @Success   200   {file}    file                              "导出操作记录"
+// @Router    /sysOperationRecord/exportSysOperationRecord [get]
+func (s *OperationRecordApi) ExportSysOperationRecord(c *gin.Context) {
+	var info systemReq.SysOperationRecordExport
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	switch info.Format {
+	case "", "csv":
+		info.Format = "csv"
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+	case "xlsx":
+		c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	default:
+		response.FailWithMessage("不支持的导出格式", c)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=operation_record_%s.%s", time.Now().Format("20060102150405"), info.Format))
+	// 数据逐行写入响应, 出错时响应头已发出, 只能记录日志并中断; xlsx 在全部写入后才输出, 尚未输出时返回错误信息
+	if err = operationRecordService.ExportSysOperationRecord(c.Request.Context(), info, c.Writer); err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("导出失败!", zap.Error(err))
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			response.FailWithMessage(err.Error(), c)
+			return
+		}
+		_ = c.Error(err)
+	}
+}
+
+// VerifyAuditLog
+// @Tags      SysOperationRecord
+// @Summary   校验审计日志哈希链
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.SysAuditLogVerify                                      true  "起始序号, 结束序号"
+// @Success   200   {object}  response.Response{data=systemRes.AuditVerifyResult,msg=string}  "校验审计日志哈希链,返回包括是否通过,问题列表"
+// @Router    /sysOperationRecord/verifyAuditLog [get]
+func (s *OperationRecordApi) VerifyAuditLog(c *gin.Context) {
+	var info systemReq.SysAuditLogVerify
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var result systemRes.AuditVerifyResult
+	result, err = auditLogService.VerifyAuditLog(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("校验失败!", zap.Error(err))
+		response.FailWithMessage("校验失败", c)
+		return
+	}
+	response.OkWithDetailed(result, "校验完成", c)
+}
+
+// GetAuditLogList
+// @Tags      SysOperationRecord
+// @Summary   分页获取审计日志
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     request.PageInfo                                        true  "页码, 每页大小"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取审计日志,返回包括列表,总数,页码,每页数量"
+// @Router    /sysOperationRecord/getAuditLogList [get]
+func (s *OperationRecordApi) GetAuditLogList(c *gin.Context) {
+	var pageInfo request.PageInfo
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := auditLogService.GetAuditLogList(c.Request.Context(), pageInfo)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -122,3 +326,33 @@ func (s *OperationRecordApi) GetSysOperationRecordList(c *gin.Context) {
 		PageSize: pageInfo.PageSize,
 	}, "获取成功", c)
 }
+
+// GetChangeHistory
+// @Tags      SysOperationRecord
+// @Summary   分页获取记录的数据变更历史
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     systemReq.SysChangeHistorySearch                        true  "表名, 记录主键, 操作类型, 页码, 每页大小"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取数据变更历史,返回包括列表,总数,页码,每页数量"
+// @Router    /sysOperationRecord/getChangeHistory [get]
+func (s *OperationRecordApi) GetChangeHistory(c *gin.Context) {
+	var info systemReq.SysChangeHistorySearch
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := changeHistoryService.GetChangeHistory(c.Request.Context(), info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     info.Page,
+		PageSize: info.PageSize,
+	}, "获取成功", c)
+}
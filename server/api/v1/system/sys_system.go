@@ -1,11 +1,12 @@
 package system
 
 import (
-	"server/global"
 	"server/model/common/response"
 	"server/model/system"
+	systemReq "server/model/system/request"
 	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -22,7 +23,7 @@ type SystemApi struct{}
 func (s *SystemApi) GetSystemConfig(c *gin.Context) {
 	config, err := systemConfigService.GetSystemConfig()
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
@@ -46,7 +47,7 @@ func (s *SystemApi) SetSystemConfig(c *gin.Context) {
 	}
 	err = systemConfigService.SetSystemConfig(sys)
 	if err != nil {
-		global.GVA_LOG.Error("设置失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("设置失败!", zap.Error(err))
 		response.FailWithMessage("设置失败", c)
 		return
 	}
@@ -64,7 +65,7 @@ func (s *SystemApi) ReloadSystem(c *gin.Context) {
 	// 触发系统重载事件
 	err := utils.GlobalSystemEvents.TriggerReload()
 	if err != nil {
-		global.GVA_LOG.Error("重载系统失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("重载系统失败!", zap.Error(err))
 		response.FailWithMessage("重载系统失败:"+err.Error(), c)
 		return
 	}
@@ -79,11 +80,48 @@ func (s *SystemApi) ReloadSystem(c *gin.Context) {
 // @Success   200  {object}  response.Response{data=map[string]interface{},msg=string}  "获取服务器信息"
 // @Router    /system/getServerInfo [post]
 func (s *SystemApi) GetServerInfo(c *gin.Context) {
-	server, err := systemConfigService.GetServerInfo()
+	server, err := systemConfigService.GetServerInfo(c.Request.Context())
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 		return
 	}
 	response.OkWithDetailed(gin.H{"server": server}, "获取成功", c)
 }
+
+// GetLogLevel
+// @Tags      System
+// @Summary   获取日志级别
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=systemRes.SysLogLevelResponse,msg=string}  "获取全局与按名称覆盖的日志级别及日志投递目标状态"
+// @Router    /system/getLogLevel [get]
+func (s *SystemApi) GetLogLevel(c *gin.Context) {
+	response.OkWithDetailed(systemConfigService.GetLogLevel(), "获取成功", c)
+}
+
+// SetLogLevel
+// @Tags      System
+// @Summary   设置日志级别
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.SysLogLevel          true  "日志名称, 级别"
+// @Success   200   {object}  response.Response{msg=string}  "设置日志级别, 立即生效"
+// @Router    /system/setLogLevel [post]
+func (s *SystemApi) SetLogLevel(c *gin.Context) {
+	var info systemReq.SysLogLevel
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = systemConfigService.SetLogLevel(info)
+	if err != nil {
+		ctxlog.Logger(c.Request.Context()).Error("设置失败!", zap.Error(err))
+		response.FailWithMessage("设置失败:"+err.Error(), c)
+		return
+	}
+	ctxlog.Logger(c.Request.Context()).Info("日志级别已修改", zap.String("name", info.Name), zap.String("level", info.Level))
+	response.OkWithMessage("设置成功", c)
+}
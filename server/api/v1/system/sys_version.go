@@ -8,12 +8,12 @@ import (
 	"strconv"
 	"time"
 
-	"server/global"
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
 	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -122,7 +122,7 @@ func (sysVersionApi *SysVersionApi) DeleteSysVersion(c *gin.Context) {
 	ID := c.Query("ID")
 	err := sysVersionService.DeleteSysVersion(ctx, ID)
 	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败:"+err.Error(), c)
 		return
 	}
@@ -144,7 +144,7 @@ func (sysVersionApi *SysVersionApi) DeleteSysVersionByIds(c *gin.Context) {
 	IDs := c.QueryArray("IDs[]")
 	err := sysVersionService.DeleteSysVersionByIds(ctx, IDs)
 	if err != nil {
-		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("批量删除失败!", zap.Error(err))
 		response.FailWithMessage("批量删除失败:"+err.Error(), c)
 		return
 	}
@@ -167,7 +167,7 @@ func (sysVersionApi *SysVersionApi) FindSysVersion(c *gin.Context) {
 	ID := c.Query("ID")
 	resysVersion, err := sysVersionService.GetSysVersion(ctx, ID)
 	if err != nil {
-		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("查询失败!", zap.Error(err))
 		response.FailWithMessage("查询失败:"+err.Error(), c)
 		return
 	}
@@ -195,7 +195,7 @@ func (sysVersionApi *SysVersionApi) GetSysVersionList(c *gin.Context) {
 	}
 	list, total, err := sysVersionService.GetSysVersionInfoList(ctx, pageInfo)
 	if err != nil {
-		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败:"+err.Error(), c)
 		return
 	}
@@ -250,7 +250,7 @@ func (sysVersionApi *SysVersionApi) ExportVersion(c *gin.Context) {
 	if len(req.MenuIds) > 0 {
 		menuData, err = sysVersionService.GetMenusByIds(ctx, req.MenuIds)
 		if err != nil {
-			global.GVA_LOG.Error("获取菜单数据失败!", zap.Error(err))
+			ctxlog.Logger(c.Request.Context()).Error("获取菜单数据失败!", zap.Error(err))
 			response.FailWithMessage("获取菜单数据失败:"+err.Error(), c)
 			return
 		}
@@ -261,7 +261,7 @@ func (sysVersionApi *SysVersionApi) ExportVersion(c *gin.Context) {
 	if len(req.ApiIds) > 0 {
 		apiData, err = sysVersionService.GetApisByIds(ctx, req.ApiIds)
 		if err != nil {
-			global.GVA_LOG.Error("获取API数据失败!", zap.Error(err))
+			ctxlog.Logger(c.Request.Context()).Error("获取API数据失败!", zap.Error(err))
 			response.FailWithMessage("获取API数据失败:"+err.Error(), c)
 			return
 		}
@@ -297,7 +297,7 @@ func (sysVersionApi *SysVersionApi) ExportVersion(c *gin.Context) {
 	// 转换为JSON
 	jsonData, err := json.MarshalIndent(exportData, "", "  ")
 	if err != nil {
-		global.GVA_LOG.Error("JSON序列化失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("JSON序列化失败!", zap.Error(err))
 		response.FailWithMessage("JSON序列化失败:"+err.Error(), c)
 		return
 	}
@@ -312,7 +312,7 @@ func (sysVersionApi *SysVersionApi) ExportVersion(c *gin.Context) {
 
 	err = sysVersionService.CreateSysVersion(ctx, &version)
 	if err != nil {
-		global.GVA_LOG.Error("保存版本记录失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("保存版本记录失败!", zap.Error(err))
 		response.FailWithMessage("保存版本记录失败:"+err.Error(), c)
 		return
 	}
@@ -341,7 +341,7 @@ func (sysVersionApi *SysVersionApi) DownloadVersionJson(c *gin.Context) {
 	// 获取版本记录
 	version, err := sysVersionService.GetSysVersion(ctx, ID)
 	if err != nil {
-		global.GVA_LOG.Error("获取版本记录失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("获取版本记录失败!", zap.Error(err))
 		response.FailWithMessage("获取版本记录失败:"+err.Error(), c)
 		return
 	}
@@ -403,7 +403,7 @@ func (sysVersionApi *SysVersionApi) ImportVersion(c *gin.Context) {
 	// 导入菜单数据
 	if len(importData.ExportMenu) > 0 {
 		if err := sysVersionService.ImportMenus(ctx, importData.ExportMenu); err != nil {
-			global.GVA_LOG.Error("导入菜单失败!", zap.Error(err))
+			ctxlog.Logger(c.Request.Context()).Error("导入菜单失败!", zap.Error(err))
 			response.FailWithMessage("导入菜单失败: "+err.Error(), c)
 			return
 		}
@@ -411,8 +411,8 @@ func (sysVersionApi *SysVersionApi) ImportVersion(c *gin.Context) {
 
 	// 导入API数据
 	if len(importData.ExportApi) > 0 {
-		if err := sysVersionService.ImportApis(importData.ExportApi); err != nil {
-			global.GVA_LOG.Error("导入API失败!", zap.Error(err))
+		if err := sysVersionService.ImportApis(ctx, importData.ExportApi); err != nil {
+			ctxlog.Logger(c.Request.Context()).Error("导入API失败!", zap.Error(err))
 			response.FailWithMessage("导入API失败: "+err.Error(), c)
 			return
 		}
@@ -429,7 +429,7 @@ func (sysVersionApi *SysVersionApi) ImportVersion(c *gin.Context) {
 
 	err = sysVersionService.CreateSysVersion(ctx, &version)
 	if err != nil {
-		global.GVA_LOG.Error("保存导入记录失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("保存导入记录失败!", zap.Error(err))
 		// 这里不返回错误，因为数据已经导入成功
 	}
 
@@ -0,0 +1,7 @@
+package config
+
+type Audit struct {
+	Enable         bool   `mapstructure:"enable" json:"enable" yaml:"enable"`                            // 是否开启防篡改审计, 开启后禁止通过接口删除操作记录
+	SigningKey     string `mapstructure:"signing-key" json:"signing-key" yaml:"signing-key"`             // 哈希链与检查点签名密钥, 为空时使用 jwt.signing-key, 修改后已有审计日志无法通过校验
+	CheckpointSpec string `mapstructure:"checkpoint-spec" json:"checkpoint-spec" yaml:"checkpoint-spec"` // 生成签名检查点的cron表达式, 默认 @hourly
+}
@@ -0,0 +1,8 @@
+package config
+
+type ChangeHistory struct {
+	Enable       bool     `mapstructure:"enable" json:"enable" yaml:"enable"`                      // 是否记录数据变更历史
+	Tables       []string `mapstructure:"tables" json:"tables" yaml:"tables"`                      // 记录变更的表, 为空时使用内置的系统表
+	MaskFields   []string `mapstructure:"mask-fields" json:"mask-fields" yaml:"mask-fields"`       // 需要脱敏的字段(数据库列名), 如 password
+	IgnoreFields []string `mapstructure:"ignore-fields" json:"ignore-fields" yaml:"ignore-fields"` // 不参与比对的字段(数据库列名), 如 updated_at
+}
@@ -37,4 +37,28 @@ type Server struct {
 
 	// MCP配置
 	MCP MCP `mapstructure:"mcp" json:"mcp" yaml:"mcp"`
+
+	// 脱敏配置
+	Mask Mask `mapstructure:"mask" json:"mask" yaml:"mask"`
+
+	// 操作记录配置
+	OperationRecord OperationRecord `mapstructure:"operation-record" json:"operation-record" yaml:"operation-record"`
+
+	// 防篡改审计配置
+	Audit Audit `mapstructure:"audit" json:"audit" yaml:"audit"`
+
+	// 数据变更历史配置
+	ChangeHistory ChangeHistory `mapstructure:"change-history" json:"change-history" yaml:"change-history"`
+
+	// 监控指标配置
+	Metrics Metrics `mapstructure:"metrics" json:"metrics" yaml:"metrics"`
+
+	// 健康检查配置
+	Health Health `mapstructure:"health" json:"health" yaml:"health"`
+
+	// 链路追踪配置
+	Trace Trace `mapstructure:"trace" json:"trace" yaml:"trace"`
+
+	// 接口超时与关闭服务配置
+	Timeout Timeout `mapstructure:"timeout" json:"timeout" yaml:"timeout"`
 }
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ChangedSections 返回取值发生变化的顶层配置项, 名称为配置文件中的键, 如 jwt、zap、mysql
+func ChangedSections(oldConf, newConf Server) []string {
+	var sections []string
+	oldValue, newValue := reflect.ValueOf(oldConf), reflect.ValueOf(newConf)
+	typ := oldValue.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("mapstructure"), ",")
+		if name == "" {
+			name = typ.Field(i).Name
+		}
+		sections = append(sections, name)
+	}
+	return sections
+}
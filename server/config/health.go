@@ -0,0 +1,6 @@
+package config
+
+type Health struct {
+	Timeout       string `mapstructure:"timeout" json:"timeout" yaml:"timeout"`                      // 就绪检查中单项依赖的超时时间, 如 3s, 默认 3s
+	ShutdownDelay string `mapstructure:"shutdown-delay" json:"shutdown-delay" yaml:"shutdown-delay"` // 收到退出信号后先置为未就绪并等待的时间, 便于负载均衡摘除流量, 如 5s
+}
@@ -0,0 +1,22 @@
+package config
+
+// Mask 操作记录与请求日志脱敏配置
+type Mask struct {
+	Enable      bool        `mapstructure:"enable" json:"enable" yaml:"enable"`                // 是否开启脱敏
+	Replacement string      `mapstructure:"replacement" json:"replacement" yaml:"replacement"` // 替换文本, 为空时使用 ******
+	Fields      []string    `mapstructure:"fields" json:"fields" yaml:"fields"`                // JSON字段, 不含 "." 时匹配任意层级的同名字段, 含 "." 时按路径匹配, "*" 匹配任意一级
+	Headers     []string    `mapstructure:"headers" json:"headers" yaml:"headers"`             // 需要脱敏的请求头
+	Regexes     []MaskRegex `mapstructure:"regexes" json:"regexes" yaml:"regexes"`             // 正则规则, 作用于最终文本
+	Paths       []MaskPath  `mapstructure:"paths" json:"paths" yaml:"paths"`                   // 针对指定接口的额外规则
+}
+
+type MaskRegex struct {
+	Pattern     string `mapstructure:"pattern" json:"pattern" yaml:"pattern"`             // 正则表达式
+	Replacement string `mapstructure:"replacement" json:"replacement" yaml:"replacement"` // 替换文本, 支持 $1 等分组引用, 为空时使用全局替换文本
+}
+
+type MaskPath struct {
+	Path    string      `mapstructure:"path" json:"path" yaml:"path"`          // 接口路径, 以 * 结尾时按前缀匹配
+	Fields  []string    `mapstructure:"fields" json:"fields" yaml:"fields"`    // 额外的JSON字段
+	Regexes []MaskRegex `mapstructure:"regexes" json:"regexes" yaml:"regexes"` // 额外的正则规则
+}
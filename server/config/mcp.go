@@ -1,9 +1,18 @@
 package config
 
 type MCP struct {
-	Name        string `mapstructure:"name" json:"name" yaml:"name"`                         // MCP名称
-	Version     string `mapstructure:"version" json:"version" yaml:"version"`                // MCP版本
-	SSEPath     string `mapstructure:"sse_path" json:"sse_path" yaml:"sse_path"`             // SSE路径
-	MessagePath string `mapstructure:"message_path" json:"message_path" yaml:"message_path"` // 消息路径
-	UrlPrefix   string `mapstructure:"url_prefix" json:"url_prefix" yaml:"url_prefix"`       // URL前缀
+	Name           string      `mapstructure:"name" json:"name" yaml:"name"`                                  // MCP名称
+	Version        string      `mapstructure:"version" json:"version" yaml:"version"`                         // MCP版本
+	SSEPath        string      `mapstructure:"sse_path" json:"sse_path" yaml:"sse_path"`                      // SSE路径
+	MessagePath    string      `mapstructure:"message_path" json:"message_path" yaml:"message_path"`          // 消息路径
+	StreamablePath string      `mapstructure:"streamable_path" json:"streamable_path" yaml:"streamable_path"` // Streamable HTTP路径, 为空时不提供
+	UrlPrefix      string      `mapstructure:"url_prefix" json:"url_prefix" yaml:"url_prefix"`                // URL前缀
+	ApiKeys        []McpApiKey `mapstructure:"api_keys" json:"api_keys" yaml:"api_keys"`                      // API Key, 未登录的客户端(如AI编辑器)使用
+}
+
+// McpApiKey 通过 x-api-key 请求头或 Authorization: Bearer 访问 MCP 服务的密钥, 以指定角色的权限调用工具
+type McpApiKey struct {
+	Name        string `mapstructure:"name" json:"name" yaml:"name"`                         // 名称, 记录在操作日志中
+	Key         string `mapstructure:"key" json:"key" yaml:"key"`                            // 密钥
+	AuthorityId uint   `mapstructure:"authority_id" json:"authority_id" yaml:"authority_id"` // 角色ID
 }
@@ -0,0 +1,9 @@
+package config
+
+type Metrics struct {
+	Enable   bool     `mapstructure:"enable" json:"enable" yaml:"enable"`          // 是否开启指标接口及埋点
+	Path     string   `mapstructure:"path" json:"path" yaml:"path"`                // 指标接口路径, 默认 /metrics
+	Username string   `mapstructure:"username" json:"username" yaml:"username"`    // Basic Auth 用户名, 为空时不校验
+	Password string   `mapstructure:"password" json:"password" yaml:"password"`    // Basic Auth 密码
+	AllowIps []string `mapstructure:"allow-ips" json:"allow-ips" yaml:"allow-ips"` // 允许访问的IP或网段(CIDR), 为空时不限制
+}
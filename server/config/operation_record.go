@@ -0,0 +1,11 @@
+package config
+
+type OperationRecord struct {
+	Async         bool     `mapstructure:"async" json:"async" yaml:"async"`                            // 是否异步批量写入
+	BufferSize    int      `mapstructure:"buffer-size" json:"buffer-size" yaml:"buffer-size"`          // 缓冲队列长度
+	BatchSize     int      `mapstructure:"batch-size" json:"batch-size" yaml:"batch-size"`             // 单批写入条数
+	FlushInterval string   `mapstructure:"flush-interval" json:"flush-interval" yaml:"flush-interval"` // 最长刷新间隔, 如 1s
+	Policy        string   `mapstructure:"policy" json:"policy" yaml:"policy"`                         // 队列满时的策略: drop(丢弃, 默认)|block(阻塞等待)
+	Sinks         []string `mapstructure:"sinks" json:"sinks" yaml:"sinks"`                            // 输出目标: db(默认)|mongo|file
+	FilePath      string   `mapstructure:"file-path" json:"file-path" yaml:"file-path"`                // file 输出的文件路径
+}
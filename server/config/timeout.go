@@ -0,0 +1,12 @@
+package config
+
+type Timeout struct {
+	Default  string         `mapstructure:"default" json:"default" yaml:"default"`    // 接口默认超时时间, 如 30s, 为空或 0 时不限制
+	Routes   []TimeoutRoute `mapstructure:"routes" json:"routes" yaml:"routes"`       // 按路径前缀覆盖超时时间, 最长匹配优先
+	Shutdown string         `mapstructure:"shutdown" json:"shutdown" yaml:"shutdown"` // 关闭服务时等待进行中的请求、定时任务与异步写入完成的最长时间, 默认 5s
+}
+
+type TimeoutRoute struct {
+	Path    string `mapstructure:"path" json:"path" yaml:"path"`          // 路径前缀, 不含 router-prefix, 如 /fileUploadAndDownload 或 /sysExportTemplate/exportExcelByToken
+	Timeout string `mapstructure:"timeout" json:"timeout" yaml:"timeout"` // 超时时间, 0 表示不限制
+}
@@ -0,0 +1,11 @@
+package config
+
+type Trace struct {
+	Enable      bool              `mapstructure:"enable" json:"enable" yaml:"enable"`                   // 是否开启链路追踪
+	ServiceName string            `mapstructure:"service-name" json:"service-name" yaml:"service-name"` // 上报的服务名, 默认 gin-vue-admin
+	Exporter    string            `mapstructure:"exporter" json:"exporter" yaml:"exporter"`             // 导出协议: otlp-grpc(默认)|otlp-http
+	Endpoint    string            `mapstructure:"endpoint" json:"endpoint" yaml:"endpoint"`             // OTLP 接收地址, 如 127.0.0.1:4317, 为空时使用 OTEL_EXPORTER_OTLP_ENDPOINT
+	Insecure    bool              `mapstructure:"insecure" json:"insecure" yaml:"insecure"`             // 是否使用非 TLS 连接
+	Headers     map[string]string `mapstructure:"headers" json:"headers" yaml:"headers"`                // 附加的请求头, 如鉴权信息
+	SampleRatio float64           `mapstructure:"sample-ratio" json:"sample-ratio" yaml:"sample-ratio"` // 采样比例 (0,1], 不在该范围时全部采样
+}
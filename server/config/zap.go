@@ -15,6 +15,9 @@ type Zap struct {
 	ShowLine      bool   `mapstructure:"show-line" json:"show-line" yaml:"show-line"`                // 显示行
 	LogInConsole  bool   `mapstructure:"log-in-console" json:"log-in-console" yaml:"log-in-console"` // 输出控制台
 	RetentionDay  int    `mapstructure:"retention-day" json:"retention-day" yaml:"retention-day"`    // 日志保留天数
+	AccessLog     bool   `mapstructure:"access-log" json:"access-log" yaml:"access-log"`             // 是否通过 zap 记录每个请求的访问日志
+
+	Sinks []ZapSink `mapstructure:"sinks" json:"sinks" yaml:"sinks"` // 额外的日志投递目标
 }
 
 // Levels 根据字符串转化为 zapcore.Levels
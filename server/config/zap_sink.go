@@ -0,0 +1,16 @@
+package config
+
+// ZapSink 日志投递目标, 日志先写入内存队列再由后台批量发送, 队列满时丢弃并计数, 不阻塞请求
+type ZapSink struct {
+	Name          string            `mapstructure:"name" json:"name" yaml:"name"`                               // 名称, 用于统计与指标, 默认同 type
+	Type          string            `mapstructure:"type" json:"type" yaml:"type"`                               // 类型: syslog|http|loki
+	Level         string            `mapstructure:"level" json:"level" yaml:"level"`                            // 最低投递级别, 为空时与全局级别一致
+	Address       string            `mapstructure:"address" json:"address" yaml:"address"`                      // syslog 为 udp://host:514 或 tcp://host:514, 为空时使用本机 syslog; http 为接收地址; loki 为服务地址, 如 http://127.0.0.1:3100
+	Tag           string            `mapstructure:"tag" json:"tag" yaml:"tag"`                                  // syslog 标签, 默认 gin-vue-admin
+	Headers       map[string]string `mapstructure:"headers" json:"headers" yaml:"headers"`                      // http/loki 请求头, 如鉴权信息
+	Labels        map[string]string `mapstructure:"labels" json:"labels" yaml:"labels"`                         // loki 流标签, 另会附加 level 标签
+	BufferSize    int               `mapstructure:"buffer-size" json:"buffer-size" yaml:"buffer-size"`          // 队列长度, 默认 1024
+	BatchSize     int               `mapstructure:"batch-size" json:"batch-size" yaml:"batch-size"`             // 单批发送条数, 默认 100
+	FlushInterval string            `mapstructure:"flush-interval" json:"flush-interval" yaml:"flush-interval"` // 最长发送间隔, 默认 1s
+	Timeout       string            `mapstructure:"timeout" json:"timeout" yaml:"timeout"`                      // 单次发送超时, 默认 5s
+}
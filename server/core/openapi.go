@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"server/global"
+	"server/initialize"
+	"server/model/system/request"
+	"server/service/system"
+)
+
+var (
+	openApiCheck = flag.Bool("openapi-check", false, "检查路由与 sys_apis、casbin 策略及 swagger 注释的一致性后退出")
+	sdkOut       = flag.String("sdk-out", "", "生成 go 与 TypeScript 接口客户端到指定目录后退出")
+	sdkPackage   = flag.String("sdk-package", "gvaclient", "生成的 go 客户端包名")
+)
+
+// RunOpenApiCheck 使用 -openapi-check 启动时注册路由并输出检查结果, 发现问题时以状态码 1 退出
+// 未使用该参数时返回 false, 继续正常启动; 检查通过时返回 true, 由调用方退出
+func RunOpenApiCheck() bool {
+	if !*openApiCheck {
+		return false
+	}
+	if global.GVA_DB == nil {
+		fmt.Println("数据库未初始化, 无法检查 sys_apis 与 casbin 策略")
+		os.Exit(1)
+	}
+	initialize.Routers()
+	result, err := system.ApiServiceApp.CheckOpenApi(context.Background())
+	if err != nil {
+		fmt.Println("检查失败:", err)
+		os.Exit(1)
+	}
+	for _, api := range result.MissingApis {
+		fmt.Printf("[sys_apis] %-6s %s 未录入 sys_apis\n", api.Method, api.Path)
+	}
+	for _, api := range result.MissingPolicies {
+		fmt.Printf("[casbin]   %-6s %s 没有任何角色拥有该接口的权限\n", api.Method, api.Path)
+	}
+	for _, mismatch := range result.Mismatches {
+		fmt.Printf("[%s] %-6s %s %s: %s\n", mismatch.Kind, mismatch.Method, mismatch.Path, mismatch.Handler, mismatch.Message)
+	}
+	if !result.Passed() {
+		fmt.Printf("共发现 %d 个路由未录入, %d 个接口缺少权限, %d 处注释不一致\n", len(result.MissingApis), len(result.MissingPolicies), len(result.Mismatches))
+		os.Exit(1)
+	}
+	fmt.Println("检查通过")
+	return true
+}
+
+// RunSdkGenerate 使用 -sdk-out 启动时注册路由, 生成 <目录>/<包名>/<包名>.go 与 <目录>/gvaClient.ts
+// 未使用该参数时返回 false, 继续正常启动; 生成成功时返回 true, 由调用方退出
+func RunSdkGenerate() bool {
+	if *sdkOut == "" {
+		return false
+	}
+	initialize.Routers()
+	for _, language := range []string{"go", "typescript"} {
+		filename, content, err := system.ApiServiceApp.GenerateSdk(request.SdkGenerate{Language: language, Package: *sdkPackage})
+		if err != nil {
+			fmt.Println("生成客户端失败:", err)
+			os.Exit(1)
+		}
+		dir := *sdkOut
+		if language == "go" {
+			dir = filepath.Join(dir, *sdkPackage)
+		}
+		if err = os.MkdirAll(dir, os.ModePerm); err == nil {
+			err = os.WriteFile(filepath.Join(dir, filename), content, 0o644)
+		}
+		if err != nil {
+			fmt.Println("写入客户端失败:", err)
+			os.Exit(1)
+		}
+		fmt.Println("已生成", filepath.Join(dir, filename))
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"server/utils/secret"
+)
+
+var encryptValue = flag.String("encrypt", "", "使用主密钥(环境变量 GVA_MASTER_KEY 或 GVA_MASTER_KEY_FILE)加密配置值, 输出 ENC(...) 后退出; 值为 - 时从标准输入读取, 避免明文留在命令历史中")
+
+// runEncrypt 使用 -encrypt 参数时输出加密后的配置值并退出
+func runEncrypt() {
+	if *encryptValue == "" {
+		return
+	}
+	key, err := secret.MasterKey()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	plaintext := *encryptValue
+	if plaintext == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Println("读取标准输入失败:", err)
+			os.Exit(1)
+		}
+		plaintext = strings.TrimRight(string(data), "\r\n")
+	}
+	value, err := secret.Encrypt(plaintext, key)
+	if err != nil {
+		fmt.Println("加密失败:", err)
+		os.Exit(1)
+	}
+	fmt.Println(value)
+	os.Exit(0)
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"server/global"
 	"server/initialize"
@@ -10,15 +11,15 @@ import (
 )
 
 func RunServer() {
-	if global.GVA_CONFIG.System.UseRedis {
+	if global.GVA_CONFIG.Load().System.UseRedis {
 		// 初始化redis服务
 		initialize.Redis()
-		if global.GVA_CONFIG.System.UseMultipoint {
+		if global.GVA_CONFIG.Load().System.UseMultipoint {
 			initialize.RedisList()
 		}
 	}
 
-	if global.GVA_CONFIG.System.UseMongo {
+	if global.GVA_CONFIG.Load().System.UseMongo {
 		err := initialize.Mongo.Initialization()
 		if err != nil {
 			zap.L().Error(fmt.Sprintf("%+v", err))
@@ -26,12 +27,12 @@ func RunServer() {
 	}
 	// 从db加载jwt数据
 	if global.GVA_DB != nil {
-		system.LoadAll()
+		system.LoadAll(context.Background())
 	}
 
 	Router := initialize.Routers()
 
-	address := fmt.Sprintf(":%d", global.GVA_CONFIG.System.Addr)
+	address := fmt.Sprintf(":%d", global.GVA_CONFIG.Load().System.Addr)
 
 	fmt.Printf(`
 	欢迎使用 gin-vue-admin
@@ -48,6 +49,6 @@ func RunServer() {
 	** 版权所有方：flipped-aurora开源团队 **
 	** 版权持有公司：北京翻转极光科技有限责任公司 **
 	** 剔除授权标识需购买商用授权：https://gin-vue-admin.com/empower/index.html **
-`, address, address, global.GVA_CONFIG.MCP.SSEPath, address, global.GVA_CONFIG.MCP.MessagePath)
+`, address, address, global.GVA_CONFIG.Load().MCP.SSEPath, address, global.GVA_CONFIG.Load().MCP.MessagePath)
 	initServer(address, Router, 10*time.Minute, 10*time.Minute)
 }
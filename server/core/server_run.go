@@ -9,6 +9,12 @@ import (
 	"syscall"
 	"time"
 
+	"server/global"
+	"server/service/system"
+	"server/utils/health"
+	"server/utils/logging"
+	"server/utils/tracing"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -45,16 +51,51 @@ func initServer(address string, router *gin.Engine, readTimeout, writeTimeout ti
 	// kill -9 发送 syscall.SIGKILL，但是无法被捕获，所以不需要添加
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	// 先置为未就绪, 等待负载均衡摘除流量后再关闭服务
+	health.SetShuttingDown()
+	if delay, _ := time.ParseDuration(global.GVA_CONFIG.Load().Health.ShutdownDelay); delay > 0 {
+		zap.L().Info("等待摘除流量", zap.Duration("delay", delay))
+		time.Sleep(delay)
+	}
 	zap.L().Info("关闭WEB服务...")
 
-	// 设置5秒的超时时间
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// 等待进行中的请求、定时任务与异步写入完成的最长时间
+	timeout, err := time.ParseDuration(global.GVA_CONFIG.Load().Timeout.Shutdown)
+	if err != nil || timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	defer cancel()
 
+	// 关闭失败时继续后续清理, 避免丢失缓冲中的数据
 	if err := srv.Shutdown(ctx); err != nil {
-		zap.L().Fatal("WEB服务关闭异常", zap.Error(err))
+		zap.L().Error("WEB服务关闭异常", zap.Error(err))
+	}
+
+	// 停止定时任务并等待执行中的任务结束
+	if global.GVA_Timer != nil {
+		if err := global.GVA_Timer.Shutdown(ctx); err != nil {
+			zap.L().Error("等待定时任务结束超时", zap.Error(err))
+		}
+	}
+
+	// 写出缓冲中的操作记录
+	if system.OperationRecordWriterApp != nil {
+		if err := system.OperationRecordWriterApp.Stop(ctx); err != nil {
+			zap.L().Error("操作记录写出超时", zap.Error(err))
+		}
+	}
+
+	// 导出缓冲中的链路数据
+	if err := tracing.Shutdown(ctx); err != nil {
+		zap.L().Error("链路数据导出失败", zap.Error(err))
 	}
 
 	zap.L().Info("WEB服务已关闭")
+
+	// 发送日志投递目标中剩余的日志, 放在最后以包含关闭过程的日志
+	if err := logging.Close(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "log sinks flush failed: %v\n", err)
+	}
 }
@@ -6,8 +6,12 @@ import (
 	"os"
 	"path/filepath"
 
+	"server/config"
 	"server/core/internal"
 	"server/global"
+	"server/initialize"
+	"server/utils"
+	"server/utils/secret"
 	"github.com/fsnotify/fsnotify"
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
@@ -15,10 +19,11 @@ import (
 
 // Viper 配置
 func Viper() *viper.Viper {
-	config := getConfigPath()
+	file := getConfigPath()
+	runEncrypt()
 
 	v := viper.New()
-	v.SetConfigFile(config)
+	v.SetConfigFile(file)
 	v.SetConfigType("yaml")
 	err := v.ReadInConfig()
 	if err != nil {
@@ -26,18 +31,21 @@ func Viper() *viper.Viper {
 	}
 	v.WatchConfig()
 
+	// 配置文件变化时只重新加载发生变化的配置项, 校验失败时保持原配置
 	v.OnConfigChange(func(e fsnotify.Event) {
 		fmt.Println("config file changed:", e.Name)
-		if err = v.Unmarshal(&global.GVA_CONFIG); err != nil {
-			fmt.Println(err)
-		}
+		_ = initialize.ApplyConfig(v)
 	})
-	if err = v.Unmarshal(&global.GVA_CONFIG); err != nil {
+	utils.GlobalSystemEvents.RegisterConfigHandler(reloadZap, "zap")
+	// 解析 ${ENV} 与 ENC(...) 值
+	var conf config.Server
+	if err = v.Unmarshal(&conf, secret.DecodeHook()); err != nil {
 		panic(fmt.Errorf("fatal error unmarshal config: %w", err))
 	}
 
 	// root 适配性 根据root位置去找到对应迁移位置,保证root路径有效
-	global.GVA_CONFIG.AutoCode.Root, _ = filepath.Abs("..")
+	conf.AutoCode.Root, _ = filepath.Abs("..")
+	global.GVA_CONFIG.Store(&conf)
 	return v
 }
 
@@ -2,9 +2,12 @@ package core
 
 import (
 	"fmt"
+	"reflect"
+	"server/config"
 	"server/core/internal"
 	"server/global"
 	"server/utils"
+	"server/utils/logging"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"os"
@@ -13,20 +16,43 @@ import (
 // Zap 获取 zap.Logger
 // Author [SliverHorn](https://github.com/SliverHorn)
 func Zap() (logger *zap.Logger) {
-	if ok, _ := utils.PathExists(global.GVA_CONFIG.Zap.Director); !ok { // 判断是否有Director文件夹
-		fmt.Printf("create %v directory\n", global.GVA_CONFIG.Zap.Director)
-		_ = os.Mkdir(global.GVA_CONFIG.Zap.Director, os.ModePerm)
+	if ok, _ := utils.PathExists(global.GVA_CONFIG.Load().Zap.Director); !ok { // 判断是否有Director文件夹
+		fmt.Printf("create %v directory\n", global.GVA_CONFIG.Load().Zap.Director)
+		_ = os.Mkdir(global.GVA_CONFIG.Load().Zap.Director, os.ModePerm)
 	}
-	levels := global.GVA_CONFIG.Zap.Levels()
-	length := len(levels)
-	cores := make([]zapcore.Core, 0, length)
-	for i := 0; i < length; i++ {
-		core := internal.NewZapCore(levels[i])
-		cores = append(cores, core)
+	// 为所有级别创建输出, 实际输出的级别由 logging.Levels 控制, 运行时可通过接口调整
+	cores := make([]zapcore.Core, 0, zapcore.FatalLevel-zapcore.DebugLevel+1)
+	for level := zapcore.DebugLevel; level <= zapcore.FatalLevel; level++ {
+		cores = append(cores, internal.NewZapCore(level))
 	}
-	logger = zap.New(zapcore.NewTee(cores...))
-	if global.GVA_CONFIG.Zap.ShowLine {
+	sinkCores, err := logging.NewSinkCores(global.GVA_CONFIG.Load().Zap.Sinks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create log sinks failed: %v\n", err)
+	}
+	cores = append(cores, sinkCores...)
+	logging.Levels.Reset(global.GVA_CONFIG.Load().Zap.Levels()[0])
+	logger = zap.New(logging.Levels.Wrap(zapcore.NewTee(cores...)))
+	if global.GVA_CONFIG.Load().Zap.ShowLine {
 		logger = logger.WithOptions(zap.AddCaller())
 	}
 	return logger
 }
+
+// reloadZap 只有日志级别变化时直接调整全局级别并保留按名称覆盖的级别, 其他日志配置变化时重建 logger
+func reloadZap(oldConf, newConf *config.Server) (utils.ConfigReload, error) {
+	level, err := zapcore.ParseLevel(newConf.Zap.Level)
+	if err != nil {
+		return utils.ConfigReload{}, fmt.Errorf("zap.level: %w", err)
+	}
+	oldZap, newZap := oldConf.Zap, newConf.Zap
+	oldZap.Level = newZap.Level
+	if reflect.DeepEqual(oldZap, newZap) {
+		return utils.ConfigReload{Apply: func() {
+			logging.Levels.SetLevel("", level)
+		}}, nil
+	}
+	return utils.ConfigReload{Apply: func() {
+		global.GVA_LOG = Zap()
+		zap.ReplaceGlobals(global.GVA_LOG)
+	}}, nil
+}
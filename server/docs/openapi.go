@@ -0,0 +1,10 @@
+package docs
+
+import _ "embed"
+
+//go:generate go run ../utils/openapi/gen -root .. -output openapi.json
+
+// OpenApiSource 编译时从源码分析得到的接口定义, 运行环境没有源码时用于生成 OpenAPI 文档
+//
+//go:embed openapi.json
+var OpenApiSource []byte
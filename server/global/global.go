@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/mark3labs/mcp-go/server"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 	"github.com/qiniu/qmgo"
@@ -28,7 +29,7 @@ var (
 	GVA_REDIS     redis.UniversalClient
 	GVA_REDISList map[string]redis.UniversalClient
 	GVA_MONGO     *qmgo.QmgoClient
-	GVA_CONFIG    config.Server
+	GVA_CONFIG    atomic.Pointer[config.Server] // 当前配置, 热加载时整体替换; 读取后不要修改, 修改需复制后 Store
 	GVA_VP        *viper.Viper
 	// GVA_LOG    *oplogging.Logger
 	GVA_LOG                 *zap.Logger
@@ -39,8 +40,22 @@ var (
 	GVA_MCP_SERVER          *server.MCPServer
 	BlackCache              local_cache.Cache
 	lock                    sync.RWMutex
+	configLock              sync.Mutex
 )
 
+func init() {
+	GVA_CONFIG.Store(new(config.Server))
+}
+
+// UpdateConfig 复制当前配置, 修改后整体替换, 读取中的配置不受影响
+func UpdateConfig(update func(conf *config.Server)) {
+	configLock.Lock()
+	defer configLock.Unlock()
+	conf := *GVA_CONFIG.Load()
+	update(&conf)
+	GVA_CONFIG.Store(&conf)
+}
+
 // GetGlobalDBByDBName 通过名称获取db list中的db
 func GetGlobalDBByDBName(dbname string) *gorm.DB {
 	lock.RLock()
@@ -0,0 +1,18 @@
+package initialize
+
+import (
+	"server/global"
+	"server/utils/changelog"
+
+	"go.uber.org/zap"
+)
+
+// ChangeHistory 按配置为 global.GVA_DB 注册数据变更历史插件
+func ChangeHistory() {
+	if global.GVA_DB == nil || !global.GVA_CONFIG.Load().ChangeHistory.Enable {
+		return
+	}
+	if err := usePlugin(global.GVA_DB, changelog.New(global.GVA_CONFIG.Load().ChangeHistory)); err != nil {
+		global.GVA_LOG.Error("register change history plugin failed", zap.Error(err))
+	}
+}
@@ -10,21 +10,12 @@ const sys = "system"
 
 func DBList() {
 	dbMap := make(map[string]*gorm.DB)
-	for _, info := range global.GVA_CONFIG.DBList {
+	for _, info := range global.GVA_CONFIG.Load().DBList {
 		if info.Disable {
 			continue
 		}
-		switch info.Type {
-		case "mysql":
-			dbMap[info.AliasName] = GormMysqlByConfig(config.Mysql{GeneralDB: info.GeneralDB})
-		case "mssql":
-			dbMap[info.AliasName] = GormMssqlByConfig(config.Mssql{GeneralDB: info.GeneralDB})
-		case "pgsql":
-			dbMap[info.AliasName] = GormPgSqlByConfig(config.Pgsql{GeneralDB: info.GeneralDB})
-		case "oracle":
-			dbMap[info.AliasName] = GormOracleByConfig(config.Oracle{GeneralDB: info.GeneralDB})
-		default:
-			continue
+		if db := GormByDBListConfig(info); db != nil {
+			dbMap[info.AliasName] = db
 		}
 	}
 	// 做特殊判断,是否有迁移
@@ -34,3 +25,37 @@ func DBList() {
 	}
 	global.GVA_DBList = dbMap
 }
+
+// GormByDBListConfig 按多数据库配置中的一项连接数据库, 不支持的类型返回 nil
+func GormByDBListConfig(info config.SpecializedDB) *gorm.DB {
+	switch info.Type {
+	case "mysql":
+		return GormMysqlByConfig(config.Mysql{GeneralDB: info.GeneralDB})
+	case "mssql":
+		return GormMssqlByConfig(config.Mssql{GeneralDB: info.GeneralDB})
+	case "pgsql":
+		return GormPgSqlByConfig(config.Pgsql{GeneralDB: info.GeneralDB})
+	case "oracle":
+		return GormOracleByConfig(config.Oracle{GeneralDB: info.GeneralDB})
+	default:
+		return nil
+	}
+}
+
+// dbListDsn 返回多数据库配置中一项的连接串
+func dbListDsn(info config.SpecializedDB) string {
+	var provider config.DsnProvider
+	switch info.Type {
+	case "mysql":
+		provider = &config.Mysql{GeneralDB: info.GeneralDB}
+	case "mssql":
+		provider = &config.Mssql{GeneralDB: info.GeneralDB}
+	case "pgsql":
+		provider = &config.Pgsql{GeneralDB: info.GeneralDB}
+	case "oracle":
+		provider = &config.Oracle{GeneralDB: info.GeneralDB}
+	default:
+		return ""
+	}
+	return info.Type + ":" + provider.Dsn()
+}
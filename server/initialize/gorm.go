@@ -3,6 +3,7 @@ package initialize
 import (
 	"os"
 
+	"server/config"
 	"server/global"
 	"server/model/example"
 	"server/model/system"
@@ -12,28 +13,60 @@ import (
 )
 
 func Gorm() *gorm.DB {
-	switch global.GVA_CONFIG.System.DbType {
+	switch global.GVA_CONFIG.Load().System.DbType {
 	case "mysql":
-		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Mysql.Dbname
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Mysql.Dbname
 		return GormMysql()
 	case "pgsql":
-		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Pgsql.Dbname
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Pgsql.Dbname
 		return GormPgSql()
 	case "oracle":
-		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Oracle.Dbname
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Oracle.Dbname
 		return GormOracle()
 	case "mssql":
-		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Mssql.Dbname
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Mssql.Dbname
 		return GormMssql()
 	case "sqlite":
-		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Sqlite.Dbname
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Sqlite.Dbname
 		return GormSqlite()
 	default:
-		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Mysql.Dbname
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Mysql.Dbname
 		return GormMysql()
 	}
 }
 
+// GormByConfig 按传入的配置连接主数据库, 用于配置热加载时在替换全局配置前建立新连接
+func GormByConfig(conf *config.Server) *gorm.DB {
+	switch conf.System.DbType {
+	case "pgsql":
+		return GormPgSqlByConfig(conf.Pgsql)
+	case "oracle":
+		return GormOracleByConfig(conf.Oracle)
+	case "mssql":
+		return GormMssqlByConfig(conf.Mssql)
+	case "sqlite":
+		return GormSqliteByConfig(conf.Sqlite)
+	default:
+		return GormMysqlByConfig(conf.Mysql)
+	}
+}
+
+// activeDB 返回主数据库的类型、通用配置与连接串
+func activeDB(conf *config.Server) (dbType string, general config.GeneralDB, dsn string) {
+	switch conf.System.DbType {
+	case "pgsql":
+		return "pgsql", conf.Pgsql.GeneralDB, conf.Pgsql.Dsn()
+	case "oracle":
+		return "oracle", conf.Oracle.GeneralDB, conf.Oracle.Dsn()
+	case "mssql":
+		return "mssql", conf.Mssql.GeneralDB, conf.Mssql.Dsn()
+	case "sqlite":
+		return "sqlite", conf.Sqlite.GeneralDB, conf.Sqlite.Dsn()
+	default:
+		return "mysql", conf.Mysql.GeneralDB, conf.Mysql.Dsn()
+	}
+}
+
 func RegisterTables() {
 	db := global.GVA_DB
 	err := db.AutoMigrate(
@@ -46,12 +79,17 @@ func RegisterTables() {
 		system.SysAuthority{},
 		system.SysDictionary{},
 		system.SysOperationRecord{},
+		system.SysAuditLog{},
+		system.SysAuditCheckpoint{},
+		system.SysChangeHistory{},
 		system.SysAutoCodeHistory{},
+		system.SysAutoCodeMigration{},
 		system.SysDictionaryDetail{},
 		system.SysBaseMenuParameter{},
 		system.SysBaseMenuBtn{},
 		system.SysAuthorityBtn{},
 		system.SysAutoCodePackage{},
+		system.SysAutoCodeTemplateSet{},
 		system.SysExportTemplate{},
 		system.Condition{},
 		system.JoinTemplate{},
@@ -20,7 +20,7 @@ import (
 // GormMssql 初始化Mssql数据库
 // Author [LouisZhang](191180776@qq.com)
 func GormMssql() *gorm.DB {
-	m := global.GVA_CONFIG.Mssql
+	m := global.GVA_CONFIG.Load().Mssql
 	if m.Dbname == "" {
 		return nil
 	}
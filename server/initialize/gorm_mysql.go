@@ -14,7 +14,7 @@ import (
 // Author [SliverHorn](https://github.com/SliverHorn)
 // Author [ByteZhou-2018](https://github.com/ByteZhou-2018)
 func GormMysql() *gorm.DB {
-	m := global.GVA_CONFIG.Mysql
+	m := global.GVA_CONFIG.Load().Mysql
 	return initMysqlDatabase(m)
 }
 
@@ -12,7 +12,7 @@ import (
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
 func GormPgSql() *gorm.DB {
-	p := global.GVA_CONFIG.Pgsql
+	p := global.GVA_CONFIG.Load().Pgsql
 	return initPgSqlDatabase(p)
 }
 
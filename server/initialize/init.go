@@ -12,4 +12,6 @@ func SetupHandlers() {
 	utils.GlobalSystemEvents.RegisterReloadHandler(func() error {
 		return Reload()
 	})
+	// 注册配置项热加载处理函数
+	ConfigHandlers()
 }
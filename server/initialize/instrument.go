@@ -0,0 +1,53 @@
+package initialize
+
+import (
+	"server/global"
+	"server/utils/metrics"
+	"server/utils/tracing"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Trace 按配置初始化链路追踪, 需在连接数据库与 Redis 之前调用
+func Trace() {
+	if !global.GVA_CONFIG.Load().Trace.Enable {
+		return
+	}
+	if err := tracing.Init(global.GVA_CONFIG.Load().Trace); err != nil {
+		global.GVA_LOG.Error("init trace failed", zap.Error(err))
+	}
+}
+
+// Instrument 按配置为 global.GVA_DB 与 global.GVA_DBList 注册数据库指标与链路追踪插件
+func Instrument() {
+	registered := make(map[*gorm.DB]bool)
+	use := func(name string, db *gorm.DB) {
+		if db == nil || registered[db] {
+			return
+		}
+		registered[db] = true
+		if global.GVA_CONFIG.Load().Metrics.Enable {
+			if err := usePlugin(db, metrics.NewGormPlugin(name)); err != nil {
+				global.GVA_LOG.Error("register metrics plugin failed", zap.String("db", name), zap.Error(err))
+			}
+		}
+		if global.GVA_CONFIG.Load().Trace.Enable {
+			if err := usePlugin(db, tracing.GormPlugin(name)); err != nil {
+				global.GVA_LOG.Error("register trace plugin failed", zap.String("db", name), zap.Error(err))
+			}
+		}
+	}
+	use("default", global.GVA_DB)
+	for name, db := range global.GVA_DBList {
+		use(name, db)
+	}
+}
+
+// usePlugin 注册 gorm 插件, 已注册同名插件时跳过, 使配置热加载后可重复调用
+func usePlugin(db *gorm.DB, plugin gorm.Plugin) error {
+	if _, ok := db.Config.Plugins[plugin.Name()]; ok {
+		return nil
+	}
+	return db.Use(plugin)
+}
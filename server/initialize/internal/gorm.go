@@ -3,6 +3,7 @@ package internal
 import (
 	"server/config"
 	"server/global"
+	"server/utils/tracing"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
@@ -17,26 +18,30 @@ type _gorm struct{}
 // Author [SliverHorn](https://github.com/SliverHorn)
 func (g *_gorm) Config(prefix string, singular bool) *gorm.Config {
 	var general config.GeneralDB
-	switch global.GVA_CONFIG.System.DbType {
+	switch global.GVA_CONFIG.Load().System.DbType {
 	case "mysql":
-		general = global.GVA_CONFIG.Mysql.GeneralDB
+		general = global.GVA_CONFIG.Load().Mysql.GeneralDB
 	case "pgsql":
-		general = global.GVA_CONFIG.Pgsql.GeneralDB
+		general = global.GVA_CONFIG.Load().Pgsql.GeneralDB
 	case "oracle":
-		general = global.GVA_CONFIG.Oracle.GeneralDB
+		general = global.GVA_CONFIG.Load().Oracle.GeneralDB
 	case "sqlite":
-		general = global.GVA_CONFIG.Sqlite.GeneralDB
+		general = global.GVA_CONFIG.Load().Sqlite.GeneralDB
 	case "mssql":
-		general = global.GVA_CONFIG.Mssql.GeneralDB
+		general = global.GVA_CONFIG.Load().Mssql.GeneralDB
 	default:
-		general = global.GVA_CONFIG.Mysql.GeneralDB
+		general = global.GVA_CONFIG.Load().Mysql.GeneralDB
+	}
+	var gormLogger logger.Interface = logger.New(NewWriter(general), logger.Config{
+		SlowThreshold: 200 * time.Millisecond,
+		LogLevel:      general.LogLevel(),
+		Colorful:      true,
+	})
+	if global.GVA_CONFIG.Load().Trace.Enable {
+		gormLogger = tracing.GormLogger{Interface: gormLogger}
 	}
 	return &gorm.Config{
-		Logger: logger.New(NewWriter(general), logger.Config{
-			SlowThreshold: 200 * time.Millisecond,
-			LogLevel:      general.LogLevel(),
-			Colorful:      true,
-		}),
+		Logger: gormLogger,
 		NamingStrategy: schema.NamingStrategy{
 			TablePrefix:   prefix,
 			SingularTable: singular,
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"server/config"
 	"server/global"
+	"server/utils/logging"
 	"gorm.io/gorm/logger"
 )
 
@@ -24,17 +25,18 @@ func (c *Writer) Printf(message string, data ...any) {
 
 	// 当开启了zap的情况，会打印到日志记录
 	if c.config.LogZap {
+		log := global.GVA_LOG.Named(logging.NameGorm)
 		switch c.config.LogLevel() {
 		case logger.Silent:
-			global.GVA_LOG.Debug(fmt.Sprintf(message, data...))
+			log.Debug(fmt.Sprintf(message, data...))
 		case logger.Error:
-			global.GVA_LOG.Error(fmt.Sprintf(message, data...))
+			log.Error(fmt.Sprintf(message, data...))
 		case logger.Warn:
-			global.GVA_LOG.Warn(fmt.Sprintf(message, data...))
+			log.Warn(fmt.Sprintf(message, data...))
 		case logger.Info:
-			global.GVA_LOG.Info(fmt.Sprintf(message, data...))
+			log.Info(fmt.Sprintf(message, data...))
 		default:
-			global.GVA_LOG.Info(fmt.Sprintf(message, data...))
+			log.Info(fmt.Sprintf(message, data...))
 		}
 		return
 	}
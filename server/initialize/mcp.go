@@ -6,20 +6,29 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func McpRun() *server.SSEServer {
-	config := global.GVA_CONFIG.MCP
+// McpRun 创建MCP服务, 同时提供旧版 SSE 与 Streamable HTTP 两种传输方式
+func McpRun() (*server.SSEServer, *server.StreamableHTTPServer) {
+	config := global.GVA_CONFIG.Load().MCP
 
+	// 调用方由 SSE 与消息接口的 middleware.McpAuth 认证, 工具按角色的 casbin 策略授权
 	s := server.NewMCPServer(
 		config.Name,
 		config.Version,
+		server.WithToolHandlerMiddleware(mcpTool.AuthorizeTool),
+		server.WithToolFilter(mcpTool.FilterTools),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
 	)
 
 	global.GVA_MCP_SERVER = s
 
 	mcpTool.RegisterAllTools(s)
+	mcpTool.RegisterAllResources(s)
+	mcpTool.RegisterAllPrompts(s)
 
-	return server.NewSSEServer(s,
+	sseServer := server.NewSSEServer(s,
 		server.WithSSEEndpoint(config.SSEPath),
 		server.WithMessageEndpoint(config.MessagePath),
 		server.WithBaseURL(config.UrlPrefix))
+	return sseServer, server.NewStreamableHTTPServer(s, server.WithEndpointPath(config.StreamablePath))
 }
@@ -41,24 +41,24 @@ func (m *mongo) Indexes(ctx context.Context) error {
 
 func (m *mongo) Initialization() error {
 	var opts []options.ClientOptions
-	if global.GVA_CONFIG.Mongo.IsZap {
+	if global.GVA_CONFIG.Load().Mongo.IsZap {
 		opts = internal.Mongo.GetClientOptions()
 	}
 	ctx := context.Background()
 	config := &qmgo.Config{
-		Uri:              global.GVA_CONFIG.Mongo.Uri(),
-		Coll:             global.GVA_CONFIG.Mongo.Coll,
-		Database:         global.GVA_CONFIG.Mongo.Database,
-		MinPoolSize:      &global.GVA_CONFIG.Mongo.MinPoolSize,
-		MaxPoolSize:      &global.GVA_CONFIG.Mongo.MaxPoolSize,
-		SocketTimeoutMS:  &global.GVA_CONFIG.Mongo.SocketTimeoutMs,
-		ConnectTimeoutMS: &global.GVA_CONFIG.Mongo.ConnectTimeoutMs,
+		Uri:              global.GVA_CONFIG.Load().Mongo.Uri(),
+		Coll:             global.GVA_CONFIG.Load().Mongo.Coll,
+		Database:         global.GVA_CONFIG.Load().Mongo.Database,
+		MinPoolSize:      &global.GVA_CONFIG.Load().Mongo.MinPoolSize,
+		MaxPoolSize:      &global.GVA_CONFIG.Load().Mongo.MaxPoolSize,
+		SocketTimeoutMS:  &global.GVA_CONFIG.Load().Mongo.SocketTimeoutMs,
+		ConnectTimeoutMS: &global.GVA_CONFIG.Load().Mongo.ConnectTimeoutMs,
 	}
-	if global.GVA_CONFIG.Mongo.Username != "" && global.GVA_CONFIG.Mongo.Password != "" {
+	if global.GVA_CONFIG.Load().Mongo.Username != "" && global.GVA_CONFIG.Load().Mongo.Password != "" {
 		config.Auth = &qmgo.Credential{
-			Username:   global.GVA_CONFIG.Mongo.Username,
-			Password:   global.GVA_CONFIG.Mongo.Password,
-			AuthSource: global.GVA_CONFIG.Mongo.AuthSource,
+			Username:   global.GVA_CONFIG.Load().Mongo.Username,
+			Password:   global.GVA_CONFIG.Load().Mongo.Password,
+			AuthSource: global.GVA_CONFIG.Load().Mongo.AuthSource,
 		}
 	}
 	client, err := qmgo.Open(ctx, config, opts...)
@@ -0,0 +1,62 @@
+package initialize
+
+import (
+	"server/global"
+	"server/service/system"
+
+	"go.uber.org/zap"
+)
+
+// OperationRecordWriter 按配置初始化操作记录写入器
+func OperationRecordWriter() {
+	conf := global.GVA_CONFIG.Load().OperationRecord
+	var sinks []system.OperationRecordSink
+	for _, name := range conf.Sinks {
+		switch name {
+		case "db":
+			sinks = append(sinks, system.DBOperationRecordSink{})
+		case "mongo":
+			if !global.GVA_CONFIG.Load().System.UseMongo {
+				global.GVA_LOG.Warn("operation record mongo sink requires system.use-mongo, skipped")
+				continue
+			}
+			sinks = append(sinks, system.MongoOperationRecordSink{})
+		case "file":
+			if conf.FilePath == "" {
+				global.GVA_LOG.Warn("operation record file sink requires file-path, skipped")
+				continue
+			}
+			sinks = append(sinks, &system.FileOperationRecordSink{Path: conf.FilePath})
+		default:
+			global.GVA_LOG.Warn("unknown operation record sink", zap.String("sink", name))
+		}
+	}
+	if global.GVA_CONFIG.Load().Audit.Enable {
+		// 审计需在数据库写入后执行以获取记录id, 数据库须为第一个输出目标
+		if _, ok := firstSink(sinks).(system.DBOperationRecordSink); !ok {
+			if len(sinks) > 0 {
+				global.GVA_LOG.Warn("audit requires db as the first operation record sink, db sink prepended")
+			}
+			sinks = append([]system.OperationRecordSink{system.DBOperationRecordSink{}}, removeDBSink(sinks)...)
+		}
+		sinks = append(sinks, system.AuditOperationRecordSink{})
+	}
+	system.OperationRecordWriterApp = system.NewOperationRecordWriter(conf, sinks...)
+}
+
+func firstSink(sinks []system.OperationRecordSink) system.OperationRecordSink {
+	if len(sinks) == 0 {
+		return nil
+	}
+	return sinks[0]
+}
+
+func removeDBSink(sinks []system.OperationRecordSink) []system.OperationRecordSink {
+	out := make([]system.OperationRecordSink, 0, len(sinks))
+	for _, sink := range sinks {
+		if _, ok := sink.(system.DBOperationRecordSink); !ok {
+			out = append(out, sink)
+		}
+	}
+	return out
+}
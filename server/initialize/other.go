@@ -6,16 +6,17 @@ import (
 	"os"
 	"strings"
 
+	"server/config"
 	"server/global"
 	"server/utils"
 )
 
 func OtherInit() {
-	dr, err := utils.ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
+	dr, err := utils.ParseDuration(global.GVA_CONFIG.Load().JWT.ExpiresTime)
 	if err != nil {
 		panic(err)
 	}
-	_, err = utils.ParseDuration(global.GVA_CONFIG.JWT.BufferTime)
+	_, err = utils.ParseDuration(global.GVA_CONFIG.Load().JWT.BufferTime)
 	if err != nil {
 		panic(err)
 	}
@@ -24,9 +25,10 @@ func OtherInit() {
 		local_cache.SetDefaultExpire(dr),
 	)
 	file, err := os.Open("go.mod")
-	if err == nil && global.GVA_CONFIG.AutoCode.Module == "" {
+	if err == nil && global.GVA_CONFIG.Load().AutoCode.Module == "" {
 		scanner := bufio.NewScanner(file)
 		scanner.Scan()
-		global.GVA_CONFIG.AutoCode.Module = strings.TrimPrefix(scanner.Text(), "module ")
+		module := strings.TrimPrefix(scanner.Text(), "module ")
+		global.UpdateConfig(func(conf *config.Server) { conf.AutoCode.Module = module })
 	}
 }
@@ -5,6 +5,8 @@ import (
 
 	"server/config"
 	"server/global"
+	"server/utils/metrics"
+	"server/utils/tracing"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -26,6 +28,18 @@ func initRedisClient(redisCfg config.Redis) (redis.UniversalClient, error) {
 			DB:       redisCfg.DB,
 		})
 	}
+	if global.GVA_CONFIG.Load().Metrics.Enable {
+		name := redisCfg.Name
+		if name == "" {
+			name = "default"
+		}
+		client.AddHook(metrics.NewRedisHook(name))
+	}
+	if global.GVA_CONFIG.Load().Trace.Enable {
+		if err := tracing.InstrumentRedis(client); err != nil {
+			global.GVA_LOG.Error("redis instrument tracing failed", zap.String("name", redisCfg.Name), zap.Error(err))
+		}
+	}
 	pong, err := client.Ping(context.Background()).Result()
 	if err != nil {
 		global.GVA_LOG.Error("redis connect ping failed, err:", zap.String("name", redisCfg.Name), zap.Error(err))
@@ -37,7 +51,7 @@ func initRedisClient(redisCfg config.Redis) (redis.UniversalClient, error) {
 }
 
 func Redis() {
-	redisClient, err := initRedisClient(global.GVA_CONFIG.Redis)
+	redisClient, err := initRedisClient(global.GVA_CONFIG.Load().Redis)
 	if err != nil {
 		panic(err)
 	}
@@ -47,7 +61,7 @@ func Redis() {
 func RedisList() {
 	redisMap := make(map[string]redis.UniversalClient)
 
-	for _, redisCfg := range global.GVA_CONFIG.RedisList {
+	for _, redisCfg := range global.GVA_CONFIG.Load().RedisList {
 		client, err := initRedisClient(redisCfg)
 		if err != nil {
 			panic(err)
@@ -1,11 +1,28 @@
 package initialize
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+
+	"server/config"
 	"server/global"
+	"server/middleware"
+	"server/utils"
+	"server/utils/secret"
+	"server/utils/upload"
+
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
-// Reload 优雅地重新加载系统配置
+// dbCloseDelay 数据库连接切换后关闭原连接池前的等待时间
+const dbCloseDelay = 30 * time.Second
+
+// Reload 重新读取配置文件, 只重新加载发生变化的配置项
 func Reload() error {
 	global.GVA_LOG.Info("正在重新加载系统配置...")
 
@@ -14,32 +31,329 @@ func Reload() error {
 		global.GVA_LOG.Error("重新读取配置文件失败!", zap.Error(err))
 		return err
 	}
+	if err := ApplyConfig(global.GVA_VP); err != nil {
+		return err
+	}
 
-	// 重新初始化数据库连接
-	if global.GVA_DB != nil {
-		db, _ := global.GVA_DB.DB()
-		err := db.Close()
-		if err != nil {
-			global.GVA_LOG.Error("关闭原数据库连接失败!", zap.Error(err))
-			return err
-		}
+	global.GVA_LOG.Info("系统配置重新加载完成")
+	return nil
+}
+
+// ApplyConfig 解析 viper 中的配置并应用, 配置文件变化与手动重载共用
+func ApplyConfig(v *viper.Viper) error {
+	var conf config.Server
+	if err := v.Unmarshal(&conf, secret.DecodeHook()); err != nil {
+		global.GVA_LOG.Error("解析配置文件失败!", zap.Error(err))
+		return err
+	}
+	// 以下字段在启动时计算, 不以配置文件为准
+	conf.AutoCode.Root = global.GVA_CONFIG.Load().AutoCode.Root
+	if conf.AutoCode.Module == "" {
+		conf.AutoCode.Module = global.GVA_CONFIG.Load().AutoCode.Module
+	}
+	changed, err := utils.GlobalSystemEvents.ApplyConfig(conf)
+	if err != nil {
+		global.GVA_LOG.Error("配置未生效!", zap.Strings("sections", changed), zap.Error(err))
+		return err
+	}
+	if len(changed) > 0 {
+		global.GVA_LOG.Info("配置已更新", zap.Strings("sections", changed))
 	}
+	return nil
+}
 
-	// 重新建立数据库连接
-	global.GVA_DB = Gorm()
+// ConfigHandlers 注册各配置项的热加载处理函数
+func ConfigHandlers() {
+	events := utils.GlobalSystemEvents
+	events.RegisterConfigHandler(reloadJWT, "jwt")
+	events.RegisterConfigHandler(reloadCaptcha, "captcha")
+	events.RegisterConfigHandler(reloadCors, "cors")
+	events.RegisterConfigHandler(reloadSystem, "system")
+	events.RegisterConfigHandler(reloadOss, "system", "local", "qiniu", "aliyun-oss", "hua-wei-obs", "tencent-cos", "aws-s3", "cloudflare-r2", "minio")
+	events.RegisterConfigHandler(reloadMask, "mask")
+	events.RegisterConfigHandler(reloadTimer, "audit")
+	events.RegisterConfigHandler(reloadDB, "system", "mysql", "mssql", "pgsql", "oracle", "sqlite")
+	events.RegisterConfigHandler(reloadDBList, "db-list")
+	events.RegisterConfigHandler(reloadTimeout, "timeout")
+	events.RegisterConfigHandler(reloadHealth, "health")
+	events.RegisterConfigHandler(reloadMetrics, "metrics")
+	events.RegisterConfigHandler(reloadTrace, "trace")
+	events.RegisterConfigHandler(reloadOperationRecord, "operation-record")
+}
 
-	// 重新初始化其他配置
-	OtherInit()
-	DBList()
+// reloadJWT 令牌在每次签发与校验时读取配置, 只需校验
+func reloadJWT(_, newConf *config.Server) (utils.ConfigReload, error) {
+	if newConf.JWT.SigningKey == "" {
+		return utils.ConfigReload{}, errors.New("jwt.signing-key 不能为空")
+	}
+	if _, err := utils.ParseDuration(newConf.JWT.ExpiresTime); err != nil {
+		return utils.ConfigReload{}, fmt.Errorf("jwt.expires-time: %w", err)
+	}
+	if _, err := utils.ParseDuration(newConf.JWT.BufferTime); err != nil {
+		return utils.ConfigReload{}, fmt.Errorf("jwt.buffer-time: %w", err)
+	}
+	return utils.ConfigReload{}, nil
+}
 
-	if global.GVA_DB != nil {
-		// 确保数据库表结构是最新的
-		RegisterTables()
+// reloadCaptcha 验证码在每次生成时读取配置, 只需校验
+func reloadCaptcha(_, newConf *config.Server) (utils.ConfigReload, error) {
+	if newConf.Captcha.KeyLong <= 0 || newConf.Captcha.ImgWidth <= 0 || newConf.Captcha.ImgHeight <= 0 {
+		return utils.ConfigReload{}, errors.New("captcha 的 key-long、img-width、img-height 必须大于 0")
 	}
+	return utils.ConfigReload{}, nil
+}
 
-	// 重新初始化定时任务
-	Timer()
+// reloadCors 跨域中间件在每次请求时读取配置, 只需校验
+func reloadCors(_, newConf *config.Server) (utils.ConfigReload, error) {
+	switch newConf.Cors.Mode {
+	case "allow-all", "whitelist", "strict-whitelist":
+		return utils.ConfigReload{}, nil
+	default:
+		return utils.ConfigReload{}, fmt.Errorf("cors.mode 不支持 %q", newConf.Cors.Mode)
+	}
+}
 
-	global.GVA_LOG.Info("系统配置重新加载完成")
+// reloadSystem 限流在每次请求时读取配置; 监听地址、路由前缀等在启动时使用, 变化时提示重启
+func reloadSystem(oldConf, newConf *config.Server) (utils.ConfigReload, error) {
+	if newConf.System.LimitCountIP < 0 || newConf.System.LimitTimeIP < 0 {
+		return utils.ConfigReload{}, errors.New("system 的 iplimit-count、iplimit-time 不能小于 0")
+	}
+	oldSystem, newSystem := oldConf.System, newConf.System
+	// 以下字段由其他处理函数负责或支持热加载
+	oldSystem.DbType, oldSystem.OssType = newSystem.DbType, newSystem.OssType
+	oldSystem.LimitCountIP, oldSystem.LimitTimeIP = newSystem.LimitCountIP, newSystem.LimitTimeIP
+	if !reflect.DeepEqual(oldSystem, newSystem) {
+		global.GVA_LOG.Warn("system 中监听地址、路由前缀、Redis/Mongo 开关等配置需重启后生效")
+	}
+	return utils.ConfigReload{}, nil
+}
+
+// reloadOss 对象存储在每次上传时按配置创建客户端, minio 客户端有缓存, 配置变化时清除
+func reloadOss(oldConf, newConf *config.Server) (utils.ConfigReload, error) {
+	if oldConf.System.OssType == newConf.System.OssType && reflect.DeepEqual(oldConf.Minio, newConf.Minio) {
+		return utils.ConfigReload{}, nil
+	}
+	return utils.ConfigReload{Apply: func() {
+		upload.MinioClient = nil
+	}}, nil
+}
+
+// reloadTimeout 校验后替换超时中间件的配置; 关闭等待时间在退出时读取
+func reloadTimeout(_, newConf *config.Server) (utils.ConfigReload, error) {
+	durations := map[string]string{"timeout.default": newConf.Timeout.Default, "timeout.shutdown": newConf.Timeout.Shutdown}
+	for _, route := range newConf.Timeout.Routes {
+		durations["timeout.routes "+route.Path] = route.Timeout
+	}
+	if err := parseDurations(durations); err != nil {
+		return utils.ConfigReload{}, err
+	}
+	return utils.ConfigReload{Apply: func() {
+		middleware.ReloadTimeout(newConf.Timeout)
+	}}, nil
+}
+
+// reloadHealth 就绪检查与退出时读取配置, 只需校验
+func reloadHealth(_, newConf *config.Server) (utils.ConfigReload, error) {
+	return utils.ConfigReload{}, parseDurations(map[string]string{
+		"health.timeout":        newConf.Health.Timeout,
+		"health.shutdown-delay": newConf.Health.ShutdownDelay,
+	})
+}
+
+// reloadMetrics 访问控制在每次请求时读取配置; 开关与接口路径在启动时使用, 变化时提示重启
+func reloadMetrics(oldConf, newConf *config.Server) (utils.ConfigReload, error) {
+	for _, item := range newConf.Metrics.AllowIps {
+		if _, _, err := net.ParseCIDR(item); err != nil && net.ParseIP(item) == nil {
+			return utils.ConfigReload{}, fmt.Errorf("metrics.allow-ips 不支持 %q", item)
+		}
+	}
+	if oldConf.Metrics.Enable != newConf.Metrics.Enable || oldConf.Metrics.Path != newConf.Metrics.Path {
+		global.GVA_LOG.Warn("metrics 的 enable、path 需重启后生效")
+	}
+	return utils.ConfigReload{}, nil
+}
+
+// reloadTrace 追踪导出器与各组件埋点在启动时创建, 变化时提示重启
+func reloadTrace(_, _ *config.Server) (utils.ConfigReload, error) {
+	global.GVA_LOG.Warn("trace 配置需重启后生效")
+	return utils.ConfigReload{}, nil
+}
+
+// reloadOperationRecord 操作记录写入器在启动时按配置创建, 变化时提示重启
+func reloadOperationRecord(_, newConf *config.Server) (utils.ConfigReload, error) {
+	if err := parseDurations(map[string]string{"operation-record.flush-interval": newConf.OperationRecord.FlushInterval}); err != nil {
+		return utils.ConfigReload{}, err
+	}
+	global.GVA_LOG.Warn("operation-record 配置需重启后生效")
+	return utils.ConfigReload{}, nil
+}
+
+// parseDurations 校验时长配置, 空值表示使用默认值
+func parseDurations(durations map[string]string) error {
+	for name, value := range durations {
+		if value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
 	return nil
 }
+
+func reloadMask(_, _ *config.Server) (utils.ConfigReload, error) {
+	return utils.ConfigReload{Apply: utils.ResetMasker}, nil
+}
+
+// reloadTimer 审计配置变化时重新注册定时任务
+func reloadTimer(_, _ *config.Server) (utils.ConfigReload, error) {
+	return utils.ConfigReload{Apply: Timer}, nil
+}
+
+// reloadDB 主数据库连接串变化时建立新连接并替换, 否则只调整连接池大小
+func reloadDB(oldConf, newConf *config.Server) (utils.ConfigReload, error) {
+	oldType, oldGeneral, oldDsn := activeDB(oldConf)
+	newType, newGeneral, newDsn := activeDB(newConf)
+	if oldType == newType && oldDsn == newDsn {
+		if oldGeneral == newGeneral {
+			return utils.ConfigReload{}, nil
+		}
+		return utils.ConfigReload{Apply: func() {
+			setPool(global.GVA_DB, newGeneral)
+			if oldGeneral.Prefix != newGeneral.Prefix || oldGeneral.Singular != newGeneral.Singular || oldGeneral.LogMode != newGeneral.LogMode || oldGeneral.LogZap != newGeneral.LogZap || oldGeneral.Engine != newGeneral.Engine {
+				global.GVA_LOG.Warn("数据库表前缀、日志等配置需重启后生效")
+			}
+		}}, nil
+	}
+
+	db, err := openDB(func() *gorm.DB { return GormByConfig(newConf) })
+	if err != nil {
+		return utils.ConfigReload{}, fmt.Errorf("连接数据库失败: %w", err)
+	}
+	if db == nil {
+		return utils.ConfigReload{}, errors.New("未配置数据库名")
+	}
+	return utils.ConfigReload{
+		Apply: func() {
+			old := global.GVA_DB
+			global.GVA_DB = db
+			setActiveDBName()
+			ChangeHistory()
+			Instrument()
+			RegisterTables()
+			closeDB(old)
+			global.GVA_LOG.Info("数据库连接已切换", zap.String("type", newType))
+		},
+		Abort: func() { closeDB(db) },
+	}, nil
+}
+
+// reloadDBList 只为连接串变化或新增的数据库建立连接, 其余沿用原连接
+func reloadDBList(oldConf, newConf *config.Server) (utils.ConfigReload, error) {
+	oldInfos := make(map[string]config.SpecializedDB, len(oldConf.DBList))
+	for _, info := range oldConf.DBList {
+		if !info.Disable {
+			oldInfos[info.AliasName] = info
+		}
+	}
+	dbMap := make(map[string]*gorm.DB, len(newConf.DBList))
+	var opened []*gorm.DB
+	abort := func() {
+		for _, db := range opened {
+			closeDB(db)
+		}
+	}
+	for _, info := range newConf.DBList {
+		if info.Disable {
+			continue
+		}
+		if old, ok := oldInfos[info.AliasName]; ok && dbListDsn(old) == dbListDsn(info) {
+			if db := global.GVA_DBList[info.AliasName]; db != nil {
+				dbMap[info.AliasName] = db
+				continue
+			}
+		}
+		db, err := openDB(func() *gorm.DB { return GormByDBListConfig(info) })
+		if err != nil {
+			abort()
+			return utils.ConfigReload{}, fmt.Errorf("连接数据库 %s 失败: %w", info.AliasName, err)
+		}
+		if db != nil {
+			opened = append(opened, db)
+			dbMap[info.AliasName] = db
+		}
+	}
+	return utils.ConfigReload{
+		Apply: func() {
+			old := global.GVA_DBList
+			for _, info := range newConf.DBList {
+				if db := dbMap[info.AliasName]; db != nil {
+					setPool(db, info.GeneralDB)
+				}
+			}
+			// 兼容低版本迁移多数据库版本
+			if sysDB, ok := dbMap[sys]; ok && sysDB != old[sys] {
+				global.GVA_DB = sysDB
+			}
+			global.GVA_DBList = dbMap
+			Instrument()
+			for name, db := range old {
+				if dbMap[name] != db && db != global.GVA_DB {
+					closeDB(db)
+				}
+			}
+		},
+		Abort: abort,
+	}, nil
+}
+
+// openDB 建立连接, 各数据库的初始化函数在连接失败时 panic, 这里转换为错误
+func openDB(open func() *gorm.DB) (db *gorm.DB, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return open(), nil
+}
+
+func setPool(db *gorm.DB, general config.GeneralDB) {
+	if db == nil {
+		return
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.SetMaxIdleConns(general.MaxIdleConns)
+		sqlDB.SetMaxOpenConns(general.MaxOpenConns)
+	}
+}
+
+// closeDB 延迟关闭连接池, 使切换前已开始的请求能完成剩余的查询
+func closeDB(db *gorm.DB) {
+	if db == nil {
+		return
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return
+	}
+	time.AfterFunc(dbCloseDelay, func() {
+		if err := sqlDB.Close(); err != nil {
+			global.GVA_LOG.Error("关闭原数据库连接失败!", zap.Error(err))
+		}
+	})
+}
+
+func setActiveDBName() {
+	switch global.GVA_CONFIG.Load().System.DbType {
+	case "pgsql":
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Pgsql.Dbname
+	case "oracle":
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Oracle.Dbname
+	case "mssql":
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Mssql.Dbname
+	case "sqlite":
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Sqlite.Dbname
+	default:
+		global.GVA_ACTIVE_DBNAME = &global.GVA_CONFIG.Load().Mysql.Dbname
+	}
+}
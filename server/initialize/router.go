@@ -3,11 +3,14 @@ package initialize
 import (
 	"net/http"
 	"os"
+	"time"
 
 	"server/docs"
 	"server/global"
 	"server/middleware"
 	"server/router"
+	"server/utils/health"
+	"server/utils/metrics"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -36,22 +39,51 @@ func (fs justFilesFilesystem) Open(name string) (http.File, error) {
 
 func Routers() *gin.Engine {
 	Router := gin.New()
-	Router.Use(gin.Recovery())
-	if gin.Mode() == gin.DebugMode {
+	// c.Done / c.Err / c.Value 回落到 c.Request.Context(), 误把 c 当作 context 传入时超时与链路仍然生效
+	Router.ContextWithFallback = true
+	if global.GVA_CONFIG.Load().Metrics.Enable {
+		// 放在 Recovery 之前, 以便记录 panic 恢复后的 500 响应
+		Router.Use(middleware.Metrics())
+	}
+	if global.GVA_CONFIG.Load().Trace.Enable {
+		Router.Use(middleware.Trace(), middleware.TraceHeader())
+	}
+	Router.Use(middleware.RequestID())
+	if global.GVA_CONFIG.Load().Zap.AccessLog {
+		Router.Use(middleware.ZapLogger())
+	} else if gin.Mode() == gin.DebugMode {
 		Router.Use(gin.Logger())
 	}
+	Router.Use(gin.Recovery())
 
-	sseServer := McpRun()
+	sseServer, streamableServer := McpRun()
 
 	// 注册mcp服务
-	Router.GET(global.GVA_CONFIG.MCP.SSEPath, func(c *gin.Context) {
+	Router.GET(global.GVA_CONFIG.Load().MCP.SSEPath, middleware.McpAuth(), func(c *gin.Context) {
 		sseServer.SSEHandler().ServeHTTP(c.Writer, c.Request)
 	})
 
-	Router.POST(global.GVA_CONFIG.MCP.MessagePath, func(c *gin.Context) {
+	Router.POST(global.GVA_CONFIG.Load().MCP.MessagePath, middleware.McpAuth(), func(c *gin.Context) {
 		sseServer.MessageHandler().ServeHTTP(c.Writer, c.Request)
 	})
 
+	if global.GVA_CONFIG.Load().Metrics.Enable {
+		path := global.GVA_CONFIG.Load().Metrics.Path
+		if path == "" {
+			path = "/metrics"
+		}
+		handler := metrics.Handler()
+		Router.GET(path, middleware.MetricsAuth(), func(c *gin.Context) {
+			handler.ServeHTTP(c.Writer, c.Request)
+		})
+	}
+
+	if global.GVA_CONFIG.Load().MCP.StreamablePath != "" {
+		Router.Match([]string{http.MethodGet, http.MethodPost, http.MethodDelete}, global.GVA_CONFIG.Load().MCP.StreamablePath, middleware.McpAuth(), func(c *gin.Context) {
+			streamableServer.ServeHTTP(c.Writer, c.Request)
+		})
+	}
+
 	systemRouter := router.RouterGroupApp.System
 	exampleRouter := router.RouterGroupApp.Example
 	// 如果想要不使用nginx代理前端网页，可以修改 web/.env.production 下的
@@ -62,26 +94,43 @@ func Routers() *gin.Engine {
 	// Router.Static("/assets", "./dist/assets")   // dist里面的静态资源
 	// Router.StaticFile("/", "./dist/index.html") // 前端网页入口页面
 
-	Router.StaticFS(global.GVA_CONFIG.Local.StorePath, justFilesFilesystem{http.Dir(global.GVA_CONFIG.Local.StorePath)}) // Router.Use(middleware.LoadTls())  // 如果需要使用https 请打开此中间件 然后前往 core/server.go 将启动模式 更变为 Router.RunTLS("端口","你的cre/pem文件","你的key文件")
+	Router.StaticFS(global.GVA_CONFIG.Load().Local.StorePath, justFilesFilesystem{http.Dir(global.GVA_CONFIG.Load().Local.StorePath)}) // Router.Use(middleware.LoadTls())  // 如果需要使用https 请打开此中间件 然后前往 core/server.go 将启动模式 更变为 Router.RunTLS("端口","你的cre/pem文件","你的key文件")
 	// 跨域，如需跨域可以打开下面的注释
 	// Router.Use(middleware.Cors()) // 直接放行全部跨域请求
 	// Router.Use(middleware.CorsByRules()) // 按照配置的规则放行跨域请求
 	// global.GVA_LOG.Info("use middleware cors")
-	docs.SwaggerInfo.BasePath = global.GVA_CONFIG.System.RouterPrefix
-	Router.GET(global.GVA_CONFIG.System.RouterPrefix+"/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	docs.SwaggerInfo.BasePath = global.GVA_CONFIG.Load().System.RouterPrefix
+	Router.GET(global.GVA_CONFIG.Load().System.RouterPrefix+"/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	global.GVA_LOG.Info("register swagger handler")
 	// 方便统一添加路由组前缀 多服务器上线使用
 
-	PublicGroup := Router.Group(global.GVA_CONFIG.System.RouterPrefix)
-	PrivateGroup := Router.Group(global.GVA_CONFIG.System.RouterPrefix)
+	PublicGroup := Router.Group(global.GVA_CONFIG.Load().System.RouterPrefix)
+	PrivateGroup := Router.Group(global.GVA_CONFIG.Load().System.RouterPrefix)
 
-	PrivateGroup.Use(middleware.JWTAuth()).Use(middleware.CasbinHandler())
+	// 接口超时放在鉴权之前, 使鉴权中的查询同样受超时控制; MCP、指标与静态文件不在这两个分组中, 不受影响
+	timeout := middleware.Timeout()
+	PublicGroup.Use(timeout)
+	PrivateGroup.Use(timeout).Use(middleware.JWTAuth()).Use(middleware.CasbinHandler())
 
 	{
 		// 健康监测
 		PublicGroup.GET("/health", func(c *gin.Context) {
 			c.JSON(http.StatusOK, "ok")
 		})
+		// 存活探针, 进程能处理请求即返回成功
+		PublicGroup.GET("/livez", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+		})
+		// 就绪探针, 依赖不可用或正在关闭时返回 503
+		PublicGroup.GET("/readyz", func(c *gin.Context) {
+			timeout, _ := time.ParseDuration(global.GVA_CONFIG.Load().Health.Timeout)
+			report := health.Readiness(c.Request.Context(), timeout)
+			if !report.Ready() {
+				c.JSON(http.StatusServiceUnavailable, report)
+				return
+			}
+			c.JSON(http.StatusOK, report)
+		})
 	}
 	{
 		systemRouter.InitBaseRouter(PublicGroup) // 注册基础功能路由 不做鉴权
@@ -1,7 +1,9 @@
 package initialize
 
 import (
+	"context"
 	"fmt"
+	"server/service/system"
 	"server/task"
 
 	"github.com/robfig/cron/v3"
@@ -9,11 +11,13 @@ import (
 	"server/global"
 )
 
+// Timer 注册系统定时任务, 先移除同名任务, 配置热加载时可重复调用
 func Timer() {
 	go func() {
 		var option []cron.Option
 		option = append(option, cron.WithSeconds())
 		// 清理DB定时任务
+		global.GVA_Timer.RemoveTaskByName("ClearDB", "定时清理数据库【日志，黑名单】内容")
 		_, err := global.GVA_Timer.AddTaskByFunc("ClearDB", "@daily", func() {
 			err := task.ClearTable(global.GVA_DB) // 定时任务方法定在task文件包中
 			if err != nil {
@@ -24,6 +28,23 @@ func Timer() {
 			fmt.Println("add timer error:", err)
 		}
 
+		// 审计日志签名检查点
+		global.GVA_Timer.RemoveTaskByName("AuditCheckpoint", "定时生成审计日志签名检查点")
+		if global.GVA_CONFIG.Load().Audit.Enable {
+			spec := global.GVA_CONFIG.Load().Audit.CheckpointSpec
+			if spec == "" {
+				spec = "@hourly"
+			}
+			_, err = global.GVA_Timer.AddTaskByFunc("AuditCheckpoint", spec, func() {
+				if _, err := system.AuditLogServiceApp.CreateCheckpoint(context.Background()); err != nil {
+					fmt.Println("timer error:", err)
+				}
+			}, "定时生成审计日志签名检查点", option...)
+			if err != nil {
+				fmt.Println("add timer error:", err)
+			}
+		}
+
 		// 其他定时任务定在这里 参考上方使用方法
 
 		//_, err := global.GVA_Timer.AddTaskByFunc("定时任务标识", "corn表达式", func() {
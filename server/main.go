@@ -30,6 +30,10 @@ import (
 func main() {
 	// 初始化系统
 	initializeSystem()
+	// 使用 -openapi-check 参数时只检查路由一致性, 使用 -sdk-out 参数时只生成接口客户端
+	if core.RunOpenApiCheck() || core.RunSdkGenerate() {
+		return
+	}
 	// 运行服务器
 	core.RunServer()
 }
@@ -41,9 +45,13 @@ func initializeSystem() {
 	initialize.OtherInit()
 	global.GVA_LOG = core.Zap() // 初始化zap日志库
 	zap.ReplaceGlobals(global.GVA_LOG)
+	initialize.Trace()                // 链路追踪
 	global.GVA_DB = initialize.Gorm() // gorm连接数据库
+	initialize.ChangeHistory()        // 数据变更历史
 	initialize.Timer()
 	initialize.DBList()
+	initialize.Instrument()            // 数据库指标与链路追踪
+	initialize.OperationRecordWriter() // 操作记录写入器
 	initialize.SetupHandlers() // 注册全局函数
 	if global.GVA_DB != nil {
 		initialize.RegisterTables() // 初始化表
@@ -126,9 +126,9 @@ func (a *ApiCreator) Handle(ctx context.Context, request mcp.CallToolRequest) (*
 			Method:      apiReq.Method,
 		}
 
-		err := apiService.CreateApi(api)
+		err := apiService.CreateApi(ctx, api)
 		if err != nil {
-			global.GVA_LOG.Warn("创建API失败",
+			logger().Warn("创建API失败",
 				zap.String("path", apiReq.Path),
 				zap.String("method", apiReq.Method),
 				zap.Error(err))
@@ -144,7 +144,7 @@ func (a *ApiCreator) Handle(ctx context.Context, request mcp.CallToolRequest) (*
 			var createdApi system.SysApi
 			err = global.GVA_DB.Where("path = ? AND method = ?", apiReq.Path, apiReq.Method).First(&createdApi).Error
 			if err != nil {
-				global.GVA_LOG.Warn("获取创建的API ID失败", zap.Error(err))
+				logger().Warn("获取创建的API ID失败", zap.Error(err))
 			}
 
 			responses = append(responses, ApiCreateResponse{
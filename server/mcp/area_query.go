@@ -0,0 +1,80 @@
+package mcpTool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	common "server/model/common/request"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemService "server/service/system"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func init() {
+	RegisterTool(&AreaQuery{})
+}
+
+// AreaQuery 查询省市区
+type AreaQuery struct{}
+
+// AreaQueryResponse 区域查询结果
+type AreaQueryResponse struct {
+	List  interface{} `json:"list"`
+	Total int64       `json:"total"`
+}
+
+func (t *AreaQuery) New() mcp.Tool {
+	return mcp.NewTool("query_areas",
+		mcp.WithDescription("按名称、区域编码、父级编码与层级查询省市区, 需要区域列表接口权限"),
+		mcp.WithString("name",
+			mcp.Description("区域名称, 模糊匹配"),
+		),
+		mcp.WithNumber("code",
+			mcp.Description("区域编码"),
+		),
+		mcp.WithNumber("parentCode",
+			mcp.Description("父级区域编码, 查询下级区域时使用"),
+		),
+		mcp.WithNumber("level",
+			mcp.Description("层级: 1-省/直辖市, 2-市/区, 3-县/区"),
+			mcp.Min(1),
+			mcp.Max(3),
+		),
+		mcp.WithNumber("page",
+			mcp.Description("页码, 默认1"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("pageSize",
+			mcp.Description("每页条数, 默认50, 最大100"),
+			mcp.Min(1),
+			mcp.Max(100),
+		),
+	)
+}
+
+func (t *AreaQuery) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if _, err := requireApi(ctx, "/area/getAreaList", http.MethodPost); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	search := systemReq.SysAreaSearch{
+		SysArea: system.SysArea{
+			N:     request.GetString("name", ""),
+			I:     request.GetInt("code", 0),
+			P:     request.GetInt("parentCode", 0),
+			Level: request.GetInt("level", 0),
+		},
+		PageInfo: common.PageInfo{Page: max(request.GetInt("page", 1), 1), PageSize: min(max(request.GetInt("pageSize", 50), 1), 100)},
+	}
+	list, total, err := systemService.AreaServiceApp.GetAreaList(ctx, search)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("查询区域失败", err), nil
+	}
+	result, err := json.Marshal(AreaQueryResponse{List: list, Total: total})
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(result)), nil
+}
@@ -0,0 +1,221 @@
+package mcpTool
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+	systemService "server/service/system"
+	"server/utils"
+	"server/utils/ctxlog"
+	"server/utils/metrics"
+	"server/utils/tracing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// operationBodySize 操作日志中工具参数与结果的最大长度, 与操作记录中间件一致
+const operationBodySize = 1024
+
+// Caller 调用 MCP 工具的用户或 API Key
+type Caller struct {
+	UserID      uint
+	Username    string
+	AuthorityId uint
+	ApiKey      string // 使用 API Key 访问时为密钥名称
+	Ip          string
+	Agent       string
+}
+
+type callerKey struct{}
+
+// WithCaller 将调用方写入上下文, 工具中可以通过 CallerFromContext 获取
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext 获取调用方, 未认证时返回 nil
+func CallerFromContext(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(callerKey{}).(*Caller)
+	return caller
+}
+
+// ToolApiPath 工具在 sys_apis 与 casbin 策略中的路径
+func ToolApiPath(name string) string {
+	return system.McpToolApiPrefix + name
+}
+
+// Authenticate 校验 SSE 与消息请求的登录令牌或 API Key, 并确认对应角色存在
+// 令牌取自 x-token 请求头, API Key 取自 x-api-key 请求头, 两者均可通过 Authorization: Bearer 传递
+// ip 由调用方按受信任代理配置解析, 不直接读取可伪造的 X-Forwarded-For
+func Authenticate(r *http.Request, ip string) (*Caller, error) {
+	caller := &Caller{Ip: ip, Agent: r.UserAgent()}
+	token := r.Header.Get("x-token")
+	apiKey := r.Header.Get("x-api-key")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && apiKey == "" && token == "" {
+		if _, isKey := findApiKey(bearer); isKey {
+			apiKey = bearer
+		} else {
+			token = bearer
+		}
+	}
+	switch {
+	case apiKey != "":
+		key, ok := findApiKey(apiKey)
+		if !ok {
+			return nil, errors.New("API Key 无效")
+		}
+		caller.ApiKey = key.Name
+		caller.AuthorityId = key.AuthorityId
+	case token != "":
+		if _, ok := global.BlackCache.Get(token); ok {
+			return nil, errors.New("您的帐户异地登陆或令牌失效")
+		}
+		claims, err := utils.NewJWT().ParseToken(token)
+		if err != nil {
+			if errors.Is(err, utils.TokenExpired) {
+				return nil, errors.New("登录已过期，请重新登录")
+			}
+			return nil, err
+		}
+		caller.UserID = claims.BaseClaims.ID
+		caller.Username = claims.Username
+		caller.AuthorityId = claims.AuthorityId
+	default:
+		return nil, errors.New("未登录或非法访问，请提供 x-token 或 x-api-key")
+	}
+	if global.GVA_DB == nil {
+		return nil, errors.New("数据库未初始化")
+	}
+	var authority system.SysAuthority
+	if err := global.GVA_DB.WithContext(r.Context()).Select("authority_id").First(&authority, "authority_id = ?", caller.AuthorityId).Error; err != nil {
+		return nil, errors.New("调用方的角色不存在")
+	}
+	return caller, nil
+}
+
+func findApiKey(value string) (config.McpApiKey, bool) {
+	for _, key := range global.GVA_CONFIG.Load().MCP.ApiKeys {
+		if key.Key != "" && subtle.ConstantTimeCompare([]byte(key.Key), []byte(value)) == 1 {
+			return key, true
+		}
+	}
+	return config.McpApiKey{}, false
+}
+
+// allowed 调用方的角色是否拥有工具权限
+func allowed(caller *Caller, tool string) bool {
+	return enforce(caller, ToolApiPath(tool), http.MethodPost)
+}
+
+// enforce 按 casbin 策略判断调用方的角色能否访问 obj
+func enforce(caller *Caller, obj, act string) bool {
+	if caller == nil {
+		return false
+	}
+	e := utils.GetCasbin()
+	if e == nil {
+		return false
+	}
+	success, _ := e.Enforce(strconv.Itoa(int(caller.AuthorityId)), obj, act)
+	metrics.ObserveCasbin("mcp", success)
+	return success
+}
+
+// requireApi 工具读取数据时要求调用方同时拥有对应 HTTP 接口的权限, 避免绕过接口权限访问数据
+func requireApi(ctx context.Context, api, method string) (*Caller, error) {
+	caller := CallerFromContext(ctx)
+	if caller == nil {
+		return nil, errors.New("未登录或非法访问")
+	}
+	if !enforce(caller, api, method) {
+		return nil, fmt.Errorf("权限不足, 需要 %s %s 接口权限", method, api)
+	}
+	return caller, nil
+}
+
+// FilterTools 工具列表中只返回调用方有权限的工具
+func FilterTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	caller := CallerFromContext(ctx)
+	result := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if allowed(caller, tool.Name) {
+			result = append(result, tool)
+		}
+	}
+	return result
+}
+
+// AuthorizeTool 按 casbin 策略校验工具权限, 并将每次调用记录到操作日志
+func AuthorizeTool(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+		caller := CallerFromContext(ctx)
+		path := ToolApiPath(request.Params.Name)
+		record := system.SysOperationRecord{Method: http.MethodPost, Path: path, Body: operationBody(path, request.Params.Arguments), RequestID: ctxlog.RequestID(ctx), TraceID: tracing.TraceID(ctx)}
+		if caller != nil {
+			record.Ip, record.Agent, record.UserID = caller.Ip, caller.Agent, int(caller.UserID)
+			if caller.ApiKey != "" {
+				record.Agent = "api-key:" + caller.ApiKey + " " + caller.Agent
+			}
+		}
+		now := time.Now()
+		switch {
+		case caller == nil:
+			record.Status = http.StatusUnauthorized
+			result = mcp.NewToolResultError("未登录或非法访问")
+		case !allowed(caller, request.Params.Name):
+			record.Status = http.StatusForbidden
+			result = mcp.NewToolResultError("权限不足")
+		default:
+			result, err = next(ctx, request)
+			record.Status = http.StatusOK
+			if err != nil {
+				record.Status = http.StatusInternalServerError
+				record.ErrorMessage = err.Error()
+			} else if result != nil && result.IsError {
+				record.Status = http.StatusInternalServerError
+			}
+		}
+		record.Latency = time.Since(now)
+		if result != nil {
+			record.Resp = operationBody(path, result.Content)
+		}
+		writeOperationRecord(record)
+		return result, err
+	}
+}
+
+func operationBody(path string, value any) string {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	if len(body) > operationBodySize {
+		return "[超出记录长度]"
+	}
+	return utils.GetMasker().MaskBody(path, string(body))
+}
+
+func writeOperationRecord(record system.SysOperationRecord) {
+	if systemService.OperationRecordWriterApp != nil {
+		systemService.OperationRecordWriterApp.Write(record)
+		return
+	}
+	if global.GVA_DB == nil {
+		return
+	}
+	if err := global.GVA_DB.Create(&record).Error; err != nil {
+		logger().Error("记录MCP工具调用失败!", zap.Error(err))
+	}
+}
@@ -0,0 +1,102 @@
+package mcpTool
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+	"server/utils"
+
+	"github.com/glebarez/sqlite"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"gorm.io/gorm"
+)
+
+func setupAuthDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysAuthority{}, &system.SysOperationRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	oldDB, oldConfig := global.GVA_DB, global.GVA_CONFIG.Load()
+	global.GVA_DB = db
+	global.BlackCache = local_cache.NewCache()
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.MCP.ApiKeys = []config.McpApiKey{{Name: "editor", Key: "secret", AuthorityId: 888}}
+	})
+	t.Cleanup(func() {
+		global.GVA_DB = oldDB
+		global.GVA_CONFIG.Store(oldConfig)
+	})
+
+	db.Create(&system.SysAuthority{AuthorityId: 888, AuthorityName: "管理员"})
+	if _, err = utils.GetCasbin().AddPolicy("888", ToolApiPath("currentTime"), "POST"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	setupAuthDB(t)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantErr bool
+	}{
+		{name: "api key", headers: map[string]string{"x-api-key": "secret"}},
+		{name: "bearer api key", headers: map[string]string{"Authorization": "Bearer secret"}},
+		{name: "invalid api key", headers: map[string]string{"x-api-key": "wrong"}, wantErr: true},
+		{name: "invalid token", headers: map[string]string{"x-token": "wrong"}, wantErr: true},
+		{name: "anonymous", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/sse", nil)
+			r.Header.Set("X-Forwarded-For", "1.2.3.4") // 伪造的来源地址不应被采用
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			caller, err := Authenticate(r, "10.0.0.1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (caller.ApiKey != "editor" || caller.AuthorityId != 888 || caller.Ip != "10.0.0.1") {
+				t.Errorf("Authenticate() = %+v", caller)
+			}
+		})
+	}
+}
+
+func TestAuthorizeTool(t *testing.T) {
+	setupAuthDB(t)
+	called := 0
+	handler := AuthorizeTool(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called++
+		return mcp.NewToolResultText("ok"), nil
+	})
+	ctx := WithCaller(context.Background(), &Caller{ApiKey: "editor", AuthorityId: 888})
+	for _, tool := range []string{"currentTime", "create_menu"} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool
+		if _, err := handler(ctx, request); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if called != 1 {
+		t.Errorf("期望只执行有权限的工具, 实际执行%d次", called)
+	}
+	if tools := FilterTools(ctx, []mcp.Tool{{Name: "currentTime"}, {Name: "create_menu"}}); len(tools) != 1 || tools[0].Name != "currentTime" {
+		t.Errorf("FilterTools() = %v", tools)
+	}
+
+	var records []system.SysOperationRecord
+	global.GVA_DB.Order("id").Find(&records)
+	if len(records) != 2 || records[0].Status != 200 || records[1].Status != 403 || records[1].Path != "/mcp/tools/create_menu" {
+		t.Errorf("操作记录 = %+v", records)
+	}
+}
@@ -4,15 +4,30 @@ import (
 	"context"
 	"errors"
 	mcpClient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-func NewClient(baseUrl, name, version, serverName string) (*mcpClient.Client, error) {
-	client, err := mcpClient.NewSSEMCPClient(baseUrl)
+// NewClient 通过 SSE 连接 MCP 服务, 服务端需要鉴权, 可通过 transport.WithHeaders 传递 x-token 或 x-api-key
+func NewClient(baseUrl, name, version, serverName string, options ...transport.ClientOption) (*mcpClient.Client, error) {
+	client, err := mcpClient.NewSSEMCPClient(baseUrl, options...)
 	if err != nil {
 		return nil, err
 	}
+	return initialize(client, name, version, serverName)
+}
+
+// NewStreamableClient 通过 Streamable HTTP 连接 MCP 服务, baseUrl 如 http://localhost:8888/mcp
+// 可通过 transport.WithHTTPHeaders 传递 x-token 或 x-api-key
+func NewStreamableClient(baseUrl, name, version, serverName string, options ...transport.StreamableHTTPCOption) (*mcpClient.Client, error) {
+	client, err := mcpClient.NewStreamableHttpClient(baseUrl, options...)
+	if err != nil {
+		return nil, err
+	}
+	return initialize(client, name, version, serverName)
+}
 
+func initialize(client *mcpClient.Client, name, version, serverName string) (*mcpClient.Client, error) {
 	ctx := context.Background()
 
 	// 启动client
@@ -247,7 +247,7 @@ func (d *DictionaryOptionsGenerator) createDictionaryWithOptions(ctx context.Con
 		Desc:   req.Description,
 	}
 
-	err = dictionaryService.CreateSysDictionary(dictionary)
+	err = dictionaryService.CreateSysDictionary(ctx, dictionary)
 	if err != nil {
 		return nil, fmt.Errorf("创建字典失败: %v", err)
 	}
@@ -272,9 +272,9 @@ func (d *DictionaryOptionsGenerator) createDictionaryWithOptions(ctx context.Con
 			SysDictionaryID: int(createdDict.ID),
 		}
 
-		err = dictionaryDetailService.CreateSysDictionaryDetail(dictionaryDetail)
+		err = dictionaryDetailService.CreateSysDictionaryDetail(ctx, dictionaryDetail)
 		if err != nil {
-			global.GVA_LOG.Warn("创建字典详情项失败", zap.Error(err))
+			logger().Warn("创建字典详情项失败", zap.Error(err))
 		} else {
 			successCount++
 		}
@@ -98,9 +98,9 @@ func (d *DictionaryQuery) Handle(ctx context.Context, request mcp.CallToolReques
 			status = &[]bool{true}[0]
 		}
 		
-		sysDictionary, err := dictionaryService.GetSysDictionary(dictType, 0, status)
+		sysDictionary, err := dictionaryService.GetSysDictionary(ctx, dictType, 0, status)
 		if err != nil {
-			global.GVA_LOG.Error("查询字典失败", zap.Error(err))
+			logger().Error("查询字典失败", zap.Error(err))
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
 					mcp.NewTextContent(fmt.Sprintf(`{"success": false, "message": "查询字典失败: %v", "total": 0, "dictionaries": []}`, err.Error())),
@@ -150,7 +150,7 @@ func (d *DictionaryQuery) Handle(ctx context.Context, request mcp.CallToolReques
 		}).Find(&sysDictionaries).Error
 		
 		if err != nil {
-			global.GVA_LOG.Error("查询字典列表失败", zap.Error(err))
+			logger().Error("查询字典列表失败", zap.Error(err))
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
 					mcp.NewTextContent(fmt.Sprintf(`{"success": false, "message": "查询字典列表失败: %v", "total": 0, "dictionaries": []}`, err.Error())),
@@ -218,7 +218,7 @@ func (d *DictionaryQuery) Handle(ctx context.Context, request mcp.CallToolReques
 	
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		global.GVA_LOG.Error("序列化响应失败", zap.Error(err))
+		logger().Error("序列化响应失败", zap.Error(err))
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				mcp.NewTextContent(fmt.Sprintf(`{"success": false, "message": "序列化响应失败: %v", "total": 0, "dictionaries": []}`, err.Error())),
@@ -4,6 +4,10 @@ import (
 	"context"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"server/global"
+	"server/utils/logging"
+
+	"go.uber.org/zap"
 )
 
 // McpTool 定义了MCP工具必须实现的接口
@@ -29,3 +33,8 @@ func RegisterAllTools(mcpServer *server.MCPServer) {
 		mcpServer.AddTool(tool.New(), tool.Handle)
 	}
 }
+
+// logger MCP 工具使用的日志, 级别可按名称 mcp 单独调整
+func logger() *zap.Logger {
+	return global.GVA_LOG.Named(logging.NameMcp)
+}
@@ -0,0 +1,123 @@
+package mcpTool
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	systemService "server/service/system"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// exportQueryMaxLimit 单次调用最多返回的行数
+const exportQueryMaxLimit = 1000
+
+func init() {
+	RegisterTool(&ExportTemplateQuery{})
+}
+
+// ExportTemplateQuery 执行导出模板并返回数据
+type ExportTemplateQuery struct{}
+
+// ExportTemplateQueryResponse 导出模板查询结果
+type ExportTemplateQueryResponse struct {
+	Name    string              `json:"name"`    // 模板名称
+	Columns []string            `json:"columns"` // 表头
+	Rows    []map[string]string `json:"rows"`    // 数据, 键为表头
+	Count   int                 `json:"count"`   // 本次返回的行数
+}
+
+func (t *ExportTemplateQuery) New() mcp.Tool {
+	return mcp.NewTool("query_export_template",
+		mcp.WithDescription(`执行导出模板(SysExportTemplate)并以 JSON 或 CSV 返回数据, 用于数据分析。
+按调用方的接口权限与资源权限执行: 需要导出接口权限, 主表含 created_by 或 sys_user_authority_id 列时只返回资源权限范围内的数据, 两列都没有时仅拥有全部资源权限的角色可以查询。`),
+		mcp.WithString("templateID",
+			mcp.Required(),
+			mcp.Description("导出模板标识"),
+		),
+		mcp.WithObject("params",
+			mcp.Description("模板查询条件, 键为条件的 from 字段, 值为字符串; 另支持 filterDeleted: \"true\" 过滤软删除数据"),
+			mcp.AdditionalProperties(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("order",
+			mcp.Description("排序, 如 \"id desc\", 为空时使用模板默认排序"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("返回行数, 默认100, 最大%d", exportQueryMaxLimit)),
+			mcp.Min(1),
+			mcp.Max(exportQueryMaxLimit),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("跳过的行数"),
+			mcp.Min(0),
+		),
+		mcp.WithString("format",
+			mcp.Description("返回格式"),
+			mcp.Enum("json", "csv"),
+			mcp.DefaultString("json"),
+		),
+	)
+}
+
+func (t *ExportTemplateQuery) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	caller, err := requireApi(ctx, "/sysExportTemplate/exportExcel", http.MethodGet)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	templateID, err := request.RequireString("templateID")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := url.Values{}
+	if values, ok := request.GetArguments()["params"].(map[string]any); ok {
+		for key, value := range values {
+			params.Set(key, fmt.Sprint(value))
+		}
+	}
+	limit := request.GetInt("limit", 100)
+	if limit <= 0 || limit > exportQueryMaxLimit {
+		limit = exportQueryMaxLimit
+	}
+	params.Set("limit", strconv.Itoa(limit))
+	if offset := request.GetInt("offset", 0); offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	if order := request.GetString("order", ""); order != "" {
+		params.Set("order", order)
+	}
+
+	name, rows, err := systemService.SysExportTemplateServiceApp.ExportData(ctx, templateID, params, caller.AuthorityId)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("执行导出模板失败", err), nil
+	}
+
+	if request.GetString("format", "json") == "csv" {
+		var buf bytes.Buffer
+		if err = csv.NewWriter(&buf).WriteAll(rows); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(buf.String()), nil
+	}
+
+	response := ExportTemplateQueryResponse{Name: name, Columns: rows[0], Rows: make([]map[string]string, 0, len(rows)-1)}
+	for _, row := range rows[1:] {
+		item := make(map[string]string, len(row))
+		for i, value := range row {
+			item[response.Columns[i]] = value
+		}
+		response.Rows = append(response.Rows, item)
+	}
+	response.Count = len(response.Rows)
+	result, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(result)), nil
+}
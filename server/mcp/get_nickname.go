@@ -39,7 +39,7 @@ func (t *GetNickname) Handle(ctx context.Context, request mcp.CallToolRequest) (
 	}
 
 	// 2. 记录操作日志
-	global.GVA_LOG.Info("getNickname 工具被调用")
+	logger().Info("getNickname 工具被调用")
 
 	// 3. 优化查询，只选择需要的字段
 	var user struct {
@@ -63,7 +63,7 @@ func (t *GetNickname) Handle(ctx context.Context, request mcp.CallToolRequest) (
 				},
 			}, nil
 		}
-		global.GVA_LOG.Error("数据库查询错误")
+		logger().Error("数据库查询错误")
 		return nil, errors.New("系统错误，请稍后再试")
 	}
 
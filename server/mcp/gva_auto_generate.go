@@ -252,11 +252,11 @@ func (t *AutomationModuleAnalyzer) scanPredesignedModules() ([]PredesignedModule
 	var predesignedModules []PredesignedModuleInfo
 
 	// 获取autocode配置路径
-	if global.GVA_CONFIG.AutoCode.Root == "" {
+	if global.GVA_CONFIG.Load().AutoCode.Root == "" {
 		return predesignedModules, nil // 配置不存在时返回空列表，不报错
 	}
 
-	serverPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server)
+	serverPath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
 
 	// 扫描plugin目录下的各个插件模块
 	pluginPath := filepath.Join(serverPath, "plugin")
@@ -542,7 +542,7 @@ func (t *AutomationModuleAnalyzer) handleAnalyze(ctx context.Context, request mc
 		// 检查包对应的文件夹是否为空
 		isEmpty, err := t.isPackageFolderEmpty(pkg.PackageName, pkg.Template)
 		if err != nil {
-			global.GVA_LOG.Warn(fmt.Sprintf("检查包 %s 文件夹失败: %v", pkg.PackageName, err))
+			logger().Warn(fmt.Sprintf("检查包 %s 文件夹失败: %v", pkg.PackageName, err))
 			// 如果检查失败，仍然保留该包
 			validPackages = append(validPackages, pkg)
 			continue
@@ -552,11 +552,11 @@ func (t *AutomationModuleAnalyzer) handleAnalyze(ctx context.Context, request mc
 			// 记录需要删除的包ID和包名
 			emptyPackageIDs = append(emptyPackageIDs, pkg.ID)
 			emptyPackageNames = append(emptyPackageNames, pkg.PackageName)
-			global.GVA_LOG.Info(fmt.Sprintf("发现空包文件夹: %s，将删除数据库记录和文件夹", pkg.PackageName))
+			logger().Info(fmt.Sprintf("发现空包文件夹: %s，将删除数据库记录和文件夹", pkg.PackageName))
 
 			// 删除空文件夹
 			if err := t.removeEmptyPackageFolder(pkg.PackageName, pkg.Template); err != nil {
-				global.GVA_LOG.Warn(fmt.Sprintf("删除空包文件夹 %s 失败: %v", pkg.PackageName, err))
+				logger().Warn(fmt.Sprintf("删除空包文件夹 %s 失败: %v", pkg.PackageName, err))
 			}
 		} else {
 			// 文件夹不为空，保留该包
@@ -567,9 +567,9 @@ func (t *AutomationModuleAnalyzer) handleAnalyze(ctx context.Context, request mc
 	// 批量删除空包的数据库记录
 	if len(emptyPackageIDs) > 0 {
 		if err := global.GVA_DB.Where("id IN ?", emptyPackageIDs).Delete(&model.SysAutoCodePackage{}).Error; err != nil {
-			global.GVA_LOG.Warn(fmt.Sprintf("删除空包数据库记录失败: %v", err))
+			logger().Warn(fmt.Sprintf("删除空包数据库记录失败: %v", err))
 		} else {
-			global.GVA_LOG.Info(fmt.Sprintf("成功删除 %d 个空包的数据库记录", len(emptyPackageIDs)))
+			logger().Info(fmt.Sprintf("成功删除 %d 个空包的数据库记录", len(emptyPackageIDs)))
 		}
 	}
 
@@ -599,17 +599,17 @@ func (t *AutomationModuleAnalyzer) handleAnalyze(ctx context.Context, request mc
 
 		// 清理相关的API和菜单记录
 		if len(emptyHistoryIDs) > 0 {
-			if err := t.cleanupRelatedApiAndMenus(emptyHistoryIDs); err != nil {
-				global.GVA_LOG.Warn(fmt.Sprintf("清理空包相关API和菜单失败: %v", err))
+			if err := t.cleanupRelatedApiAndMenus(ctx, emptyHistoryIDs); err != nil {
+				logger().Warn(fmt.Sprintf("清理空包相关API和菜单失败: %v", err))
 			}
 		}
 
 		// 批量删除相关历史记录
 		if len(emptyHistoryIDs) > 0 {
 			if err := global.GVA_DB.Where("id IN ?", emptyHistoryIDs).Delete(&model.SysAutoCodeHistory{}).Error; err != nil {
-				global.GVA_LOG.Warn(fmt.Sprintf("删除空包相关历史记录失败: %v", err))
+				logger().Warn(fmt.Sprintf("删除空包相关历史记录失败: %v", err))
 			} else {
-				global.GVA_LOG.Info(fmt.Sprintf("成功删除 %d 个空包相关的历史记录", len(emptyHistoryIDs)))
+				logger().Info(fmt.Sprintf("成功删除 %d 个空包相关的历史记录", len(emptyHistoryIDs)))
 			}
 		}
 	}
@@ -631,14 +631,14 @@ func (t *AutomationModuleAnalyzer) handleAnalyze(ctx context.Context, request mc
 	// 删除脏历史记录
 	if len(dirtyHistoryIDs) > 0 {
 		// 清理相关的API和菜单记录
-		if err := t.cleanupRelatedApiAndMenus(dirtyHistoryIDs); err != nil {
-			global.GVA_LOG.Warn(fmt.Sprintf("清理脏历史记录相关API和菜单失败: %v", err))
+		if err := t.cleanupRelatedApiAndMenus(ctx, dirtyHistoryIDs); err != nil {
+			logger().Warn(fmt.Sprintf("清理脏历史记录相关API和菜单失败: %v", err))
 		}
 
 		if err := global.GVA_DB.Where("id IN ?", dirtyHistoryIDs).Delete(&model.SysAutoCodeHistory{}).Error; err != nil {
-			global.GVA_LOG.Warn(fmt.Sprintf("删除脏历史记录失败: %v", err))
+			logger().Warn(fmt.Sprintf("删除脏历史记录失败: %v", err))
 		} else {
-			global.GVA_LOG.Info(fmt.Sprintf("成功删除 %d 个脏历史记录（包名不在有效包列表中）", len(dirtyHistoryIDs)))
+			logger().Info(fmt.Sprintf("成功删除 %d 个脏历史记录（包名不在有效包列表中）", len(dirtyHistoryIDs)))
 		}
 	}
 
@@ -663,7 +663,7 @@ func (t *AutomationModuleAnalyzer) handleAnalyze(ctx context.Context, request mc
 	// 扫描预设计的模块
 	allPredesignedModules, err := t.scanPredesignedModules()
 	if err != nil {
-		global.GVA_LOG.Warn("扫描预设计模块失败" + err.Error())
+		logger().Warn("扫描预设计模块失败" + err.Error())
 		allPredesignedModules = []PredesignedModuleInfo{} // 确保不为nil
 	}
 
@@ -1067,7 +1067,7 @@ func (t *AutomationModuleAnalyzer) buildDirectoryStructure(plan *ExecutionPlan)
 	paths := make(map[string]string)
 
 	// 获取配置信息
-	autoCodeConfig := global.GVA_CONFIG.AutoCode
+	autoCodeConfig := global.GVA_CONFIG.Load().AutoCode
 
 	// 构建基础路径
 	rootPath := autoCodeConfig.Root
@@ -1434,7 +1434,7 @@ func (t *AutomationModuleAnalyzer) createRequiredDictionaries(ctx context.Contex
 						Desc:   fmt.Sprintf("自动生成的字典，用于模块 %s 字段: %s (%s)", modulesInfo.StructName, field.FieldName, field.FieldDesc),
 					}
 
-					err = dictionaryService.CreateSysDictionary(dictionary)
+					err = dictionaryService.CreateSysDictionary(ctx, dictionary)
 					if err != nil {
 						messages = append(messages, fmt.Sprintf("创建字典 %s 失败: %v; ", field.DictType, err))
 					} else {
@@ -1488,7 +1488,7 @@ func (t *AutomationModuleAnalyzer) generateDictionaryName(dictType, fieldDesc st
 func (t *AutomationModuleAnalyzer) createDefaultDictionaryDetails(ctx context.Context, dictType, fieldDesc string) {
 	// 字典选项现在通过 generate_dictionary_options MCP工具由AI client传入
 	// 这里不再创建默认选项，只是保留方法以保持兼容性
-	global.GVA_LOG.Info(fmt.Sprintf("字典 %s 已创建，请使用 generate_dictionary_options 工具添加字典选项", dictType))
+	logger().Info(fmt.Sprintf("字典 %s 已创建，请使用 generate_dictionary_options 工具添加字典选项", dictType))
 }
 
 // DictionaryOption 字典选项结构
@@ -1563,10 +1563,10 @@ func (t *AutomationModuleAnalyzer) isPackageFolderEmpty(packageName, template st
 	// 根据模板类型确定基础路径
 	var basePath string
 	if template == "plugin" {
-		basePath = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", packageName)
+		basePath = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", packageName)
 	} else {
 		// package 类型
-		basePath = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "model", packageName)
+		basePath = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "model", packageName)
 	}
 
 	// 检查文件夹是否存在
@@ -1624,17 +1624,17 @@ func (t *AutomationModuleAnalyzer) removeEmptyPackageFolder(packageName, templat
 
 	if template == "plugin" {
 		// plugin 类型只删除 plugin 目录下的文件夹
-		basePath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", packageName)
+		basePath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", packageName)
 		if err := t.removeDirectoryIfExists(basePath); err != nil {
 			errors = append(errors, fmt.Sprintf("删除plugin文件夹失败: %v", err))
 		}
 	} else {
 		// package 类型需要删除多个目录下的相关文件
 		paths := []string{
-			filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "model", packageName),
-			filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "api", "v1", packageName),
-			filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service", packageName),
-			filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "router", packageName),
+			filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "model", packageName),
+			filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "api", "v1", packageName),
+			filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service", packageName),
+			filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "router", packageName),
 		}
 
 		for _, path := range paths {
@@ -1666,12 +1666,12 @@ func (t *AutomationModuleAnalyzer) removeDirectoryIfExists(dirPath string) error
 		return fmt.Errorf("删除文件夹失败: %v", err)
 	}
 
-	global.GVA_LOG.Info(fmt.Sprintf("成功删除目录: %s", dirPath))
+	logger().Info(fmt.Sprintf("成功删除目录: %s", dirPath))
 	return nil
 }
 
 // cleanupRelatedApiAndMenus 清理与删除的模块相关的API和菜单记录
-func (t *AutomationModuleAnalyzer) cleanupRelatedApiAndMenus(historyIDs []uint) error {
+func (t *AutomationModuleAnalyzer) cleanupRelatedApiAndMenus(ctx context.Context, historyIDs []uint) error {
 	if len(historyIDs) == 0 {
 		return nil
 	}
@@ -1692,27 +1692,27 @@ func (t *AutomationModuleAnalyzer) cleanupRelatedApiAndMenus(historyIDs []uint)
 				ids = append(ids, int(id))
 			}
 			idsReq := common.IdsReq{Ids: ids}
-			if err := systemService.ApiServiceApp.DeleteApisByIds(idsReq); err != nil {
-				global.GVA_LOG.Warn(fmt.Sprintf("删除API记录失败 (模块: %s): %v", history.StructName, err))
+			if err := systemService.ApiServiceApp.DeleteApisByIds(ctx, idsReq); err != nil {
+				logger().Warn(fmt.Sprintf("删除API记录失败 (模块: %s): %v", history.StructName, err))
 			} else {
 				deletedApiCount += len(ids)
-				global.GVA_LOG.Info(fmt.Sprintf("成功删除API记录 (模块: %s, 数量: %d)", history.StructName, len(ids)))
+				logger().Info(fmt.Sprintf("成功删除API记录 (模块: %s, 数量: %d)", history.StructName, len(ids)))
 			}
 		}
 
 		// 删除相关的菜单记录（使用存储的菜单ID）
 		if history.MenuID != 0 {
-			if err := systemService.BaseMenuServiceApp.DeleteBaseMenu(int(history.MenuID)); err != nil {
-				global.GVA_LOG.Warn(fmt.Sprintf("删除菜单记录失败 (模块: %s, 菜单ID: %d): %v", history.StructName, history.MenuID, err))
+			if err := systemService.BaseMenuServiceApp.DeleteBaseMenu(ctx, int(history.MenuID)); err != nil {
+				logger().Warn(fmt.Sprintf("删除菜单记录失败 (模块: %s, 菜单ID: %d): %v", history.StructName, history.MenuID, err))
 			} else {
 				deletedMenuCount++
-				global.GVA_LOG.Info(fmt.Sprintf("成功删除菜单记录 (模块: %s, 菜单ID: %d)", history.StructName, history.MenuID))
+				logger().Info(fmt.Sprintf("成功删除菜单记录 (模块: %s, 菜单ID: %d)", history.StructName, history.MenuID))
 			}
 		}
 	}
 
 	if deletedApiCount > 0 || deletedMenuCount > 0 {
-		global.GVA_LOG.Info(fmt.Sprintf("清理完成：删除了 %d 个API记录和 %d 个菜单记录", deletedApiCount, deletedMenuCount))
+		logger().Info(fmt.Sprintf("清理完成：删除了 %d 个API记录和 %d 个菜单记录", deletedApiCount, deletedMenuCount))
 	}
 
 	return nil
@@ -240,7 +240,7 @@ func (m *MenuCreator) Handle(ctx context.Context, request mcp.CallToolRequest) (
 
 	// 创建菜单
 	menuService := service.ServiceGroupApp.SystemServiceGroup.MenuService
-	err := menuService.AddBaseMenu(menu)
+	err := menuService.AddBaseMenu(ctx, menu)
 	if err != nil {
 		return nil, fmt.Errorf("创建菜单失败: %v", err)
 	}
@@ -249,7 +249,7 @@ func (m *MenuCreator) Handle(ctx context.Context, request mcp.CallToolRequest) (
 	var createdMenu system.SysBaseMenu
 	err = global.GVA_DB.Where("name = ? AND path = ?", name, path).First(&createdMenu).Error
 	if err != nil {
-		global.GVA_LOG.Warn("获取创建的菜单ID失败", zap.Error(err))
+		logger().Warn("获取创建的菜单ID失败", zap.Error(err))
 	}
 
 	// 构建响应
@@ -0,0 +1,113 @@
+package mcpTool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	common "server/model/common/request"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemService "server/service/system"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func init() {
+	RegisterTool(&OperationRecordQuery{})
+}
+
+// OperationRecordQuery 按条件查询操作记录
+type OperationRecordQuery struct{}
+
+// OperationRecordQueryResponse 操作记录查询结果
+type OperationRecordQueryResponse struct {
+	List     []system.SysOperationRecord `json:"list"`
+	Total    int64                       `json:"total"`
+	Page     int                         `json:"page"`
+	PageSize int                         `json:"pageSize"`
+}
+
+func (t *OperationRecordQuery) New() mcp.Tool {
+	return mcp.NewTool("query_operation_records",
+		mcp.WithDescription(`按请求方法、路径、状态码、用户与时间范围分页查询操作记录, 按时间倒序返回。
+需要操作记录列表接口权限, 只返回调用方资源权限范围内用户的记录。`),
+		mcp.WithString("method",
+			mcp.Description("请求方法"),
+			mcp.Enum("GET", "POST", "PUT", "DELETE"),
+		),
+		mcp.WithString("path",
+			mcp.Description("请求路径, 模糊匹配"),
+		),
+		mcp.WithNumber("status",
+			mcp.Description("HTTP 状态码"),
+		),
+		mcp.WithNumber("userId",
+			mcp.Description("用户ID"),
+		),
+		mcp.WithString("startTime",
+			mcp.Description("开始时间, 格式 2006-01-02 15:04:05, 需与 endTime 同时传入"),
+		),
+		mcp.WithString("endTime",
+			mcp.Description("结束时间, 格式 2006-01-02 15:04:05"),
+		),
+		mcp.WithNumber("page",
+			mcp.Description("页码, 默认1"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("pageSize",
+			mcp.Description("每页条数, 默认10, 最大100"),
+			mcp.Min(1),
+			mcp.Max(100),
+		),
+	)
+}
+
+func (t *OperationRecordQuery) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	caller, err := requireApi(ctx, "/sysOperationRecord/getSysOperationRecordList", http.MethodGet)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	info := systemReq.SysOperationRecordQuery{
+		SysOperationRecordFilter: systemReq.SysOperationRecordFilter{
+			Method: request.GetString("method", ""),
+			Path:   request.GetString("path", ""),
+			Status: request.GetInt("status", 0),
+			UserID: request.GetInt("userId", 0),
+		},
+		PageInfo: common.PageInfo{Page: request.GetInt("page", 1), PageSize: request.GetInt("pageSize", 10)},
+	}
+	// 与 PageInfo.Paginate 的取值范围一致, 返回实际使用的分页参数
+	if info.Page <= 0 {
+		info.Page = 1
+	}
+	if info.PageSize <= 0 {
+		info.PageSize = 10
+	}
+	if info.PageSize > 100 {
+		info.PageSize = 100
+	}
+	if start, end := request.GetString("startTime", ""), request.GetString("endTime", ""); start != "" && end != "" {
+		startTime, err := time.ParseInLocation(time.DateTime, start, time.Local)
+		if err != nil {
+			return mcp.NewToolResultError("startTime 格式错误: " + err.Error()), nil
+		}
+		endTime, err := time.ParseInLocation(time.DateTime, end, time.Local)
+		if err != nil {
+			return mcp.NewToolResultError("endTime 格式错误: " + err.Error()), nil
+		}
+		info.StartCreatedAt, info.EndCreatedAt = &startTime, &endTime
+	}
+
+	list, total, err := systemService.OperationRecordServiceApp.GetSysOperationRecordListByAuthority(ctx, info, caller.AuthorityId)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("查询操作记录失败", err), nil
+	}
+	result, err := json.Marshal(OperationRecordQueryResponse{List: list, Total: total, Page: info.Page, PageSize: info.PageSize})
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(result)), nil
+}
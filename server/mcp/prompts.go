@@ -0,0 +1,58 @@
+package mcpTool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAllPrompts 将提示词模板注册到MCP服务中, 提示词只引导助手读取资源, 不调用任何修改数据的工具
+func RegisterAllPrompts(mcpServer *server.MCPServer) {
+	mcpServer.AddPrompt(mcp.NewPrompt("gva_inspect_table",
+		mcp.WithPromptDescription("查看数据表结构并说明如何为其生成 CRUD 模块"),
+		mcp.WithArgument("dbName", mcp.RequiredArgument(), mcp.ArgumentDescription("库名, 可从 gva://databases 获取")),
+		mcp.WithArgument("tableName", mcp.RequiredArgument(), mcp.ArgumentDescription("表名")),
+		mcp.WithArgument("businessDB", mcp.ArgumentDescription("业务库别名, 查询主库时留空")),
+	), inspectTablePrompt)
+
+	mcpServer.AddPrompt(mcp.NewPrompt("gva_system_overview",
+		mcp.WithPromptDescription("汇总当前系统的菜单、API、字典与代码生成包, 用于回答系统现状相关的问题"),
+		mcp.WithArgument("question", mcp.ArgumentDescription("需要回答的问题")),
+	), systemOverviewPrompt)
+}
+
+func inspectTablePrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := request.Params.Arguments
+	if args["dbName"] == "" || args["tableName"] == "" {
+		return nil, fmt.Errorf("dbName 与 tableName 不能为空")
+	}
+	uri := fmt.Sprintf("gva://tables/%s/%s", args["dbName"], args["tableName"])
+	if args["businessDB"] != "" {
+		uri += "?businessDB=" + args["businessDB"]
+	}
+	text := fmt.Sprintf(`请读取资源 %s 获取表 %s 的字段信息, 然后:
+1. 逐个说明字段的含义、类型与是否可为空
+2. 结合 gva://dictionaries 判断哪些字段适合绑定字典
+3. 结合 gva://autocode/packages 建议生成代码使用的包名
+只做分析与建议, 不要调用任何创建或修改数据的工具。`, uri, args["tableName"])
+	return mcp.NewGetPromptResult("查看数据表结构", []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	}), nil
+}
+
+func systemOverviewPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	text := `请依次读取以下只读资源了解系统现状:
+- gva://menus 菜单树
+- gva://apis API列表
+- gva://dictionaries 字典
+- gva://autocode/packages 代码生成包
+读取失败的资源说明调用方没有对应权限, 请如实说明而不要猜测其内容。`
+	if question := request.Params.Arguments["question"]; question != "" {
+		text += "\n\n然后回答: " + question
+	}
+	return mcp.NewGetPromptResult("系统概览", []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	}), nil
+}
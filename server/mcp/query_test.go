@@ -0,0 +1,59 @@
+package mcpTool
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"server/global"
+	"server/model/system"
+	"server/utils"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestOperationRecordQuery_DataAuthority(t *testing.T) {
+	setupAuthDB(t)
+	db := global.GVA_DB
+	if err := db.AutoMigrate(&system.SysUser{}, &system.SysUserAuthority{}); err != nil {
+		t.Fatal(err)
+	}
+	var admin system.SysAuthority
+	db.First(&admin, "authority_id = ?", 888)
+	guest := system.SysAuthority{AuthorityId: 9528, AuthorityName: "测试角色"}
+	db.Create(&guest)
+	if err := db.Model(&admin).Association("DataAuthorityId").Replace([]*system.SysAuthority{&admin}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&[]system.SysUserAuthority{{SysUserId: 1, SysAuthorityAuthorityId: 888}, {SysUserId: 2, SysAuthorityAuthorityId: 9528}})
+	db.Create(&[]system.SysOperationRecord{
+		{Method: "POST", Path: "/user/admin_register", Status: 200, UserID: 1},
+		{Method: "POST", Path: "/user/admin_register", Status: 200, UserID: 2},
+		{Method: "GET", Path: "/menu/getMenu", Status: 200, UserID: 1},
+	})
+
+	tool := &OperationRecordQuery{}
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"method": "POST"}
+	ctx := WithCaller(context.Background(), &Caller{ApiKey: "editor", AuthorityId: 888})
+
+	result, _ := tool.Handle(ctx, request)
+	if !result.IsError {
+		t.Fatal("没有操作记录列表接口权限时应拒绝查询")
+	}
+
+	if _, err := utils.GetCasbin().AddPolicy("888", "/sysOperationRecord/getSysOperationRecordList", "GET"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := tool.Handle(ctx, request)
+	if err != nil || result.IsError {
+		t.Fatalf("Handle() = %+v, %v", result, err)
+	}
+	var response OperationRecordQueryResponse
+	if err = json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Total != 1 || response.List[0].UserID != 1 {
+		t.Errorf("只应返回资源权限范围内用户的记录, 实际 %+v", response)
+	}
+}
@@ -0,0 +1,162 @@
+package mcpTool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"server/global"
+	"server/model/system"
+	"server/service"
+	systemService "server/service/system"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gorm.io/gorm"
+)
+
+// resourceEntry 只读资源, 读取时要求调用方拥有对应 HTTP 接口的权限
+type resourceEntry struct {
+	uri         string // 资源地址或 RFC 6570 地址模板
+	name        string
+	description string
+	api         string // 对应的 HTTP 接口, 与其共用 casbin 策略
+	method      string
+	read        func(ctx context.Context, caller *Caller, args map[string]string) (any, error)
+}
+
+var resources = []resourceEntry{
+	{
+		uri:         "gva://menus",
+		name:        "菜单树",
+		description: "调用方角色可分配的基础菜单树, 包含路由名称、路径、组件与按钮",
+		api:         "/menu/getBaseMenuTree",
+		method:      http.MethodPost,
+		read: func(ctx context.Context, caller *Caller, args map[string]string) (any, error) {
+			return systemService.MenuServiceApp.GetBaseMenuTree(ctx, caller.AuthorityId)
+		},
+	},
+	{
+		uri:         "gva://apis",
+		name:        "API列表",
+		description: "系统中登记的全部 API 及其分组, 严格角色模式下只包含调用方可分配的 API",
+		api:         "/api/getAllApis",
+		method:      http.MethodPost,
+		read: func(ctx context.Context, caller *Caller, args map[string]string) (any, error) {
+			return systemService.ApiServiceApp.GetAllApis(ctx, caller.AuthorityId)
+		},
+	},
+	{
+		uri:         "gva://dictionaries",
+		name:        "字典",
+		description: "全部字典及按排序排列的字典详情",
+		api:         "/sysDictionary/getSysDictionaryList",
+		method:      http.MethodGet,
+		read: func(ctx context.Context, caller *Caller, args map[string]string) (any, error) {
+			var dictionaries []system.SysDictionary
+			err := global.GVA_DB.WithContext(ctx).Preload("SysDictionaryDetails", func(db *gorm.DB) *gorm.DB {
+				return db.Order("sort")
+			}).Find(&dictionaries).Error
+			return dictionaries, err
+		},
+	},
+	{
+		uri:         "gva://autocode/packages",
+		name:        "代码生成包",
+		description: "代码生成器中已创建的包与插件, 生成代码时 package 参数取自此处",
+		api:         "/autoCode/getPackage",
+		method:      http.MethodPost,
+		read: func(ctx context.Context, caller *Caller, args map[string]string) (any, error) {
+			return service.ServiceGroupApp.SystemServiceGroup.AutoCodePackage.All(ctx)
+		},
+	},
+	{
+		uri:         "gva://databases",
+		name:        "数据库",
+		description: "当前数据库中的库名, 以及 db-list 中配置的业务库(aliasName 即 businessDB)",
+		api:         "/autoCode/getDB",
+		method:      http.MethodGet,
+		read: func(ctx context.Context, caller *Caller, args map[string]string) (any, error) {
+			dbs, err := service.ServiceGroupApp.SystemServiceGroup.AutoCodeService.Database("").GetDB(ctx, "")
+			if err != nil {
+				return nil, err
+			}
+			businessDBs := make([]map[string]any, 0, len(global.GVA_CONFIG.Load().DBList))
+			for _, db := range global.GVA_CONFIG.Load().DBList {
+				businessDBs = append(businessDBs, map[string]any{"aliasName": db.AliasName, "dbName": db.Dbname, "dbtype": db.Type, "disable": db.Disable})
+			}
+			return map[string]any{"dbs": dbs, "dbList": businessDBs}, nil
+		},
+	},
+	{
+		uri:         "gva://tables/{dbName}{?businessDB}",
+		name:        "数据表",
+		description: "指定库中的全部数据表, 查询业务库时传入 businessDB",
+		api:         "/autoCode/getTables",
+		method:      http.MethodGet,
+		read: func(ctx context.Context, caller *Caller, args map[string]string) (any, error) {
+			businessDB := args["businessDB"]
+			return service.ServiceGroupApp.SystemServiceGroup.AutoCodeService.Database(businessDB).GetTables(ctx, businessDB, args["dbName"])
+		},
+	},
+	{
+		uri:         "gva://tables/{dbName}/{tableName}{?businessDB}",
+		name:        "表结构",
+		description: "指定数据表的字段名、类型、长度、注释与主键, 查询业务库时传入 businessDB",
+		api:         "/autoCode/getColumn",
+		method:      http.MethodGet,
+		read: func(ctx context.Context, caller *Caller, args map[string]string) (any, error) {
+			businessDB := args["businessDB"]
+			return service.ServiceGroupApp.SystemServiceGroup.AutoCodeService.Database(businessDB).GetColumn(ctx, businessDB, args["tableName"], args["dbName"])
+		},
+	},
+}
+
+// RegisterAllResources 将只读资源注册到MCP服务中, 地址中包含 { 的注册为资源模板
+func RegisterAllResources(mcpServer *server.MCPServer) {
+	for i := range resources {
+		entry := resources[i]
+		handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return entry.handle(ctx, request)
+		}
+		if strings.Contains(entry.uri, "{") {
+			mcpServer.AddResourceTemplate(mcp.NewResourceTemplate(entry.uri, entry.name,
+				mcp.WithTemplateDescription(entry.description),
+				mcp.WithTemplateMIMEType("application/json"),
+			), handler)
+			continue
+		}
+		mcpServer.AddResource(mcp.NewResource(entry.uri, entry.name,
+			mcp.WithResourceDescription(entry.description),
+			mcp.WithMIMEType("application/json"),
+		), handler)
+	}
+}
+
+func (entry resourceEntry) handle(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	caller, err := requireApi(ctx, entry.api, entry.method)
+	if err != nil {
+		return nil, err
+	}
+	args := make(map[string]string, len(request.Params.Arguments))
+	for name, value := range request.Params.Arguments {
+		switch v := value.(type) {
+		case string:
+			args[name] = v
+		case []string:
+			if len(v) > 0 {
+				args[name] = v[0]
+			}
+		}
+	}
+	data, err := entry.read(ctx, caller, args)
+	if err != nil {
+		return nil, err
+	}
+	text, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/json", Text: string(text)}}, nil
+}
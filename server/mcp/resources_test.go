@@ -0,0 +1,80 @@
+package mcpTool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/global"
+	mcpClient "server/mcp/client"
+	"server/model/system"
+	"server/utils"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestStreamableResources(t *testing.T) {
+	setupAuthDB(t)
+	if err := global.GVA_DB.AutoMigrate(&system.SysDictionary{}, &system.SysDictionaryDetail{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB.Create(&system.SysDictionary{Name: "性别", Type: "gender"})
+	if _, err := utils.GetCasbin().AddPolicy("888", "/sysDictionary/getSysDictionaryList", "GET"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := server.NewMCPServer("GVA_MCP", "test", server.WithResourceCapabilities(false, false), server.WithPromptCapabilities(false))
+	RegisterAllResources(s)
+	RegisterAllPrompts(s)
+	streamable := server.NewStreamableHTTPServer(s)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, err := Authenticate(r, r.RemoteAddr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		streamable.ServeHTTP(w, r.WithContext(WithCaller(r.Context(), caller)))
+	}))
+	defer ts.Close()
+
+	if _, err := mcpClient.NewStreamableClient(ts.URL, "test", "v1", "GVA_MCP"); err == nil {
+		t.Fatal("未携带 API Key 时应拒绝连接")
+	}
+	c, err := mcpClient.NewStreamableClient(ts.URL, "test", "v1", "GVA_MCP", transport.WithHTTPHeaders(map[string]string{"x-api-key": "secret"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "gva://dictionaries"
+	result, err := c.ReadResource(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := result.Contents[0].(mcp.TextResourceContents).Text; !strings.Contains(text, `"gender"`) {
+		t.Errorf("gva://dictionaries = %s", text)
+	}
+
+	request.Params.URI = "gva://tables/gva/sys_users"
+	if _, err = c.ReadResource(ctx, request); err == nil || !strings.Contains(err.Error(), "权限不足") {
+		t.Errorf("没有 /autoCode/getColumn 权限时应拒绝读取, err = %v", err)
+	}
+
+	prompts, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{})
+	if err != nil || len(prompts.Prompts) != 2 {
+		t.Fatalf("ListPrompts() = %v, %v", prompts, err)
+	}
+	prompt := mcp.GetPromptRequest{}
+	prompt.Params.Name = "gva_inspect_table"
+	prompt.Params.Arguments = map[string]string{"dbName": "gva", "tableName": "sys_users"}
+	got, err := c.GetPrompt(ctx, prompt)
+	if err != nil || !strings.Contains(got.Messages[0].Content.(mcp.TextContent).Text, "gva://tables/gva/sys_users") {
+		t.Errorf("GetPrompt() = %v, %v", got, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"server/global"
 	"server/model/common/response"
 	"server/utils"
+	"server/utils/metrics"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,13 +17,14 @@ func CasbinHandler() gin.HandlerFunc {
 		waitUse, _ := utils.GetClaims(c)
 		//获取请求的PATH
 		path := c.Request.URL.Path
-		obj := strings.TrimPrefix(path, global.GVA_CONFIG.System.RouterPrefix)
+		obj := strings.TrimPrefix(path, global.GVA_CONFIG.Load().System.RouterPrefix)
 		// 获取请求方法
 		act := c.Request.Method
 		// 获取用户的角色
 		sub := strconv.Itoa(int(waitUse.AuthorityId))
 		e := utils.GetCasbin() // 判断策略中是否存在
 		success, _ := e.Enforce(sub, obj, act)
+		metrics.ObserveCasbin("http", success)
 		if !success {
 			response.FailWithDetailed(gin.H{}, "权限不足", c)
 			c.Abort()
@@ -13,9 +13,9 @@ func Cors() gin.HandlerFunc {
 		method := c.Request.Method
 		origin := c.Request.Header.Get("Origin")
 		c.Header("Access-Control-Allow-Origin", origin)
-		c.Header("Access-Control-Allow-Headers", "Content-Type,AccessToken,X-CSRF-Token, Authorization, Token,X-Token,X-User-Id")
+		c.Header("Access-Control-Allow-Headers", "Content-Type,AccessToken,X-CSRF-Token, Authorization, Token,X-Token,X-User-Id,X-Request-ID")
 		c.Header("Access-Control-Allow-Methods", "POST, GET, OPTIONS,DELETE,PUT")
-		c.Header("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type, New-Token, New-Expires-At")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type, New-Token, New-Expires-At, X-Request-ID, X-Trace-Id")
 		c.Header("Access-Control-Allow-Credentials", "true")
 
 		// 放行所有OPTIONS方法
@@ -27,13 +27,15 @@ func Cors() gin.HandlerFunc {
 	}
 }
 
-// CorsByRules 按照配置处理跨域请求
+// CorsByRules 按照配置处理跨域请求, 每次请求读取配置, 支持热加载
 func CorsByRules() gin.HandlerFunc {
-	// 放行全部
-	if global.GVA_CONFIG.Cors.Mode == "allow-all" {
-		return Cors()
-	}
+	allowAll := Cors()
 	return func(c *gin.Context) {
+		// 放行全部
+		if global.GVA_CONFIG.Load().Cors.Mode == "allow-all" {
+			allowAll(c)
+			return
+		}
 		whitelist := checkCors(c.GetHeader("origin"))
 
 		// 通过检查, 添加请求头
@@ -48,7 +50,7 @@ func CorsByRules() gin.HandlerFunc {
 		}
 
 		// 严格白名单模式且未通过检查，直接拒绝处理请求
-		if whitelist == nil && global.GVA_CONFIG.Cors.Mode == "strict-whitelist" && !(c.Request.Method == "GET" && c.Request.URL.Path == "/health") {
+		if whitelist == nil && global.GVA_CONFIG.Load().Cors.Mode == "strict-whitelist" && !(c.Request.Method == "GET" && c.Request.URL.Path == "/health") {
 			c.AbortWithStatus(http.StatusForbidden)
 		} else {
 			// 非严格白名单模式，无论是否通过检查均放行所有 OPTIONS 方法
@@ -63,7 +65,7 @@ func CorsByRules() gin.HandlerFunc {
 }
 
 func checkCors(currentOrigin string) *config.CORSWhitelist {
-	for _, whitelist := range global.GVA_CONFIG.Cors.Whitelist {
+	for _, whitelist := range global.GVA_CONFIG.Load().Cors.Whitelist {
 		// 遍历配置中的跨域头，寻找匹配项
 		if currentOrigin == whitelist.AllowOrigin {
 			return &whitelist
@@ -8,6 +8,7 @@ import (
 
 	"server/plugin/email/utils"
 	utils2 "server/utils"
+	"server/utils/ctxlog"
 
 	"server/global"
 	"server/model/system"
@@ -50,8 +51,8 @@ func ErrorToEmail() gin.HandlerFunc {
 		str := "接收到的请求为" + record.Body + "\n" + "请求方式为" + record.Method + "\n" + "报错信息如下" + record.ErrorMessage + "\n" + "耗时" + latency.String() + "\n"
 		if status != 200 {
 			subject := username + "" + record.Ip + "调用了" + record.Path + "报错了"
-			if err := utils.ErrorToEmail(subject, str); err != nil {
-				global.GVA_LOG.Error("ErrorToEmail Failed, err:", zap.Error(err))
+			if err := utils.ErrorToEmail(c.Request.Context(), subject, str); err != nil {
+				ctxlog.Logger(c.Request.Context()).Error("ErrorToEmail Failed, err:", zap.Error(err))
 			}
 		}
 	}
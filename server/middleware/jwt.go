@@ -4,6 +4,8 @@ import (
 	"errors"
 	"server/global"
 	"server/utils"
+	"server/utils/changelog"
+	"server/utils/ctxlog"
 	"github.com/golang-jwt/jwt/v5"
 	"strconv"
 	"time"
@@ -52,15 +54,17 @@ func JWTAuth() gin.HandlerFunc {
 		//	c.Abort()
 		//}
 		c.Set("claims", claims)
+		ctxlog.SetUserID(c.Request.Context(), claims.BaseClaims.ID)
+		c.Request = c.Request.WithContext(changelog.WithOperator(c.Request.Context(), claims.BaseClaims.ID, claims.Username))
 		if claims.ExpiresAt.Unix()-time.Now().Unix() < claims.BufferTime {
-			dr, _ := utils.ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
+			dr, _ := utils.ParseDuration(global.GVA_CONFIG.Load().JWT.ExpiresTime)
 			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(dr))
 			newToken, _ := j.CreateTokenByOldToken(token, *claims)
 			newClaims, _ := j.ParseToken(newToken)
 			c.Header("new-token", newToken)
 			c.Header("new-expires-at", strconv.FormatInt(newClaims.ExpiresAt.Unix(), 10))
 			utils.SetToken(c, newToken, int(dr.Seconds()))
-			if global.GVA_CONFIG.System.UseMultipoint {
+			if global.GVA_CONFIG.Load().System.UseMultipoint {
 				// 记录新的活跃jwt
 				_ = utils.SetRedisJWT(newToken, newClaims.Username)
 			}
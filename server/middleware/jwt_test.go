@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"server/config"
+	"server/global"
+	"server/model/system/request"
+	"server/utils"
+	"server/utils/changelog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+)
+
+func TestJWTAuthOperator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	oldConfig := global.GVA_CONFIG.Load()
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.JWT.SigningKey = "test"
+		conf.JWT.ExpiresTime = "1h"
+		conf.JWT.BufferTime = "0s"
+	})
+	global.BlackCache = local_cache.NewCache()
+	t.Cleanup(func() { global.GVA_CONFIG.Store(oldConfig) })
+
+	j := utils.NewJWT()
+	token, err := j.CreateToken(j.CreateClaims(request.BaseClaims{ID: 7, Username: "admin"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var id uint
+	var name string
+	router := gin.New()
+	router.Use(JWTAuth())
+	router.POST("/write", func(c *gin.Context) {
+		// 服务层只拿得到请求上下文, 操作人必须能从中读出
+		id, name = changelog.Operator(c.Request.Context())
+	})
+	req := httptest.NewRequest(http.MethodPost, "/write", nil)
+	req.Header.Set("x-token", token)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if id != 7 || name != "admin" {
+		t.Errorf("Operator = (%d, %q), want (7, \"admin\")", id, name)
+	}
+}
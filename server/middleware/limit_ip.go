@@ -52,13 +52,16 @@ func DefaultCheckOrMark(key string, expire int, limit int) (err error) {
 	return err
 }
 
+// DefaultLimit 按系统配置限制单个IP的访问次数, 每次请求读取配置, 支持热加载
 func DefaultLimit() gin.HandlerFunc {
-	return LimitConfig{
-		GenerationKey: DefaultGenerationKey,
-		CheckOrMark:   DefaultCheckOrMark,
-		Expire:        global.GVA_CONFIG.System.LimitTimeIP,
-		Limit:         global.GVA_CONFIG.System.LimitCountIP,
-	}.LimitWithTime()
+	return func(c *gin.Context) {
+		LimitConfig{
+			GenerationKey: DefaultGenerationKey,
+			CheckOrMark:   DefaultCheckOrMark,
+			Expire:        global.GVA_CONFIG.Load().System.LimitTimeIP,
+			Limit:         global.GVA_CONFIG.Load().System.LimitCountIP,
+		}.LimitWithTime()(c)
+	}
 }
 
 // SetLimitWithTime 设置访问次数
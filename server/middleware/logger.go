@@ -5,18 +5,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 	"time"
 
+	"server/global"
+	"server/utils"
+	"server/utils/ctxlog"
+	"server/utils/tracing"
+
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 // LogLayout 日志layout
 type LogLayout struct {
 	Time      time.Time
 	Metadata  map[string]interface{} // 存储自定义原数据
+	RequestID string                 // 请求ID
+	TraceID   string                 // 链路追踪ID
+	UserID    uint                   // 用户ID, 未登录时为0
+	Method    string                 // 请求方法
+	Route     string                 // 路由模板
 	Path      string                 // 访问路径
+	Status    int                    // 响应状态码
 	Query     string                 // 携带query
+	Header    http.Header            // 请求头
 	Body      string                 // 携带body数据
 	IP        string                 // ip地址
 	UserAgent string                 // 代理
@@ -53,7 +67,10 @@ func (l Logger) SetLoggerMiddleware() gin.HandlerFunc {
 		cost := time.Since(start)
 		layout := LogLayout{
 			Time:      time.Now(),
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
 			Path:      path,
+			Status:    c.Writer.Status(),
 			Query:     query,
 			IP:        c.ClientIP(),
 			UserAgent: c.Request.UserAgent(),
@@ -61,9 +78,19 @@ func (l Logger) SetLoggerMiddleware() gin.HandlerFunc {
 			Cost:      cost,
 			Source:    l.Source,
 		}
+		if info := ctxlog.Request(c.Request.Context()); info != nil {
+			layout.RequestID, layout.UserID = info.RequestID, info.UserID()
+		}
+		layout.TraceID = tracing.TraceID(c.Request.Context())
 		if l.Filter != nil && !l.Filter(c) {
 			layout.Body = string(body)
 		}
+		// 按配置脱敏
+		masker := utils.GetMasker()
+		maskPath := strings.TrimPrefix(path, global.GVA_CONFIG.Load().System.RouterPrefix)
+		layout.Query = masker.MaskQuery(maskPath, layout.Query)
+		layout.Body = masker.MaskBody(maskPath, layout.Body)
+		layout.Header = masker.MaskHeader(c.Request.Header)
 		if l.AuthProcess != nil {
 			// 处理鉴权需要的信息
 			l.AuthProcess(c, &layout)
@@ -87,3 +114,35 @@ func DefaultLogger() gin.HandlerFunc {
 		Source: "GVA",
 	}.SetLoggerMiddleware()
 }
+
+// ZapLogger 使用 GVA_LOG 输出请求日志, 上传文件时不记录body
+func ZapLogger() gin.HandlerFunc {
+	return Logger{
+		Filter: func(c *gin.Context) bool {
+			return strings.Contains(c.GetHeader("Content-Type"), "multipart/form-data")
+		},
+		Print: func(layout LogLayout) {
+			fields := []zap.Field{
+				zap.String("request_id", layout.RequestID),
+				zap.String("method", layout.Method),
+				zap.String("route", layout.Route),
+				zap.Int("status", layout.Status),
+				zap.Uint("user_id", layout.UserID),
+			}
+			if layout.TraceID != "" {
+				fields = append(fields, zap.String("trace_id", layout.TraceID))
+			}
+			global.GVA_LOG.Info(layout.Path, append(fields,
+				zap.String("query", layout.Query),
+				zap.String("body", layout.Body),
+				zap.Any("header", layout.Header),
+				zap.String("ip", layout.IP),
+				zap.String("user-agent", layout.UserAgent),
+				zap.String("error", layout.Error),
+				zap.Duration("cost", layout.Cost),
+				zap.String("source", layout.Source),
+			)...)
+		},
+		Source: "GVA",
+	}.SetLoggerMiddleware()
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	mcpTool "server/mcp"
+	"server/model/common/response"
+	"server/utils/changelog"
+	"server/utils/ctxlog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// McpAuth MCP 的 SSE 与消息接口鉴权, 通过后将调用方写入请求上下文, 由工具中间件校验工具权限
+func McpAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		caller, err := mcpTool.Authenticate(c.Request, c.ClientIP())
+		if err != nil {
+			response.NoAuth(err.Error(), c)
+			c.Abort()
+			return
+		}
+		ctxlog.SetUserID(c.Request.Context(), caller.UserID)
+		ctx := changelog.WithOperator(c.Request.Context(), caller.UserID, caller.Username)
+		c.Request = c.Request.WithContext(mcpTool.WithCaller(ctx, caller))
+		c.Next()
+	}
+}
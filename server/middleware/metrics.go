@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"time"
+
+	"server/global"
+	"server/utils/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 记录请求数与耗时, 路由标签使用路由模板(如 /user/:id)避免标签数量膨胀
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		metrics.ObserveHTTP(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
+
+// MetricsAuth 指标接口访问控制, 按配置校验来源IP与 Basic Auth
+func MetricsAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		conf := global.GVA_CONFIG.Load().Metrics
+		if len(conf.AllowIps) > 0 && !ipAllowed(c.ClientIP(), conf.AllowIps) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		if conf.Username != "" {
+			username, password, ok := c.Request.BasicAuth()
+			if !ok ||
+				subtle.ConstantTimeCompare([]byte(username), []byte(conf.Username)) != 1 ||
+				subtle.ConstantTimeCompare([]byte(password), []byte(conf.Password)) != 1 {
+				c.Header("WWW-Authenticate", `Basic realm="metrics"`)
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// ipAllowed 判断 ip 是否命中白名单, 白名单项可以是单个IP或CIDR网段
+func ipAllowed(ip string, allowIps []string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, item := range allowIps {
+		if _, network, err := net.ParseCIDR(item); err == nil {
+			if network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(item); allowed != nil && allowed.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
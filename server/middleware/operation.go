@@ -12,9 +12,12 @@ import (
 	"time"
 
 	"server/utils"
+	"server/utils/ctxlog"
+	"server/utils/tracing"
 
 	"server/global"
 	"server/model/system"
+	systemService "server/service/system"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -63,13 +66,17 @@ func OperationRecord() gin.HandlerFunc {
 			}
 			userId = id
 		}
+		masker := utils.GetMasker()
+		maskPath := strings.TrimPrefix(c.Request.URL.Path, global.GVA_CONFIG.Load().System.RouterPrefix)
 		record := system.SysOperationRecord{
-			Ip:     c.ClientIP(),
-			Method: c.Request.Method,
-			Path:   c.Request.URL.Path,
-			Agent:  c.Request.UserAgent(),
-			Body:   "",
-			UserID: userId,
+			Ip:        c.ClientIP(),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			Agent:     c.Request.UserAgent(),
+			Body:      "",
+			UserID:    userId,
+			RequestID: ctxlog.RequestID(c.Request.Context()),
+			TraceID:   tracing.TraceID(c.Request.Context()),
 		}
 
 		// 上传文件时候 中间件日志进行裁断操作
@@ -79,7 +86,7 @@ func OperationRecord() gin.HandlerFunc {
 			if len(body) > bufferSize {
 				record.Body = "[超出记录长度]"
 			} else {
-				record.Body = string(body)
+				record.Body = masker.MaskBody(maskPath, string(body))
 			}
 		}
 
@@ -96,7 +103,7 @@ func OperationRecord() gin.HandlerFunc {
 		record.ErrorMessage = c.Errors.ByType(gin.ErrorTypePrivate).String()
 		record.Status = c.Writer.Status()
 		record.Latency = latency
-		record.Resp = writer.body.String()
+		record.Resp = masker.MaskBody(maskPath, writer.body.String())
 
 		if strings.Contains(c.Writer.Header().Get("Pragma"), "public") ||
 			strings.Contains(c.Writer.Header().Get("Expires"), "0") ||
@@ -112,8 +119,12 @@ func OperationRecord() gin.HandlerFunc {
 				record.Body = "超出记录长度"
 			}
 		}
+		if systemService.OperationRecordWriterApp != nil {
+			systemService.OperationRecordWriterApp.Write(record)
+			return
+		}
 		if err := global.GVA_DB.Create(&record).Error; err != nil {
-			global.GVA_LOG.Error("create operation record error:", zap.Error(err))
+			ctxlog.Logger(c.Request.Context()).Error("create operation record error:", zap.Error(err))
 		}
 	}
 }
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"server/utils/ctxlog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader 请求ID的请求头与响应头
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID 为每个请求分配请求ID, 上游已传入合法的 X-Request-ID 时沿用, 并在响应头中返回
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		info := &ctxlog.RequestInfo{RequestID: requestID, Route: c.FullPath()}
+		c.Request = c.Request.WithContext(ctxlog.WithRequest(c.Request.Context(), info))
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// validRequestID 只接受不超过64位的字母、数字与 -_.: , 避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
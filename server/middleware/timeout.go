@@ -1,55 +1,262 @@
 package middleware
 
 import (
+	"bufio"
+	"bytes"
 	"context"
-	"github.com/gin-gonic/gin"
+	"errors"
+	"net"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"server/config"
+	"server/global"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
+// currentTimeoutPolicy 当前生效的超时配置, 配置热加载时整体替换
+var currentTimeoutPolicy atomic.Pointer[timeoutPolicy]
+
+// Timeout 按配置为接口设置超时时间, 路径前缀最长匹配的覆盖优先, 未匹配时使用默认超时
+// 超时后立即返回 504 并取消请求上下文, 使用 WithContext 的数据库查询随之中断
+func Timeout() gin.HandlerFunc {
+	ReloadTimeout(global.GVA_CONFIG.Load().Timeout)
+	prefix := global.GVA_CONFIG.Load().System.RouterPrefix
+	return func(c *gin.Context) {
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+		if timeout := currentTimeoutPolicy.Load().match(strings.TrimPrefix(path, prefix)); timeout > 0 {
+			runWithTimeout(c, timeout)
+			return
+		}
+		c.Next()
+	}
+}
+
+// ReloadTimeout 替换 Timeout 中间件使用的超时配置, 之后的请求按新配置生效
+func ReloadTimeout(conf config.Timeout) {
+	currentTimeoutPolicy.Store(newTimeoutPolicy(conf))
+}
+
 // TimeoutMiddleware 创建超时中间件
 // 入参 timeout 设置超时时间（例如：time.Second * 5）
 // 使用示例 xxx.Get("path",middleware.TimeoutMiddleware(30*time.Second),HandleFunc)
 func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
-		defer cancel()
-
-		c.Request = c.Request.WithContext(ctx)
-
-		// 使用 buffered channel 避免 goroutine 泄漏
-		done := make(chan struct{}, 1)
-		panicChan := make(chan interface{}, 1)
-
-		go func() {
-			defer func() {
-				if p := recover(); p != nil {
-					select {
-					case panicChan <- p:
-					default:
-					}
-				}
-				select {
-				case done <- struct{}{}:
-				default:
-				}
-			}()
-			c.Next()
+		runWithTimeout(c, timeout)
+	}
+}
+
+type timeoutRoute struct {
+	path    string
+	timeout time.Duration
+}
+
+type timeoutPolicy struct {
+	defaultTimeout time.Duration
+	routes         []timeoutRoute // 按路径长度倒序
+}
+
+func newTimeoutPolicy(conf config.Timeout) *timeoutPolicy {
+	policy := &timeoutPolicy{defaultTimeout: parseTimeout("default", conf.Default)}
+	for _, route := range conf.Routes {
+		if route.Path == "" {
+			continue
+		}
+		policy.routes = append(policy.routes, timeoutRoute{
+			path:    strings.TrimSuffix(route.Path, "/"),
+			timeout: parseTimeout(route.Path, route.Timeout),
+		})
+	}
+	sort.SliceStable(policy.routes, func(i, j int) bool {
+		return len(policy.routes[i].path) > len(policy.routes[j].path)
+	})
+	return policy
+}
+
+// match 返回路径对应的超时时间, 0 表示不限制
+func (p *timeoutPolicy) match(path string) time.Duration {
+	for _, route := range p.routes {
+		if path == route.path || strings.HasPrefix(path, route.path+"/") {
+			return route.timeout
+		}
+	}
+	return p.defaultTimeout
+}
+
+func parseTimeout(name, value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		global.GVA_LOG.Warn("超时时间配置错误, 已忽略", zap.String("route", name), zap.String("timeout", value), zap.Error(err))
+		return 0
+	}
+	return timeout
+}
+
+// runWithTimeout 在新协程中执行后续处理, 响应先写入缓冲, 按时完成时再写出
+// 超时后直接向客户端返回 504, 并等待处理函数结束后再返回, 避免 gin.Context 被回收复用时仍在使用
+func runWithTimeout(c *gin.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
+
+	w := c.Writer
+	tw := &timeoutWriter{ResponseWriter: w, header: w.Header().Clone(), status: http.StatusOK}
+	c.Writer = tw
+
+	done := make(chan struct{})
+	var p any
+	go func() {
+		defer func() {
+			p = recover()
+			close(done)
 		}()
+		c.Next()
+	}()
 
-		select {
-		case p := <-panicChan:
-			panic(p)
-		case <-done:
-			return
-		case <-ctx.Done():
-			// 确保服务器超时设置足够长
-			c.Header("Connection", "close")
-			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
-				"code": 504,
-				"msg":  "请求超时",
-			})
-			return
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// 超时后立即响应, 但仍需等待处理函数结束
+		tw.timeout()
+		writeTimeout(w)
+		<-done
+	}
+	// 处理函数因超时取消而结束时, 其响应通常是查询失败, 同样按超时处理
+	if !tw.timedOut && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		tw.timeout()
+		writeTimeout(w)
+	}
+	c.Writer = w
+
+	if p != nil {
+		panic(p)
+	}
+	tw.flush()
+}
+
+func writeTimeout(w gin.ResponseWriter) {
+	w.Header().Set("Connection", "close")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusGatewayTimeout)
+	_, _ = w.WriteString(`{"code":504,"msg":"请求超时"}`)
+	w.Flush()
+}
+
+// timeoutWriter 缓冲处理函数写出的响应, 超时后丢弃所有写入
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu          sync.Mutex
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if code > 0 && !w.wroteHeader {
+		w.status = code
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.wroteHeader = true
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.wroteHeader = true
+	return w.body.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.wroteHeader {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.wroteHeader
+}
+
+// Flush 缓冲写出, 流式响应的接口应将超时配置为 0
+func (w *timeoutWriter) Flush() {}
+
+func (w *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("timeout middleware does not support hijacking")
+}
+
+func (w *timeoutWriter) Pusher() http.Pusher {
+	return nil
+}
+
+func (w *timeoutWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timedOut = true
+}
+
+// flush 将缓冲的响应写出到原始 writer, 超时后不再写出
+func (w *timeoutWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return
+	}
+	dst := w.ResponseWriter.Header()
+	for key := range dst {
+		if _, ok := w.header[key]; !ok {
+			dst.Del(key)
 		}
 	}
+	for key, values := range w.header {
+		dst[key] = values
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.wroteHeader {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
 }
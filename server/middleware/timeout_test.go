@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+	systemService "server/service/system"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestTimeoutPolicyMatch(t *testing.T) {
+	policy := newTimeoutPolicy(config.Timeout{
+		Default: "30s",
+		Routes: []config.TimeoutRoute{
+			{Path: "/sysExportTemplate", Timeout: "5m"},
+			{Path: "/sysExportTemplate/exportExcelByToken/", Timeout: "0"},
+		},
+	})
+	cases := map[string]time.Duration{
+		"/user/getUserList":                        30 * time.Second,
+		"/sysExportTemplate/importExcel":           5 * time.Minute,
+		"/sysExportTemplate/exportExcelByToken":    0,
+		"/sysExportTemplateX/getSysExportTemplate": 30 * time.Second,
+	}
+	for path, want := range cases {
+		if got := policy.match(path); got != want {
+			t.Errorf("match(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	finished := make(chan struct{})
+	router := gin.New()
+	router.Use(TimeoutMiddleware(50 * time.Millisecond))
+	router.GET("/fast", func(c *gin.Context) {
+		c.Header("X-Handler", "fast")
+		c.String(http.StatusCreated, "ok")
+	})
+	router.GET("/slow", func(c *gin.Context) {
+		defer close(finished)
+		<-c.Request.Context().Done()
+		// 超时后的写入应被丢弃
+		c.String(http.StatusOK, "late")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if w.Code != http.StatusCreated || w.Body.String() != "ok" || w.Header().Get("X-Handler") != "fast" {
+		t.Errorf("fast: code=%d body=%q header=%v", w.Code, w.Body.String(), w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	select {
+	case <-finished:
+	default:
+		t.Fatal("超时后应等待处理函数结束再返回")
+	}
+	if w.Code != http.StatusGatewayTimeout || w.Body.String() != `{"code":504,"msg":"请求超时"}` {
+		t.Errorf("slow: code=%d body=%q", w.Code, w.Body.String())
+	}
+}
+
+func TestTimeoutReload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout())
+	t.Cleanup(func() { ReloadTimeout(global.GVA_CONFIG.Load().Timeout) })
+	router.GET("/slow", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(100 * time.Millisecond):
+		}
+		c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("未配置超时时应正常返回, 实际 %d", w.Code)
+	}
+
+	// 热加载后的请求按新配置超时
+	ReloadTimeout(config.Timeout{Default: "20ms"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("热加载后应超时, 实际 %d", w.Code)
+	}
+}
+
+func TestTimeoutReachesWrite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, err := gorm.Open(sqlite.Open("file:"+t.TempDir()+"/timeout.db"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysApi{}); err != nil {
+		t.Fatal(err)
+	}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+	api := system.SysApi{Path: "/api/test", Method: "POST"}
+	db.Create(&api)
+
+	router := gin.New()
+	router.Use(TimeoutMiddleware(20 * time.Millisecond))
+	router.POST("/api/deleteApi", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		err = systemService.ApiServiceApp.DeleteApi(c.Request.Context(), api)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/deleteApi", nil))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DeleteApi err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	var count int64
+	db.Model(&system.SysApi{}).Count(&count)
+	if count != 1 {
+		t.Errorf("超时后不应删除记录, count = %d", count)
+	}
+}
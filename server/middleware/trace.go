@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"server/global"
+	"server/utils/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Trace 为每个请求创建 server span, span 名称为路由模板, 并从请求头中继承上游的 traceparent
+func Trace() gin.HandlerFunc {
+	serviceName := global.GVA_CONFIG.Load().Trace.ServiceName
+	if serviceName == "" {
+		serviceName = tracing.DefaultServiceName
+	}
+	return otelgin.Middleware(serviceName)
+}
+
+// TraceHeader 在响应头 X-Trace-Id 中返回 trace id, 需注册在 Trace 之后
+func TraceHeader() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+			c.Header("X-Trace-Id", traceID)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"server/api/v1/system"
+	"server/config"
+	"server/global"
+	systemModel "server/model/system"
+	"server/utils/tracing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+func TestTraceWriteHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exporter := tracetest.NewInMemoryExporter()
+	tracing.Setup(config.Trace{ServiceName: "test"}, sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tracing.Shutdown(context.Background()) })
+
+	db, err := gorm.Open(sqlite.Open("file:"+t.TempDir()+"/trace.db"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&systemModel.SysApi{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Use(tracing.GormPlugin("test")); err != nil {
+		t.Fatal(err)
+	}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+
+	router := gin.New()
+	router.Use(Trace())
+	router.POST("/api/createApi", new(system.SystemApiApi).CreateApi)
+	body := `{"path":"/test","description":"测试","apiGroup":"test","method":"POST"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/createApi", strings.NewReader(body)))
+	if !strings.Contains(w.Body.String(), "创建成功") {
+		t.Fatalf("createApi: %s", w.Body.String())
+	}
+
+	var server trace.SpanContext
+	var queries []tracetest.SpanStub
+	for _, s := range exporter.GetSpans() {
+		if s.SpanKind == trace.SpanKindServer {
+			server = s.SpanContext
+		} else {
+			queries = append(queries, s)
+		}
+	}
+	if !server.IsValid() || len(queries) < 2 {
+		t.Fatalf("应导出 server span 与查询/写入 span, 实际 %v", exporter.GetSpans().Snapshots())
+	}
+	for _, s := range queries {
+		// 写入的 span 必须挂在请求 span 下, 而不是成为孤立的根链路
+		if s.Parent.SpanID() != server.SpanID() || s.SpanContext.TraceID() != server.TraceID() {
+			t.Errorf("span %s 未挂在请求 span 下", s.Name)
+		}
+	}
+}
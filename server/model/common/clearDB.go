@@ -4,4 +4,5 @@ type ClearDB struct {
 	TableName    string
 	CompareField string
 	Interval     string
+	Keep         string // 保留满足该条件的记录, 为空时不限制
 }
@@ -12,3 +12,9 @@ type SearchApiParams struct {
 	OrderKey string `json:"orderKey"` // 排序
 	Desc     bool   `json:"desc"`     // 排序方式:升序false(默认)|降序true
 }
+
+// SdkGenerate 生成接口客户端
+type SdkGenerate struct {
+	Language string `json:"language" form:"language"` // 客户端语言: go|typescript
+	Package  string `json:"package" form:"package"`   // go 客户端包名, 默认 gvaclient
+}
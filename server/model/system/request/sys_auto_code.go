@@ -7,6 +7,7 @@ import (
 	model "server/model/system"
 	"github.com/pkg/errors"
 	"go/token"
+	"slices"
 	"strings"
 )
 
@@ -47,6 +48,10 @@ type AutoCode struct {
 	HasSearchTimer      bool                   `json:"-"`
 	HasArray            bool                   `json:"-"`
 	HasExcel            bool                   `json:"-"`
+	Relations           []*AutoCodeRelation    `json:"relations"`   // 关联关系
+	RelationPackages    []string               `json:"-"`           // 关联模型所在的其他包
+	HasNestedRelation   bool                   `json:"-"`           // 是否存在一对多/多对多关联
+	TemplateSet         string                 `json:"templateSet"` // 自定义模板集名称, 为空时使用内置模板
 }
 
 type DataSource struct {
@@ -118,7 +123,7 @@ func (r *AutoCode) Menu(template string) model.SysBaseMenu {
 // Pretreatment 预处理
 // Author [SliverHorn](https://github.com/SliverHorn)
 func (r *AutoCode) Pretreatment() error {
-	r.Module = global.GVA_CONFIG.AutoCode.Module
+	r.Module = global.GVA_CONFIG.Load().AutoCode.Module
 	if token.IsKeyword(r.Abbreviation) {
 		r.Abbreviation = r.Abbreviation + "_"
 	} // go 关键字处理
@@ -178,6 +183,17 @@ func (r *AutoCode) Pretreatment() error {
 			r.DictTypes = append(r.DictTypes, key)
 		}
 	} // DictTypes => 字典
+	for _, relation := range r.Relations {
+		if err := relation.Pretreatment(); err != nil {
+			return err
+		}
+		if relation.Type == RelationHasMany || relation.Type == RelationMany2Many {
+			r.HasNestedRelation = true
+		}
+		if relation.Package != "" && relation.Package != r.Package && !slices.Contains(r.RelationPackages, relation.Package) {
+			r.RelationPackages = append(r.RelationPackages, relation.Package)
+		}
+	} // Relations => 关联关系
 	{
 		if r.GvaModel {
 			r.PrimaryField = &AutoCodeField{
@@ -209,6 +225,21 @@ func (r *AutoCode) Pretreatment() error {
 	return nil
 }
 
+// AutoCodeRegenerate 重新生成已创建的模块
+type AutoCodeRegenerate struct {
+	AutoCode
+	Preview     bool `json:"preview" example:"false"`     // 是否只预览合并结果
+	ConfirmDrop bool `json:"confirmDrop" example:"false"` // 是否确认删除列, 字段变更包含删除列时必须为 true
+}
+
+// AutoCodeSpec 由已有数据表或建表语句生成 AutoCode, ddl 不为空时优先解析 ddl
+type AutoCodeSpec struct {
+	BusinessDB string `json:"businessDB" example:"业务数据库"` // 业务数据库, 为空时使用主库
+	TableName  string `json:"tableName" example:"表名"`     // 表名
+	DDL        string `json:"ddl" example:"建表语句"`         // CREATE TABLE 语句
+	Package    string `json:"package" example:"包名"`       // 生成到的包
+}
+
 func (r *AutoCode) History() SysAutoHistoryCreate {
 	bytes, _ := json.Marshal(r)
 	return SysAutoHistoryCreate{
@@ -229,6 +260,7 @@ type AutoCodeField struct {
 	DataTypeLong    string `json:"dataTypeLong"`    // 数据库字段长度
 	Comment         string `json:"comment"`         // 数据库字段描述
 	ColumnName      string `json:"columnName"`      // 数据库字段
+	RenameFrom      string `json:"renameFrom"`      // 重新生成时原数据库字段, 修改列名时填写以生成重命名而非删除后新增
 	FieldSearchType string `json:"fieldSearchType"` // 搜索条件
 	FieldSearchHide bool   `json:"fieldSearchHide"` // 是否隐藏查询条件
 	DictType        string `json:"dictType"`        // 字典
@@ -248,6 +280,57 @@ type AutoCodeField struct {
 	FieldIndexType  string      `json:"fieldIndexType"`  // 索引类型
 }
 
+const (
+	RelationBelongsTo = "belongsTo"
+	RelationHasMany   = "hasMany"
+	RelationMany2Many = "many2many"
+)
+
+// AutoCodeRelation 生成模型的 GORM 关联字段
+type AutoCodeRelation struct {
+	Type         string `json:"type"`         // 关联类型 belongsTo 属于|hasMany 一对多|many2many 多对多
+	FieldName    string `json:"fieldName"`    // 关联字段名, 如 Author、Tags
+	FieldDesc    string `json:"fieldDesc"`    // 中文名
+	FieldJson    string `json:"fieldJson"`    // json名, 默认为首字母小写的字段名
+	Package      string `json:"package"`      // 关联模型所在包, 为空时与当前包相同
+	StructName   string `json:"structName"`   // 关联模型结构体名
+	RelatedTable string `json:"relatedTable"` // 关联模型表名, 按关联字段搜索时必填
+	ForeignKey   string `json:"foreignKey"`   // 外键字段名: belongsTo 为当前结构体的字段, hasMany 为关联结构体的字段, many2many 为当前结构体被中间表引用的字段, 默认主键
+	References   string `json:"references"`   // 引用字段名, 默认 ID
+	JoinTable    string `json:"joinTable"`    // 多对多中间表名
+	Preload      bool   `json:"preload"`      // 查询列表和详情时是否预加载
+	SearchColumn string `json:"searchColumn"` // 按关联表该列模糊搜索, 为空时不生成搜索条件
+}
+
+// Pretreatment 校验关联关系并补全默认值
+func (r *AutoCodeRelation) Pretreatment() error {
+	if r.FieldName == "" || r.StructName == "" {
+		return errors.New("关联关系缺少字段名或关联结构体!")
+	}
+	switch r.Type {
+	case RelationBelongsTo, RelationHasMany:
+		if r.ForeignKey == "" {
+			return errors.Errorf("关联字段[%s]缺少外键!", r.FieldName)
+		}
+	case RelationMany2Many:
+		if r.JoinTable == "" {
+			return errors.Errorf("关联字段[%s]缺少中间表名!", r.FieldName)
+		}
+	default:
+		return errors.Errorf("关联字段[%s]的关联类型[%s]不支持!", r.FieldName, r.Type)
+	}
+	if r.SearchColumn != "" && r.RelatedTable == "" {
+		return errors.Errorf("关联字段[%s]按关联表搜索时需填写关联表名!", r.FieldName)
+	}
+	if r.References == "" {
+		r.References = "ID"
+	}
+	if r.FieldJson == "" {
+		r.FieldJson = strings.ToLower(r.FieldName[:1]) + r.FieldName[1:]
+	}
+	return nil
+}
+
 type AutoFunc struct {
 	Package         string `json:"package"`
 	FuncName        string `json:"funcName"`        // 方法名称
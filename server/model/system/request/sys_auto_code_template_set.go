@@ -0,0 +1,34 @@
+package request
+
+import (
+	model "server/model/system"
+)
+
+// SysAutoCodeTemplateSetCreate 注册模板集
+// source 为 dir 时从 dir 下的 manifest.json 读取清单, 为 db 时使用 manifest 和 files
+type SysAutoCodeTemplateSetCreate struct {
+	Name     string                         `json:"name" example:"模板集名称"`
+	Desc     string                         `json:"desc" example:"描述"`
+	Template string                         `json:"template" example:"适用的包模版 package|plugin"`
+	Source   string                         `json:"source" example:"来源 dir|db"`
+	Dir      string                         `json:"dir" example:"模板目录, autocode.root/autocode.server 下的相对路径"`
+	Manifest model.AutoCodeTemplateManifest `json:"manifest"`
+	Files    map[string]string              `json:"files"` // 模板相对路径 => 模板内容
+}
+
+func (r *SysAutoCodeTemplateSetCreate) Create() model.SysAutoCodeTemplateSet {
+	return model.SysAutoCodeTemplateSet{
+		Name:     r.Name,
+		Desc:     r.Desc,
+		Template: r.Template,
+		Source:   r.Source,
+		Dir:      r.Dir,
+		Manifest: r.Manifest,
+		Files:    r.Files,
+	}
+}
+
+// AutoCodeTemplateSetValidate 使用示例结构体校验模板集
+type AutoCodeTemplateSetValidate struct {
+	Name string `json:"name" example:"模板集名称"`
+}
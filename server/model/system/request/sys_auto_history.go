@@ -14,6 +14,7 @@ type SysAutoHistoryCreate struct {
 	Description      string            // Struct中文名称
 	Injections       map[string]string // 注入路径
 	Templates        map[string]string // 模板信息
+	Generated        map[string]string // 生成时的原始代码
 	ApiIDs           []uint            // api表注册内容
 	MenuID           uint              // 菜单ID
 	ExportTemplateID uint              // 导出模板ID
@@ -30,6 +31,7 @@ func (r *SysAutoHistoryCreate) Create() model.SysAutoCodeHistory {
 		Description:      r.Description,
 		Injections:       r.Injections,
 		Templates:        r.Templates,
+		Generated:        r.Generated,
 		ApiIDs:           r.ApiIDs,
 		MenuID:           r.MenuID,
 		ExportTemplateID: r.ExportTemplateID,
@@ -45,6 +47,7 @@ type SysAutoHistoryRollBack struct {
 	DeleteApi   bool `json:"deleteApi" form:"deleteApi"`     // 是否删除接口
 	DeleteMenu  bool `json:"deleteMenu" form:"deleteMenu"`   // 是否删除菜单
 	DeleteTable bool `json:"deleteTable" form:"deleteTable"` // 是否删除表
+	Preview     bool `json:"preview" form:"preview"`         // 是否只预览注入代码的回滚, 不做任何修改
 }
 
 func (r *SysAutoHistoryRollBack) ApiIds(entity model.SysAutoCodeHistory) common.IdsReq {
@@ -55,3 +58,14 @@ func (r *SysAutoHistoryRollBack) ApiIds(entity model.SysAutoCodeHistory) common.
 	}
 	return common.IdsReq{Ids: ids}
 }
+
+// SysAutoCodeMigrationSearch 分页查询代码生成器迁移
+type SysAutoCodeMigrationSearch struct {
+	common.PageInfo
+	HistoryID uint `json:"historyId" form:"historyId"` // 生成记录ID, 为0时查询全部
+}
+
+// SysAutoCodeMigrationVersion 执行或回滚指定版本的迁移
+type SysAutoCodeMigrationVersion struct {
+	Version string `json:"version" form:"version" binding:"required"` // 版本号
+}
@@ -0,0 +1,13 @@
+package request
+
+import (
+	"server/model/common/request"
+)
+
+// SysChangeHistorySearch 查询某条记录的变更历史
+type SysChangeHistorySearch struct {
+	Table    string `json:"table" form:"table" binding:"required"` // 表名
+	RecordID string `json:"recordId" form:"recordId"`              // 记录主键, 为空时返回整张表的变更
+	Action   string `json:"action" form:"action"`                  // 操作类型 create|update|delete
+	request.PageInfo
+}
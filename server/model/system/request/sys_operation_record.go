@@ -1,6 +1,8 @@
 package request
 
 import (
+	"time"
+
 	"server/model/common/request"
 	"server/model/system"
 )
@@ -9,3 +11,44 @@ type SysOperationRecordSearch struct {
 	system.SysOperationRecord
 	request.PageInfo
 }
+
+// SysOperationRecordFilter 操作记录统计与导出的筛选条件
+type SysOperationRecordFilter struct {
+	StartCreatedAt *time.Time `json:"startCreatedAt" form:"startCreatedAt"` // 开始时间
+	EndCreatedAt   *time.Time `json:"endCreatedAt" form:"endCreatedAt"`     // 结束时间
+	Method         string     `json:"method" form:"method"`                 // 请求方法
+	Path           string     `json:"path" form:"path"`                     // 请求路径, 模糊匹配
+	Status         int        `json:"status" form:"status"`                 // 请求状态
+	UserID         int        `json:"user_id" form:"user_id"`               // 用户id
+}
+
+// SysOperationRecordQuery 按筛选条件分页查询操作记录
+type SysOperationRecordQuery struct {
+	SysOperationRecordFilter
+	request.PageInfo
+}
+
+// SysOperationRecordStats 按时间粒度统计请求数
+type SysOperationRecordStats struct {
+	SysOperationRecordFilter
+	Bucket  string `json:"bucket" form:"bucket"`   // 时间粒度: hour|day(默认)
+	GroupBy string `json:"groupBy" form:"groupBy"` // 分组维度: user|path|status, 为空时仅按时间统计
+}
+
+// SysOperationRecordTop 排行统计
+type SysOperationRecordTop struct {
+	SysOperationRecordFilter
+	Limit int `json:"limit" form:"limit"` // 返回条数, 默认10, 最大100
+}
+
+// SysOperationRecordExport 导出操作记录
+type SysOperationRecordExport struct {
+	SysOperationRecordFilter
+	Format string `json:"format" form:"format"` // 导出格式: csv(默认)|xlsx, xlsx 最多导出 100000 条
+}
+
+// SysAuditLogVerify 校验审计日志的序号范围, 为0表示不限制
+type SysAuditLogVerify struct {
+	StartSeq uint64 `json:"startSeq" form:"startSeq"` // 起始序号
+	EndSeq   uint64 `json:"endSeq" form:"endSeq"`     // 结束序号
+}
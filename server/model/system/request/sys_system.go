@@ -0,0 +1,7 @@
+package request
+
+// SysLogLevel 设置日志级别
+type SysLogLevel struct {
+	Name  string `json:"name"`  // 日志名称(logger.Named), 如 gorm、mcp、operation_record, 为空时设置全局级别
+	Level string `json:"level"` // 级别: debug|info|warn|error|dpanic|panic|fatal; 指定名称且为空时移除该名称的覆盖
+}
@@ -2,6 +2,7 @@ package response
 
 import (
 	"server/model/system"
+	"server/utils/openapi"
 )
 
 type SysAPIResponse struct {
@@ -16,3 +17,15 @@ type SysSyncApis struct {
 	NewApis    []system.SysApi `json:"newApis"`
 	DeleteApis []system.SysApi `json:"deleteApis"`
 }
+
+// SysOpenApiCheck 路由一致性检查结果
+type SysOpenApiCheck struct {
+	MissingApis     []system.SysApi    `json:"missingApis"`     // 已注册但不在 sys_apis 中的路由
+	MissingPolicies []system.SysApi    `json:"missingPolicies"` // 没有任何角色拥有 casbin 策略的接口
+	Mismatches      []openapi.Mismatch `json:"mismatches"`      // 与 swagger 注释不一致的接口
+}
+
+// Passed 是否没有发现问题
+func (c SysOpenApiCheck) Passed() bool {
+	return len(c.MissingApis) == 0 && len(c.MissingPolicies) == 0 && len(c.Mismatches) == 0
+}
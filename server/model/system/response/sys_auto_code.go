@@ -1,5 +1,10 @@
 package response
 
+import (
+	"server/model/system"
+	"server/utils/ast"
+)
+
 type Db struct {
 	Database string `json:"database" gorm:"column:database"`
 }
@@ -15,3 +20,30 @@ type Column struct {
 	ColumnComment string `json:"columnComment" gorm:"column:column_comment"`
 	PrimaryKey    bool   `json:"primaryKey" gorm:"column:primary_key"`
 }
+
+// AutoCodeMergeFile 重新生成时单个文件的合并结果
+type AutoCodeMergeFile struct {
+	Path      string `json:"path"`      // 相对 autocode.root 的路径
+	Status    string `json:"status"`    // created 新建|unchanged 无变化|merged 合并成功|conflict 存在冲突
+	Conflicts int    `json:"conflicts"` // 冲突块数量
+	Content   string `json:"content"`   // 合并后的内容
+}
+
+// AutoCodeRegenerate 重新生成结果
+type AutoCodeRegenerate struct {
+	Files     []AutoCodeMergeFile          `json:"files"`     // 各文件合并结果
+	Migration *system.SysAutoCodeMigration `json:"migration"` // 字段变更生成的迁移, 无字段变更时为空
+}
+
+// AutoCodeTemplateCheck 模板集中单个模板的校验结果
+type AutoCodeTemplateCheck struct {
+	Template string `json:"template"` // 模板相对模板集的路径
+	Output   string `json:"output"`   // 以示例结构体渲染出的输出路径
+	Error    string `json:"error"`    // 解析、渲染或格式化失败的原因, 为空表示通过
+}
+
+// AutoCodeInjection 注入预览, 只计算不写入
+type AutoCodeInjection struct {
+	Changes   []ast.Change   `json:"changes"`   // 各被注入文件的 unified diff
+	Conflicts []ast.Conflict `json:"conflicts"` // 预检冲突, 文件缺失或无法解析时不能执行
+}
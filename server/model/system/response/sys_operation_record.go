@@ -0,0 +1,48 @@
+package response
+
+import "time"
+
+// OperationRecordBucketStat 时间段内的请求数
+type OperationRecordBucketStat struct {
+	Bucket string `json:"bucket"` // 时间段
+	Key    string `json:"key"`    // 分组值
+	Label  string `json:"label"`  // 分组展示名, 按用户分组时为用户名
+	Count  int64  `json:"count"`  // 请求数
+}
+
+// OperationRecordErrorPath 错误请求最多的路径
+type OperationRecordErrorPath struct {
+	Path     string    `json:"path"`     // 请求路径
+	Method   string    `json:"method"`   // 请求方法
+	Count    int64     `json:"count"`    // 错误次数
+	LastTime time.Time `json:"lastTime"` // 最近一次出错时间
+}
+
+// OperationRecordLatency 路径耗时分布, 单位毫秒
+type OperationRecordLatency struct {
+	Path  string  `json:"path"`  // 请求路径
+	Count int64   `json:"count"` // 请求数
+	Avg   float64 `json:"avg"`   // 平均耗时
+	P50   float64 `json:"p50"`   // 50分位耗时
+	P90   float64 `json:"p90"`   // 90分位耗时
+	P99   float64 `json:"p99"`   // 99分位耗时
+	Max   float64 `json:"max"`   // 最大耗时
+}
+
+// AuditIssue 审计日志校验发现的问题
+type AuditIssue struct {
+	Seq     uint64 `json:"seq"`     // 序号
+	Type    string `json:"type"`    // 问题类型: gap|prev_mismatch|hash_mismatch|record_modified|checkpoint_invalid|checkpoint_mismatch|checkpoint_gap
+	Message string `json:"message"` // 描述
+}
+
+// AuditVerifyResult 审计日志校验结果
+type AuditVerifyResult struct {
+	Valid       bool         `json:"valid"`       // 是否通过校验
+	Checked     int64        `json:"checked"`     // 校验条数
+	FirstSeq    uint64       `json:"firstSeq"`    // 起始序号
+	LastSeq     uint64       `json:"lastSeq"`     // 结束序号
+	Checkpoints int64        `json:"checkpoints"` // 校验的检查点数
+	IssueCount  int64        `json:"issueCount"`  // 问题总数
+	Issues      []AuditIssue `json:"issues"`      // 问题列表, 最多返回100条
+}
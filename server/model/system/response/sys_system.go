@@ -1,7 +1,16 @@
 package response
 
-import "server/config"
+import (
+	"server/config"
+	"server/utils/logging"
+)
 
 type SysConfigResponse struct {
 	Config config.Server `json:"config"`
 }
+
+// SysLogLevelResponse 当前日志级别与日志投递目标状态
+type SysLogLevelResponse struct {
+	logging.LevelStatus
+	Sinks []logging.SinkStats `json:"sinks"`
+}
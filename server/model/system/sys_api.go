@@ -4,6 +4,9 @@ import (
 	"server/global"
 )
 
+// McpToolApiPrefix MCP 工具在 sys_apis 与 casbin 策略中的路径前缀, 如 /mcp/tools/create_api, 方法固定为 POST
+const McpToolApiPrefix = "/mcp/tools/"
+
 type SysApi struct {
 	global.GVA_MODEL
 	Path        string `json:"path" gorm:"comment:api路径"`             // api路径
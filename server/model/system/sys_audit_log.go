@@ -0,0 +1,36 @@
+package system
+
+import (
+	"time"
+)
+
+// SysAuditLog 只追加的审计日志, 每条记录保存上一条的哈希形成哈希链
+type SysAuditLog struct {
+	ID        uint      `gorm:"primarykey" json:"ID"`                                   // 主键ID
+	CreatedAt time.Time `json:"CreatedAt"`                                              // 创建时间
+	Seq       uint64    `json:"seq" gorm:"column:seq;uniqueIndex;comment:序号"`           // 序号, 从1开始连续递增
+	RecordID  uint      `json:"recordId" gorm:"column:record_id;index;comment:操作记录id"`  // 操作记录id
+	Payload   string    `json:"payload" gorm:"column:payload;type:text;comment:审计内容"`   // 审计内容, 规范化JSON
+	PrevHash  string    `json:"prevHash" gorm:"column:prev_hash;size:64;comment:上一条哈希"` // 上一条哈希
+	Hash      string    `json:"hash" gorm:"column:hash;size:64;comment:本条哈希"`           // 本条哈希
+}
+
+func (SysAuditLog) TableName() string {
+	return "sys_audit_logs"
+}
+
+// SysAuditCheckpoint 审计日志签名检查点, 记录某一时刻哈希链末端并用密钥签名
+// 检查点按编号连续递增, 签名包含上一个检查点的签名, 删除任一检查点都会被发现
+type SysAuditCheckpoint struct {
+	ID            uint      `gorm:"primarykey" json:"ID"`                                                // 主键ID
+	CreatedAt     time.Time `json:"CreatedAt"`                                                           // 创建时间
+	Number        uint64    `json:"number" gorm:"column:number;uniqueIndex;comment:检查点编号"`               // 检查点编号, 从1开始连续递增
+	Seq           uint64    `json:"seq" gorm:"column:seq;index;comment:末端序号"`                            // 末端序号
+	Hash          string    `json:"hash" gorm:"column:hash;size:64;comment:末端哈希"`                        // 末端哈希
+	PrevSignature string    `json:"prevSignature" gorm:"column:prev_signature;size:64;comment:上一个检查点签名"` // 上一个检查点签名
+	Signature     string    `json:"signature" gorm:"column:signature;size:64;comment:签名"`                // HMAC-SHA256签名
+}
+
+func (SysAuditCheckpoint) TableName() string {
+	return "sys_audit_checkpoints"
+}
@@ -21,6 +21,7 @@ type SysAutoCodeHistory struct {
 	Description      string             `json:"description" gorm:"column:description;comment:Struct中文名称"`
 	Templates        map[string]string  `json:"template" gorm:"serializer:json;type:text;column:templates;comment:模板信息"`
 	Injections       map[string]string  `json:"injections" gorm:"serializer:json;type:text;column:Injections;comment:注入路径"`
+	Generated        map[string]string  `json:"-" gorm:"serializer:json;type:text;column:generated;comment:生成时的原始代码"`
 	Flag             int                `json:"flag" gorm:"column:flag;comment:[0:创建,1:回滚]"`
 	ApiIDs           []uint             `json:"apiIDs" gorm:"serializer:json;column:api_ids;comment:api表注册内容"`
 	MenuID           uint               `json:"menuId" gorm:"column:menu_id;comment:菜单ID"`
@@ -30,9 +31,15 @@ type SysAutoCodeHistory struct {
 }
 
 func (s *SysAutoCodeHistory) BeforeCreate(db *gorm.DB) error {
-	templates := make(map[string]string, len(s.Templates))
-	for key, value := range s.Templates {
-		server := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server)
+	s.Templates = RelativeTemplates(s.Templates)
+	return nil
+}
+
+// RelativeTemplates 将 模板 => 生成路径 转换为相对 server/web 目录的路径保存
+func RelativeTemplates(absolute map[string]string) map[string]string {
+	templates := make(map[string]string, len(absolute))
+	for key, value := range absolute {
+		server := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
 		{
 			hasServer := strings.Index(key, server)
 			if hasServer != -1 {
@@ -41,7 +48,7 @@ func (s *SysAutoCodeHistory) BeforeCreate(db *gorm.DB) error {
 				key = path.Join(keys...)
 			}
 		} // key
-		web := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.WebRoot())
+		web := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.WebRoot())
 		hasWeb := strings.Index(value, web)
 		if hasWeb != -1 {
 			value = strings.TrimPrefix(value, web)
@@ -59,8 +66,7 @@ func (s *SysAutoCodeHistory) BeforeCreate(db *gorm.DB) error {
 			continue
 		}
 	}
-	s.Templates = templates
-	return nil
+	return templates
 }
 
 func (s *SysAutoCodeHistory) TableName() string {
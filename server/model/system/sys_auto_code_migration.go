@@ -0,0 +1,35 @@
+package system
+
+import (
+	"time"
+
+	"server/global"
+)
+
+// SysAutoCodeMigration 代码生成器修改字段时生成的版本化迁移, 同时保存所有方言的SQL
+type SysAutoCodeMigration struct {
+	global.GVA_MODEL
+	Version    string            `json:"version" gorm:"column:version;size:64;uniqueIndex;comment:版本号"`
+	HistoryID  uint              `json:"historyId" gorm:"column:history_id;index;comment:生成记录ID"`
+	Table      string            `json:"tableName" gorm:"column:table_name;comment:表名"`
+	BusinessDB string            `json:"businessDb" gorm:"column:business_db;comment:业务库"`
+	Changes    string            `json:"changes" gorm:"type:text;column:changes;comment:字段变更"`
+	Up         map[string]string `json:"up" gorm:"serializer:json;type:text;column:up_sql;comment:升级SQL"`
+	Down       map[string]string `json:"down" gorm:"serializer:json;type:text;column:down_sql;comment:回滚SQL"`
+	Applied    bool              `json:"applied" gorm:"-"`   // 是否已在业务库执行
+	AppliedAt  *time.Time        `json:"appliedAt" gorm:"-"` // 执行时间
+}
+
+func (SysAutoCodeMigration) TableName() string {
+	return "sys_auto_code_migrations"
+}
+
+// SysSchemaMigration 已执行的迁移版本, 存放在迁移所作用的业务库中
+type SysSchemaMigration struct {
+	Version   string    `json:"version" gorm:"primarykey;size:64;column:version"`
+	AppliedAt time.Time `json:"appliedAt" gorm:"column:applied_at"`
+}
+
+func (SysSchemaMigration) TableName() string {
+	return "schema_migrations"
+}
@@ -0,0 +1,39 @@
+package system
+
+import (
+	"server/global"
+)
+
+const (
+	TemplateSetSourceDir = "dir" // 模板集存放在目录中, 目录下需有 manifest.json
+	TemplateSetSourceDB  = "db"  // 模板集及清单存放在数据库中
+)
+
+// SysAutoCodeTemplateSet 自定义代码生成模板集
+type SysAutoCodeTemplateSet struct {
+	global.GVA_MODEL
+	Name     string                   `json:"name" gorm:"uniqueIndex;size:64;comment:模板集名称"`
+	Desc     string                   `json:"desc" gorm:"comment:描述"`
+	Template string                   `json:"template" gorm:"comment:适用的包模版 package|plugin"`
+	Source   string                   `json:"source" gorm:"comment:来源 dir|db"`
+	Dir      string                   `json:"dir" gorm:"comment:模板目录"`
+	Manifest AutoCodeTemplateManifest `json:"manifest" gorm:"type:text;serializer:json;comment:清单"`
+	Files    map[string]string        `json:"files,omitempty" gorm:"type:text;serializer:json;comment:模板内容"`
+}
+
+func (s *SysAutoCodeTemplateSet) TableName() string {
+	return "sys_auto_code_template_sets"
+}
+
+// AutoCodeTemplateManifest 模板集清单
+type AutoCodeTemplateManifest struct {
+	Files      []AutoCodeTemplateFile `json:"files"`      // 模板文件及输出路径
+	Injections []string               `json:"injections"` // 需要执行的AST注入类型, 如 PackageApiModuleEnter、PackageInitializeRouter
+}
+
+// AutoCodeTemplateFile 模板文件与输出路径的对应关系
+type AutoCodeTemplateFile struct {
+	Template string `json:"template"` // 模板文件相对模板集的路径
+	Output   string `json:"output"`   // 输出路径, 相对 autocode.root, 支持模板语法, 如 server/repository/{{.Package}}/{{.HumpPackageName}}.go
+	Type     string `json:"type"`     // server|web, 对应 generateServer 和 generateWeb 开关, 默认为 server
+}
@@ -0,0 +1,23 @@
+package system
+
+import (
+	"time"
+)
+
+// SysChangeHistory 数据变更历史, 每条记录对应一行数据的一次新增/修改/删除
+type SysChangeHistory struct {
+	ID           uint      `gorm:"primarykey" json:"ID"`                                                          // 主键ID
+	CreatedAt    time.Time `json:"CreatedAt"`                                                                     // 创建时间
+	Table        string    `json:"table" gorm:"column:table_name;size:64;index:idx_change_record;comment:表名"`     // 表名
+	RecordID     string    `json:"recordId" gorm:"column:record_id;size:64;index:idx_change_record;comment:记录主键"` // 记录主键
+	Action       string    `json:"action" gorm:"column:action;size:16;comment:操作类型"`                              // 操作类型 create|update|delete
+	OperatorID   uint      `json:"operatorId" gorm:"column:operator_id;comment:操作人id"`                            // 操作人id, 0表示系统
+	OperatorName string    `json:"operatorName" gorm:"column:operator_name;size:64;comment:操作人"`                  // 操作人用户名
+	Before       string    `json:"before" gorm:"column:before_data;type:text;comment:变更前"`                        // 变更前数据, JSON
+	After        string    `json:"after" gorm:"column:after_data;type:text;comment:变更后"`                          // 变更后数据, JSON
+	Diff         string    `json:"diff" gorm:"column:diff;type:text;comment:字段差异"`                                // 字段差异, JSON {"字段":{"before":..,"after":..}}
+}
+
+func (SysChangeHistory) TableName() string {
+	return "sys_change_histories"
+}
@@ -1,10 +1,10 @@
 package api
 
 import (
-	"server/global"
 	"server/model/common/response"
 	email_response "server/plugin/email/model/response"
 	"server/plugin/email/service"
+	"server/utils/ctxlog"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -19,9 +19,9 @@ type EmailApi struct{}
 // @Success   200  {string}  string  "{"success":true,"data":{},"msg":"发送成功"}"
 // @Router    /email/emailTest [post]
 func (s *EmailApi) EmailTest(c *gin.Context) {
-	err := service.ServiceGroupApp.EmailTest()
+	err := service.ServiceGroupApp.EmailTest(c.Request.Context())
 	if err != nil {
-		global.GVA_LOG.Error("发送失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("发送失败!", zap.Error(err))
 		response.FailWithMessage("发送失败", c)
 		return
 	}
@@ -43,9 +43,9 @@ func (s *EmailApi) SendEmail(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = service.ServiceGroupApp.SendEmail(email.To, email.Subject, email.Body)
+	err = service.ServiceGroupApp.SendEmail(c.Request.Context(), email.To, email.Subject, email.Body)
 	if err != nil {
-		global.GVA_LOG.Error("发送失败!", zap.Error(err))
+		ctxlog.Logger(c.Request.Context()).Error("发送失败!", zap.Error(err))
 		response.FailWithMessage("发送失败", c)
 		return
 	}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"server/plugin/email/utils"
 )
 
@@ -9,12 +11,13 @@ type EmailService struct{}
 //@author: [maplepie](https://github.com/maplepie)
 //@function: EmailTest
 //@description: 发送邮件测试
+//@param: ctx context.Context
 //@return: err error
 
-func (e *EmailService) EmailTest() (err error) {
+func (e *EmailService) EmailTest(ctx context.Context) (err error) {
 	subject := "test"
 	body := "test"
-	err = utils.EmailTest(subject, body)
+	err = utils.EmailTest(ctx, subject, body)
 	return err
 }
 
@@ -26,7 +29,7 @@ func (e *EmailService) EmailTest() (err error) {
 //@params subject string   标题（主题）
 //@params body  string 	 邮件内容
 
-func (e *EmailService) SendEmail(to, subject, body string) (err error) {
-	err = utils.Email(to, subject, body)
+func (e *EmailService) SendEmail(ctx context.Context, to, subject, body string) (err error) {
+	err = utils.Email(ctx, to, subject, body)
 	return err
 }
@@ -1,59 +1,70 @@
 package utils
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/smtp"
 	"strings"
 
 	"server/plugin/email/global"
+	"server/utils/tracing"
 
 	"github.com/jordan-wright/email"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 //@author: [maplepie](https://github.com/maplepie)
 //@function: Email
 //@description: Email发送方法
-//@param: subject string, body string
+//@param: ctx context.Context, To string, subject string, body string
 //@return: error
 
-func Email(To, subject string, body string) error {
+func Email(ctx context.Context, To, subject string, body string) error {
 	to := strings.Split(To, ",")
-	return send(to, subject, body)
+	return send(ctx, to, subject, body)
 }
 
 //@author: [SliverHorn](https://github.com/SliverHorn)
 //@function: ErrorToEmail
 //@description: 给email中间件错误发送邮件到指定邮箱
-//@param: subject string, body string
+//@param: ctx context.Context, subject string, body string
 //@return: error
 
-func ErrorToEmail(subject string, body string) error {
+func ErrorToEmail(ctx context.Context, subject string, body string) error {
 	to := strings.Split(global.GlobalConfig.To, ",")
 	if to[len(to)-1] == "" { // 判断切片的最后一个元素是否为空,为空则移除
 		to = to[:len(to)-1]
 	}
-	return send(to, subject, body)
+	return send(ctx, to, subject, body)
 }
 
 //@author: [maplepie](https://github.com/maplepie)
 //@function: EmailTest
 //@description: Email测试方法
-//@param: subject string, body string
+//@param: ctx context.Context, subject string, body string
 //@return: error
 
-func EmailTest(subject string, body string) error {
+func EmailTest(ctx context.Context, subject string, body string) error {
 	to := []string{global.GlobalConfig.To}
-	return send(to, subject, body)
+	return send(ctx, to, subject, body)
 }
 
 //@author: [maplepie](https://github.com/maplepie)
 //@function: send
-//@description: Email发送方法
-//@param: subject string, body string
+//@description: Email发送方法, 发送过程记录为 ctx 中链路的子 span
+//@param: ctx context.Context, to []string, subject string, body string
 //@return: error
 
-func send(to []string, subject string, body string) error {
+func send(ctx context.Context, to []string, subject string, body string) (err error) {
+	_, span := tracing.Start(ctx, "email.send",
+		attribute.String("smtp.host", global.GlobalConfig.Host),
+		attribute.Int("email.recipients", len(to)),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	from := global.GlobalConfig.From
 	nickname := global.GlobalConfig.Nickname
 	secret := global.GlobalConfig.Secret
@@ -77,7 +88,6 @@ func send(to []string, subject string, body string) error {
 	e.To = to
 	e.Subject = subject
 	e.HTML = []byte(body)
-	var err error
 	hostAddr := fmt.Sprintf("%s:%d", host, port)
 	if isSSL {
 		err = e.SendWithTLS(hostAddr, auth, &tls.Config{ServerName: host})
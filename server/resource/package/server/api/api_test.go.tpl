@@ -0,0 +1,241 @@
+{{- $ptr := printf "%sTestPtr" .Abbreviation }}
+{{- $enum := false }}
+{{- range .Fields }}
+ {{- if eq .FieldType "enum" }}
+  {{- $enum = true }}
+ {{- end }}
+{{- end }}
+{{- if .IsAdd}}
+// {{.Abbreviation}}TestModel 新增如下字段的测试值
+{{- range .Fields}}
+	{{$.Abbreviation}}.{{.FieldName}} = {{ GenerateTestValue . false $ptr }}
+{{- end }}
+{{- else}}
+package {{.Package}}
+{{- if not .OnlyTemplate }}
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	{{- if $enum }}
+	"strings"
+	{{- end }}
+	"testing"
+	{{- if .HasTimer }}
+	"time"
+	{{- end }}
+
+	"{{.Module}}/global"
+	"{{.Module}}/model/common/response"
+	"{{.Module}}/model/{{.Package}}"
+	systemReq "{{.Module}}/model/system/request"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	{{- if .NeedJSON }}
+	"gorm.io/datatypes"
+	{{- end }}
+	"gorm.io/gorm"
+	{{- if $enum }}
+	"gorm.io/gorm/schema"
+	{{- end }}
+	"go.uber.org/zap"
+)
+
+func {{$ptr}}[T any](v T) *T { return &v }
+
+// {{.Abbreviation}}TestDB 使用内存 SQLite 替换{{.Description}}所在的数据库, 测试结束后恢复
+func {{.Abbreviation}}TestDB(t *testing.T) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	{{- if $enum }}
+	// SQLite 不支持 enum 类型, 迁移前将枚举字段替换为字符串
+	stmt := &gorm.Statement{DB: db}
+	if err = stmt.Parse(&{{.Package}}.{{.StructName}}{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range stmt.Schema.Fields {
+		if strings.HasPrefix(string(field.DataType), "enum") {
+			field.DataType = schema.String
+		}
+	}
+	{{- end }}
+	if err = db.AutoMigrate(&{{.Package}}.{{.StructName}}{}); err != nil {
+		t.Fatal(err)
+	}
+	{{- if eq .BusinessDB "" }}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+	{{- else }}
+	if global.GVA_DBList == nil {
+		global.GVA_DBList = make(map[string]*gorm.DB)
+	}
+	old := global.GVA_DBList["{{.BusinessDB}}"]
+	global.GVA_DBList["{{.BusinessDB}}"] = db
+	t.Cleanup(func() { global.GVA_DBList["{{.BusinessDB}}"] = old })
+	{{- end }}
+	if global.GVA_LOG == nil {
+		global.GVA_LOG = zap.NewNop()
+	}
+}
+
+// {{.Abbreviation}}TestRouter 注册{{.Description}}接口, 以固定的 claims 代替 JWT 与 casbin 鉴权
+func {{.Abbreviation}}TestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("claims", &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1, Username: "tester", AuthorityId: 888}})
+		c.Next()
+	})
+	api := new({{.StructName}}Api)
+	group := router.Group("{{.Abbreviation}}")
+	group.POST("create{{.StructName}}", api.Create{{.StructName}})
+	group.DELETE("delete{{.StructName}}", api.Delete{{.StructName}})
+	group.DELETE("delete{{.StructName}}ByIds", api.Delete{{.StructName}}ByIds)
+	group.PUT("update{{.StructName}}", api.Update{{.StructName}})
+	group.GET("find{{.StructName}}", api.Find{{.StructName}})
+	group.GET("get{{.StructName}}List", api.Get{{.StructName}}List)
+	return router
+}
+
+// {{.Abbreviation}}TestRequest 发起请求并解析统一响应, data 不为空时将响应数据解析到 data
+func {{.Abbreviation}}TestRequest(t *testing.T, router *gin.Engine, method string, path string, body any, data any) response.Response {
+	t.Helper()
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s %s status = %d", method, path, w.Code)
+	}
+	var resp response.Response
+	if data != nil {
+		resp.Data = data
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%s %s decode error = %v", method, path, err)
+	}
+	return resp
+}
+
+// {{.Abbreviation}}TestModel 构造测试用的{{.Description}}, updated 为 true 时返回更新用的字段值
+func {{.Abbreviation}}TestModel(updated bool) {{.Package}}.{{.StructName}} {
+	var {{.Abbreviation}} {{.Package}}.{{.StructName}}
+	if updated {
+	{{- range .Fields}}
+		{{- if not .PrimaryKey }}
+		{{$.Abbreviation}}.{{.FieldName}} = {{ GenerateTestValue . true $ptr }}
+		{{- end }}
+	{{- end }}
+		return {{.Abbreviation}}
+	}
+	{{- range .Fields}}
+	{{- if or (not .PrimaryKey) (eq .FieldType "string") }}
+	{{$.Abbreviation}}.{{.FieldName}} = {{ GenerateTestValue . false $ptr }}
+	{{- end }}
+	{{- end }}
+	return {{.Abbreviation}}
+}
+
+func Test{{.StructName}}Api(t *testing.T) {
+	{{.Abbreviation}}TestDB(t)
+	router := {{.Abbreviation}}TestRouter()
+
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodPost, "/{{.Abbreviation}}/create{{.StructName}}", {{.Abbreviation}}TestModel(false), nil); resp.Code != response.SUCCESS {
+		t.Fatalf("create{{.StructName}} = %+v", resp)
+	}
+
+	{{- if .IsTree }}
+	var list []*{{.Package}}.{{.StructName}}
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/get{{.StructName}}List", nil, &list); resp.Code != response.SUCCESS || len(list) != 1 {
+		t.Fatalf("get{{.StructName}}List = %+v", resp)
+	}
+	created := *list[0]
+	{{- else }}
+	var page struct {
+		List  []{{.Package}}.{{.StructName}} `json:"list"`
+		Total int64 `json:"total"`
+	}
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/get{{.StructName}}List?page=1&pageSize=10", nil, &page); resp.Code != response.SUCCESS || page.Total != 1 || len(page.List) != 1 {
+		t.Fatalf("get{{.StructName}}List = %+v", resp)
+	}
+	created := page.List[0]
+	{{- end }}
+	id := url.QueryEscape({{ GenerateTestPrimaryKey . "created" }})
+
+	var found {{.Package}}.{{.StructName}}
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/find{{.StructName}}?{{.PrimaryField.FieldJson}}="+id, nil, &found); resp.Code != response.SUCCESS {
+		t.Fatalf("find{{.StructName}} = %+v", resp)
+	}
+
+	updated := {{.Abbreviation}}TestModel(true)
+	updated.{{.PrimaryField.FieldName}} = created.{{.PrimaryField.FieldName}}
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodPut, "/{{.Abbreviation}}/update{{.StructName}}", updated, nil); resp.Code != response.SUCCESS {
+		t.Fatalf("update{{.StructName}} = %+v", resp)
+	}
+	var got {{.Package}}.{{.StructName}}
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/find{{.StructName}}?{{.PrimaryField.FieldJson}}="+id, nil, &got); resp.Code != response.SUCCESS {
+		t.Fatalf("find{{.StructName}} = %+v", resp)
+	}
+	{{- range .Fields}}
+	{{- if not .PrimaryKey }}
+	{{- $assert := GenerateTestAssert . "got" "updated" }}
+	{{- if $assert }}
+	{{$assert}}
+	{{- end }}
+	{{- end }}
+	{{- end }}
+
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodDelete, "/{{.Abbreviation}}/delete{{.StructName}}?{{.PrimaryField.FieldJson}}="+id, nil, nil); resp.Code != response.SUCCESS {
+		t.Fatalf("delete{{.StructName}} = %+v", resp)
+	}
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/find{{.StructName}}?{{.PrimaryField.FieldJson}}="+id, nil, nil); resp.Code == response.SUCCESS {
+		t.Errorf("find{{.StructName}} after delete = %+v", resp)
+	}
+
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodPost, "/{{.Abbreviation}}/create{{.StructName}}", {{.Abbreviation}}TestModel(false), nil); resp.Code != response.SUCCESS {
+		t.Fatalf("create{{.StructName}} = %+v", resp)
+	}
+	{{- if .IsTree }}
+	list = nil
+	{{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/get{{.StructName}}List", nil, &list)
+	another := *list[0]
+	{{- else }}
+	{{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/get{{.StructName}}List?page=1&pageSize=10", nil, &page)
+	another := page.List[0]
+	{{- end }}
+	ids := fmt.Sprintf("{{.PrimaryField.FieldJson}}s[]=%s", url.QueryEscape({{ GenerateTestPrimaryKey . "another" }}))
+	if resp := {{.Abbreviation}}TestRequest(t, router, http.MethodDelete, "/{{.Abbreviation}}/delete{{.StructName}}ByIds?"+ids, nil, nil); resp.Code != response.SUCCESS {
+		t.Fatalf("delete{{.StructName}}ByIds = %+v", resp)
+	}
+	{{- if .IsTree }}
+	list = nil
+	{{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/get{{.StructName}}List", nil, &list)
+	if len(list) != 0 {
+		t.Errorf("get{{.StructName}}List after batch delete = %d", len(list))
+	}
+	{{- else }}
+	page.List, page.Total = nil, 0
+	{{.Abbreviation}}TestRequest(t, router, http.MethodGet, "/{{.Abbreviation}}/get{{.StructName}}List?page=1&pageSize=10", nil, &page)
+	if page.Total != 0 {
+		t.Errorf("get{{.StructName}}List after batch delete total = %d", page.Total)
+	}
+	{{- end }}
+}
+{{- end }}
+{{- end }}
@@ -3,6 +3,9 @@
 {{- range .Fields}}
   {{ GenerateField . }}
 {{- end }}
+{{- range .Relations}}
+  {{ GenerateRelationField . $.Package }}
+{{- end }}
 
 {{ else }}
 // 自动生成模板{{.StructName}}
@@ -19,6 +22,9 @@ import (
 	{{- if .NeedJSON }}
 	"gorm.io/datatypes"
 	{{- end }}
+	{{- range .RelationPackages }}
+	"{{$.Module}}/model/{{.}}"
+	{{- end }}
 )
 {{- end }}
 
@@ -30,6 +36,9 @@ type {{.StructName}} struct {
 {{- end }}
 {{- range .Fields}}
   {{ GenerateField . }}
+{{- end }}
+{{- range .Relations}}
+  {{ GenerateRelationField . $.Package }}
 {{- end }}
     {{- if .AutoCreateResource }}
     CreatedBy  uint   `gorm:"column:created_by;comment:创建者"`
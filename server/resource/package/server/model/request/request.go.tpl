@@ -5,6 +5,11 @@
       {{ GenerateSearchField . }}
     {{- end}}
 {{- end }}
+{{- range .Relations}}
+    {{- if .SearchColumn}}
+      {{ GenerateRelationSearchField . }}
+    {{- end}}
+{{- end }}
 {{- if .NeedSort}}
 Sort  string `json:"sort" form:"sort"`
 Order string `json:"order" form:"order"`
@@ -28,6 +33,11 @@ type {{.StructName}}Search struct{
     {{- if ne .FieldSearchType ""}}
       {{ GenerateSearchField . }}
     {{- end}}
+{{- end }}
+{{- range .Relations}}
+    {{- if .SearchColumn}}
+      {{ GenerateRelationSearchField . }}
+    {{- end}}
 {{- end }}
     request.PageInfo
     {{- if .NeedSort}}
@@ -9,6 +9,7 @@
 
 // Get{{.StructName}}InfoList 新增搜索语句
        {{ GenerateSearchConditions .Fields }}
+       {{ GenerateRelationSearchConditions . $db }}
 // Get{{.StructName}}InfoList 新增排序语句 请自行在搜索语句中添加orderMap内容
        {{- range .Fields}}
             {{- if .Sort}}
@@ -45,9 +46,12 @@ import (
     "{{.Module}}/utils"
     "errors"
     {{- end }}
-    {{- if .AutoCreateResource }}
+    {{- if or .AutoCreateResource .HasNestedRelation }}
     "gorm.io/gorm"
     {{- end}}
+    {{- if .HasNestedRelation }}
+    "gorm.io/gorm/clause"
+    {{- end}}
 {{- end }}
 )
 
@@ -113,14 +117,31 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}ById
 // Update{{.StructName}} 更新{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Update{{.StructName}}(ctx context.Context, {{.Abbreviation}} {{.Package}}.{{.StructName}}) (err error) {
+	{{- if .HasNestedRelation }}
+	// 主表更新时忽略关联, 一对多和多对多关联按提交内容整体替换
+	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
+	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Omit(clause.Associations).Updates(&{{.Abbreviation}}).Error; err != nil {
+	        return err
+	    }
+	    {{- range .Relations }}
+	    {{- if ne .Type "belongsTo" }}
+	    if err := tx.Model(&{{$.Abbreviation}}).Association("{{.FieldName}}").Replace({{$.Abbreviation}}.{{.FieldName}}); err != nil {
+	        return err
+	    }
+	    {{- end }}
+	    {{- end }}
+	    return nil
+	})
+	{{- else }}
 	err = {{$db}}.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Updates(&{{.Abbreviation}}).Error
+	{{- end }}
 	return err
 }
 
 // Get{{.StructName}} 根据{{.PrimaryField.FieldJson}}获取{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}(ctx context.Context, {{.PrimaryField.FieldJson}} string) ({{.Abbreviation}} {{.Package}}.{{.StructName}}, err error) {
-	err = {{$db}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
+	err = {{$db}}{{ GenerateRelationPreload .Relations }}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
 	return
 }
 
@@ -133,7 +154,7 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}InfoLis
 	db := {{$db}}.Model(&{{.Package}}.{{.StructName}}{})
     var {{.Abbreviation}}s []*{{.Package}}.{{.StructName}}
 
-	err = db.Find(&{{.Abbreviation}}s).Error
+	err = db{{ GenerateRelationPreload .Relations }}.Find(&{{.Abbreviation}}s).Error
 
 	return utils.BuildTree({{.Abbreviation}}s), err
 }
@@ -153,6 +174,9 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}InfoLis
     }
 {{- end }}
     {{ GenerateSearchConditions .Fields }}
+    {{- if .Relations }}
+    {{ GenerateRelationSearchConditions . $db }}
+    {{- end }}
 	err = db.Count(&total).Error
 	if err!=nil {
     	return
@@ -182,7 +206,7 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}InfoLis
        db = db.Limit(limit).Offset(offset)
     }
 
-	err = db.Find(&{{.Abbreviation}}s).Error
+	err = db{{ GenerateRelationPreload .Relations }}.Find(&{{.Abbreviation}}s).Error
 	return  {{.Abbreviation}}s, total, err
 }
 
@@ -0,0 +1,180 @@
+{{- $ptr := printf "%sTestPtr" .Abbreviation }}
+{{- $enum := false }}
+{{- range .Fields }}
+ {{- if eq .FieldType "enum" }}
+  {{- $enum = true }}
+ {{- end }}
+{{- end }}
+{{- if .IsAdd}}
+// {{.Abbreviation}}TestModel 新增如下字段的测试值
+{{- range .Fields}}
+	{{$.Abbreviation}}.{{.FieldName}} = {{ GenerateTestValue . false $ptr }}
+{{- end }}
+{{- else}}
+package {{.Package}}
+{{- if not .OnlyTemplate }}
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	{{- if $enum }}
+	"strings"
+	{{- end }}
+	"testing"
+	{{- if .HasTimer }}
+	"time"
+	{{- end }}
+
+	"{{.Module}}/global"
+	"{{.Module}}/model/{{.Package}}"
+	{{- if not .IsTree }}
+	{{.Package}}Req "{{.Module}}/model/{{.Package}}/request"
+	{{- end }}
+	"github.com/glebarez/sqlite"
+	{{- if .NeedJSON }}
+	"gorm.io/datatypes"
+	{{- end }}
+	"gorm.io/gorm"
+	{{- if $enum }}
+	"gorm.io/gorm/schema"
+	{{- end }}
+)
+
+func {{$ptr}}[T any](v T) *T { return &v }
+
+// {{.Abbreviation}}TestDB 使用内存 SQLite 替换{{.Description}}所在的数据库, 测试结束后恢复
+func {{.Abbreviation}}TestDB(t *testing.T) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	{{- if $enum }}
+	// SQLite 不支持 enum 类型, 迁移前将枚举字段替换为字符串
+	stmt := &gorm.Statement{DB: db}
+	if err = stmt.Parse(&{{.Package}}.{{.StructName}}{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range stmt.Schema.Fields {
+		if strings.HasPrefix(string(field.DataType), "enum") {
+			field.DataType = schema.String
+		}
+	}
+	{{- end }}
+	if err = db.AutoMigrate(&{{.Package}}.{{.StructName}}{}); err != nil {
+		t.Fatal(err)
+	}
+	{{- if eq .BusinessDB "" }}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+	{{- else }}
+	if global.GVA_DBList == nil {
+		global.GVA_DBList = make(map[string]*gorm.DB)
+	}
+	old := global.GVA_DBList["{{.BusinessDB}}"]
+	global.GVA_DBList["{{.BusinessDB}}"] = db
+	t.Cleanup(func() { global.GVA_DBList["{{.BusinessDB}}"] = old })
+	{{- end }}
+}
+
+// {{.Abbreviation}}TestModel 构造测试用的{{.Description}}, updated 为 true 时返回更新用的字段值
+func {{.Abbreviation}}TestModel(updated bool) {{.Package}}.{{.StructName}} {
+	var {{.Abbreviation}} {{.Package}}.{{.StructName}}
+	if updated {
+	{{- range .Fields}}
+		{{- if not .PrimaryKey }}
+		{{$.Abbreviation}}.{{.FieldName}} = {{ GenerateTestValue . true $ptr }}
+		{{- end }}
+	{{- end }}
+		return {{.Abbreviation}}
+	}
+	{{- range .Fields}}
+	{{- if or (not .PrimaryKey) (eq .FieldType "string") }}
+	{{$.Abbreviation}}.{{.FieldName}} = {{ GenerateTestValue . false $ptr }}
+	{{- end }}
+	{{- end }}
+	return {{.Abbreviation}}
+}
+
+func Test{{.StructName}}Service(t *testing.T) {
+	{{.Abbreviation}}TestDB(t)
+	ctx := context.Background()
+	service := new({{.StructName}}Service)
+
+	created := {{.Abbreviation}}TestModel(false)
+	if err := service.Create{{.StructName}}(ctx, &created); err != nil {
+		t.Fatalf("Create{{.StructName}}() error = %v", err)
+	}
+	id := {{ GenerateTestPrimaryKey . "created" }}
+	if _, err := service.Get{{.StructName}}(ctx, id); err != nil {
+		t.Fatalf("Get{{.StructName}}() error = %v", err)
+	}
+
+	updated := {{.Abbreviation}}TestModel(true)
+	updated.{{.PrimaryField.FieldName}} = created.{{.PrimaryField.FieldName}}
+	if err := service.Update{{.StructName}}(ctx, updated); err != nil {
+		t.Fatalf("Update{{.StructName}}() error = %v", err)
+	}
+	got, err := service.Get{{.StructName}}(ctx, id)
+	if err != nil {
+		t.Fatalf("Get{{.StructName}}() error = %v", err)
+	}
+	{{- range .Fields}}
+	{{- if not .PrimaryKey }}
+	{{- $assert := GenerateTestAssert . "got" "updated" }}
+	{{- if $assert }}
+	{{$assert}}
+	{{- end }}
+	{{- end }}
+	{{- end }}
+	_ = got
+
+	{{- if .IsTree }}
+	list, err := service.Get{{.StructName}}InfoList(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("Get{{.StructName}}InfoList() = %d, %v", len(list), err)
+	}
+	{{- else }}
+	list, total, err := service.Get{{.StructName}}InfoList(ctx, {{.Package}}Req.{{.StructName}}Search{})
+	if err != nil || total != 1 || len(list) != 1 {
+		t.Fatalf("Get{{.StructName}}InfoList() = %d, %d, %v", len(list), total, err)
+	}
+	{{- range .Fields}}
+	{{- $search := GenerateTestSearch . "info" $ptr }}
+	{{- if $search }}
+	t.Run("search {{.FieldName}}", func(t *testing.T) {
+		var info {{$.Package}}Req.{{$.StructName}}Search
+		{{$search}}
+		_, total, err := service.Get{{$.StructName}}InfoList(ctx, info)
+		if err != nil || total != 1 {
+			t.Errorf("Get{{$.StructName}}InfoList() total = %d, %v", total, err)
+		}
+	})
+	{{- end }}
+	{{- end }}
+	{{- end }}
+
+	if err = service.Delete{{.StructName}}(ctx, id{{- if .AutoCreateResource }}, 1{{- end }}); err != nil {
+		t.Fatalf("Delete{{.StructName}}() error = %v", err)
+	}
+	if _, err = service.Get{{.StructName}}(ctx, id); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Get{{.StructName}}() after delete error = %v", err)
+	}
+
+	another := {{.Abbreviation}}TestModel(false)
+	if err = service.Create{{.StructName}}(ctx, &another); err != nil {
+		t.Fatalf("Create{{.StructName}}() error = %v", err)
+	}
+	if err = service.Delete{{.StructName}}ByIds(ctx, []string{ {{- GenerateTestPrimaryKey . "another" -}} }{{- if .AutoCreateResource }}, 1{{- end }}); err != nil {
+		t.Fatalf("Delete{{.StructName}}ByIds() error = %v", err)
+	}
+	if _, err = service.Get{{.StructName}}(ctx, {{ GenerateTestPrimaryKey . "another" }}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Get{{.StructName}}() after batch delete error = %v", err)
+	}
+}
+{{- end }}
+{{- end }}
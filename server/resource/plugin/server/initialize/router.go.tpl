@@ -7,8 +7,8 @@ import (
 )
 
 func Router(engine *gin.Engine) {
-	public := engine.Group(global.GVA_CONFIG.System.RouterPrefix).Group("")
+	public := engine.Group(global.GVA_CONFIG.Load().System.RouterPrefix).Group("")
 	public.Use()
-	private := engine.Group(global.GVA_CONFIG.System.RouterPrefix).Group("")
+	private := engine.Group(global.GVA_CONFIG.Load().System.RouterPrefix).Group("")
 	private.Use(middleware.JWTAuth()).Use(middleware.CasbinHandler())
 }
@@ -3,6 +3,9 @@
 {{- range .Fields}}
   {{ GenerateField . }}
 {{- end }}
+{{- range .Relations}}
+  {{ GenerateRelationField . $.Package }}
+{{- end }}
 
 {{ else }}
 package model
@@ -18,6 +21,9 @@ import (
 	{{- if .NeedJSON }}
 	"gorm.io/datatypes"
 	{{- end }}
+	{{- range .RelationPackages }}
+	"{{$.Module}}/model/{{.}}"
+	{{- end }}
 )
 {{- end }}
 
@@ -29,6 +35,9 @@ type {{.StructName}} struct {
 {{- end }}
 {{- range .Fields}}
   {{ GenerateField . }}
+{{- end }}
+{{- range .Relations}}
+  {{ GenerateRelationField . $.Package }}
 {{- end }}
     {{- if .AutoCreateResource }}
     CreatedBy  uint   `gorm:"column:created_by;comment:创建者"`
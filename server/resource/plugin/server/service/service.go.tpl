@@ -10,6 +10,7 @@
 // Get{{.StructName}}InfoList 新增搜索语句
 
     {{ GenerateSearchConditions .Fields }}
+    {{ GenerateRelationSearchConditions . $db }}
 
 // Get{{.StructName}}InfoList 新增排序语句 请自行在搜索语句中添加orderMap内容
        {{- range .Fields}}
@@ -40,9 +41,12 @@ import (
     {{- else }}
     "errors"
     {{- end }}
-    {{- if .AutoCreateResource }}
+    {{- if or .AutoCreateResource .HasNestedRelation }}
     "gorm.io/gorm"
     {{- end}}
+    {{- if .HasNestedRelation }}
+    "gorm.io/gorm/clause"
+    {{- end}}
 {{- if .IsTree }}
     "{{.Module}}/utils"
 {{- end }}
@@ -120,14 +124,31 @@ func (s *{{.Abbreviation}}) Delete{{.StructName}}ByIds(ctx context.Context, {{.P
 // Update{{.StructName}} 更新{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func (s *{{.Abbreviation}}) Update{{.StructName}}(ctx context.Context, {{.Abbreviation}} model.{{.StructName}}) (err error) {
+	{{- if .HasNestedRelation }}
+	// 主表更新时忽略关联, 一对多和多对多关联按提交内容整体替换
+	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
+	    if err := tx.Model(&model.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Omit(clause.Associations).Updates(&{{.Abbreviation}}).Error; err != nil {
+	        return err
+	    }
+	    {{- range .Relations }}
+	    {{- if ne .Type "belongsTo" }}
+	    if err := tx.Model(&{{$.Abbreviation}}).Association("{{.FieldName}}").Replace({{$.Abbreviation}}.{{.FieldName}}); err != nil {
+	        return err
+	    }
+	    {{- end }}
+	    {{- end }}
+	    return nil
+	})
+	{{- else }}
 	err = {{$db}}.Model(&model.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Updates(&{{.Abbreviation}}).Error
+	{{- end }}
 	return err
 }
 
 // Get{{.StructName}} 根据{{.PrimaryField.FieldJson}}获取{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func (s *{{.Abbreviation}}) Get{{.StructName}}(ctx context.Context, {{.PrimaryField.FieldJson}} string) ({{.Abbreviation}} model.{{.StructName}}, err error) {
-	err = {{$db}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
+	err = {{$db}}{{ GenerateRelationPreload .Relations }}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
 	return
 }
 
@@ -140,7 +161,7 @@ func (s *{{.Abbreviation}}) Get{{.StructName}}InfoList(ctx context.Context) (lis
 	db := {{$db}}.Model(&model.{{.StructName}}{})
     var {{.Abbreviation}}s []*model.{{.StructName}}
 
-	err = db.Find(&{{.Abbreviation}}s).Error
+	err = db{{ GenerateRelationPreload .Relations }}.Find(&{{.Abbreviation}}s).Error
 
 	return utils.BuildTree({{.Abbreviation}}s), err
 }
@@ -160,6 +181,9 @@ func (s *{{.Abbreviation}}) Get{{.StructName}}InfoList(ctx context.Context, info
     }
 {{- end }}
   {{ GenerateSearchConditions .Fields }}
+    {{- if .Relations }}
+    {{ GenerateRelationSearchConditions . $db }}
+    {{- end }}
 	err = db.Count(&total).Error
 	if err!=nil {
     	return
@@ -188,7 +212,7 @@ func (s *{{.Abbreviation}}) Get{{.StructName}}InfoList(ctx context.Context, info
 	if limit != 0 {
        db = db.Limit(limit).Offset(offset)
     }
-	err = db.Find(&{{.Abbreviation}}s).Error
+	err = db{{ GenerateRelationPreload .Relations }}.Find(&{{.Abbreviation}}s).Error
 	return  {{.Abbreviation}}s, total, err
 }
 {{- end }}
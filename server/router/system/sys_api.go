@@ -24,10 +24,13 @@ func (s *ApiRouter) InitApiRouter(Router *gin.RouterGroup, RouterPub *gin.Router
 		apiRouter.DELETE("deleteApisByIds", apiRouterApi.DeleteApisByIds) // 删除选中api
 	}
 	{
-		apiRouterWithoutRecord.POST("getAllApis", apiRouterApi.GetAllApis) // 获取所有api
-		apiRouterWithoutRecord.POST("getApiList", apiRouterApi.GetApiList) // 获取Api列表
+		apiRouterWithoutRecord.POST("getAllApis", apiRouterApi.GetAllApis)    // 获取所有api
+		apiRouterWithoutRecord.POST("getApiList", apiRouterApi.GetApiList)    // 获取Api列表
+		apiRouterWithoutRecord.GET("checkOpenApi", apiRouterApi.CheckOpenApi) // 检查路由一致性
+		apiRouterWithoutRecord.GET("getSdk", apiRouterApi.GetSdk)             // 生成接口客户端
 	}
 	{
 		apiPublicRouterWithoutRecord.GET("freshCasbin", apiRouterApi.FreshCasbin) // 刷新casbin权限
+		apiPublicRouterWithoutRecord.GET("openapi.json", apiRouterApi.GetOpenApi) // OpenAPI文档
 	}
 }
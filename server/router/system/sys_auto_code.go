@@ -13,11 +13,14 @@ func (s *AutoCodeRouter) InitAutoCodeRouter(Router *gin.RouterGroup, RouterPubli
 		autoCodeRouter.GET("getDB", autoCodeApi.GetDB)         // 获取数据库
 		autoCodeRouter.GET("getTables", autoCodeApi.GetTables) // 获取对应数据库的表
 		autoCodeRouter.GET("getColumn", autoCodeApi.GetColumn) // 获取指定表所有字段信息
+		autoCodeRouter.POST("getSpec", autoCodeApi.Spec)       // 由数据表或建表语句生成代码生成器参数
 	}
 	{
-		autoCodeRouter.POST("preview", autoCodeTemplateApi.Preview)   // 获取自动创建代码预览
-		autoCodeRouter.POST("createTemp", autoCodeTemplateApi.Create) // 创建自动化代码
-		autoCodeRouter.POST("addFunc", autoCodeTemplateApi.AddFunc)   // 为代码插入方法
+		autoCodeRouter.POST("preview", autoCodeTemplateApi.Preview)                   // 获取自动创建代码预览
+		autoCodeRouter.POST("previewInjection", autoCodeTemplateApi.PreviewInjection) // 预览注入代码的diff及冲突
+		autoCodeRouter.POST("createTemp", autoCodeTemplateApi.Create)                 // 创建自动化代码
+		autoCodeRouter.POST("regenerate", autoCodeTemplateApi.Regenerate)             // 重新生成并合并手工修改
+		autoCodeRouter.POST("addFunc", autoCodeTemplateApi.AddFunc)                   // 为代码插入方法
 	}
 	{
 		autoCodeRouter.POST("mcp", autoCodeTemplateApi.MCP)         // 自动创建Mcp Tool模板
@@ -32,6 +35,17 @@ func (s *AutoCodeRouter) InitAutoCodeRouter(Router *gin.RouterGroup, RouterPubli
 	{
 		autoCodeRouter.GET("getTemplates", autoCodePackageApi.Templates) // 创建package包
 	}
+	{
+		autoCodeRouter.GET("getMigrations", autoCodeMigrationApi.GetList)       // 获取字段变更迁移
+		autoCodeRouter.POST("applyMigration", autoCodeMigrationApi.Apply)       // 执行迁移
+		autoCodeRouter.POST("rollbackMigration", autoCodeMigrationApi.Rollback) // 回滚迁移
+	}
+	{
+		autoCodeRouter.GET("getTemplateSets", autoCodeTemplateSetApi.All)           // 获取自定义模板集
+		autoCodeRouter.POST("createTemplateSet", autoCodeTemplateSetApi.Create)     // 注册自定义模板集
+		autoCodeRouter.POST("delTemplateSet", autoCodeTemplateSetApi.Delete)        // 删除自定义模板集
+		autoCodeRouter.POST("validateTemplateSet", autoCodeTemplateSetApi.Validate) // 以示例结构体校验模板集
+	}
 	{
 		autoCodeRouter.POST("pubPlug", autoCodePluginApi.Packaged)      // 打包插件
 		autoCodeRouter.POST("installPlugin", autoCodePluginApi.Install) // 自动安装插件
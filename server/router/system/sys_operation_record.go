@@ -13,6 +13,14 @@ func (s *OperationRecordRouter) InitSysOperationRecordRouter(Router *gin.RouterG
 		operationRecordRouter.DELETE("deleteSysOperationRecordByIds", operationRecordApi.DeleteSysOperationRecordByIds) // 批量删除SysOperationRecord
 		operationRecordRouter.GET("findSysOperationRecord", operationRecordApi.FindSysOperationRecord)                  // 根据ID获取SysOperationRecord
 		operationRecordRouter.GET("getSysOperationRecordList", operationRecordApi.GetSysOperationRecordList)            // 获取SysOperationRecord列表
+		operationRecordRouter.GET("getOperationRecordWriterStats", operationRecordApi.GetOperationRecordWriterStats)    // 获取操作记录写入器状态
+		operationRecordRouter.GET("getSysOperationRecordStats", operationRecordApi.GetSysOperationRecordStats)          // 按时间粒度统计操作记录
+		operationRecordRouter.GET("getTopErrorPaths", operationRecordApi.GetTopErrorPaths)                              // 获取出错最多的路径
+		operationRecordRouter.GET("getSlowestPaths", operationRecordApi.GetSlowestPaths)                                // 获取耗时分位最高的路径
+		operationRecordRouter.GET("exportSysOperationRecord", operationRecordApi.ExportSysOperationRecord)              // 导出操作记录
+		operationRecordRouter.GET("verifyAuditLog", operationRecordApi.VerifyAuditLog)                                  // 校验审计日志哈希链
+		operationRecordRouter.GET("getAuditLogList", operationRecordApi.GetAuditLogList)                                // 分页获取审计日志
+		operationRecordRouter.GET("getChangeHistory", operationRecordApi.GetChangeHistory)                              // 分页获取数据变更历史
 
 	}
 }
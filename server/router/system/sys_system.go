@@ -14,9 +14,11 @@ func (s *SysRouter) InitSystemRouter(Router *gin.RouterGroup) {
 	{
 		sysRouter.POST("setSystemConfig", systemApi.SetSystemConfig) // 设置配置文件内容
 		sysRouter.POST("reloadSystem", systemApi.ReloadSystem)       // 重启服务
+		sysRouter.POST("setLogLevel", systemApi.SetLogLevel)         // 设置日志级别
 	}
 	{
 		sysRouterWithoutRecord.POST("getSystemConfig", systemApi.GetSystemConfig) // 获取配置文件内容
 		sysRouterWithoutRecord.POST("getServerInfo", systemApi.GetServerInfo)     // 获取服务器信息
+		sysRouterWithoutRecord.GET("getLogLevel", systemApi.GetLogLevel)          // 获取日志级别
 	}
 }
@@ -1,6 +1,7 @@
 package example
 
 import (
+	"context"
 	"errors"
 
 	"server/global"
@@ -18,19 +19,19 @@ var FileUploadAndDownloadServiceApp = new(FileUploadAndDownloadService)
 //@param: fileMd5 string, fileName string, chunkTotal int
 //@return: file model.ExaFile, err error
 
-func (e *FileUploadAndDownloadService) FindOrCreateFile(fileMd5 string, fileName string, chunkTotal int) (file example.ExaFile, err error) {
+func (e *FileUploadAndDownloadService) FindOrCreateFile(ctx context.Context, fileMd5 string, fileName string, chunkTotal int) (file example.ExaFile, err error) {
 	var cfile example.ExaFile
 	cfile.FileMd5 = fileMd5
 	cfile.FileName = fileName
 	cfile.ChunkTotal = chunkTotal
 
-	if errors.Is(global.GVA_DB.Where("file_md5 = ? AND is_finish = ?", fileMd5, true).First(&file).Error, gorm.ErrRecordNotFound) {
-		err = global.GVA_DB.Where("file_md5 = ? AND file_name = ?", fileMd5, fileName).Preload("ExaFileChunk").FirstOrCreate(&file, cfile).Error
+	if errors.Is(global.GVA_DB.WithContext(ctx).Where("file_md5 = ? AND is_finish = ?", fileMd5, true).First(&file).Error, gorm.ErrRecordNotFound) {
+		err = global.GVA_DB.WithContext(ctx).Where("file_md5 = ? AND file_name = ?", fileMd5, fileName).Preload("ExaFileChunk").FirstOrCreate(&file, cfile).Error
 		return file, err
 	}
 	cfile.IsFinish = true
 	cfile.FilePath = file.FilePath
-	err = global.GVA_DB.Create(&cfile).Error
+	err = global.GVA_DB.WithContext(ctx).Create(&cfile).Error
 	return cfile, err
 }
 
@@ -40,12 +41,12 @@ func (e *FileUploadAndDownloadService) FindOrCreateFile(fileMd5 string, fileName
 //@param: id uint, fileChunkPath string, fileChunkNumber int
 //@return: error
 
-func (e *FileUploadAndDownloadService) CreateFileChunk(id uint, fileChunkPath string, fileChunkNumber int) error {
+func (e *FileUploadAndDownloadService) CreateFileChunk(ctx context.Context, id uint, fileChunkPath string, fileChunkNumber int) error {
 	var chunk example.ExaFileChunk
 	chunk.FileChunkPath = fileChunkPath
 	chunk.ExaFileID = id
 	chunk.FileChunkNumber = fileChunkNumber
-	err := global.GVA_DB.Create(&chunk).Error
+	err := global.GVA_DB.WithContext(ctx).Create(&chunk).Error
 	return err
 }
 
@@ -55,10 +56,10 @@ func (e *FileUploadAndDownloadService) CreateFileChunk(id uint, fileChunkPath st
 //@param: fileMd5 string, fileName string, filePath string
 //@return: error
 
-func (e *FileUploadAndDownloadService) DeleteFileChunk(fileMd5 string, filePath string) error {
+func (e *FileUploadAndDownloadService) DeleteFileChunk(ctx context.Context, fileMd5 string, filePath string) error {
 	var chunks []example.ExaFileChunk
 	var file example.ExaFile
-	err := global.GVA_DB.Where("file_md5 = ?", fileMd5).First(&file).
+	err := global.GVA_DB.WithContext(ctx).Where("file_md5 = ?", fileMd5).First(&file).
 		Updates(map[string]interface{}{
 			"IsFinish":  true,
 			"file_path": filePath,
@@ -66,6 +67,6 @@ func (e *FileUploadAndDownloadService) DeleteFileChunk(fileMd5 string, filePath
 	if err != nil {
 		return err
 	}
-	err = global.GVA_DB.Where("exa_file_id = ?", file.ID).Delete(&chunks).Unscoped().Error
+	err = global.GVA_DB.WithContext(ctx).Where("exa_file_id = ?", file.ID).Delete(&chunks).Unscoped().Error
 	return err
 }
@@ -1,6 +1,7 @@
 package example
 
 import (
+	"context"
 	"errors"
 	"mime/multipart"
 	"strings"
@@ -8,7 +9,10 @@ import (
 	"server/global"
 	"server/model/example"
 	"server/model/example/request"
+	"server/utils/tracing"
 	"server/utils/upload"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -17,8 +21,8 @@ import (
 //@param: file model.ExaFileUploadAndDownload
 //@return: error
 
-func (e *FileUploadAndDownloadService) Upload(file example.ExaFileUploadAndDownload) error {
-	return global.GVA_DB.Create(&file).Error
+func (e *FileUploadAndDownloadService) Upload(ctx context.Context, file example.ExaFileUploadAndDownload) error {
+	return global.GVA_DB.WithContext(ctx).Create(&file).Error
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -27,36 +31,42 @@ func (e *FileUploadAndDownloadService) Upload(file example.ExaFileUploadAndDownl
 //@param: id uint
 //@return: model.ExaFileUploadAndDownload, error
 
-func (e *FileUploadAndDownloadService) FindFile(id uint) (example.ExaFileUploadAndDownload, error) {
+func (e *FileUploadAndDownloadService) FindFile(ctx context.Context, id uint) (example.ExaFileUploadAndDownload, error) {
 	var file example.ExaFileUploadAndDownload
-	err := global.GVA_DB.Where("id = ?", id).First(&file).Error
+	err := global.GVA_DB.WithContext(ctx).Where("id = ?", id).First(&file).Error
 	return file, err
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: DeleteFile
 //@description: 删除文件记录
-//@param: file model.ExaFileUploadAndDownload
+//@param: ctx context.Context, file model.ExaFileUploadAndDownload
 //@return: err error
 
-func (e *FileUploadAndDownloadService) DeleteFile(file example.ExaFileUploadAndDownload) (err error) {
+func (e *FileUploadAndDownloadService) DeleteFile(ctx context.Context, file example.ExaFileUploadAndDownload) (err error) {
 	var fileFromDb example.ExaFileUploadAndDownload
-	fileFromDb, err = e.FindFile(file.ID)
+	fileFromDb, err = e.FindFile(ctx, file.ID)
 	if err != nil {
 		return
 	}
 	oss := upload.NewOss()
-	if err = oss.DeleteFile(fileFromDb.Key); err != nil {
+	_, span := tracing.Start(ctx, "oss.DeleteFile",
+		attribute.String("oss.type", global.GVA_CONFIG.Load().System.OssType),
+		attribute.String("oss.key", fileFromDb.Key),
+	)
+	err = oss.DeleteFile(fileFromDb.Key)
+	tracing.End(span, err)
+	if err != nil {
 		return errors.New("文件删除失败")
 	}
-	err = global.GVA_DB.Where("id = ?", file.ID).Unscoped().Delete(&file).Error
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", file.ID).Unscoped().Delete(&file).Error
 	return err
 }
 
 // EditFileName 编辑文件名或者备注
-func (e *FileUploadAndDownloadService) EditFileName(file example.ExaFileUploadAndDownload) (err error) {
+func (e *FileUploadAndDownloadService) EditFileName(ctx context.Context, file example.ExaFileUploadAndDownload) (err error) {
 	var fileFromDb example.ExaFileUploadAndDownload
-	return global.GVA_DB.Where("id = ?", file.ID).First(&fileFromDb).Update("name", file.Name).Error
+	return global.GVA_DB.WithContext(ctx).Where("id = ?", file.ID).First(&fileFromDb).Update("name", file.Name).Error
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -65,10 +75,10 @@ func (e *FileUploadAndDownloadService) EditFileName(file example.ExaFileUploadAn
 //@param: info request.ExaAttachmentCategorySearch
 //@return: list interface{}, total int64, err error
 
-func (e *FileUploadAndDownloadService) GetFileRecordInfoList(info request.ExaAttachmentCategorySearch) (list []example.ExaFileUploadAndDownload, total int64, err error) {
+func (e *FileUploadAndDownloadService) GetFileRecordInfoList(ctx context.Context, info request.ExaAttachmentCategorySearch) (list []example.ExaFileUploadAndDownload, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.GVA_DB.Model(&example.ExaFileUploadAndDownload{})
+	db := global.GVA_DB.WithContext(ctx).Model(&example.ExaFileUploadAndDownload{})
 
 	if len(info.Keyword) > 0 {
 		db = db.Where("name LIKE ?", "%"+info.Keyword+"%")
@@ -89,12 +99,18 @@ func (e *FileUploadAndDownloadService) GetFileRecordInfoList(info request.ExaAtt
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: UploadFile
 //@description: 根据配置文件判断是文件上传到本地或者七牛云
-//@param: header *multipart.FileHeader, noSave string
+//@param: ctx context.Context, header *multipart.FileHeader, noSave string
 //@return: file model.ExaFileUploadAndDownload, err error
 
-func (e *FileUploadAndDownloadService) UploadFile(header *multipart.FileHeader, noSave string, classId int) (file example.ExaFileUploadAndDownload, err error) {
+func (e *FileUploadAndDownloadService) UploadFile(ctx context.Context, header *multipart.FileHeader, noSave string, classId int) (file example.ExaFileUploadAndDownload, err error) {
 	oss := upload.NewOss()
+	_, span := tracing.Start(ctx, "oss.UploadFile",
+		attribute.String("oss.type", global.GVA_CONFIG.Load().System.OssType),
+		attribute.String("file.name", header.Filename),
+		attribute.Int64("file.size", header.Size),
+	)
 	filePath, key, uploadErr := oss.UploadFile(header)
+	tracing.End(span, uploadErr)
 	if uploadErr != nil {
 		return file, uploadErr
 	}
@@ -107,7 +123,7 @@ func (e *FileUploadAndDownloadService) UploadFile(header *multipart.FileHeader,
 		Key:     key,
 	}
 	if noSave == "0" {
-		return f, e.Upload(f)
+		return f, e.Upload(ctx, f)
 	}
 	return f, nil
 }
@@ -118,6 +134,6 @@ func (e *FileUploadAndDownloadService) UploadFile(header *multipart.FileHeader,
 //@param: file model.ExaFileUploadAndDownload
 //@return: error
 
-func (e *FileUploadAndDownloadService) ImportURL(file *[]example.ExaFileUploadAndDownload) error {
-	return global.GVA_DB.Create(&file).Error
+func (e *FileUploadAndDownloadService) ImportURL(ctx context.Context, file *[]example.ExaFileUploadAndDownload) error {
+	return global.GVA_DB.WithContext(ctx).Create(&file).Error
 }
@@ -3,11 +3,11 @@ package system
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"server/utils/ast"
 	"github.com/pkg/errors"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,7 +16,9 @@ import (
 	common "server/model/common/request"
 	model "server/model/system"
 	request "server/model/system/request"
+	"server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
 
 	"go.uber.org/zap"
 )
@@ -52,57 +54,46 @@ func (s *autoCodeHistory) First(ctx context.Context, info common.GetById) (strin
 // Repeat 检测重复
 // Author [SliverHorn](https://github.com/SliverHorn)
 // Author [songzhibin97](https://github.com/songzhibin97)
-func (s *autoCodeHistory) Repeat(businessDB, structName, abbreviation, Package string) bool {
+func (s *autoCodeHistory) Repeat(ctx context.Context, businessDB, structName, abbreviation, Package string) bool {
 	var count int64
-	global.GVA_DB.Model(&model.SysAutoCodeHistory{}).Where("business_db = ? and (struct_name = ? OR abbreviation = ?) and package = ? and flag = ?", businessDB, structName, abbreviation, Package, 0).Count(&count).Debug()
+	global.GVA_DB.WithContext(ctx).Model(&model.SysAutoCodeHistory{}).Where("business_db = ? and (struct_name = ? OR abbreviation = ?) and package = ? and flag = ?", businessDB, structName, abbreviation, Package, 0).Count(&count).Debug()
 	return count > 0
 }
 
 // RollBack 回滚
+// 先预检注入代码的回滚, 目标文件缺失或无法解析时不做任何修改; Preview 为 true 时只返回回滚的 diff 与冲突
 // Author [SliverHorn](https://github.com/SliverHorn)
 // Author [songzhibin97](https://github.com/songzhibin97)
-func (s *autoCodeHistory) RollBack(ctx context.Context, info request.SysAutoHistoryRollBack) error {
+func (s *autoCodeHistory) RollBack(ctx context.Context, info request.SysAutoHistoryRollBack) (*response.AutoCodeInjection, error) {
 	var history model.SysAutoCodeHistory
-	err := global.GVA_DB.Where("id = ?", info.ID).First(&history).Error
+	err := global.GVA_DB.WithContext(ctx).Where("id = ?", info.ID).First(&history).Error
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if history.ExportTemplateID != 0 {
-		err = global.GVA_DB.Delete(&model.SysExportTemplate{}, "id = ?", history.ExportTemplateID).Error
-		if err != nil {
-			return err
-		}
+	injections, err := s.injections(history)
+	if err != nil {
+		return nil, err
 	}
-	if info.DeleteApi {
-		ids := info.ApiIds(history)
-		err = ApiServiceApp.DeleteApisByIds(ids)
-		if err != nil {
-			global.GVA_LOG.Error("ClearTag DeleteApiByIds:", zap.Error(err))
-		}
-	} // 清除API表
-	if info.DeleteMenu {
-		err = BaseMenuServiceApp.DeleteBaseMenu(int(history.MenuID))
-		if err != nil {
-			return errors.Wrap(err, "删除菜单失败!")
-		}
-	} // 清除菜单表
-	if info.DeleteTable {
-		err = s.DropTable(history.BusinessDB, history.Table)
-		if err != nil {
-			return errors.Wrap(err, "删除表失败!")
-		}
-	} // 删除表
+	plan := ast.NewRollbackPlan(injections...)
+	changes, conflicts := plan.Diff()
+	if info.Preview {
+		return &response.AutoCodeInjection{Changes: changes, Conflicts: conflicts}, nil
+	}
+	err = ast.Conflicts(conflicts)
+	if err != nil {
+		return nil, err
+	} // 预检注入代码
 	templates := make(map[string]string, len(history.Templates))
 	for key, template := range history.Templates {
 		{
-			server := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server)
+			server := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
 			keys := strings.Split(key, "/")
 			key = filepath.Join(keys...)
 			key = strings.TrimPrefix(key, server)
 		} // key
 		{
-			web := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.WebRoot())
-			server := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server)
+			web := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.WebRoot())
+			server := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
 			slices := strings.Split(template, "/")
 			template = filepath.Join(slices...)
 			ext := path.Ext(template)
@@ -116,69 +107,89 @@ func (s *autoCodeHistory) RollBack(ctx context.Context, info request.SysAutoHist
 		templates[key] = template
 	}
 	history.Templates = templates
-	for key, value := range history.Injections {
+	// 先回滚代码再删除数据库中的资源, 代码回滚失败时数据库保持不变
+	changes, err = plan.Apply()
+	if err != nil {
+		return nil, err
+	} // 清除注入代码
+	removeBasePath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, "rm_file", strconv.FormatInt(int64(time.Now().Nanosecond()), 10))
+	for _, value := range history.Templates {
+		if !filepath.IsAbs(value) {
+			continue
+		}
+		removePath := filepath.Join(removeBasePath, strings.TrimPrefix(value, global.GVA_CONFIG.Load().AutoCode.Root))
+		err = utils.FileMove(value, removePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[src:%s][dst:%s]文件移动失败!", value, removePath)
+		}
+	} // 移动文件
+	if history.ExportTemplateID != 0 {
+		err = global.GVA_DB.WithContext(ctx).Delete(&model.SysExportTemplate{}, "id = ?", history.ExportTemplateID).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	if info.DeleteApi {
+		ids := info.ApiIds(history)
+		err = ApiServiceApp.DeleteApisByIds(ctx, ids)
+		if err != nil {
+			ctxlog.Logger(ctx).Error("ClearTag DeleteApiByIds:", zap.Error(err))
+		}
+	} // 清除API表
+	if info.DeleteMenu {
+		err = BaseMenuServiceApp.DeleteBaseMenu(ctx, int(history.MenuID))
+		if err != nil {
+			return nil, errors.Wrap(err, "删除菜单失败!")
+		}
+	} // 清除菜单表
+	if info.DeleteTable {
+		err = s.DropTable(ctx, history.BusinessDB, history.Table)
+		if err != nil {
+			return nil, errors.Wrap(err, "删除表失败!")
+		}
+	} // 删除表
+	err = global.GVA_DB.WithContext(ctx).Model(&model.SysAutoCodeHistory{}).Where("id = ?", info.ID).Update("flag", 1).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "更新失败!")
+	}
+	return &response.AutoCodeInjection{Changes: changes, Conflicts: conflicts}, nil
+}
+
+// injections 由历史记录还原需要回滚的注入, 按类型排序保证同一文件的回滚顺序稳定
+func (s *autoCodeHistory) injections(history model.SysAutoCodeHistory) ([]ast.Ast, error) {
+	keys := make([]string, 0, len(history.Injections))
+	for key := range history.Injections {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	injections := make([]ast.Ast, 0, len(keys))
+	for _, key := range keys {
 		var injection ast.Ast
 		switch key {
-		case ast.TypePackageApiEnter, ast.TypePackageRouterEnter, ast.TypePackageServiceEnter:
-
 		case ast.TypePackageApiModuleEnter, ast.TypePackageRouterModuleEnter, ast.TypePackageServiceModuleEnter:
-			var entity ast.PackageModuleEnter
-			_ = json.Unmarshal([]byte(value), &entity)
-			injection = &entity
+			injection = new(ast.PackageModuleEnter)
 		case ast.TypePackageInitializeGorm:
-			var entity ast.PackageInitializeGorm
-			_ = json.Unmarshal([]byte(value), &entity)
-			injection = &entity
+			injection = new(ast.PackageInitializeGorm)
 		case ast.TypePackageInitializeRouter:
-			var entity ast.PackageInitializeRouter
-			_ = json.Unmarshal([]byte(value), &entity)
-			injection = &entity
+			injection = new(ast.PackageInitializeRouter)
 		case ast.TypePluginGen:
-			var entity ast.PluginGen
-			_ = json.Unmarshal([]byte(value), &entity)
-			injection = &entity
+			injection = new(ast.PluginGen)
 		case ast.TypePluginApiEnter, ast.TypePluginRouterEnter, ast.TypePluginServiceEnter:
-			var entity ast.PluginEnter
-			_ = json.Unmarshal([]byte(value), &entity)
-			injection = &entity
+			injection = new(ast.PluginEnter)
 		case ast.TypePluginInitializeGorm:
-			var entity ast.PluginInitializeGorm
-			_ = json.Unmarshal([]byte(value), &entity)
-			injection = &entity
+			injection = new(ast.PluginInitializeGorm)
 		case ast.TypePluginInitializeRouter:
-			var entity ast.PluginInitializeRouter
-			_ = json.Unmarshal([]byte(value), &entity)
-			injection = &entity
-		}
-		if injection == nil {
-			continue
-		}
-		file, _ := injection.Parse("", nil)
-		if file != nil {
-			_ = injection.Rollback(file)
-			err = injection.Format("", nil, file)
-			if err != nil {
-				return err
-			}
-			fmt.Printf("[filepath:%s]回滚注入代码成功!\n", key)
-		}
-	} // 清除注入代码
-	removeBasePath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, "rm_file", strconv.FormatInt(int64(time.Now().Nanosecond()), 10))
-	for _, value := range history.Templates {
-		if !filepath.IsAbs(value) {
+			injection = new(ast.PluginInitializeRouter)
+		default:
 			continue
-		}
-		removePath := filepath.Join(removeBasePath, strings.TrimPrefix(value, global.GVA_CONFIG.AutoCode.Root))
-		err = utils.FileMove(value, removePath)
+		} // 包级别的 enter 注入随包保留, 不回滚
+		err := json.Unmarshal([]byte(history.Injections[key]), injection)
 		if err != nil {
-			return errors.Wrapf(err, "[src:%s][dst:%s]文件移动失败!", value, removePath)
+			return nil, errors.Wrapf(err, "[type:%s]解析注入记录失败!", key)
 		}
-	} // 移动文件
-	err = global.GVA_DB.WithContext(ctx).Model(&model.SysAutoCodeHistory{}).Where("id = ?", info.ID).Update("flag", 1).Error
-	if err != nil {
-		return errors.Wrap(err, "更新失败!")
+		injections = append(injections, injection)
 	}
-	return nil
+	return injections, nil
 }
 
 // Delete 删除历史数据
@@ -208,10 +219,10 @@ func (s *autoCodeHistory) GetList(ctx context.Context, info common.PageInfo) (li
 
 // DropTable 获取指定数据库和指定数据表的所有字段名,类型值等
 // @author: [piexlmax](https://github.com/piexlmax)
-func (s *autoCodeHistory) DropTable(BusinessDb, tableName string) error {
+func (s *autoCodeHistory) DropTable(ctx context.Context, BusinessDb, tableName string) error {
 	if BusinessDb != "" {
-		return global.MustGetGlobalDBByDBName(BusinessDb).Exec("DROP TABLE " + tableName).Error
+		return global.MustGetGlobalDBByDBName(BusinessDb).WithContext(ctx).Exec("DROP TABLE " + tableName).Error
 	} else {
-		return global.GVA_DB.Exec("DROP TABLE " + tableName).Error
+		return global.GVA_DB.WithContext(ctx).Exec("DROP TABLE " + tableName).Error
 	}
 }
@@ -0,0 +1,203 @@
+package system
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"server/global"
+	model "server/model/system"
+	"server/model/system/request"
+	"server/utils"
+	"server/utils/ast"
+)
+
+// autoCodeInjectionTypes 各包模版支持的注入类型
+var autoCodeInjectionTypes = map[string][]string{
+	"package": {
+		ast.TypePackageApiEnter,
+		ast.TypePackageApiModuleEnter,
+		ast.TypePackageRouterEnter,
+		ast.TypePackageRouterModuleEnter,
+		ast.TypePackageServiceEnter,
+		ast.TypePackageServiceModuleEnter,
+		ast.TypePackageInitializeRouter,
+		ast.TypePackageInitializeGorm,
+	},
+	"plugin": {
+		ast.TypePluginApiEnter,
+		ast.TypePluginRouterEnter,
+		ast.TypePluginServiceEnter,
+		ast.TypePluginGen,
+		ast.TypePluginInitializeGorm,
+		ast.TypePluginInitializeRouter,
+	},
+}
+
+//@function: autoCodeInjection
+//@description: 按类型构建注入, 内置模板与模板集共用, 回滚时可按类型还原; 不支持的类型返回 nil
+//@param: typ string, entity model.SysAutoCodePackage, info request.AutoCode
+//@return: injection ast.Ast, path string 注入的文件路径
+
+func autoCodeInjection(typ string, entity model.SysAutoCodePackage, info request.AutoCode) (injection ast.Ast, path string) {
+	server := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
+	module := global.GVA_CONFIG.Load().AutoCode.Module
+	pkg := entity.PackageName
+	switch typ {
+	case ast.TypePackageApiEnter:
+		path = filepath.Join(server, "api", "v1", "enter.go")
+		injection = &ast.PackageEnter{
+			Type:              ast.TypePackageApiEnter,
+			Path:              path,
+			ImportPath:        fmt.Sprintf(`"%s/%s/%s/%s"`, module, "api", "v1", pkg),
+			StructName:        utils.FirstUpper(pkg) + "ApiGroup",
+			PackageName:       pkg,
+			PackageStructName: "ApiGroup",
+		}
+	case ast.TypePackageApiModuleEnter:
+		path = filepath.Join(server, "api", "v1", pkg, "enter.go")
+		injection = &ast.PackageModuleEnter{
+			Type:        ast.TypePackageApiModuleEnter,
+			Path:        path,
+			ImportPath:  fmt.Sprintf(`"%s/service"`, module),
+			StructName:  info.StructName + "Api",
+			AppName:     "ServiceGroupApp",
+			GroupName:   utils.FirstUpper(pkg) + "ServiceGroup",
+			ModuleName:  info.Abbreviation + "Service",
+			PackageName: "service",
+			ServiceName: info.StructName + "Service",
+		}
+	case ast.TypePackageRouterEnter:
+		path = filepath.Join(server, "router", "enter.go")
+		injection = &ast.PackageEnter{
+			Type:              ast.TypePackageRouterEnter,
+			Path:              path,
+			ImportPath:        fmt.Sprintf(`"%s/%s/%s"`, module, "router", pkg),
+			StructName:        utils.FirstUpper(pkg),
+			PackageName:       pkg,
+			PackageStructName: "RouterGroup",
+		}
+	case ast.TypePackageRouterModuleEnter:
+		path = filepath.Join(server, "router", pkg, "enter.go")
+		injection = &ast.PackageModuleEnter{
+			Type:        ast.TypePackageRouterModuleEnter,
+			Path:        path,
+			ImportPath:  fmt.Sprintf(`api "%s/api/v1"`, module),
+			StructName:  info.StructName + "Router",
+			AppName:     "ApiGroupApp",
+			GroupName:   utils.FirstUpper(pkg) + "ApiGroup",
+			ModuleName:  info.Abbreviation + "Api",
+			PackageName: "api",
+			ServiceName: info.StructName + "Api",
+		}
+	case ast.TypePackageServiceEnter:
+		path = filepath.Join(server, "service", "enter.go")
+		injection = &ast.PackageEnter{
+			Type:              ast.TypePackageServiceEnter,
+			Path:              path,
+			ImportPath:        fmt.Sprintf(`"%s/service/%s"`, module, pkg),
+			StructName:        utils.FirstUpper(pkg) + "ServiceGroup",
+			PackageName:       pkg,
+			PackageStructName: "ServiceGroup",
+		}
+	case ast.TypePackageServiceModuleEnter:
+		path = filepath.Join(server, "service", pkg, "enter.go")
+		injection = &ast.PackageModuleEnter{
+			Type:       ast.TypePackageServiceModuleEnter,
+			Path:       path,
+			StructName: info.StructName + "Service",
+		}
+	case ast.TypePackageInitializeRouter:
+		path = filepath.Join(server, "initialize", "router_biz.go")
+		injection = &ast.PackageInitializeRouter{
+			Type:                 ast.TypePackageInitializeRouter,
+			Path:                 path,
+			ImportPath:           fmt.Sprintf(`"%s/router"`, module),
+			AppName:              "RouterGroupApp",
+			GroupName:            utils.FirstUpper(pkg),
+			ModuleName:           pkg + "Router",
+			PackageName:          "router",
+			FunctionName:         "Init" + info.StructName + "Router",
+			LeftRouterGroupName:  "privateGroup",
+			RightRouterGroupName: "publicGroup",
+		}
+	case ast.TypePackageInitializeGorm:
+		path = filepath.Join(server, "initialize", "gorm_biz.go")
+		injection = &ast.PackageInitializeGorm{
+			Type:        ast.TypePackageInitializeGorm,
+			Path:        path,
+			ImportPath:  fmt.Sprintf(`"%s/model/%s"`, module, pkg),
+			Business:    info.BusinessDB,
+			StructName:  info.StructName,
+			PackageName: pkg,
+			IsNew:       true,
+		}
+	case ast.TypePluginApiEnter:
+		path = filepath.Join(server, "plugin", pkg, "api", "enter.go")
+		injection = &ast.PluginEnter{
+			Type:            ast.TypePluginApiEnter,
+			Path:            path,
+			ImportPath:      fmt.Sprintf(`"%s/plugin/%s/service"`, module, pkg),
+			StructName:      info.StructName,
+			StructCamelName: info.Abbreviation,
+			ModuleName:      "service" + info.StructName,
+			GroupName:       "Service",
+			PackageName:     "service",
+			ServiceName:     info.StructName,
+		}
+	case ast.TypePluginRouterEnter:
+		path = filepath.Join(server, "plugin", pkg, "router", "enter.go")
+		injection = &ast.PluginEnter{
+			Type:            ast.TypePluginRouterEnter,
+			Path:            path,
+			ImportPath:      fmt.Sprintf(`"%s/plugin/%s/api"`, module, pkg),
+			StructName:      info.StructName,
+			StructCamelName: info.Abbreviation,
+			ModuleName:      "api" + info.StructName,
+			GroupName:       "Api",
+			PackageName:     "api",
+			ServiceName:     info.StructName,
+		}
+	case ast.TypePluginServiceEnter:
+		path = filepath.Join(server, "plugin", pkg, "service", "enter.go")
+		injection = &ast.PluginEnter{
+			Type:            ast.TypePluginServiceEnter,
+			Path:            path,
+			StructName:      info.StructName,
+			StructCamelName: info.Abbreviation,
+		}
+	case ast.TypePluginGen:
+		path = filepath.Join(server, "plugin", pkg, "gen", "gen.go")
+		injection = &ast.PluginGen{
+			Type:        ast.TypePluginGen,
+			Path:        path,
+			ImportPath:  fmt.Sprintf(`"%s/plugin/%s/model"`, module, pkg),
+			StructName:  info.StructName,
+			PackageName: "model",
+			IsNew:       true,
+		}
+	case ast.TypePluginInitializeGorm:
+		path = filepath.Join(server, "plugin", pkg, "initialize", "gorm.go")
+		injection = &ast.PluginInitializeGorm{
+			Type:        ast.TypePluginInitializeGorm,
+			Path:        path,
+			ImportPath:  fmt.Sprintf(`"%s/plugin/%s/model"`, module, pkg),
+			StructName:  info.StructName,
+			PackageName: "model",
+			IsNew:       true,
+		}
+	case ast.TypePluginInitializeRouter:
+		path = filepath.Join(server, "plugin", pkg, "initialize", "router.go")
+		injection = &ast.PluginInitializeRouter{
+			Type:                 ast.TypePluginInitializeRouter,
+			Path:                 path,
+			ImportPath:           fmt.Sprintf(`"%s/plugin/%s/router"`, module, pkg),
+			AppName:              "Router",
+			GroupName:            info.StructName,
+			PackageName:          "router",
+			FunctionName:         "Init",
+			LeftRouterGroupName:  "public",
+			RightRouterGroupName: "private",
+		}
+	}
+	return injection, path
+}
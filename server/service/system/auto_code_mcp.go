@@ -12,8 +12,8 @@ import (
 )
 
 func (s *autoCodeTemplate) CreateMcp(ctx context.Context, info request.AutoMcpTool) (toolFilePath string, err error) {
-	mcpTemplatePath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "resource", "mcp", "tools.tpl")
-	mcpToolPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "mcp")
+	mcpTemplatePath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "resource", "mcp", "tools.tpl")
+	mcpToolPath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "mcp")
 
 	var files *template.Template
 
@@ -0,0 +1,173 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"server/global"
+	model "server/model/system"
+	"server/model/system/request"
+	"server/utils/autocode"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var AutoCodeMigration = new(autoCodeMigration)
+
+type autoCodeMigration struct{}
+
+// Build 比对生成记录中的字段与本次字段, 生成各方言的迁移SQL, 无字段变更时返回 nil
+// 变更包含删除列且未确认时返回错误, 避免误改列名等操作生成删除数据的迁移
+func (s *autoCodeMigration) Build(history model.SysAutoCodeHistory, info request.AutoCode, confirmDrop bool) (*model.SysAutoCodeMigration, error) {
+	var previous request.AutoCode
+	err := json.Unmarshal([]byte(history.Request), &previous)
+	if err != nil {
+		return nil, errors.Wrap(err, "解析生成记录失败!")
+	}
+	changes := autocode.DiffFields(previous.Fields, info.Fields)
+	if len(changes) == 0 || info.TableName == "" {
+		return nil, nil
+	}
+	if drops := autocode.Drops(changes); len(drops) > 0 && !confirmDrop {
+		return nil, errors.Errorf("本次变更将删除列 %s, 修改列名请填写 renameFrom, 确认删除请设置 confirmDrop!", strings.Join(drops, ", "))
+	}
+	bytes, _ := json.Marshal(changes)
+	migration := &model.SysAutoCodeMigration{
+		Version:    fmt.Sprintf("%s_%s", time.Now().Format("20060102150405"), info.TableName),
+		HistoryID:  history.ID,
+		Table:      info.TableName,
+		BusinessDB: info.BusinessDB,
+		Changes:    string(bytes),
+		Up:         make(map[string]string, len(autocode.Dialects)),
+		Down:       make(map[string]string, len(autocode.Dialects)),
+	}
+	for _, dialect := range autocode.Dialects {
+		// 方言不支持本次变更时不保存该方言的SQL, 执行时报错而不是记为已执行
+		up, down, err := autocode.MigrationSQL(dialect, info.TableName, changes)
+		if err != nil {
+			continue
+		}
+		migration.Up[dialect], migration.Down[dialect] = up, down
+	}
+	return migration, nil
+}
+
+// Create 保存迁移, 版本号由唯一索引保证不重复, 同一秒内多次生成同一张表的迁移时在版本号后追加序号重试
+func (s *autoCodeMigration) Create(ctx context.Context, migration *model.SysAutoCodeMigration) error {
+	db := global.GVA_DB.WithContext(ctx)
+	base := migration.Version
+	for seq := 2; ; seq++ {
+		err := db.Create(migration).Error
+		if err == nil {
+			return nil
+		}
+		var count int64
+		if db.Unscoped().Model(&model.SysAutoCodeMigration{}).Where("version = ?", migration.Version).Count(&count); count == 0 || seq > 99 {
+			return errors.Wrap(err, "保存迁移失败!")
+		}
+		migration.Version = fmt.Sprintf("%s_%02d", base, seq)
+	}
+}
+
+// GetList 分页获取迁移, 并标记在业务库中是否已执行
+func (s *autoCodeMigration) GetList(ctx context.Context, info request.SysAutoCodeMigrationSearch) (list []model.SysAutoCodeMigration, total int64, err error) {
+	db := global.GVA_DB.WithContext(ctx).Model(&model.SysAutoCodeMigration{})
+	if info.HistoryID != 0 {
+		db = db.Where("history_id = ?", info.HistoryID)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err = db.Scopes(info.Paginate()).Order("version desc").Find(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range list {
+		target, _, err := s.target(ctx, list[i].BusinessDB)
+		if err != nil || !target.Migrator().HasTable(&model.SysSchemaMigration{}) {
+			continue
+		}
+		var applied model.SysSchemaMigration
+		if target.WithContext(ctx).Where("version = ?", list[i].Version).Limit(1).Find(&applied).RowsAffected > 0 {
+			list[i].Applied, list[i].AppliedAt = true, &applied.AppliedAt
+		}
+	}
+	return list, total, nil
+}
+
+// Apply 在业务库中执行迁移并记录到 schema_migrations
+func (s *autoCodeMigration) Apply(ctx context.Context, version string) error {
+	return s.run(ctx, version, true)
+}
+
+// Rollback 在业务库中执行回滚SQL并从 schema_migrations 中移除
+func (s *autoCodeMigration) Rollback(ctx context.Context, version string) error {
+	return s.run(ctx, version, false)
+}
+
+func (s *autoCodeMigration) run(ctx context.Context, version string, up bool) error {
+	var migration model.SysAutoCodeMigration
+	err := global.GVA_DB.WithContext(ctx).Where("version = ?", version).First(&migration).Error
+	if err != nil {
+		return errors.Wrap(err, "查询迁移失败!")
+	}
+	target, dialect, err := s.target(ctx, migration.BusinessDB)
+	if err != nil {
+		return err
+	}
+	target = target.WithContext(ctx)
+	if err = target.AutoMigrate(&model.SysSchemaMigration{}); err != nil {
+		return errors.Wrap(err, "创建schema_migrations表失败!")
+	}
+	var count int64
+	if err = target.Model(&model.SysSchemaMigration{}).Where("version = ?", version).Count(&count).Error; err != nil {
+		return err
+	}
+	sql := migration.Down[dialect]
+	if up {
+		sql = migration.Up[dialect]
+	}
+	switch {
+	case up && count > 0:
+		return errors.New("该迁移已执行!")
+	case !up && count == 0:
+		return errors.New("该迁移尚未执行!")
+	case sql == "":
+		return errors.Errorf("该迁移缺少%s方言的SQL, 该方言可能不支持本次字段变更, 需手动迁移!", dialect)
+	}
+	return target.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range autocode.SplitStatements(sql) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return errors.Wrapf(err, "执行[%s]失败!", statement)
+			}
+		}
+		if up {
+			return tx.Create(&model.SysSchemaMigration{Version: version, AppliedAt: time.Now()}).Error
+		}
+		return tx.Where("version = ?", version).Delete(&model.SysSchemaMigration{}).Error
+	})
+}
+
+// target 获取迁移作用的业务库及其方言
+func (s *autoCodeMigration) target(ctx context.Context, businessDB string) (*gorm.DB, string, error) {
+	db := global.GVA_DB
+	if businessDB != "" {
+		db = global.GetGlobalDBByDBName(businessDB)
+	}
+	if db == nil {
+		return nil, "", errors.Errorf("业务库[%s]未初始化!", businessDB)
+	}
+	db = db.WithContext(ctx)
+	switch name := db.Dialector.Name(); name {
+	case "postgres":
+		return db, "pgsql", nil
+	case "sqlserver":
+		return db, "mssql", nil
+	default:
+		return db, name, nil
+	}
+}
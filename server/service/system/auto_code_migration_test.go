@@ -0,0 +1,100 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"server/global"
+	model "server/model/system"
+	"server/model/system/request"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAutoCodeMigration_ApplyRollback(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&model.SysAutoCodeMigration{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Exec("CREATE TABLE demo (id integer primary key, name text)").Error; err != nil {
+		t.Fatal(err)
+	}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+
+	previous := request.AutoCode{TableName: "demo", Fields: []*request.AutoCodeField{{FieldName: "Name", ColumnName: "name", FieldType: "string"}}}
+	bytes, _ := json.Marshal(previous)
+	history := model.SysAutoCodeHistory{Request: string(bytes)}
+	current := previous
+	current.Fields = append(current.Fields, &request.AutoCodeField{FieldName: "Email", ColumnName: "email", FieldType: "string"})
+
+	migration, err := AutoCodeMigration.Build(history, current, false)
+	if err != nil || migration == nil {
+		t.Fatalf("Build() = %v, %v", migration, err)
+	}
+	ctx := context.Background()
+	if err = AutoCodeMigration.Create(ctx, migration); err != nil {
+		t.Fatal(err)
+	}
+	if err = AutoCodeMigration.Apply(ctx, migration.Version); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !db.Migrator().HasColumn("demo", "email") {
+		t.Fatal("Apply() 后缺少 email 列")
+	}
+	if err = AutoCodeMigration.Apply(ctx, migration.Version); err == nil {
+		t.Error("重复执行应返回错误")
+	}
+	list, _, err := AutoCodeMigration.GetList(ctx, request.SysAutoCodeMigrationSearch{})
+	if err != nil || len(list) != 1 || !list[0].Applied {
+		t.Errorf("GetList() = %+v, %v", list, err)
+	}
+	if err = AutoCodeMigration.Rollback(ctx, migration.Version); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if db.Migrator().HasColumn("demo", "email") {
+		t.Error("Rollback() 后 email 列仍存在")
+	}
+
+	// 同一秒内再次生成同一张表的迁移, 版本号追加序号
+	again, _ := AutoCodeMigration.Build(history, current, false)
+	again.Version = migration.Version
+	if err = AutoCodeMigration.Create(ctx, again); err != nil {
+		t.Fatalf("重复版本号应追加序号, Create() error = %v", err)
+	}
+	if again.Version != migration.Version+"_02" {
+		t.Errorf("Version = %s", again.Version)
+	}
+
+	// 删除列需确认
+	dropped := previous
+	dropped.Fields = nil
+	if _, err = AutoCodeMigration.Build(history, dropped, false); err == nil {
+		t.Error("未确认删除列时 Build() 应返回错误")
+	}
+	if _, err = AutoCodeMigration.Build(history, dropped, true); err != nil {
+		t.Errorf("确认删除列后 Build() error = %v", err)
+	}
+
+	// sqlite 不支持修改列类型, 执行时报错且不记为已执行
+	altered := previous
+	altered.Fields = []*request.AutoCodeField{{FieldName: "Name", ColumnName: "name", FieldType: "int"}}
+	migration, _ = AutoCodeMigration.Build(history, altered, false)
+	if err = AutoCodeMigration.Create(ctx, migration); err != nil {
+		t.Fatal(err)
+	}
+	if err = AutoCodeMigration.Apply(ctx, migration.Version); err == nil {
+		t.Error("sqlite 修改列类型应执行失败")
+	}
+	if list, _, _ = AutoCodeMigration.GetList(ctx, request.SysAutoCodeMigrationSearch{}); list[0].Applied {
+		t.Error("执行失败的迁移不应记为已执行")
+	}
+}
@@ -7,7 +7,6 @@ import (
 	common "server/model/common/request"
 	model "server/model/system"
 	"server/model/system/request"
-	"server/utils"
 	"server/utils/ast"
 	"server/utils/autocode"
 	"github.com/pkg/errors"
@@ -15,6 +14,7 @@ import (
 	"gorm.io/gorm"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 )
@@ -43,7 +43,7 @@ func (s *autoCodePackage) Create(ctx context.Context, info *request.SysAutoCodeP
 	default:
 		break
 	}
-	if !errors.Is(global.GVA_DB.Where("package_name = ? and template = ?", info.PackageName, info.Template).First(&model.SysAutoCodePackage{}).Error, gorm.ErrRecordNotFound) {
+	if !errors.Is(global.GVA_DB.WithContext(ctx).Where("package_name = ? and template = ?", info.PackageName, info.Template).First(&model.SysAutoCodePackage{}).Error, gorm.ErrRecordNotFound) {
 		return errors.New("存在相同PackageName")
 	}
 	create := info.Create()
@@ -57,6 +57,25 @@ func (s *autoCodePackage) Create(ctx context.Context, info *request.SysAutoCodeP
 		if err != nil {
 			return err
 		}
+		keys := make([]string, 0, len(asts))
+		for key := range asts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		injections := make([]ast.Ast, 0, len(keys))
+		for _, key := range keys {
+			values := strings.Split(key, "=>")
+			if len(values) == 2 {
+				switch values[1] {
+				case ast.TypePluginInitializeV2, ast.TypePackageApiEnter, ast.TypePackageRouterEnter, ast.TypePackageServiceEnter:
+					injections = append(injections, asts[key])
+				}
+			}
+		}
+		err = ast.Conflicts(ast.NewInjectionPlan(injections...).Check())
+		if err != nil {
+			return err
+		} // 预检注入, 入口文件缺失或无法解析时不生成任何文件
 		for key, value := range creates { // key 为 模版绝对路径
 			var files *template.Template
 			files, err = template.New(filepath.Base(key)).Funcs(autocode.GetTemplateFuncMap()).ParseFiles(key)
@@ -79,25 +98,12 @@ func (s *autoCodePackage) Create(ctx context.Context, info *request.SysAutoCodeP
 			}
 			fmt.Printf("[template:%s][filepath:%s]生成成功!\n", key, value)
 		}
-		for key, value := range asts {
-			keys := strings.Split(key, "=>")
-			if len(keys) == 2 {
-				switch keys[1] {
-				case ast.TypePluginInitializeV2, ast.TypePackageApiEnter, ast.TypePackageRouterEnter, ast.TypePackageServiceEnter:
-					file, _ := value.Parse("", nil)
-					if file != nil {
-						err = value.Injection(file)
-						if err != nil {
-							return err
-						}
-						err = value.Format("", nil, file)
-						if err != nil {
-							return err
-						}
-					}
-					fmt.Printf("[type:%s]注入成功!\n", key)
-				}
-			}
+		_, err = ast.NewInjectionPlan(injections...).Apply()
+		if err != nil {
+			return err
+		}
+		for _, value := range injections {
+			fmt.Printf("[filepath:%s]注入成功!\n", value.Filepath())
 		}
 		return nil
 	})
@@ -134,8 +140,8 @@ func (s *autoCodePackage) DeleteByNames(ctx context.Context, names []string) err
 func (s *autoCodePackage) All(ctx context.Context) (entities []model.SysAutoCodePackage, err error) {
 	server := make([]model.SysAutoCodePackage, 0)
 	plugin := make([]model.SysAutoCodePackage, 0)
-	serverPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service")
-	pluginPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin")
+	serverPath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service")
+	pluginPath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin")
 	serverDir, err := os.ReadDir(serverPath)
 	if err != nil {
 		return nil, errors.Wrap(err, "读取service文件夹失败!")
@@ -151,7 +157,7 @@ func (s *autoCodePackage) All(ctx context.Context) (entities []model.SysAutoCode
 				Template:    "package",
 				Label:       serverDir[i].Name() + "包",
 				Desc:        "系统自动读取" + serverDir[i].Name() + "包",
-				Module:      global.GVA_CONFIG.AutoCode.Module,
+				Module:      global.GVA_CONFIG.Load().AutoCode.Module,
 			}
 			server = append(server, serverPackage)
 		}
@@ -187,7 +193,7 @@ func (s *autoCodePackage) All(ctx context.Context) (entities []model.SysAutoCode
 				Template:    "plugin",
 				Label:       pluginDir[i].Name() + "插件",
 				Desc:        "系统自动读取" + pluginDir[i].Name() + "插件，使用前请确认是否为v2版本插件",
-				Module:      global.GVA_CONFIG.AutoCode.Module,
+				Module:      global.GVA_CONFIG.Load().AutoCode.Module,
 			}
 			plugin = append(plugin, pluginPackage)
 		}
@@ -260,7 +266,16 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 	code = make(map[string]string)
 	asts = make(map[string]ast.Ast)
 	creates = make(map[string]string)
-	templateDir := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "resource", entity.Template)
+	// inject 添加指定类型的注入, 返回最后一个注入的文件路径
+	inject := func(types ...string) (path string) {
+		for _, typ := range types {
+			var injection ast.Ast
+			injection, path = autoCodeInjection(typ, entity, info)
+			asts[path+"=>"+typ] = injection
+		}
+		return path
+	}
+	templateDir := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "resource", entity.Template)
 	templateDirs, err := os.ReadDir(templateDir)
 	if err != nil {
 		return nil, nil, nil, errors.Wrapf(err, "读取模版文件夹[%s]失败!", templateDir)
@@ -291,9 +306,9 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 					if name == "main.go" || name == "plugin.go" {
 						pluginInitialize := &ast.PluginInitializeV2{
 							Type:        ast.TypePluginInitializeV2,
-							Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, name),
-							PluginPath:  filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "plugin_biz_v2.go"),
-							ImportPath:  fmt.Sprintf(`"%s/plugin/%s"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName),
+							Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", entity.PackageName, name),
+							PluginPath:  filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "plugin_biz_v2.go"),
+							ImportPath:  fmt.Sprintf(`"%s/plugin/%s"`, global.GVA_CONFIG.Load().AutoCode.Module, entity.PackageName),
 							PackageName: entity.PackageName,
 						}
 						asts[pluginInitialize.PluginPath+"=>"+pluginInitialize.Type.String()] = pluginInitialize
@@ -329,94 +344,27 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 							return nil, nil, nil, errors.Errorf("[filpath:%s]非法模版文件!", four)
 						}
 						if entity.Template == "package" {
-							create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, info.HumpPackageName+".go")
+							name := info.HumpPackageName + ".go"
+							if strings.HasSuffix(threeDirs[k].Name(), "_test.go.tpl") {
+								name = info.HumpPackageName + "_test.go" // 测试模版生成同名的 _test.go 文件
+							}
+							create := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, secondDirs[j].Name(), entity.PackageName, name)
 							if api != -1 {
-								create = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), "v1", entity.PackageName, info.HumpPackageName+".go")
+								create = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, secondDirs[j].Name(), "v1", entity.PackageName, name)
 							}
 							if hasEnter != -1 {
 								isApi := strings.Index(secondDirs[j].Name(), "api")
 								isRouter := strings.Index(secondDirs[j].Name(), "router")
 								isService := strings.Index(secondDirs[j].Name(), "service")
 								if isApi != -1 {
-									packageApiEnter := &ast.PackageEnter{
-										Type:              ast.TypePackageApiEnter,
-										Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), "v1", "enter.go"),
-										ImportPath:        fmt.Sprintf(`"%s/%s/%s/%s"`, global.GVA_CONFIG.AutoCode.Module, "api", "v1", entity.PackageName),
-										StructName:        utils.FirstUpper(entity.PackageName) + "ApiGroup",
-										PackageName:       entity.PackageName,
-										PackageStructName: "ApiGroup",
-									}
-									asts[packageApiEnter.Path+"=>"+packageApiEnter.Type.String()] = packageApiEnter
-									packageApiModuleEnter := &ast.PackageModuleEnter{
-										Type:        ast.TypePackageApiModuleEnter,
-										Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), "v1", entity.PackageName, "enter.go"),
-										ImportPath:  fmt.Sprintf(`"%s/service"`, global.GVA_CONFIG.AutoCode.Module),
-										StructName:  info.StructName + "Api",
-										AppName:     "ServiceGroupApp",
-										GroupName:   utils.FirstUpper(entity.PackageName) + "ServiceGroup",
-										ModuleName:  info.Abbreviation + "Service",
-										PackageName: "service",
-										ServiceName: info.StructName + "Service",
-									}
-									asts[packageApiModuleEnter.Path+"=>"+packageApiModuleEnter.Type.String()] = packageApiModuleEnter
-									creates[four] = packageApiModuleEnter.Path
+									creates[four] = inject(ast.TypePackageApiEnter, ast.TypePackageApiModuleEnter)
 								}
 								if isRouter != -1 {
-									packageRouterEnter := &ast.PackageEnter{
-										Type:              ast.TypePackageRouterEnter,
-										Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), "enter.go"),
-										ImportPath:        fmt.Sprintf(`"%s/%s/%s"`, global.GVA_CONFIG.AutoCode.Module, secondDirs[j].Name(), entity.PackageName),
-										StructName:        utils.FirstUpper(entity.PackageName),
-										PackageName:       entity.PackageName,
-										PackageStructName: "RouterGroup",
-									}
-									asts[packageRouterEnter.Path+"=>"+packageRouterEnter.Type.String()] = packageRouterEnter
-									packageRouterModuleEnter := &ast.PackageModuleEnter{
-										Type:        ast.TypePackageRouterModuleEnter,
-										Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, "enter.go"),
-										ImportPath:  fmt.Sprintf(`api "%s/api/v1"`, global.GVA_CONFIG.AutoCode.Module),
-										StructName:  info.StructName + "Router",
-										AppName:     "ApiGroupApp",
-										GroupName:   utils.FirstUpper(entity.PackageName) + "ApiGroup",
-										ModuleName:  info.Abbreviation + "Api",
-										PackageName: "api",
-										ServiceName: info.StructName + "Api",
-									}
-									creates[four] = packageRouterModuleEnter.Path
-									asts[packageRouterModuleEnter.Path+"=>"+packageRouterModuleEnter.Type.String()] = packageRouterModuleEnter
-									packageInitializeRouter := &ast.PackageInitializeRouter{
-										Type:                 ast.TypePackageInitializeRouter,
-										Path:                 filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "router_biz.go"),
-										ImportPath:           fmt.Sprintf(`"%s/router"`, global.GVA_CONFIG.AutoCode.Module),
-										AppName:              "RouterGroupApp",
-										GroupName:            utils.FirstUpper(entity.PackageName),
-										ModuleName:           entity.PackageName + "Router",
-										PackageName:          "router",
-										FunctionName:         "Init" + info.StructName + "Router",
-										LeftRouterGroupName:  "privateGroup",
-										RightRouterGroupName: "publicGroup",
-									}
-									asts[packageInitializeRouter.Path+"=>"+packageInitializeRouter.Type.String()] = packageInitializeRouter
+									creates[four] = inject(ast.TypePackageRouterEnter, ast.TypePackageRouterModuleEnter)
+									inject(ast.TypePackageInitializeRouter)
 								}
 								if isService != -1 {
-									path := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext))
-									importPath := fmt.Sprintf(`"%s/service/%s"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName)
-									packageServiceEnter := &ast.PackageEnter{
-										Type:              ast.TypePackageServiceEnter,
-										Path:              path,
-										ImportPath:        importPath,
-										StructName:        utils.FirstUpper(entity.PackageName) + "ServiceGroup",
-										PackageName:       entity.PackageName,
-										PackageStructName: "ServiceGroup",
-									}
-									asts[packageServiceEnter.Path+"=>"+packageServiceEnter.Type.String()] = packageServiceEnter
-									packageServiceModuleEnter := &ast.PackageModuleEnter{
-										Type:       ast.TypePackageServiceModuleEnter,
-										Path:       filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, "enter.go"),
-										StructName: info.StructName + "Service",
-									}
-									asts[packageServiceModuleEnter.Path+"=>"+packageServiceModuleEnter.Type.String()] = packageServiceModuleEnter
-									creates[four] = packageServiceModuleEnter.Path
+									creates[four] = inject(ast.TypePackageServiceEnter, ast.TypePackageServiceModuleEnter)
 								}
 								continue
 							}
@@ -428,48 +376,17 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 							isRouter := strings.Index(secondDirs[j].Name(), "router")
 							isService := strings.Index(secondDirs[j].Name(), "service")
 							if isRouter != -1 {
-								pluginRouterEnter := &ast.PluginEnter{
-									Type:            ast.TypePluginRouterEnter,
-									Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext)),
-									ImportPath:      fmt.Sprintf(`"%s/plugin/%s/api"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName),
-									StructName:      info.StructName,
-									StructCamelName: info.Abbreviation,
-									ModuleName:      "api" + info.StructName,
-									GroupName:       "Api",
-									PackageName:     "api",
-									ServiceName:     info.StructName,
-								}
-								asts[pluginRouterEnter.Path+"=>"+pluginRouterEnter.Type.String()] = pluginRouterEnter
-								creates[four] = pluginRouterEnter.Path
+								creates[four] = inject(ast.TypePluginRouterEnter)
 							}
 							if isApi != -1 {
-								pluginApiEnter := &ast.PluginEnter{
-									Type:            ast.TypePluginApiEnter,
-									Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext)),
-									ImportPath:      fmt.Sprintf(`"%s/plugin/%s/service"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName),
-									StructName:      info.StructName,
-									StructCamelName: info.Abbreviation,
-									ModuleName:      "service" + info.StructName,
-									GroupName:       "Service",
-									PackageName:     "service",
-									ServiceName:     info.StructName,
-								}
-								asts[pluginApiEnter.Path+"=>"+pluginApiEnter.Type.String()] = pluginApiEnter
-								creates[four] = pluginApiEnter.Path
+								creates[four] = inject(ast.TypePluginApiEnter)
 							}
 							if isService != -1 {
-								pluginServiceEnter := &ast.PluginEnter{
-									Type:            ast.TypePluginServiceEnter,
-									Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext)),
-									StructName:      info.StructName,
-									StructCamelName: info.Abbreviation,
-								}
-								asts[pluginServiceEnter.Path+"=>"+pluginServiceEnter.Type.String()] = pluginServiceEnter
-								creates[four] = pluginServiceEnter.Path
+								creates[four] = inject(ast.TypePluginServiceEnter)
 							}
 							continue
 						} // enter.go
-						create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), info.HumpPackageName+".go")
+						create := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), info.HumpPackageName+".go")
 						code[four] = create
 					}
 				case "gen", "config", "initialize", "plugin", "response":
@@ -506,46 +423,16 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 							return nil, nil, nil, errors.Errorf("[filpath:%s]非法模版文件!", four)
 						}
 						if api != -1 || menu != -1 || viper != -1 || response != -1 || plugin != -1 || config != -1 {
-							creates[four] = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext))
+							creates[four] = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext))
 						}
 						if gen != -1 {
-							pluginGen := &ast.PluginGen{
-								Type:        ast.TypePluginGen,
-								Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext)),
-								ImportPath:  fmt.Sprintf(`"%s/plugin/%s/model"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName),
-								StructName:  info.StructName,
-								PackageName: "model",
-								IsNew:       true,
-							}
-							asts[pluginGen.Path+"=>"+pluginGen.Type.String()] = pluginGen
-							creates[four] = pluginGen.Path
+							creates[four] = inject(ast.TypePluginGen)
 						}
 						if hasGorm != -1 {
-							pluginInitializeGorm := &ast.PluginInitializeGorm{
-								Type:        ast.TypePluginInitializeGorm,
-								Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext)),
-								ImportPath:  fmt.Sprintf(`"%s/plugin/%s/model"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName),
-								StructName:  info.StructName,
-								PackageName: "model",
-								IsNew:       true,
-							}
-							asts[pluginInitializeGorm.Path+"=>"+pluginInitializeGorm.Type.String()] = pluginInitializeGorm
-							creates[four] = pluginInitializeGorm.Path
+							creates[four] = inject(ast.TypePluginInitializeGorm)
 						}
 						if router != -1 {
-							pluginInitializeRouter := &ast.PluginInitializeRouter{
-								Type:                 ast.TypePluginInitializeRouter,
-								Path:                 filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), strings.TrimSuffix(threeDirs[k].Name(), ext)),
-								ImportPath:           fmt.Sprintf(`"%s/plugin/%s/router"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName),
-								AppName:              "Router",
-								GroupName:            info.StructName,
-								PackageName:          "router",
-								FunctionName:         "Init",
-								LeftRouterGroupName:  "public",
-								RightRouterGroupName: "private",
-							}
-							asts[pluginInitializeRouter.Path+"=>"+pluginInitializeRouter.Type.String()] = pluginInitializeRouter
-							creates[four] = pluginInitializeRouter.Path
+							creates[four] = inject(ast.TypePluginInitializeRouter)
 						}
 					}
 				case "model":
@@ -581,9 +468,9 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 								if hasRequest == -1 {
 									return nil, nil, nil, errors.Errorf("[filpath:%s]非法模版文件!", five)
 								}
-								create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), threeDirs[k].Name(), info.HumpPackageName+".go")
+								create := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), threeDirs[k].Name(), info.HumpPackageName+".go")
 								if entity.Template == "package" {
-									create = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, threeDirs[k].Name(), info.HumpPackageName+".go")
+									create = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, secondDirs[j].Name(), entity.PackageName, threeDirs[k].Name(), info.HumpPackageName+".go")
 								}
 								code[five] = create
 							}
@@ -597,20 +484,10 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 						if hasModel == -1 {
 							return nil, nil, nil, errors.Errorf("[filpath:%s]非法模版文件!", four)
 						}
-						create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), info.HumpPackageName+".go")
+						create := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", entity.PackageName, secondDirs[j].Name(), info.HumpPackageName+".go")
 						if entity.Template == "package" {
-							packageInitializeGorm := &ast.PackageInitializeGorm{
-								Type:        ast.TypePackageInitializeGorm,
-								Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
-								ImportPath:  fmt.Sprintf(`"%s/model/%s"`, global.GVA_CONFIG.AutoCode.Module, entity.PackageName),
-								Business:    info.BusinessDB,
-								StructName:  info.StructName,
-								PackageName: entity.PackageName,
-								IsNew:       true,
-							}
-							code[four] = packageInitializeGorm.Path
-							asts[packageInitializeGorm.Path+"=>"+packageInitializeGorm.Type.String()] = packageInitializeGorm
-							create = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, info.HumpPackageName+".go")
+							inject(ast.TypePackageInitializeGorm)
+							create = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, secondDirs[j].Name(), entity.PackageName, info.HumpPackageName+".go")
 						}
 						code[four] = create
 					}
@@ -666,18 +543,18 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 								formPath := filepath.Join(three, "form.vue"+ext)
 								value, ok := code[formPath]
 								if ok {
-									value = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.WebRoot(), secondDirs[j].Name(), entity.PackageName, info.PackageName, info.PackageName+"Form"+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
+									value = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.WebRoot(), secondDirs[j].Name(), entity.PackageName, info.PackageName, info.PackageName+"Form"+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
 									code[formPath] = value
 								}
 							}
-							create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.WebRoot(), secondDirs[j].Name(), entity.PackageName, info.PackageName, info.PackageName+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
+							create := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.WebRoot(), secondDirs[j].Name(), entity.PackageName, info.PackageName, info.PackageName+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
 							if api != -1 {
-								create = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.WebRoot(), secondDirs[j].Name(), entity.PackageName, info.PackageName+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
+								create = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.WebRoot(), secondDirs[j].Name(), entity.PackageName, info.PackageName+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
 							}
 							code[four] = create
 							continue
 						}
-						create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.WebRoot(), "plugin", entity.PackageName, secondDirs[j].Name(), info.PackageName+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
+						create := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.WebRoot(), "plugin", entity.PackageName, secondDirs[j].Name(), info.PackageName+filepath.Ext(strings.TrimSuffix(threeDirs[k].Name(), ext)))
 						code[four] = create
 					}
 				default:
@@ -11,12 +11,15 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
 	"server/global"
 	model "server/model/system"
 	"server/model/system/request"
+	"server/model/system/response"
+	"server/utils"
 	utilsAst "server/utils/ast"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
@@ -29,23 +32,23 @@ type autoCodeTemplate struct{}
 func (s *autoCodeTemplate) checkPackage(Pkg string, template string) (err error) {
 	switch template {
 	case "package":
-		apiEnter := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "api", "v1", Pkg, "enter.go")
+		apiEnter := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "api", "v1", Pkg, "enter.go")
 		_, err = os.Stat(apiEnter)
 		if err != nil {
 			return fmt.Errorf("package结构异常,缺少api/v1/%s/enter.go", Pkg)
 		}
-		serviceEnter := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service", Pkg, "enter.go")
+		serviceEnter := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service", Pkg, "enter.go")
 		_, err = os.Stat(serviceEnter)
 		if err != nil {
 			return fmt.Errorf("package结构异常,缺少service/%s/enter.go", Pkg)
 		}
-		routerEnter := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "router", Pkg, "enter.go")
+		routerEnter := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "router", Pkg, "enter.go")
 		_, err = os.Stat(routerEnter)
 		if err != nil {
 			return fmt.Errorf("package结构异常,缺少router/%s/enter.go", Pkg)
 		}
 	case "plugin":
-		pluginEnter := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", Pkg, "plugin.go")
+		pluginEnter := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", Pkg, "plugin.go")
 		_, err = os.Stat(pluginEnter)
 		if err != nil {
 			return fmt.Errorf("plugin结构异常,缺少plugin/%s/plugin.go", Pkg)
@@ -67,7 +70,7 @@ func (s *autoCodeTemplate) Create(ctx context.Context, info request.AutoCode) er
 		return err
 	}
 	// 增加判断: 重复创建struct 或者重复的简称
-	if AutocodeHistory.Repeat(info.BusinessDB, info.StructName, info.Abbreviation, info.Package) {
+	if AutocodeHistory.Repeat(ctx, info.BusinessDB, info.StructName, info.Abbreviation, info.Package) {
 		return errors.New("已经创建过此数据结构,请勿重复创建!")
 	}
 
@@ -75,15 +78,19 @@ func (s *autoCodeTemplate) Create(ctx context.Context, info request.AutoCode) er
 	if err != nil {
 		return err
 	}
-	for key, builder := range generate {
-		err = os.MkdirAll(filepath.Dir(key), os.ModePerm)
-		if err != nil {
-			return errors.Wrapf(err, "[filepath:%s]创建文件夹失败!", key)
-		}
-		err = os.WriteFile(key, []byte(builder.String()), 0666)
-		if err != nil {
-			return errors.Wrapf(err, "[filepath:%s]写入文件失败!", key)
+	history.Generated = make(map[string]string, len(templates))
+	for _, create := range templates {
+		if builder, ok := generate[create]; ok {
+			history.Generated[s.relative(create)] = builder.String()
 		}
+	} // 保存原始生成代码, 重新生成时作为三方合并的共同祖先
+	files := make(map[string][]byte, len(generate))
+	for key, builder := range generate {
+		files[key] = []byte(builder.String())
+	}
+	err = utils.WriteFiles(files) // 生成的文件与注入的文件一并写入, 任一失败时全部恢复
+	if err != nil {
+		return err
 	}
 
 	// 自动创建api
@@ -164,7 +171,7 @@ func (s *autoCodeTemplate) Create(ctx context.Context, info request.AutoCode) er
 			TemplateID:   name,
 			TemplateInfo: string(templateInfo),
 		}
-		err = SysExportTemplateServiceApp.CreateSysExportTemplate(&sysExportTemplate)
+		err = SysExportTemplateServiceApp.CreateSysExportTemplate(ctx, &sysExportTemplate)
 		if err != nil {
 			return err
 		}
@@ -193,7 +200,7 @@ func (s *autoCodeTemplate) Preview(ctx context.Context, info request.AutoCode) (
 		return nil, errors.Wrap(err, "查询包失败!")
 	}
 	// 增加判断: 重复创建struct 或者重复的简称
-	if AutocodeHistory.Repeat(info.BusinessDB, info.StructName, info.Abbreviation, info.Package) && !info.IsAdd {
+	if AutocodeHistory.Repeat(ctx, info.BusinessDB, info.StructName, info.Abbreviation, info.Package) && !info.IsAdd {
 		return nil, errors.New("已经创建过此数据结构或重复简称,请勿重复创建!")
 	}
 
@@ -203,8 +210,8 @@ func (s *autoCodeTemplate) Preview(ctx context.Context, info request.AutoCode) (
 		return nil, err
 	}
 	for key, writer := range codes {
-		if len(key) > len(global.GVA_CONFIG.AutoCode.Root) {
-			key, _ = filepath.Rel(global.GVA_CONFIG.AutoCode.Root, key)
+		if len(key) > len(global.GVA_CONFIG.Load().AutoCode.Root) {
+			key, _ = filepath.Rel(global.GVA_CONFIG.Load().AutoCode.Root, key)
 		}
 		// 获取key的后缀 取消.
 		suffix := filepath.Ext(key)[1:]
@@ -218,62 +225,241 @@ func (s *autoCodeTemplate) Preview(ctx context.Context, info request.AutoCode) (
 }
 
 func (s *autoCodeTemplate) generate(ctx context.Context, info request.AutoCode, entity model.SysAutoCodePackage) (map[string]strings.Builder, map[string]string, map[string]utilsAst.Ast, error) {
-	templates, asts, _, err := AutoCodePackage.templates(ctx, entity, info, false)
+	templates, sources, asts, err := s.templates(ctx, entity, info)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	code := make(map[string]strings.Builder)
+	code, err := s.render(info, templates, sources)
+	if err != nil {
+		return nil, nil, nil, err
+	} // 生成文件
+	injections := make(map[string]utilsAst.Ast, len(asts))
+	values := make([]utilsAst.Ast, 0, len(asts))
+	for _, key := range s.injectionKeys(info, asts) {
+		keys := strings.Split(key, "=>")
+		injections[keys[1]] = asts[key]
+		values = append(values, asts[key])
+	}
+	changes, conflicts := utilsAst.NewInjectionPlan(values...).Diff()
+	if err = utilsAst.Conflicts(conflicts); err != nil {
+		return nil, nil, nil, err
+	}
+	for _, change := range changes {
+		var builder strings.Builder
+		builder.WriteString(change.After)
+		code[change.Filename] = builder
+	}
+	// 注入代码
+	return code, templates, injections, nil
+}
+
+// injectionKeys 需要执行的注入, 按 key 排序保证同一文件的注入顺序稳定
+func (s *autoCodeTemplate) injectionKeys(info request.AutoCode, asts map[string]utilsAst.Ast) []string {
+	keys := make([]string, 0, len(asts))
+	for key := range asts {
+		values := strings.Split(key, "=>")
+		if len(values) != 2 {
+			continue
+		}
+		switch values[1] {
+		case utilsAst.TypePluginInitializeV2:
+			continue
+		case utilsAst.TypePackageInitializeGorm, utilsAst.TypePluginInitializeGorm:
+			if info.OnlyTemplate || !info.AutoMigrate {
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Injections 预览注入: 返回每个被注入文件的 unified diff 以及预检冲突, 不写入文件
+func (s *autoCodeTemplate) Injections(ctx context.Context, info request.AutoCode) (*response.AutoCodeInjection, error) {
+	var entity model.SysAutoCodePackage
+	err := global.GVA_DB.WithContext(ctx).Where("package_name = ?", info.Package).First(&entity).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "查询包失败!")
+	}
+	_, _, asts, err := s.templates(ctx, entity, info)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]utilsAst.Ast, 0, len(asts))
+	for _, key := range s.injectionKeys(info, asts) {
+		values = append(values, asts[key])
+	}
+	changes, conflicts := utilsAst.NewInjectionPlan(values...).Diff()
+	return &response.AutoCodeInjection{Changes: changes, Conflicts: conflicts}, nil
+}
+
+// templates 指定了模板集时使用自定义模板集, 否则使用内置模板, 返回 模板 => 生成路径、模板 => 模板内容 以及注入
+func (s *autoCodeTemplate) templates(ctx context.Context, entity model.SysAutoCodePackage, info request.AutoCode) (map[string]string, map[string]string, map[string]utilsAst.Ast, error) {
+	if info.TemplateSet != "" {
+		return AutoCodeTemplateSet.templates(ctx, entity, info)
+	}
+	templates, asts, _, err := AutoCodePackage.templates(ctx, entity, info, false)
+	return templates, nil, asts, err
+}
+
+// render 按模板生成文件内容, 返回 生成路径 => 内容, sources 中没有的模板从磁盘读取
+func (s *autoCodeTemplate) render(info request.AutoCode, templates map[string]string, sources map[string]string) (map[string]strings.Builder, error) {
+	code := make(map[string]strings.Builder, len(templates))
 	for key, create := range templates {
-		var files *template.Template
-		files, err = template.New(filepath.Base(key)).Funcs(autocode.GetTemplateFuncMap()).ParseFiles(key)
+		files := template.New(filepath.Base(key)).Funcs(autocode.GetTemplateFuncMap())
+		var err error
+		if source, ok := sources[key]; ok {
+			files, err = files.Parse(source)
+		} else {
+			files, err = files.ParseFiles(key)
+		}
 		if err != nil {
-			return nil, nil, nil, errors.Wrapf(err, "[filpath:%s]读取模版文件失败!", key)
+			return nil, errors.Wrapf(err, "[filpath:%s]读取模版文件失败!", key)
 		}
 		var builder strings.Builder
 		err = files.Execute(&builder, info)
 		if err != nil {
-			return nil, nil, nil, errors.Wrapf(err, "[filpath:%s]生成文件失败!", create)
+			return nil, errors.Wrapf(err, "[filpath:%s]生成文件失败!", create)
 		}
 		code[create] = builder
-	} // 生成文件
-	injections := make(map[string]utilsAst.Ast, len(asts))
-	for key, value := range asts {
-		keys := strings.Split(key, "=>")
-		if len(keys) == 2 {
-			if keys[1] == utilsAst.TypePluginInitializeV2 {
-				continue
-			}
-			if info.OnlyTemplate {
-				if keys[1] == utilsAst.TypePackageInitializeGorm || keys[1] == utilsAst.TypePluginInitializeGorm {
-					continue
-				}
-			}
-			if !info.AutoMigrate {
-				if keys[1] == utilsAst.TypePackageInitializeGorm || keys[1] == utilsAst.TypePluginInitializeGorm {
-					continue
-				}
-			}
-			var builder strings.Builder
-			parse, _ := value.Parse("", &builder)
-			if parse != nil {
-				_ = value.Injection(parse)
-				err = value.Format("", &builder, parse)
-				if err != nil {
-					return nil, nil, nil, err
-				}
-				code[keys[0]] = builder
-				injections[keys[1]] = value
-				fmt.Println(keys[0], "注入成功!")
+	}
+	return code, nil
+}
+
+// relative 生成文件相对 autocode.root 的路径, 统一使用 / 分隔
+func (s *autoCodeTemplate) relative(create string) string {
+	rel, err := filepath.Rel(global.GVA_CONFIG.Load().AutoCode.Root, create)
+	if err != nil {
+		rel = create
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Regenerate 重新生成已创建的模块
+// 以上次生成的原始代码为共同祖先, 对磁盘上的代码和新生成的代码逐文件做三方合并, 无冲突的直接写入, 有冲突的写入冲突标记
+// 字段有变更时同时生成版本化迁移, 迁移需通过 AutoCodeMigration.Apply 执行
+// preview 为 true 时只返回合并结果与迁移, 不写入文件也不保存; 迁移包含删除列时需 confirmDrop 为 true 才会保存
+func (s *autoCodeTemplate) Regenerate(ctx context.Context, info request.AutoCode, preview, confirmDrop bool) (*response.AutoCodeRegenerate, error) {
+	var autoPkg model.SysAutoCodePackage
+	err := global.GVA_DB.WithContext(ctx).Where("package_name = ?", info.Package).First(&autoPkg).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "查询包失败!")
+	}
+	err = s.checkPackage(info.Package, autoPkg.Template)
+	if err != nil {
+		return nil, err
+	}
+	var history model.SysAutoCodeHistory
+	err = global.GVA_DB.WithContext(ctx).Where("business_db = ? and struct_name = ? and package = ? and flag = ?", info.BusinessDB, info.StructName, info.Package, 0).Order("id desc").First(&history).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "未找到该结构体的生成记录!")
+	}
+	if len(history.Generated) == 0 {
+		return nil, errors.New("该结构体的生成记录缺少原始生成代码, 无法合并!")
+	}
+	migration, err := AutoCodeMigration.Build(history, info, preview || confirmDrop)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, sources, _, err := s.templates(ctx, autoPkg, info)
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.render(info, templates, sources)
+	if err != nil {
+		return nil, err
+	}
+	creates := make([]string, 0, len(code))
+	for create := range code {
+		creates = append(creates, create)
+	}
+	sort.Strings(creates)
+
+	files := make([]response.AutoCodeMergeFile, 0, len(creates))
+	generated := make(map[string]string, len(creates))
+	writes := make(map[string]string, len(creates))
+	for _, create := range creates {
+		builder := code[create]
+		incoming := builder.String()
+		file := response.AutoCodeMergeFile{Path: s.relative(create)}
+		generated[file.Path] = incoming
+		current, err := os.ReadFile(create)
+		switch {
+		case os.IsNotExist(err):
+			file.Status, file.Content = "created", incoming
+		case err != nil:
+			return nil, errors.Wrapf(err, "[filepath:%s]读取文件失败!", create)
+		default:
+			merged := autocode.Merge(history.Generated[file.Path], string(current), incoming)
+			file.Content, file.Conflicts = merged.Content, merged.Conflicts
+			switch {
+			case merged.Conflicts > 0:
+				file.Status = "conflict"
+			case merged.Content == string(current):
+				file.Status = "unchanged"
+			default:
+				file.Status = "merged"
 			}
 		}
+		if file.Status != "unchanged" {
+			writes[create] = file.Content
+		}
+		files = append(files, file)
 	}
-	// 注入代码
-	return code, templates, injections, nil
+	result := &response.AutoCodeRegenerate{Files: files, Migration: migration}
+	if preview {
+		return result, nil
+	}
+
+	for create, content := range writes {
+		err = os.MkdirAll(filepath.Dir(create), os.ModePerm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[filepath:%s]创建文件夹失败!", create)
+		}
+		err = os.WriteFile(create, []byte(content), 0666)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[filepath:%s]写入文件失败!", create)
+		}
+	}
+	// 本次新建的文件记入生成记录, 回滚时一并删除
+	createdFiles := make(map[string]bool)
+	for _, file := range files {
+		if file.Status == "created" {
+			createdFiles[file.Path] = true
+		}
+	}
+	created := make(map[string]string, len(createdFiles))
+	for key, create := range templates {
+		if createdFiles[s.relative(create)] {
+			created[key] = create
+		}
+	}
+	if history.Templates == nil {
+		history.Templates = make(map[string]string, len(created))
+	}
+	for key, value := range model.RelativeTemplates(created) {
+		history.Templates[key] = value
+	}
+	history.Request = info.History().Request
+	history.Generated = generated
+	err = global.GVA_DB.WithContext(ctx).Model(&history).Select("request", "generated", "templates").Updates(&history).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "更新生成记录失败!")
+	}
+	if migration != nil {
+		err = AutoCodeMigration.Create(ctx, migration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
 }
 
-func (s *autoCodeTemplate) AddFunc(info request.AutoFunc) error {
+func (s *autoCodeTemplate) AddFunc(ctx context.Context, info request.AutoFunc) error {
 	autoPkg := model.SysAutoCodePackage{}
-	err := global.GVA_DB.First(&autoPkg, "package_name = ?", info.Package).Error
+	err := global.GVA_DB.WithContext(ctx).First(&autoPkg, "package_name = ?", info.Package).Error
 	if err != nil {
 		return err
 	}
@@ -295,9 +481,9 @@ func (s *autoCodeTemplate) AddFunc(info request.AutoFunc) error {
 	return s.addTemplateToAst("router", info)
 }
 
-func (s *autoCodeTemplate) GetApiAndServer(info request.AutoFunc) (map[string]string, error) {
+func (s *autoCodeTemplate) GetApiAndServer(ctx context.Context, info request.AutoFunc) (map[string]string, error) {
 	autoPkg := model.SysAutoCodePackage{}
-	err := global.GVA_DB.First(&autoPkg, "package_name = ?", info.Package).Error
+	err := global.GVA_DB.WithContext(ctx).First(&autoPkg, "package_name = ?", info.Package).Error
 	if err != nil {
 		return nil, err
 	}
@@ -322,7 +508,7 @@ func (s *autoCodeTemplate) GetApiAndServer(info request.AutoFunc) (map[string]st
 }
 
 func (s *autoCodeTemplate) getTemplateStr(t string, info request.AutoFunc) (string, error) {
-	tempPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "resource", "function", t+".tpl")
+	tempPath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "resource", "function", t+".tpl")
 	files, err := template.New(filepath.Base(tempPath)).Funcs(autocode.GetTemplateFuncMap()).ParseFiles(tempPath)
 	if err != nil {
 		return "", errors.Wrapf(err, "[filepath:%s]读取模版文件失败!", tempPath)
@@ -337,7 +523,7 @@ func (s *autoCodeTemplate) getTemplateStr(t string, info request.AutoFunc) (stri
 }
 
 func (s *autoCodeTemplate) addTemplateToAst(t string, info request.AutoFunc) error {
-	tPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "router", info.Package, info.HumpPackageName+".go")
+	tPath := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "router", info.Package, info.HumpPackageName+".go")
 	funcName := fmt.Sprintf("Init%sRouter", info.StructName)
 
 	routerStr := "RouterWithoutAuth"
@@ -347,7 +533,7 @@ func (s *autoCodeTemplate) addTemplateToAst(t string, info request.AutoFunc) err
 
 	stmtStr := fmt.Sprintf("%s%s.%s(\"%s\", %sApi.%s)", info.Abbreviation, routerStr, info.Method, info.Router, info.Abbreviation, info.FuncName)
 	if info.IsPlugin {
-		tPath = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", info.Package, "router", info.HumpPackageName+".go")
+		tPath = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", info.Package, "router", info.HumpPackageName+".go")
 		stmtStr = fmt.Sprintf("group.%s(\"%s\", api%s.%s)", info.Method, info.Router, info.StructName, info.FuncName)
 		funcName = "Init"
 	}
@@ -412,26 +598,26 @@ func (s *autoCodeTemplate) addTemplateToFile(t string, info request.AutoFunc) er
 		if info.IsAi && info.ApiFunc != "" {
 			getTemplateStr = info.ApiFunc
 		}
-		target = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "api", "v1", info.Package, info.HumpPackageName+".go")
+		target = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "api", "v1", info.Package, info.HumpPackageName+".go")
 	case "server.go":
 		if info.IsAi && info.ServerFunc != "" {
 			getTemplateStr = info.ServerFunc
 		}
-		target = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service", info.Package, info.HumpPackageName+".go")
+		target = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service", info.Package, info.HumpPackageName+".go")
 	case "api.js":
 		if info.IsAi && info.JsFunc != "" {
 			getTemplateStr = info.JsFunc
 		}
-		target = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Web, "api", info.Package, info.PackageName+".js")
+		target = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Web, "api", info.Package, info.PackageName+".js")
 	}
 	if info.IsPlugin {
 		switch t {
 		case "api.go":
-			target = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", info.Package, "api", info.HumpPackageName+".go")
+			target = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", info.Package, "api", info.HumpPackageName+".go")
 		case "server.go":
-			target = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", info.Package, "service", info.HumpPackageName+".go")
+			target = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", info.Package, "service", info.HumpPackageName+".go")
 		case "api.js":
-			target = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Web, "plugin", info.Package, "api", info.PackageName+".js")
+			target = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Web, "plugin", info.Package, "api", info.PackageName+".js")
 		}
 	}
 
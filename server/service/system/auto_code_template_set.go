@@ -0,0 +1,290 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"server/global"
+	common "server/model/common/request"
+	model "server/model/system"
+	"server/model/system/request"
+	"server/model/system/response"
+	utilsAst "server/utils/ast"
+	"server/utils/autocode"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var AutoCodeTemplateSet = new(autoCodeTemplateSet)
+
+type autoCodeTemplateSet struct{}
+
+// Create 注册模板集, 注册前校验清单中的模板文件、输出路径和注入类型
+func (s *autoCodeTemplateSet) Create(ctx context.Context, info request.SysAutoCodeTemplateSetCreate) error {
+	entity := info.Create()
+	if entity.Name == "" {
+		return errors.New("模板集名称不能为空!")
+	}
+	if entity.Template != "package" && entity.Template != "plugin" {
+		return errors.Errorf("模板集适用的包模版[%s]不合法!", entity.Template)
+	}
+	switch entity.Source {
+	case model.TemplateSetSourceDir:
+		if entity.Dir == "" {
+			return errors.New("目录模板集需填写模板目录!")
+		}
+		entity.Manifest, entity.Files = model.AutoCodeTemplateManifest{}, nil
+	case model.TemplateSetSourceDB:
+		entity.Dir = ""
+	default:
+		return errors.Errorf("模板集来源[%s]不合法!", entity.Source)
+	}
+	if _, _, err := s.load(entity); err != nil {
+		return err
+	}
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 名称唯一索引包含已软删除的记录, 需连同软删除的同名记录一起检查
+		var exist model.SysAutoCodeTemplateSet
+		err := tx.Unscoped().Where("name = ?", entity.Name).First(&exist).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+		case err != nil:
+			return err
+		case !exist.DeletedAt.Valid:
+			return errors.New("存在相同名称的模板集!")
+		default:
+			if err = tx.Unscoped().Delete(&exist).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&entity).Error
+	})
+}
+
+// Delete 删除模板集, 目录模板集只删除注册记录; 直接删除记录, 使名称可以重新注册
+func (s *autoCodeTemplateSet) Delete(ctx context.Context, info common.GetById) error {
+	return global.GVA_DB.WithContext(ctx).Unscoped().Delete(&model.SysAutoCodeTemplateSet{}, info.Uint()).Error
+}
+
+// All 获取全部模板集, 不返回模板内容
+func (s *autoCodeTemplateSet) All(ctx context.Context) (entities []model.SysAutoCodeTemplateSet, err error) {
+	err = global.GVA_DB.WithContext(ctx).Omit("files").Order("id desc").Find(&entities).Error
+	return entities, err
+}
+
+// Validate 以示例结构体渲染模板集中的每个模板, go 文件额外校验能否格式化
+func (s *autoCodeTemplateSet) Validate(ctx context.Context, name string) ([]response.AutoCodeTemplateCheck, error) {
+	var entity model.SysAutoCodeTemplateSet
+	err := global.GVA_DB.WithContext(ctx).Where("name = ?", name).First(&entity).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "查询模板集失败!")
+	}
+	manifest, sources, err := s.load(entity)
+	if err != nil {
+		return nil, err
+	}
+	sample := s.sample()
+	checks := make([]response.AutoCodeTemplateCheck, 0, len(manifest.Files))
+	for _, file := range manifest.Files {
+		check := response.AutoCodeTemplateCheck{Template: file.Template}
+		output, err := s.output(file, sample)
+		if err != nil {
+			check.Error = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+		check.Output = output
+		content, err := s.execute(file.Template, sources[file.Template], sample)
+		if err == nil && filepath.Ext(output) == ".go" {
+			_, err = format.Source([]byte(content))
+		}
+		if err != nil {
+			check.Error = err.Error()
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// templates 按模板集清单返回 模板 => 生成路径、模板 => 模板内容 以及需要执行的注入, 与 autoCodePackage.templates 的返回保持一致
+func (s *autoCodeTemplateSet) templates(ctx context.Context, entity model.SysAutoCodePackage, info request.AutoCode) (code map[string]string, sources map[string]string, asts map[string]utilsAst.Ast, err error) {
+	var set model.SysAutoCodeTemplateSet
+	err = global.GVA_DB.WithContext(ctx).Where("name = ?", info.TemplateSet).First(&set).Error
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "查询模板集[%s]失败!", info.TemplateSet)
+	}
+	if set.Template != entity.Template {
+		return nil, nil, nil, errors.Errorf("模板集[%s]适用于%s, 当前包为%s!", set.Name, set.Template, entity.Template)
+	}
+	manifest, contents, err := s.load(set)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	code = make(map[string]string, len(manifest.Files))
+	sources = make(map[string]string, len(manifest.Files))
+	for _, file := range manifest.Files {
+		if file.Type == "web" && !info.GenerateWeb || file.Type != "web" && !info.GenerateServer {
+			continue
+		}
+		var output string
+		output, err = s.output(file, info)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		key := path.Join(set.Name, file.Template)
+		if set.Source == model.TemplateSetSourceDir {
+			var dir string
+			if dir, err = s.dir(set.Dir); err != nil {
+				return nil, nil, nil, err
+			}
+			key = filepath.Join(dir, filepath.FromSlash(file.Template))
+		}
+		code[key] = filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, filepath.FromSlash(output))
+		sources[key] = contents[file.Template]
+	}
+	asts = make(map[string]utilsAst.Ast, len(manifest.Injections))
+	for _, name := range manifest.Injections {
+		injection, path, err := s.injection(name, entity, info)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		asts[path+"=>"+name] = injection
+	}
+	return code, sources, asts, nil
+}
+
+// load 读取模板集清单与模板内容, 返回的模板内容以清单中的相对路径为键
+func (s *autoCodeTemplateSet) load(entity model.SysAutoCodeTemplateSet) (model.AutoCodeTemplateManifest, map[string]string, error) {
+	manifest := entity.Manifest
+	sources := make(map[string]string)
+	if entity.Source == model.TemplateSetSourceDir {
+		dir, err := s.dir(entity.Dir)
+		if err != nil {
+			return manifest, nil, err
+		}
+		bytes, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+		if err != nil {
+			return manifest, nil, errors.Wrapf(err, "读取模板集清单[%s]失败!", dir)
+		}
+		err = json.Unmarshal(bytes, &manifest)
+		if err != nil {
+			return manifest, nil, errors.Wrapf(err, "解析模板集清单[%s]失败!", dir)
+		}
+		for _, file := range manifest.Files {
+			if !filepath.IsLocal(filepath.FromSlash(file.Template)) {
+				return manifest, nil, errors.Errorf("[filpath:%s]模板路径不能超出模板集目录!", file.Template)
+			}
+			bytes, err = os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Template)))
+			if err != nil {
+				return manifest, nil, errors.Wrapf(err, "[filpath:%s]读取模版文件失败!", file.Template)
+			}
+			sources[file.Template] = string(bytes)
+		}
+	} else {
+		for _, file := range manifest.Files {
+			content, ok := entity.Files[file.Template]
+			if !ok {
+				return manifest, nil, errors.Errorf("[filpath:%s]模板集中缺少该模板!", file.Template)
+			}
+			sources[file.Template] = content
+		}
+	}
+	if len(manifest.Files) == 0 {
+		return manifest, nil, errors.New("模板集清单中没有模板文件!")
+	}
+	for _, file := range manifest.Files {
+		if file.Output == "" {
+			return manifest, nil, errors.Errorf("[filpath:%s]未声明输出路径!", file.Template)
+		}
+		if file.Type != "" && file.Type != "server" && file.Type != "web" {
+			return manifest, nil, errors.Errorf("[filpath:%s]模板类型[%s]不合法!", file.Template, file.Type)
+		}
+	}
+	for _, name := range manifest.Injections {
+		if _, _, err := s.injection(name, model.SysAutoCodePackage{Template: entity.Template}, request.AutoCode{}); err != nil {
+			return manifest, nil, err
+		}
+	}
+	return manifest, sources, nil
+}
+
+// dir 模板集目录, 只允许 autocode.root/autocode.server 下的相对路径
+func (s *autoCodeTemplateSet) dir(dir string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(dir)) {
+		return "", errors.Errorf("模板目录[%s]需为%s目录下的相对路径!", dir, global.GVA_CONFIG.Load().AutoCode.Server)
+	}
+	return filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, filepath.FromSlash(dir)), nil
+}
+
+// output 渲染输出路径, 只允许输出到 server 或 web 目录下, 以便回滚时能够删除
+func (s *autoCodeTemplateSet) output(file model.AutoCodeTemplateFile, info request.AutoCode) (string, error) {
+	output, err := s.execute(file.Template+"#output", file.Output, info)
+	if err != nil {
+		return "", err
+	}
+	output = path.Clean(filepath.ToSlash(output))
+	root := global.GVA_CONFIG.Load().AutoCode.Server
+	if file.Type == "web" {
+		root = filepath.ToSlash(global.GVA_CONFIG.Load().AutoCode.WebRoot())
+	}
+	rel, err := filepath.Rel(filepath.FromSlash(root), filepath.FromSlash(output))
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", errors.Errorf("[filpath:%s]输出路径[%s]需位于%s目录下!", file.Template, output, root)
+	}
+	return output, nil
+}
+
+func (s *autoCodeTemplateSet) execute(name string, source string, info request.AutoCode) (string, error) {
+	files, err := template.New(path.Base(name)).Funcs(autocode.GetTemplateFuncMap()).Parse(source)
+	if err != nil {
+		return "", errors.Wrapf(err, "[filpath:%s]解析模版失败!", name)
+	}
+	var builder strings.Builder
+	err = files.Execute(&builder, info)
+	if err != nil {
+		return "", errors.Wrapf(err, "[filpath:%s]生成文件失败!", name)
+	}
+	return builder.String(), nil
+}
+
+// sample 校验模板使用的示例结构体, 覆盖常用的字段类型
+func (s *autoCodeTemplateSet) sample() request.AutoCode {
+	info := request.AutoCode{
+		Package:         "example",
+		TableName:       "samples",
+		StructName:      "Sample",
+		PackageName:     "sample",
+		Description:     "示例",
+		Abbreviation:    "sample",
+		HumpPackageName: "sample",
+		GvaModel:        true,
+		AutoMigrate:     true,
+		GenerateWeb:     true,
+		GenerateServer:  true,
+		Fields: []*request.AutoCodeField{
+			{FieldName: "Name", FieldDesc: "名称", FieldType: "string", FieldJson: "name", ColumnName: "name", DataTypeLong: "64", FieldSearchType: "LIKE", Form: true, Table: true, Desc: true, Sort: true},
+			{FieldName: "Count", FieldDesc: "数量", FieldType: "int", FieldJson: "count", ColumnName: "count", FieldSearchType: "=", Form: true, Table: true, Desc: true},
+			{FieldName: "Enabled", FieldDesc: "启用", FieldType: "bool", FieldJson: "enabled", ColumnName: "enabled", Form: true, Table: true, Desc: true},
+			{FieldName: "PublishedAt", FieldDesc: "发布时间", FieldType: "time.Time", FieldJson: "publishedAt", ColumnName: "published_at", FieldSearchType: "BETWEEN", Form: true, Table: true, Desc: true},
+		},
+	}
+	_ = info.Pretreatment()
+	return info
+}
+
+// injection 构建模板集清单中声明的注入, 只允许包模版支持的类型
+func (s *autoCodeTemplateSet) injection(name string, entity model.SysAutoCodePackage, info request.AutoCode) (utilsAst.Ast, string, error) {
+	if !slices.Contains(autoCodeInjectionTypes[entity.Template], name) {
+		return nil, "", errors.Errorf("%s模板集不支持注入类型[%s]!", entity.Template, name)
+	}
+	injection, path := autoCodeInjection(name, entity, info)
+	return injection, path, nil
+}
@@ -0,0 +1,139 @@
+package system
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/config"
+	"server/global"
+	common "server/model/common/request"
+	model "server/model/system"
+	"server/model/system/request"
+	utilsAst "server/utils/ast"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAutoCodeTemplateSet(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&model.SysAutoCodeTemplateSet{}); err != nil {
+		t.Fatal(err)
+	}
+	oldDB, oldConfig := global.GVA_DB, global.GVA_CONFIG.Load()
+	global.GVA_DB = db
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.AutoCode.Root = t.TempDir()
+		conf.AutoCode.Server = "server"
+		conf.AutoCode.Web = "web/src"
+		conf.AutoCode.Module = "server"
+	})
+	t.Cleanup(func() {
+		global.GVA_DB = oldDB
+		global.GVA_CONFIG.Store(oldConfig)
+	})
+
+	ctx := context.Background()
+	create := request.SysAutoCodeTemplateSetCreate{
+		Name:     "repository",
+		Template: "package",
+		Source:   model.TemplateSetSourceDB,
+		Manifest: model.AutoCodeTemplateManifest{
+			Files: []model.AutoCodeTemplateFile{
+				{Template: "repository.go.tpl", Output: "server/repository/{{.Package}}/{{.HumpPackageName}}.go"},
+				{Template: "broken.go.tpl", Output: "server/broken/{{.HumpPackageName}}.go"},
+			},
+			Injections: []string{utilsAst.TypePackageInitializeGorm},
+		},
+		Files: map[string]string{
+			"repository.go.tpl": "package {{.Package}}\n\ntype {{.StructName}}Repository struct{}\n",
+			"broken.go.tpl":     "package {{.Package}}\n\nfunc {{.StructName}}( {\n",
+		},
+	}
+	if err = AutoCodeTemplateSet.Create(ctx, create); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err = AutoCodeTemplateSet.Create(ctx, create); err == nil {
+		t.Error("重复注册应返回错误")
+	}
+	escape := create
+	escape.Name = "escape"
+	escape.Manifest.Files = []model.AutoCodeTemplateFile{{Template: "repository.go.tpl", Output: "server/../../etc/{{.Package}}.go"}}
+	if err = AutoCodeTemplateSet.Create(ctx, escape); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	} // 输出路径在渲染时校验
+	unknown := create
+	unknown.Name = "unknown"
+	unknown.Manifest.Injections = []string{utilsAst.TypePluginGen}
+	if err = AutoCodeTemplateSet.Create(ctx, unknown); err == nil {
+		t.Error("package模板集使用plugin注入应返回错误")
+	}
+	for _, dir := range []string{"../templates", filepath.Join(t.TempDir(), "templates")} {
+		outside := create
+		outside.Name, outside.Source, outside.Dir = "outside", model.TemplateSetSourceDir, dir
+		if err = AutoCodeTemplateSet.Create(ctx, outside); err == nil || !strings.Contains(err.Error(), "相对路径") {
+			t.Errorf("模板目录[%s]超出server目录应返回错误, got %v", dir, err)
+		}
+	}
+
+	checks, err := AutoCodeTemplateSet.Validate(ctx, "repository")
+	if err != nil || len(checks) != 2 {
+		t.Fatalf("Validate() = %+v, %v", checks, err)
+	}
+	if checks[0].Error != "" || checks[0].Output != "server/repository/example/sample.go" {
+		t.Errorf("Validate() repository = %+v", checks[0])
+	}
+	if checks[1].Error == "" {
+		t.Errorf("Validate() broken should fail to format")
+	}
+	if checks, _ = AutoCodeTemplateSet.Validate(ctx, "escape"); len(checks) != 1 || checks[0].Error == "" {
+		t.Errorf("Validate() escape = %+v", checks)
+	}
+
+	// 删除后可以重新注册同名模板集, 已软删除的旧记录同样不影响
+	var saved model.SysAutoCodeTemplateSet
+	if err = db.Where("name = ?", "escape").First(&saved).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = AutoCodeTemplateSet.Delete(ctx, common.GetById{ID: int(saved.ID)}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err = AutoCodeTemplateSet.Create(ctx, escape); err != nil {
+		t.Fatalf("删除后重新注册 error = %v", err)
+	}
+	if err = db.Where("name = ?", "escape").Delete(&model.SysAutoCodeTemplateSet{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = AutoCodeTemplateSet.Create(ctx, escape); err != nil {
+		t.Fatalf("软删除后重新注册 error = %v", err)
+	}
+
+	info := request.AutoCode{Package: "blog", StructName: "Article", HumpPackageName: "article", TemplateSet: "repository", GenerateServer: true}
+	code, sources, asts, err := AutoCodeTemplate.templates(ctx, model.SysAutoCodePackage{Template: "package", PackageName: "blog"}, info)
+	if err != nil {
+		t.Fatalf("templates() error = %v", err)
+	}
+	want := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, "server", "repository", "blog", "article.go")
+	if code["repository/repository.go.tpl"] != want || len(sources) != 2 || len(asts) != 1 {
+		t.Fatalf("templates() = %v, %d sources, %v", code, len(sources), asts)
+	}
+	delete(code, "repository/broken.go.tpl")
+	rendered, err := AutoCodeTemplate.render(info, code, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := rendered[want]
+	if !strings.Contains(builder.String(), "type ArticleRepository struct{}") {
+		t.Errorf("render() = %s", builder.String())
+	}
+	if _, _, _, err = AutoCodeTemplate.templates(ctx, model.SysAutoCodePackage{Template: "plugin", PackageName: "blog"}, info); err == nil {
+		t.Error("plugin包使用package模板集应返回错误")
+	}
+}
@@ -13,14 +13,18 @@ type ServiceGroup struct {
 	DictionaryService
 	SystemConfigService
 	OperationRecordService
+	AuditLogService
+	ChangeHistoryService
 	DictionaryDetailService
 	AuthorityBtnService
 	SysExportTemplateService
 	SysParamsService
 	SysVersionService
 	AreaService
-	AutoCodePlugin   autoCodePlugin
-	AutoCodePackage  autoCodePackage
-	AutoCodeHistory  autoCodeHistory
-	AutoCodeTemplate autoCodeTemplate
+	AutoCodePlugin      autoCodePlugin
+	AutoCodePackage     autoCodePackage
+	AutoCodeHistory     autoCodeHistory
+	AutoCodeTemplate    autoCodeTemplate
+	AutoCodeMigration   autoCodeMigration
+	AutoCodeTemplateSet autoCodeTemplateSet
 }
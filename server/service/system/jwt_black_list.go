@@ -7,6 +7,7 @@ import (
 
 	"server/global"
 	"server/model/system"
+	"server/utils/ctxlog"
 )
 
 type JwtService struct{}
@@ -19,8 +20,8 @@ var JwtServiceApp = new(JwtService)
 //@param: jwtList model.JwtBlacklist
 //@return: err error
 
-func (jwtService *JwtService) JsonInBlacklist(jwtList system.JwtBlacklist) (err error) {
-	err = global.GVA_DB.Create(&jwtList).Error
+func (jwtService *JwtService) JsonInBlacklist(ctx context.Context, jwtList system.JwtBlacklist) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Create(&jwtList).Error
 	if err != nil {
 		return
 	}
@@ -39,11 +40,11 @@ func (jwtService *JwtService) GetRedisJWT(userName string) (redisJWT string, err
 	return redisJWT, err
 }
 
-func LoadAll() {
+func LoadAll(ctx context.Context) {
 	var data []string
-	err := global.GVA_DB.Model(&system.JwtBlacklist{}).Select("jwt").Find(&data).Error
+	err := global.GVA_DB.WithContext(ctx).Model(&system.JwtBlacklist{}).Select("jwt").Find(&data).Error
 	if err != nil {
-		global.GVA_LOG.Error("加载数据库jwt黑名单失败!", zap.Error(err))
+		ctxlog.Logger(ctx).Error("加载数据库jwt黑名单失败!", zap.Error(err))
 		return
 	}
 	for i := 0; i < len(data); i++ {
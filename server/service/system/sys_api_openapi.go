@@ -0,0 +1,112 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"server/docs"
+	"server/global"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+	"server/utils/openapi"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+)
+
+var (
+	openApiOnce   sync.Once
+	openApiSource *openapi.Source
+	openApiErr    error
+)
+
+// openApiSourceCode 获取接口定义, 路由注册后不会变化, 只加载一次
+// 开发环境存在源码时直接解析, 自动化代码新增的接口无需重新生成; 部署环境使用编译时 go generate 嵌入的结果
+func openApiSourceCode() (*openapi.Source, error) {
+	openApiOnce.Do(func() {
+		root := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+			openApiSource, openApiErr = openapi.Parse(root)
+			return
+		}
+		openApiSource, openApiErr = openapi.Load(docs.OpenApiSource)
+	})
+	return openApiSource, openApiErr
+}
+
+// GetOpenApi 根据已注册的路由生成 OpenAPI 3.1 文档
+func (apiService *ApiService) GetOpenApi() (*openapi.Document, error) {
+	source, err := openApiSourceCode()
+	if err != nil {
+		return nil, err
+	}
+	return source.Document(global.GVA_ROUTERS, openapi.Info{
+		Title:       docs.SwaggerInfo.Title,
+		Description: docs.SwaggerInfo.Description,
+		Version:     docs.SwaggerInfo.Version,
+	}), nil
+}
+
+// GenerateSdk 根据 OpenAPI 文档生成 go 或 TypeScript 接口客户端, 返回文件名与源码
+func (apiService *ApiService) GenerateSdk(info systemReq.SdkGenerate) (filename string, content []byte, err error) {
+	document, err := apiService.GetOpenApi()
+	if err != nil {
+		return "", nil, err
+	}
+	switch info.Language {
+	case "go", "":
+		if info.Package == "" {
+			info.Package = "gvaclient"
+		}
+		content, err = openapi.GenerateGo(document, info.Package)
+		return info.Package + ".go", content, err
+	case "typescript", "ts":
+		return "gvaClient.ts", openapi.GenerateTypeScript(document), nil
+	}
+	return "", nil, fmt.Errorf("不支持的客户端语言: %s", info.Language)
+}
+
+// CheckOpenApi 检查已注册的路由是否录入 sys_apis、是否分配了 casbin 策略, 以及与 swagger 注释是否一致
+func (apiService *ApiService) CheckOpenApi(ctx context.Context) (result systemRes.SysOpenApiCheck, err error) {
+	source, err := openApiSourceCode()
+	if err != nil {
+		return
+	}
+	result.MissingApis, _, _, err = apiService.SyncApi(ctx)
+	if err != nil {
+		return
+	}
+
+	var apis []system.SysApi
+	if err = global.GVA_DB.WithContext(ctx).Order("path").Find(&apis).Error; err != nil {
+		return
+	}
+	var rules []gormadapter.CasbinRule
+	if err = global.GVA_DB.WithContext(ctx).Where("ptype = ?", "p").Find(&rules).Error; err != nil {
+		return
+	}
+	policies := make(map[string]bool, len(rules))
+	for i := range rules {
+		policies[rules[i].V2+" "+rules[i].V1] = true
+	}
+	registered := make(map[string]bool, len(global.GVA_ROUTERS))
+	for i := range global.GVA_ROUTERS {
+		registered[global.GVA_ROUTERS[i].Method+" "+global.GVA_ROUTERS[i].Path] = true
+	}
+	result.MissingPolicies = make([]system.SysApi, 0)
+	for i := range apis {
+		key := apis[i].Method + " " + apis[i].Path
+		if registered[key] && !policies[key] {
+			result.MissingPolicies = append(result.MissingPolicies, apis[i])
+		}
+	}
+
+	result.Mismatches = source.Mismatches(global.GVA_ROUTERS, global.GVA_CONFIG.Load().System.RouterPrefix)
+	if result.Mismatches == nil {
+		result.Mismatches = make([]openapi.Mismatch, 0)
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -18,14 +19,14 @@ type AreaService struct{}
 var AreaServiceApp = new(AreaService)
 
 // CreateArea 创建区域信息
-func (areaService *AreaService) CreateArea(area system.SysArea) (err error) {
+func (areaService *AreaService) CreateArea(ctx context.Context, area system.SysArea) (err error) {
 	// 检查区域编码是否已存在
-	if !errors.Is(global.GVA_DB.Where("i = ?", area.I).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
+	if !errors.Is(global.GVA_DB.WithContext(ctx).Where("i = ?", area.I).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
 		return errors.New("区域编码已存在")
 	}
 
 	// 检查同一父级下是否存在相同名称
-	if !errors.Is(global.GVA_DB.Where("n = ? AND p = ?", area.N, area.P).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
+	if !errors.Is(global.GVA_DB.WithContext(ctx).Where("n = ? AND p = ?", area.N, area.P).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
 		return errors.New("同一父级下区域名称已存在")
 	}
 
@@ -34,7 +35,7 @@ func (areaService *AreaService) CreateArea(area system.SysArea) (err error) {
 		area.Level = 1 // 省级
 	} else {
 		var parent system.SysArea
-		if err = global.GVA_DB.Where("i = ?", area.P).First(&parent).Error; err != nil {
+		if err = global.GVA_DB.WithContext(ctx).Where("i = ?", area.P).First(&parent).Error; err != nil {
 			return errors.New("父级区域不存在")
 		}
 		area.Level = parent.Level + 1
@@ -43,14 +44,14 @@ func (areaService *AreaService) CreateArea(area system.SysArea) (err error) {
 		}
 	}
 
-	return global.GVA_DB.Create(&area).Error
+	return global.GVA_DB.WithContext(ctx).Create(&area).Error
 }
 
 // DeleteArea 删除区域信息
-func (areaService *AreaService) DeleteArea(area system.SysArea) (err error) {
+func (areaService *AreaService) DeleteArea(ctx context.Context, area system.SysArea) (err error) {
 	// 检查是否有子区域
 	var count int64
-	err = global.GVA_DB.Model(&system.SysArea{}).Where("p = ?", area.I).Count(&count).Error
+	err = global.GVA_DB.WithContext(ctx).Model(&system.SysArea{}).Where("p = ?", area.I).Count(&count).Error
 	if err != nil {
 		return err
 	}
@@ -58,12 +59,12 @@ func (areaService *AreaService) DeleteArea(area system.SysArea) (err error) {
 		return errors.New("存在子区域，不能删除")
 	}
 
-	return global.GVA_DB.Where("id = ?", area.ID).Delete(&area).Error
+	return global.GVA_DB.WithContext(ctx).Where("id = ?", area.ID).Delete(&area).Error
 }
 
 // DeleteAreasByIds 批量删除区域
-func (areaService *AreaService) DeleteAreasByIds(ids request.IdsReq) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+func (areaService *AreaService) DeleteAreasByIds(ctx context.Context, ids request.IdsReq) (err error) {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var areas []system.SysArea
 		if err := tx.Find(&areas, "id in ?", ids.Ids).Error; err != nil {
 			return err
@@ -85,22 +86,22 @@ func (areaService *AreaService) DeleteAreasByIds(ids request.IdsReq) (err error)
 }
 
 // UpdateArea 更新区域信息
-func (areaService *AreaService) UpdateArea(area system.SysArea) (err error) {
+func (areaService *AreaService) UpdateArea(ctx context.Context, area system.SysArea) (err error) {
 	var oldArea system.SysArea
-	if err = global.GVA_DB.First(&oldArea, "id = ?", area.ID).Error; err != nil {
+	if err = global.GVA_DB.WithContext(ctx).First(&oldArea, "id = ?", area.ID).Error; err != nil {
 		return err
 	}
 
 	// 如果修改了区域编码，检查新编码是否已存在
 	if oldArea.I != area.I {
-		if !errors.Is(global.GVA_DB.Where("i = ? AND id != ?", area.I, area.ID).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
+		if !errors.Is(global.GVA_DB.WithContext(ctx).Where("i = ? AND id != ?", area.I, area.ID).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
 			return errors.New("区域编码已存在")
 		}
 	}
 
 	// 如果修改了名称或父级ID，检查同一父级下名称是否重复
 	if oldArea.N != area.N || oldArea.P != area.P {
-		if !errors.Is(global.GVA_DB.Where("n = ? AND p = ? AND id != ?", area.N, area.P, area.ID).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
+		if !errors.Is(global.GVA_DB.WithContext(ctx).Where("n = ? AND p = ? AND id != ?", area.N, area.P, area.ID).First(&system.SysArea{}).Error, gorm.ErrRecordNotFound) {
 			return errors.New("同一父级下区域名称已存在")
 		}
 	}
@@ -111,7 +112,7 @@ func (areaService *AreaService) UpdateArea(area system.SysArea) (err error) {
 			area.Level = 1
 		} else {
 			var parent system.SysArea
-			if err = global.GVA_DB.Where("i = ?", area.P).First(&parent).Error; err != nil {
+			if err = global.GVA_DB.WithContext(ctx).Where("i = ?", area.P).First(&parent).Error; err != nil {
 				return errors.New("父级区域不存在")
 			}
 			area.Level = parent.Level + 1
@@ -121,14 +122,14 @@ func (areaService *AreaService) UpdateArea(area system.SysArea) (err error) {
 		}
 	}
 
-	return global.GVA_DB.Save(&area).Error
+	return global.GVA_DB.WithContext(ctx).Save(&area).Error
 }
 
 // GetAreaList 获取区域分页列表
-func (areaService *AreaService) GetAreaList(info systemReq.SysAreaSearch) (list interface{}, total int64, err error) {
+func (areaService *AreaService) GetAreaList(ctx context.Context, info systemReq.SysAreaSearch) (list interface{}, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.GVA_DB.Model(&system.SysArea{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysArea{})
 	var areaList []system.SysArea
 
 	// 搜索条件
@@ -180,21 +181,21 @@ func (areaService *AreaService) GetAreaList(info systemReq.SysAreaSearch) (list
 }
 
 // GetAreaById 根据ID获取区域信息
-func (areaService *AreaService) GetAreaById(id uint) (area system.SysArea, err error) {
-	err = global.GVA_DB.First(&area, "id = ?", id).Error
+func (areaService *AreaService) GetAreaById(ctx context.Context, id uint) (area system.SysArea, err error) {
+	err = global.GVA_DB.WithContext(ctx).First(&area, "id = ?", id).Error
 	return
 }
 
 // GetAreaByAreaId 根据区域编码获取区域信息
-func (areaService *AreaService) GetAreaByAreaId(areaId int) (area system.SysArea, err error) {
-	err = global.GVA_DB.First(&area, "i = ?", areaId).Error
+func (areaService *AreaService) GetAreaByAreaId(ctx context.Context, areaId int) (area system.SysArea, err error) {
+	err = global.GVA_DB.WithContext(ctx).First(&area, "i = ?", areaId).Error
 	return
 }
 
 // GetAreaTree 获取区域树形结构
-func (areaService *AreaService) GetAreaTree(req systemReq.AreaTree) (tree []systemRes.SysAreaTreeNode, err error) {
+func (areaService *AreaService) GetAreaTree(ctx context.Context, req systemReq.AreaTree) (tree []systemRes.SysAreaTreeNode, err error) {
 	var areas []system.SysArea
-	db := global.GVA_DB.Model(&system.SysArea{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysArea{})
 
 	// 过滤条件
 	if req.Level != nil {
@@ -238,14 +239,14 @@ func (areaService *AreaService) GetAreaTree(req systemReq.AreaTree) (tree []syst
 }
 
 // GetAreasByParentId 根据父级ID获取子区域列表
-func (areaService *AreaService) GetAreasByParentId(parentId int) (areas []system.SysArea, err error) {
-	err = global.GVA_DB.Where("p = ?", parentId).Order("i ASC").Find(&areas).Error
+func (areaService *AreaService) GetAreasByParentId(ctx context.Context, parentId int) (areas []system.SysArea, err error) {
+	err = global.GVA_DB.WithContext(ctx).Where("p = ?", parentId).Order("i ASC").Find(&areas).Error
 	return
 }
 
 // ImportAreaData 导入区域数据
-func (areaService *AreaService) ImportAreaData(req systemReq.ImportAreaReq) (result systemRes.ImportAreaResponse, err error) {
-	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+func (areaService *AreaService) ImportAreaData(ctx context.Context, req systemReq.ImportAreaReq) (result systemRes.ImportAreaResponse, err error) {
+	err = global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 清空现有数据
 		if req.ClearData {
 			if err := tx.Unscoped().Delete(&system.SysArea{}, "1 = 1").Error; err != nil {
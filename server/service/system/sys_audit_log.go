@@ -0,0 +1,346 @@
+package system
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"server/global"
+	"server/model/common/request"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AuditIssueGap                = "gap"
+	AuditIssuePrevMismatch       = "prev_mismatch"
+	AuditIssueHashMismatch       = "hash_mismatch"
+	AuditIssueRecordModified     = "record_modified"
+	AuditIssueCheckpointInvalid  = "checkpoint_invalid"
+	AuditIssueCheckpointMismatch = "checkpoint_mismatch"
+	AuditIssueCheckpointGap      = "checkpoint_gap"
+
+	auditMaxIssues  = 100
+	auditBatchSize  = 500
+	auditAppendTry  = 3
+	auditGenesisKey = "GVA_AUDIT_GENESIS"
+)
+
+var ErrAuditProtected = errors.New("已开启防篡改审计, 禁止删除操作记录")
+
+// AuditPayload 审计内容, 请求体与响应体只保存摘要
+type AuditPayload struct {
+	RecordID     uint   `json:"recordId"`
+	UserID       int    `json:"userId"`
+	Ip           string `json:"ip"`
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	Status       int    `json:"status"`
+	Latency      int64  `json:"latency"`
+	Agent        string `json:"agent"`
+	ErrorMessage string `json:"errorMessage"`
+	BodyHash     string `json:"bodyHash"`
+	RespHash     string `json:"respHash"`
+	CreatedAt    int64  `json:"createdAt"`
+}
+
+type AuditLogService struct{}
+
+var AuditLogServiceApp = new(AuditLogService)
+
+// auditAppendMu 串行化本实例内的追加操作, 多实例间依赖 seq 唯一索引重试
+var auditAppendMu sync.Mutex
+
+// NewAuditPayload 根据操作记录生成规范化的审计内容
+func NewAuditPayload(record system.SysOperationRecord) string {
+	payload, _ := json.Marshal(AuditPayload{
+		RecordID:     record.ID,
+		UserID:       record.UserID,
+		Ip:           record.Ip,
+		Method:       record.Method,
+		Path:         record.Path,
+		Status:       record.Status,
+		Latency:      int64(record.Latency),
+		Agent:        record.Agent,
+		ErrorMessage: record.ErrorMessage,
+		BodyHash:     sha256Hex(record.Body),
+		RespHash:     sha256Hex(record.Resp),
+		CreatedAt:    record.CreatedAt.Round(time.Millisecond).Unix(), // 与数据库毫秒精度保持一致
+	})
+	return string(payload)
+}
+
+// AuditHash 计算哈希链中一条记录的哈希, 使用审计密钥做 HMAC, 没有密钥无法重算整条链
+func AuditHash(seq uint64, prevHash, payload string) string {
+	return auditHMAC(strconv.FormatUint(seq, 10) + "|" + prevHash + "|" + payload)
+}
+
+func auditGenesisHash() string {
+	return auditHMAC(auditGenesisKey)
+}
+
+//@function: AppendOperationRecords
+//@description: 将操作记录追加到审计哈希链, 序号冲突(多实例并发)时重试
+//@param: ctx context.Context, records []system.SysOperationRecord
+//@return: err error
+
+func (auditLogService *AuditLogService) AppendOperationRecords(ctx context.Context, records []system.SysOperationRecord) (err error) {
+	if len(records) == 0 {
+		return nil
+	}
+	auditAppendMu.Lock()
+	defer auditAppendMu.Unlock()
+	for i := 0; i < auditAppendTry; i++ {
+		err = global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var last system.SysAuditLog
+			prevHash, seq := auditGenesisHash(), uint64(0)
+			err := tx.Order("seq desc").Limit(1).Find(&last).Error
+			if err != nil {
+				return err
+			}
+			if last.ID != 0 {
+				prevHash, seq = last.Hash, last.Seq
+			}
+			logs := make([]system.SysAuditLog, 0, len(records))
+			for _, record := range records {
+				seq++
+				payload := NewAuditPayload(record)
+				hash := AuditHash(seq, prevHash, payload)
+				logs = append(logs, system.SysAuditLog{Seq: seq, RecordID: record.ID, Payload: payload, PrevHash: prevHash, Hash: hash})
+				prevHash = hash
+			}
+			return tx.CreateInBatches(logs, auditBatchSize).Error
+		})
+		if err == nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	return err
+}
+
+//@function: CreateCheckpoint
+//@description: 为哈希链末端生成签名检查点, 编号接续上一个检查点并将其签名纳入本次签名; 链为空或末端未变化时跳过
+//@return: checkpoint system.SysAuditCheckpoint, err error
+
+func (auditLogService *AuditLogService) CreateCheckpoint(ctx context.Context) (checkpoint system.SysAuditCheckpoint, err error) {
+	var last system.SysAuditLog
+	if err = global.GVA_DB.WithContext(ctx).Order("seq desc").Limit(1).Find(&last).Error; err != nil || last.ID == 0 {
+		return checkpoint, err
+	}
+	var prev system.SysAuditCheckpoint
+	if err = global.GVA_DB.WithContext(ctx).Order("number desc").Limit(1).Find(&prev).Error; err != nil {
+		return checkpoint, err
+	}
+	if prev.ID != 0 && prev.Seq == last.Seq {
+		return prev, nil
+	}
+	// 多实例同时生成时编号唯一索引冲突, 由下一次定时任务重试
+	checkpoint = system.SysAuditCheckpoint{Number: prev.Number + 1, Seq: last.Seq, Hash: last.Hash, PrevSignature: prev.Signature}
+	checkpoint.Signature = auditSign(checkpoint)
+	err = global.GVA_DB.WithContext(ctx).Create(&checkpoint).Error
+	return checkpoint, err
+}
+
+//@function: VerifyAuditLog
+//@description: 按序号分批校验哈希链, 检测缺号、哈希不一致、操作记录被修改以及检查点签名
+//@param: info systemReq.SysAuditLogVerify
+//@return: result systemRes.AuditVerifyResult, err error
+
+func (auditLogService *AuditLogService) VerifyAuditLog(ctx context.Context, info systemReq.SysAuditLogVerify) (result systemRes.AuditVerifyResult, err error) {
+	addIssue := func(seq uint64, typ, msg string) {
+		if len(result.Issues) < auditMaxIssues {
+			result.Issues = append(result.Issues, systemRes.AuditIssue{Seq: seq, Type: typ, Message: msg})
+		}
+		result.IssueCount++
+	}
+
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysAuditLog{})
+	if info.StartSeq > 0 {
+		db = db.Where("seq >= ?", info.StartSeq)
+	}
+	if info.EndSeq > 0 {
+		db = db.Where("seq <= ?", info.EndSeq)
+	}
+
+	var prev *system.SysAuditLog
+	if info.StartSeq > 1 {
+		var p system.SysAuditLog
+		if err = global.GVA_DB.WithContext(ctx).Where("seq = ?", info.StartSeq-1).Limit(1).Find(&p).Error; err != nil {
+			return result, err
+		}
+		if p.ID != 0 {
+			prev = &p
+		}
+	}
+	// 检查点数量较少, 全部取出校验编号与签名链, 范围内的检查点在扫描时记录对应序号的哈希
+	var checkpoints []system.SysAuditCheckpoint
+	if err = global.GVA_DB.WithContext(ctx).Order("number").Find(&checkpoints).Error; err != nil {
+		return result, err
+	}
+	inRange := func(seq uint64) bool {
+		return (info.StartSeq == 0 || seq >= info.StartSeq) && (info.EndSeq == 0 || seq <= info.EndSeq)
+	}
+	hashes := make(map[uint64]string, len(checkpoints))
+	for _, cp := range checkpoints {
+		if inRange(cp.Seq) {
+			hashes[cp.Seq] = ""
+		}
+	}
+	var lastSeq uint64
+	for {
+		var logs []system.SysAuditLog
+		if err = db.Session(&gorm.Session{}).Where("seq > ?", lastSeq).Order("seq").Limit(auditBatchSize).Find(&logs).Error; err != nil {
+			return result, err
+		}
+		if len(logs) == 0 {
+			break
+		}
+		if err = auditLogService.verifyRecords(ctx, logs, addIssue); err != nil {
+			return result, err
+		}
+		for i := range logs {
+			log := logs[i]
+			if result.Checked == 0 {
+				result.FirstSeq = log.Seq
+				if prev == nil && log.Seq != 1 && info.StartSeq <= 1 {
+					addIssue(log.Seq, AuditIssueGap, fmt.Sprintf("缺少序号 1-%d", log.Seq-1))
+				}
+			}
+			expectedPrev := auditGenesisHash()
+			if prev != nil {
+				expectedPrev = prev.Hash
+				if log.Seq != prev.Seq+1 {
+					addIssue(log.Seq, AuditIssueGap, fmt.Sprintf("缺少序号 %d-%d", prev.Seq+1, log.Seq-1))
+				}
+			}
+			if (prev != nil || log.Seq == 1) && log.PrevHash != expectedPrev {
+				addIssue(log.Seq, AuditIssuePrevMismatch, "上一条哈希与链不一致")
+			}
+			if AuditHash(log.Seq, log.PrevHash, log.Payload) != log.Hash {
+				addIssue(log.Seq, AuditIssueHashMismatch, "审计内容与哈希不一致")
+			}
+			if _, ok := hashes[log.Seq]; ok {
+				hashes[log.Seq] = log.Hash
+			}
+			prev = &log
+			result.Checked++
+		}
+		result.LastSeq = logs[len(logs)-1].Seq
+		lastSeq = result.LastSeq
+		if len(logs) < auditBatchSize {
+			break
+		}
+	}
+
+	// 检查点编号缺失或签名链断开说明检查点被删除, 其覆盖范围内的链可能已被重写
+	var prevCp system.SysAuditCheckpoint
+	for _, cp := range checkpoints {
+		if cp.Number != prevCp.Number+1 {
+			addIssue(cp.Seq, AuditIssueCheckpointGap, fmt.Sprintf("缺少检查点 %d-%d, 序号 %d-%d 未受检查点保护", prevCp.Number+1, cp.Number-1, prevCp.Seq+1, cp.Seq))
+		} else if cp.PrevSignature != prevCp.Signature || cp.Seq < prevCp.Seq {
+			addIssue(cp.Seq, AuditIssueCheckpointGap, fmt.Sprintf("检查点 %d 与上一个检查点不连续", cp.Number))
+		}
+		prevCp = cp
+		if !hmac.Equal([]byte(auditSign(cp)), []byte(cp.Signature)) {
+			addIssue(cp.Seq, AuditIssueCheckpointInvalid, fmt.Sprintf("检查点 %d 签名无效", cp.Number))
+			continue
+		}
+		if !inRange(cp.Seq) {
+			continue
+		}
+		result.Checkpoints++
+		if hashes[cp.Seq] != cp.Hash {
+			addIssue(cp.Seq, AuditIssueCheckpointMismatch, fmt.Sprintf("检查点 %d 记录的哈希与当前链不一致", cp.Number))
+		}
+	}
+	result.Valid = result.IssueCount == 0
+	return result, nil
+}
+
+// verifyRecords 对比仍存在的操作记录与审计内容; 定时清理会保留已审计的记录, 记录不存在时不在此处比较
+func (auditLogService *AuditLogService) verifyRecords(ctx context.Context, logs []system.SysAuditLog, addIssue func(seq uint64, typ, msg string)) error {
+	ids := make([]uint, 0, len(logs))
+	for _, log := range logs {
+		if log.RecordID != 0 {
+			ids = append(ids, log.RecordID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var records []system.SysOperationRecord
+	if err := global.GVA_DB.WithContext(ctx).Where("id in ?", ids).Find(&records).Error; err != nil {
+		return err
+	}
+	payloads := make(map[uint]string, len(records))
+	for _, record := range records {
+		payloads[record.ID] = NewAuditPayload(record)
+	}
+	for _, log := range logs {
+		if payload, ok := payloads[log.RecordID]; ok && payload != log.Payload {
+			addIssue(log.Seq, AuditIssueRecordModified, fmt.Sprintf("操作记录 %d 已被修改", log.RecordID))
+		}
+	}
+	return nil
+}
+
+//@function: GetAuditLogList
+//@description: 分页获取审计日志
+//@param: info request.PageInfo
+//@return: list interface{}, total int64, err error
+
+func (auditLogService *AuditLogService) GetAuditLogList(ctx context.Context, info request.PageInfo) (list interface{}, total int64, err error) {
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysAuditLog{})
+	var logs []system.SysAuditLog
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Scopes(info.Paginate()).Order("seq desc").Find(&logs).Error
+	return logs, total, err
+}
+
+func auditSign(cp system.SysAuditCheckpoint) string {
+	return auditHMAC(fmt.Sprintf("%d|%d|%s|%s", cp.Number, cp.Seq, cp.Hash, cp.PrevSignature))
+}
+
+// auditHMAC 使用审计密钥计算 HMAC-SHA256, 未配置时使用 jwt.signing-key
+func auditHMAC(s string) string {
+	key := global.GVA_CONFIG.Load().Audit.SigningKey
+	if key == "" {
+		key = global.GVA_CONFIG.Load().JWT.SigningKey
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditOperationRecordSink 将操作记录追加到审计哈希链, 需排在数据库输出之后以获取记录id
+type AuditOperationRecordSink struct{}
+
+func (AuditOperationRecordSink) Name() string { return "audit" }
+
+func (AuditOperationRecordSink) dependsOnFirst() {}
+
+func (AuditOperationRecordSink) Write(ctx context.Context, records []system.SysOperationRecord) error {
+	for i := range records {
+		if records[i].ID == 0 {
+			return errors.New("操作记录未写入数据库, 无法加入审计链")
+		}
+	}
+	return AuditLogServiceApp.AppendOperationRecords(ctx, records)
+}
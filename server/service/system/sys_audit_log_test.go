@@ -0,0 +1,149 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+	systemReq "server/model/system/request"
+)
+
+func TestAuditLogService_Verify(t *testing.T) {
+	setupOperationRecordDB(t)
+	if err := global.GVA_DB.AutoMigrate(&system.SysAuditLog{}, &system.SysAuditCheckpoint{}); err != nil {
+		t.Fatal(err)
+	}
+	global.UpdateConfig(func(conf *config.Server) { conf.Audit.SigningKey = "test-key" })
+	t.Cleanup(func() { global.UpdateConfig(func(conf *config.Server) { conf.Audit.SigningKey = "" }) })
+	s := &AuditLogService{}
+
+	var records []system.SysOperationRecord
+	if err := global.GVA_DB.Order("id").Limit(10).Find(&records).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AppendOperationRecords(context.Background(), records[:6]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateCheckpoint(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AppendOperationRecords(context.Background(), records[6:]); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.VerifyAuditLog(context.Background(), systemReq.SysAuditLogVerify{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || result.Checked != 10 || result.Checkpoints != 1 {
+		t.Fatalf("未篡改时应通过校验, got %+v", result)
+	}
+
+	// 修改操作记录
+	global.GVA_DB.Model(&system.SysOperationRecord{}).Where("id = ?", records[2].ID).Update("status", 200+1)
+	// 删除中间一条审计日志
+	global.GVA_DB.Where("seq = ?", 8).Delete(&system.SysAuditLog{})
+	// 篡改审计内容
+	global.GVA_DB.Model(&system.SysAuditLog{}).Where("seq = ?", 4).Update("payload", "{}")
+
+	result, err = s.VerifyAuditLog(context.Background(), systemReq.SysAuditLogVerify{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, issue := range result.Issues {
+		found[fmt.Sprintf("%s:%d", issue.Type, issue.Seq)] = true
+	}
+	if result.Valid || !found["record_modified:3"] || !found["gap:9"] || !found["hash_mismatch:4"] {
+		t.Errorf("应发现篡改, got %+v", result)
+	}
+
+	// 更换密钥后检查点签名与链哈希均校验失败
+	global.UpdateConfig(func(conf *config.Server) { conf.Audit.SigningKey = "other-key" })
+	result, _ = s.VerifyAuditLog(context.Background(), systemReq.SysAuditLogVerify{StartSeq: 1, EndSeq: 6})
+	if result.Valid {
+		t.Errorf("密钥不一致时检查点应校验失败, got %+v", result)
+	}
+}
+
+func TestAuditLogService_VerifyRecomputedChain(t *testing.T) {
+	setupOperationRecordDB(t)
+	if err := global.GVA_DB.AutoMigrate(&system.SysAuditLog{}, &system.SysAuditCheckpoint{}); err != nil {
+		t.Fatal(err)
+	}
+	global.UpdateConfig(func(conf *config.Server) { conf.Audit.SigningKey = "test-key" })
+	t.Cleanup(func() { global.UpdateConfig(func(conf *config.Server) { conf.Audit.SigningKey = "" }) })
+	s := &AuditLogService{}
+
+	var records []system.SysOperationRecord
+	if err := global.GVA_DB.Order("id").Limit(9).Find(&records).Error; err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(records); i += 3 {
+		if err := s.AppendOperationRecords(context.Background(), records[i:i+3]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.CreateCheckpoint(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 攻击者修改第5条操作记录, 不知道密钥只能用自己的密钥重算整条链, 并删除之后的检查点
+	global.GVA_DB.Model(&system.SysOperationRecord{}).Where("id = ?", records[4].ID).Update("status", 404)
+	global.UpdateConfig(func(conf *config.Server) { conf.Audit.SigningKey = "attacker-key" })
+	var logs []system.SysAuditLog
+	global.GVA_DB.Order("seq").Find(&logs)
+	prevHash := auditGenesisHash()
+	for _, log := range logs {
+		var record system.SysOperationRecord
+		global.GVA_DB.First(&record, log.RecordID)
+		payload := NewAuditPayload(record)
+		hash := AuditHash(log.Seq, prevHash, payload)
+		global.GVA_DB.Model(&log).Updates(map[string]interface{}{"payload": payload, "prev_hash": prevHash, "hash": hash})
+		prevHash = hash
+	}
+	global.UpdateConfig(func(conf *config.Server) { conf.Audit.SigningKey = "test-key" })
+	global.GVA_DB.Where("number >= ?", 2).Delete(&system.SysAuditCheckpoint{})
+
+	result, err := s.VerifyAuditLog(context.Background(), systemReq.SysAuditLogVerify{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, issue := range result.Issues {
+		found[fmt.Sprintf("%s:%d", issue.Type, issue.Seq)] = true
+	}
+	if result.Valid || !found["prev_mismatch:1"] || !found["hash_mismatch:5"] || !found["checkpoint_mismatch:3"] {
+		t.Errorf("重算后的链应校验失败, got %+v", result)
+	}
+
+	// 重新生成审计链与3个检查点
+	if err = global.GVA_DB.Where("1 = 1").Delete(&system.SysAuditCheckpoint{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB.Where("1 = 1").Delete(&system.SysAuditLog{})
+	global.GVA_DB.Order("id").Limit(9).Find(&records)
+	if err = s.AppendOperationRecords(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = s.AppendOperationRecords(context.Background(), records[:1]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = s.CreateCheckpoint(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if result, _ = s.VerifyAuditLog(context.Background(), systemReq.SysAuditLogVerify{}); !result.Valid {
+		t.Fatalf("重新生成的链应通过校验, got %+v", result)
+	}
+	// 删除中间的检查点, 编号不连续
+	global.GVA_DB.Where("number = ?", 2).Delete(&system.SysAuditCheckpoint{})
+	result, _ = s.VerifyAuditLog(context.Background(), systemReq.SysAuditLogVerify{StartSeq: 12})
+	if result.Valid || len(result.Issues) == 0 || result.Issues[0].Type != AuditIssueCheckpointGap {
+		t.Errorf("缺少检查点时应校验失败, got %+v", result)
+	}
+}
@@ -1,6 +1,8 @@
 package system
 
 import (
+	"context"
+
 	"server/global"
 	"server/model/system/response"
 )
@@ -8,15 +10,15 @@ import (
 type AutoCodeService struct{}
 
 type Database interface {
-	GetDB(businessDB string) (data []response.Db, err error)
-	GetTables(businessDB string, dbName string) (data []response.Table, err error)
-	GetColumn(businessDB string, tableName string, dbName string) (data []response.Column, err error)
+	GetDB(ctx context.Context, businessDB string) (data []response.Db, err error)
+	GetTables(ctx context.Context, businessDB string, dbName string) (data []response.Table, err error)
+	GetColumn(ctx context.Context, businessDB string, tableName string, dbName string) (data []response.Column, err error)
 }
 
 func (autoCodeService *AutoCodeService) Database(businessDB string) Database {
 
 	if businessDB == "" {
-		switch global.GVA_CONFIG.System.DbType {
+		switch global.GVA_CONFIG.Load().System.DbType {
 		case "mysql":
 			return AutoCodeMysql
 		case "pgsql":
@@ -31,7 +33,7 @@ func (autoCodeService *AutoCodeService) Database(businessDB string) Database {
 			return AutoCodeMysql
 		}
 	} else {
-		for _, info := range global.GVA_CONFIG.DBList {
+		for _, info := range global.GVA_CONFIG.Load().DBList {
 			if info.AliasName == businessDB {
 				switch info.Type {
 				case "mysql":
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"fmt"
 	"server/global"
 	"server/model/system/response"
@@ -13,13 +14,13 @@ type autoCodeMssql struct{}
 // GetDB 获取数据库的所有数据库名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeMssql) GetDB(businessDB string) (data []response.Db, err error) {
+func (s *autoCodeMssql) GetDB(ctx context.Context, businessDB string) (data []response.Db, err error) {
 	var entities []response.Db
 	sql := "select name AS 'database' from sys.databases;"
 	if businessDB == "" {
-		err = global.GVA_DB.Raw(sql).Scan(&entities).Error
+		err = global.GVA_DB.WithContext(ctx).Raw(sql).Scan(&entities).Error
 	} else {
-		err = global.GVA_DBList[businessDB].Raw(sql).Scan(&entities).Error
+		err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql).Scan(&entities).Error
 	}
 	return entities, err
 }
@@ -27,14 +28,14 @@ func (s *autoCodeMssql) GetDB(businessDB string) (data []response.Db, err error)
 // GetTables 获取数据库的所有表名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeMssql) GetTables(businessDB string, dbName string) (data []response.Table, err error) {
+func (s *autoCodeMssql) GetTables(ctx context.Context, businessDB string, dbName string) (data []response.Table, err error) {
 	var entities []response.Table
 
 	sql := fmt.Sprintf(`select name as 'table_name' from %s.DBO.sysobjects where xtype='U'`, dbName)
 	if businessDB == "" {
-		err = global.GVA_DB.Raw(sql).Scan(&entities).Error
+		err = global.GVA_DB.WithContext(ctx).Raw(sql).Scan(&entities).Error
 	} else {
-		err = global.GVA_DBList[businessDB].Raw(sql).Scan(&entities).Error
+		err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql).Scan(&entities).Error
 	}
 
 	return entities, err
@@ -43,7 +44,7 @@ func (s *autoCodeMssql) GetTables(businessDB string, dbName string) (data []resp
 // GetColumn 获取指定数据库和指定数据表的所有字段名,类型值等
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeMssql) GetColumn(businessDB string, tableName string, dbName string) (data []response.Column, err error) {
+func (s *autoCodeMssql) GetColumn(ctx context.Context, businessDB string, tableName string, dbName string) (data []response.Column, err error) {
 	var entities []response.Column
 	sql := fmt.Sprintf(`
 SELECT
@@ -74,9 +75,9 @@ ORDER BY
 `, dbName, dbName, tableName, dbName, dbName, dbName)
 
 	if businessDB == "" {
-		err = global.GVA_DB.Raw(sql).Scan(&entities).Error
+		err = global.GVA_DB.WithContext(ctx).Raw(sql).Scan(&entities).Error
 	} else {
-		err = global.GVA_DBList[businessDB].Raw(sql).Scan(&entities).Error
+		err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql).Scan(&entities).Error
 	}
 
 	return entities, err
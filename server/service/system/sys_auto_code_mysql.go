@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"server/global"
 	"server/model/system/response"
 )
@@ -12,13 +13,13 @@ type autoCodeMysql struct{}
 // GetDB 获取数据库的所有数据库名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeMysql) GetDB(businessDB string) (data []response.Db, err error) {
+func (s *autoCodeMysql) GetDB(ctx context.Context, businessDB string) (data []response.Db, err error) {
 	var entities []response.Db
 	sql := "SELECT SCHEMA_NAME AS `database` FROM INFORMATION_SCHEMA.SCHEMATA;"
 	if businessDB == "" {
-		err = global.GVA_DB.Raw(sql).Scan(&entities).Error
+		err = global.GVA_DB.WithContext(ctx).Raw(sql).Scan(&entities).Error
 	} else {
-		err = global.GVA_DBList[businessDB].Raw(sql).Scan(&entities).Error
+		err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql).Scan(&entities).Error
 	}
 	return entities, err
 }
@@ -26,13 +27,13 @@ func (s *autoCodeMysql) GetDB(businessDB string) (data []response.Db, err error)
 // GetTables 获取数据库的所有表名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeMysql) GetTables(businessDB string, dbName string) (data []response.Table, err error) {
+func (s *autoCodeMysql) GetTables(ctx context.Context, businessDB string, dbName string) (data []response.Table, err error) {
 	var entities []response.Table
 	sql := `select table_name as table_name from information_schema.tables where table_schema = ?`
 	if businessDB == "" {
-		err = global.GVA_DB.Raw(sql, dbName).Scan(&entities).Error
+		err = global.GVA_DB.WithContext(ctx).Raw(sql, dbName).Scan(&entities).Error
 	} else {
-		err = global.GVA_DBList[businessDB].Raw(sql, dbName).Scan(&entities).Error
+		err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql, dbName).Scan(&entities).Error
 	}
 
 	return entities, err
@@ -41,7 +42,7 @@ func (s *autoCodeMysql) GetTables(businessDB string, dbName string) (data []resp
 // GetColumn 获取指定数据库和指定数据表的所有字段名,类型值等
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeMysql) GetColumn(businessDB string, tableName string, dbName string) (data []response.Column, err error) {
+func (s *autoCodeMysql) GetColumn(ctx context.Context, businessDB string, tableName string, dbName string) (data []response.Column, err error) {
 	var entities []response.Column
 	sql := `
 	SELECT 
@@ -74,9 +75,9 @@ WHERE
 ORDER BY 
     c.ORDINAL_POSITION;`
 	if businessDB == "" {
-		err = global.GVA_DB.Raw(sql, tableName, dbName).Scan(&entities).Error
+		err = global.GVA_DB.WithContext(ctx).Raw(sql, tableName, dbName).Scan(&entities).Error
 	} else {
-		err = global.GVA_DBList[businessDB].Raw(sql, tableName, dbName).Scan(&entities).Error
+		err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql, tableName, dbName).Scan(&entities).Error
 	}
 
 	return entities, err
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"server/global"
 	"server/model/system/response"
 )
@@ -12,28 +13,28 @@ type autoCodeOracle struct{}
 // GetDB 获取数据库的所有数据库名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeOracle) GetDB(businessDB string) (data []response.Db, err error) {
+func (s *autoCodeOracle) GetDB(ctx context.Context, businessDB string) (data []response.Db, err error) {
 	var entities []response.Db
 	sql := `SELECT lower(username) AS "database" FROM all_users`
-	err = global.GVA_DBList[businessDB].Raw(sql).Scan(&entities).Error
+	err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql).Scan(&entities).Error
 	return entities, err
 }
 
 // GetTables 获取数据库的所有表名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeOracle) GetTables(businessDB string, dbName string) (data []response.Table, err error) {
+func (s *autoCodeOracle) GetTables(ctx context.Context, businessDB string, dbName string) (data []response.Table, err error) {
 	var entities []response.Table
 	sql := `select lower(table_name) as "table_name" from all_tables where lower(owner) = ?`
 
-	err = global.GVA_DBList[businessDB].Raw(sql, dbName).Scan(&entities).Error
+	err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql, dbName).Scan(&entities).Error
 	return entities, err
 }
 
 // GetColumn 获取指定数据库和指定数据表的所有字段名,类型值等
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (s *autoCodeOracle) GetColumn(businessDB string, tableName string, dbName string) (data []response.Column, err error) {
+func (s *autoCodeOracle) GetColumn(ctx context.Context, businessDB string, tableName string, dbName string) (data []response.Column, err error) {
 	var entities []response.Column
 	sql := `
 	SELECT
@@ -67,6 +68,6 @@ ORDER BY
     a.COLUMN_ID;
 `
 
-	err = global.GVA_DBList[businessDB].Raw(sql, tableName, dbName).Scan(&entities).Error
+	err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql, tableName, dbName).Scan(&entities).Error
 	return entities, err
 }
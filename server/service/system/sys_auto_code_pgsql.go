@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"server/global"
 	"server/model/system/response"
 )
@@ -12,13 +13,13 @@ type autoCodePgsql struct{}
 // GetDB 获取数据库的所有数据库名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (a *autoCodePgsql) GetDB(businessDB string) (data []response.Db, err error) {
+func (a *autoCodePgsql) GetDB(ctx context.Context, businessDB string) (data []response.Db, err error) {
 	var entities []response.Db
 	sql := `SELECT datname as database FROM pg_database WHERE datistemplate = false`
 	if businessDB == "" {
-		err = global.GVA_DB.Raw(sql).Scan(&entities).Error
+		err = global.GVA_DB.WithContext(ctx).Raw(sql).Scan(&entities).Error
 	} else {
-		err = global.GVA_DBList[businessDB].Raw(sql).Scan(&entities).Error
+		err = global.GVA_DBList[businessDB].WithContext(ctx).Raw(sql).Scan(&entities).Error
 	}
 
 	return entities, err
@@ -27,13 +28,13 @@ func (a *autoCodePgsql) GetDB(businessDB string) (data []response.Db, err error)
 // GetTables 获取数据库的所有表名
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (a *autoCodePgsql) GetTables(businessDB string, dbName string) (data []response.Table, err error) {
+func (a *autoCodePgsql) GetTables(ctx context.Context, businessDB string, dbName string) (data []response.Table, err error) {
 	var entities []response.Table
 	sql := `select table_name as table_name from information_schema.tables where table_catalog = ? and table_schema = ?`
 
-	db := global.GVA_DB
+	db := global.GVA_DB.WithContext(ctx)
 	if businessDB != "" {
-		db = global.GVA_DBList[businessDB]
+		db = global.GVA_DBList[businessDB].WithContext(ctx)
 	}
 
 	err = db.Raw(sql, dbName, "public").Scan(&entities).Error
@@ -43,7 +44,7 @@ func (a *autoCodePgsql) GetTables(businessDB string, dbName string) (data []resp
 // GetColumn 获取指定数据库和指定数据表的所有字段名,类型值等
 // Author [piexlmax](https://github.com/piexlmax)
 // Author [SliverHorn](https://github.com/SliverHorn)
-func (a *autoCodePgsql) GetColumn(businessDB string, tableName string, dbName string) (data []response.Column, err error) {
+func (a *autoCodePgsql) GetColumn(ctx context.Context, businessDB string, tableName string, dbName string) (data []response.Column, err error) {
 	// todo 数据获取不全, 待完善sql
 	sql := `
 SELECT
@@ -125,9 +126,9 @@ ORDER BY
 	var entities []response.Column
 	//sql = strings.ReplaceAll(sql, "@table_catalog", dbName)
 	//sql = strings.ReplaceAll(sql, "@table_name", tableName)
-	db := global.GVA_DB
+	db := global.GVA_DB.WithContext(ctx)
 	if businessDB != "" {
-		db = global.GVA_DBList[businessDB]
+		db = global.GVA_DBList[businessDB].WithContext(ctx)
 	}
 
 	err = db.Raw(sql, dbName, tableName).Scan(&entities).Error
@@ -0,0 +1,103 @@
+package system
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"server/global"
+	"server/model/system/request"
+	"server/utils/autocode"
+
+	"github.com/pkg/errors"
+)
+
+// Spec 由已有数据表或建表语句生成完整的 AutoCode, 字段类型、搜索方式、必填、字典和主键均按列信息推断
+// Author [yourname](https://github.com/yourname)
+func (autoCodeService *AutoCodeService) Spec(ctx context.Context, info request.AutoCodeSpec) (*request.AutoCode, error) {
+	var (
+		table   string
+		comment string
+		columns []autocode.Column
+		err     error
+	)
+	if strings.TrimSpace(info.DDL) != "" {
+		table, comment, columns, err = autocode.ParseDDL(info.DDL)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if info.TableName == "" {
+			return nil, errors.New("请填写表名或建表语句!")
+		}
+		table = info.TableName
+		columns, err = autoCodeService.columns(ctx, info.BusinessDB, info.TableName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	spec := autocode.Spec(table, comment, columns)
+	spec.Package = info.Package
+	spec.BusinessDB = info.BusinessDB
+	spec.AutoMigrate = info.DDL != "" // 已有数据表不再自动迁移
+	if autoCodeService.dbType(info.BusinessDB) == "oracle" {
+		for _, field := range spec.Fields {
+			field.ColumnName = strings.ToUpper(field.ColumnName)
+		}
+	}
+	return &spec, nil
+}
+
+// columns 通过 gorm Migrator 读取列信息, 各数据库方言通用
+func (autoCodeService *AutoCodeService) columns(ctx context.Context, businessDB string, tableName string) ([]autocode.Column, error) {
+	db := global.GVA_DB
+	if businessDB != "" {
+		var ok bool
+		if db, ok = global.GVA_DBList[businessDB]; !ok {
+			return nil, errors.Errorf("业务数据库[%s]未配置!", businessDB)
+		}
+	}
+	db = db.WithContext(ctx)
+	if !db.Migrator().HasTable(tableName) {
+		return nil, errors.Errorf("数据表[%s]不存在!", tableName)
+	}
+	columnTypes, err := db.Migrator().ColumnTypes(tableName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "读取数据表[%s]的列失败!", tableName)
+	}
+	columns := make([]autocode.Column, 0, len(columnTypes))
+	for _, columnType := range columnTypes {
+		column := autocode.Column{Name: columnType.Name(), DataType: strings.ToLower(columnType.DatabaseTypeName())}
+		if full, ok := columnType.ColumnType(); ok {
+			if start, end := strings.Index(full, "("), strings.LastIndex(full, ")"); start != -1 && end > start {
+				column.Length = strings.ReplaceAll(full[start+1:end], " ", "")
+			}
+		}
+		if column.Length == "" {
+			if precision, scale, ok := columnType.DecimalSize(); ok && precision > 0 {
+				column.Length = strconv.FormatInt(precision, 10) + "," + strconv.FormatInt(scale, 10)
+			} else if length, ok := columnType.Length(); ok && length > 0 {
+				column.Length = strconv.FormatInt(length, 10)
+			}
+		}
+		column.PrimaryKey, _ = columnType.PrimaryKey()
+		column.AutoIncrement, _ = columnType.AutoIncrement()
+		column.Comment, _ = columnType.Comment()
+		column.Nullable, _ = columnType.Nullable()
+		_, column.HasDefault = columnType.DefaultValue()
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func (autoCodeService *AutoCodeService) dbType(businessDB string) string {
+	if businessDB == "" {
+		return global.GVA_CONFIG.Load().System.DbType
+	}
+	for _, info := range global.GVA_CONFIG.Load().DBList {
+		if info.AliasName == businessDB {
+			return info.Type
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"server/global"
+	"server/model/system/request"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAutoCodeService_Spec(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.Exec("CREATE TABLE books (id integer primary key autoincrement, title varchar(128) NOT NULL, price decimal(10,2), published_at datetime)").Error; err != nil {
+		t.Fatal(err)
+	}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+
+	service := new(AutoCodeService)
+	spec, err := service.Spec(context.Background(), request.AutoCodeSpec{TableName: "books", Package: "example"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.StructName != "Books" || spec.Package != "example" || spec.AutoMigrate || len(spec.Fields) != 4 {
+		t.Fatalf("Spec() = %+v", spec)
+	}
+	if id := spec.Fields[0]; !id.PrimaryKey || id.FieldType != "int" || id.Require {
+		t.Errorf("id = %+v", id)
+	}
+	if title := spec.Fields[1]; title.FieldType != "string" || title.DataTypeLong != "128" || !title.Require {
+		t.Errorf("title = %+v", title)
+	}
+	if price := spec.Fields[2]; price.FieldType != "float64" || price.Require {
+		t.Errorf("price = %+v", price)
+	}
+	if published := spec.Fields[3]; published.FieldType != "time.Time" || published.FieldSearchType != "BETWEEN" {
+		t.Errorf("published_at = %+v", published)
+	}
+	if _, err = service.Spec(context.Background(), request.AutoCodeSpec{TableName: "missing"}); err == nil {
+		t.Error("Spec() 不存在的表应返回错误")
+	}
+}
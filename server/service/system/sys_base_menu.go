@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 
 	"server/global"
@@ -18,21 +19,21 @@ type BaseMenuService struct{}
 
 var BaseMenuServiceApp = new(BaseMenuService)
 
-func (baseMenuService *BaseMenuService) DeleteBaseMenu(id int) (err error) {
-	err = global.GVA_DB.First(&system.SysBaseMenu{}, "parent_id = ?", id).Error
+func (baseMenuService *BaseMenuService) DeleteBaseMenu(ctx context.Context, id int) (err error) {
+	err = global.GVA_DB.WithContext(ctx).First(&system.SysBaseMenu{}, "parent_id = ?", id).Error
 	if err == nil {
 		return errors.New("此菜单存在子菜单不可删除")
 	}
 	var menu system.SysBaseMenu
-	err = global.GVA_DB.First(&menu, id).Error
+	err = global.GVA_DB.WithContext(ctx).First(&menu, id).Error
 	if err != nil {
 		return errors.New("记录不存在")
 	}
-	err = global.GVA_DB.First(&system.SysAuthority{}, "default_router = ?", menu.Name).Error
+	err = global.GVA_DB.WithContext(ctx).First(&system.SysAuthority{}, "default_router = ?", menu.Name).Error
 	if err == nil {
 		return errors.New("此菜单有角色正在作为首页，不可删除")
 	}
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 
 		err = tx.Delete(&system.SysBaseMenu{}, "id = ?", id).Error
 		if err != nil {
@@ -68,7 +69,7 @@ func (baseMenuService *BaseMenuService) DeleteBaseMenu(id int) (err error) {
 //@param: menu model.SysBaseMenu
 //@return: err error
 
-func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu) (err error) {
+func (baseMenuService *BaseMenuService) UpdateBaseMenu(ctx context.Context, menu system.SysBaseMenu) (err error) {
 	var oldMenu system.SysBaseMenu
 	upDateMap := make(map[string]interface{})
 	upDateMap["keep_alive"] = menu.KeepAlive
@@ -85,7 +86,7 @@ func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu)
 	upDateMap["icon"] = menu.Icon
 	upDateMap["sort"] = menu.Sort
 
-	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	err = global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		tx.Where("id = ?", menu.ID).Find(&oldMenu)
 		if oldMenu.Name != menu.Name {
 			if !errors.Is(tx.Where("id <> ? AND name = ?", menu.ID, menu.Name).First(&system.SysBaseMenu{}).Error, gorm.ErrRecordNotFound) {
@@ -141,7 +142,7 @@ func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu)
 //@param: id float64
 //@return: menu system.SysBaseMenu, err error
 
-func (baseMenuService *BaseMenuService) GetBaseMenuById(id int) (menu system.SysBaseMenu, err error) {
-	err = global.GVA_DB.Preload("MenuBtn").Preload("Parameters").Where("id = ?", id).First(&menu).Error
+func (baseMenuService *BaseMenuService) GetBaseMenuById(ctx context.Context, id int) (menu system.SysBaseMenu, err error) {
+	err = global.GVA_DB.WithContext(ctx).Preload("MenuBtn").Preload("Parameters").Where("id = ?", id).First(&menu).Error
 	return
 }
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"strconv"
 
@@ -23,15 +24,15 @@ type CasbinService struct{}
 
 var CasbinServiceApp = new(CasbinService)
 
-func (casbinService *CasbinService) UpdateCasbin(adminAuthorityID, AuthorityID uint, casbinInfos []request.CasbinInfo) error {
+func (casbinService *CasbinService) UpdateCasbin(ctx context.Context, adminAuthorityID, AuthorityID uint, casbinInfos []request.CasbinInfo) error {
 
-	err := AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, AuthorityID)
+	err := AuthorityServiceApp.CheckAuthorityIDAuth(ctx, adminAuthorityID, AuthorityID)
 	if err != nil {
 		return err
 	}
 
-	if global.GVA_CONFIG.System.UseStrictAuth {
-		apis, e := ApiServiceApp.GetAllApis(adminAuthorityID)
+	if global.GVA_CONFIG.Load().System.UseStrictAuth {
+		apis, e := ApiServiceApp.GetAllApis(ctx, adminAuthorityID)
 		if e != nil {
 			return e
 		}
@@ -79,8 +80,8 @@ func (casbinService *CasbinService) UpdateCasbin(adminAuthorityID, AuthorityID u
 //@param: oldPath string, newPath string, oldMethod string, newMethod string
 //@return: error
 
-func (casbinService *CasbinService) UpdateCasbinApi(oldPath string, newPath string, oldMethod string, newMethod string) error {
-	err := global.GVA_DB.Model(&gormadapter.CasbinRule{}).Where("v1 = ? AND v2 = ?", oldPath, oldMethod).Updates(map[string]interface{}{
+func (casbinService *CasbinService) UpdateCasbinApi(ctx context.Context, oldPath string, newPath string, oldMethod string, newMethod string) error {
+	err := global.GVA_DB.WithContext(ctx).Model(&gormadapter.CasbinRule{}).Where("v1 = ? AND v2 = ?", oldPath, oldMethod).Updates(map[string]interface{}{
 		"v1": newPath,
 		"v2": newMethod,
 	}).Error
@@ -0,0 +1,33 @@
+package system
+
+import (
+	"context"
+	"server/global"
+	"server/model/system"
+	"server/model/system/request"
+)
+
+type ChangeHistoryService struct{}
+
+var ChangeHistoryServiceApp = new(ChangeHistoryService)
+
+//@function: GetChangeHistory
+//@description: 分页获取某条记录的数据变更历史, 按时间倒序
+//@param: info request.SysChangeHistorySearch
+//@return: list interface{}, total int64, err error
+
+func (changeHistoryService *ChangeHistoryService) GetChangeHistory(ctx context.Context, info request.SysChangeHistorySearch) (list interface{}, total int64, err error) {
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysChangeHistory{}).Where("table_name = ?", info.Table)
+	if info.RecordID != "" {
+		db = db.Where("record_id = ?", info.RecordID)
+	}
+	if info.Action != "" {
+		db = db.Where("action = ?", info.Action)
+	}
+	var histories []system.SysChangeHistory
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Scopes(info.Paginate()).Order("id desc").Find(&histories).Error
+	return histories, total, err
+}
@@ -0,0 +1,65 @@
+package system
+
+import (
+	"context"
+	"server/global"
+	"server/model/system"
+
+	"gorm.io/gorm"
+)
+
+//@function: GetDataAuthorityIds
+//@description: 获取角色的资源权限, 即该角色可以访问哪些角色的数据
+//@param: authorityID uint
+//@return: authorityIds []uint, err error
+
+func (authorityService *AuthorityService) GetDataAuthorityIds(ctx context.Context, authorityID uint) (authorityIds []uint, err error) {
+	authority, err := authorityService.GetAuthorityInfo(ctx, system.SysAuthority{AuthorityId: authorityID})
+	if err != nil {
+		return nil, err
+	}
+	authorityIds = make([]uint, 0, len(authority.DataAuthorityId))
+	for _, v := range authority.DataAuthorityId {
+		authorityIds = append(authorityIds, v.AuthorityId)
+	}
+	return authorityIds, nil
+}
+
+//@function: GetDataAuthorityUserIds
+//@description: 获取角色资源权限范围内的用户ID, 即拥有任一可访问角色的用户
+//@param: authorityIds []uint
+//@return: userIds []uint, err error
+
+func (authorityService *AuthorityService) GetDataAuthorityUserIds(ctx context.Context, authorityIds []uint) (userIds []uint, err error) {
+	userIds = make([]uint, 0)
+	if len(authorityIds) == 0 {
+		return userIds, nil
+	}
+	err = global.GVA_DB.WithContext(ctx).Model(&system.SysUserAuthority{}).Distinct("sys_user_id").
+		Where("sys_authority_authority_id IN ?", authorityIds).Pluck("sys_user_id", &userIds).Error
+	return userIds, err
+}
+
+//@function: HasAllDataAuthority
+//@description: 资源权限是否覆盖全部角色, 覆盖时可以访问无法按用户限定范围的数据
+//@param: authorityIds []uint
+//@return: bool, error
+
+func (authorityService *AuthorityService) HasAllDataAuthority(ctx context.Context, authorityIds []uint) (bool, error) {
+	if len(authorityIds) == 0 {
+		return false, nil
+	}
+	var count int64
+	err := global.GVA_DB.WithContext(ctx).Model(&system.SysAuthority{}).Where("authority_id NOT IN ?", authorityIds).Count(&count).Error
+	return count == 0, err
+}
+
+// dataAuthorityScope 只保留 column 列属于 ids 的数据, ids 为空时不返回任何数据
+func dataAuthorityScope(column string, ids []uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(ids) == 0 {
+			return db.Where("1 = 0")
+		}
+		return db.Where(column+" IN ?", ids)
+	}
+}
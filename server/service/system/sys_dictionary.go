@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 
 	"server/global"
@@ -18,11 +19,11 @@ type DictionaryService struct{}
 
 var DictionaryServiceApp = new(DictionaryService)
 
-func (dictionaryService *DictionaryService) CreateSysDictionary(sysDictionary system.SysDictionary) (err error) {
-	if (!errors.Is(global.GVA_DB.First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound)) {
+func (dictionaryService *DictionaryService) CreateSysDictionary(ctx context.Context, sysDictionary system.SysDictionary) (err error) {
+	if (!errors.Is(global.GVA_DB.WithContext(ctx).First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound)) {
 		return errors.New("存在相同的type，不允许创建")
 	}
-	err = global.GVA_DB.Create(&sysDictionary).Error
+	err = global.GVA_DB.WithContext(ctx).Create(&sysDictionary).Error
 	return err
 }
 
@@ -32,21 +33,21 @@ func (dictionaryService *DictionaryService) CreateSysDictionary(sysDictionary sy
 //@param: sysDictionary model.SysDictionary
 //@return: err error
 
-func (dictionaryService *DictionaryService) DeleteSysDictionary(sysDictionary system.SysDictionary) (err error) {
-	err = global.GVA_DB.Where("id = ?", sysDictionary.ID).Preload("SysDictionaryDetails").First(&sysDictionary).Error
+func (dictionaryService *DictionaryService) DeleteSysDictionary(ctx context.Context, sysDictionary system.SysDictionary) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", sysDictionary.ID).Preload("SysDictionaryDetails").First(&sysDictionary).Error
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.New("请不要搞事")
 	}
 	if err != nil {
 		return err
 	}
-	err = global.GVA_DB.Delete(&sysDictionary).Error
+	err = global.GVA_DB.WithContext(ctx).Delete(&sysDictionary).Error
 	if err != nil {
 		return err
 	}
 
 	if sysDictionary.SysDictionaryDetails != nil {
-		return global.GVA_DB.Where("sys_dictionary_id=?", sysDictionary.ID).Delete(sysDictionary.SysDictionaryDetails).Error
+		return global.GVA_DB.WithContext(ctx).Where("sys_dictionary_id=?", sysDictionary.ID).Delete(sysDictionary.SysDictionaryDetails).Error
 	}
 	return
 }
@@ -57,7 +58,7 @@ func (dictionaryService *DictionaryService) DeleteSysDictionary(sysDictionary sy
 //@param: sysDictionary *model.SysDictionary
 //@return: err error
 
-func (dictionaryService *DictionaryService) UpdateSysDictionary(sysDictionary *system.SysDictionary) (err error) {
+func (dictionaryService *DictionaryService) UpdateSysDictionary(ctx context.Context, sysDictionary *system.SysDictionary) (err error) {
 	var dict system.SysDictionary
 	sysDictionaryMap := map[string]interface{}{
 		"Name":   sysDictionary.Name,
@@ -65,17 +66,17 @@ func (dictionaryService *DictionaryService) UpdateSysDictionary(sysDictionary *s
 		"Status": sysDictionary.Status,
 		"Desc":   sysDictionary.Desc,
 	}
-	err = global.GVA_DB.Where("id = ?", sysDictionary.ID).First(&dict).Error
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", sysDictionary.ID).First(&dict).Error
 	if err != nil {
 		global.GVA_LOG.Debug(err.Error())
 		return errors.New("查询字典数据失败")
 	}
 	if dict.Type != sysDictionary.Type {
-		if !errors.Is(global.GVA_DB.First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound) {
+		if !errors.Is(global.GVA_DB.WithContext(ctx).First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound) {
 			return errors.New("存在相同的type，不允许创建")
 		}
 	}
-	err = global.GVA_DB.Model(&dict).Updates(sysDictionaryMap).Error
+	err = global.GVA_DB.WithContext(ctx).Model(&dict).Updates(sysDictionaryMap).Error
 	return err
 }
 
@@ -85,14 +86,14 @@ func (dictionaryService *DictionaryService) UpdateSysDictionary(sysDictionary *s
 //@param: Type string, Id uint
 //@return: err error, sysDictionary model.SysDictionary
 
-func (dictionaryService *DictionaryService) GetSysDictionary(Type string, Id uint, status *bool) (sysDictionary system.SysDictionary, err error) {
+func (dictionaryService *DictionaryService) GetSysDictionary(ctx context.Context, Type string, Id uint, status *bool) (sysDictionary system.SysDictionary, err error) {
 	var flag = false
 	if status == nil {
 		flag = true
 	} else {
 		flag = *status
 	}
-	err = global.GVA_DB.Where("(type = ? OR id = ?) and status = ?", Type, Id, flag).Preload("SysDictionaryDetails", func(db *gorm.DB) *gorm.DB {
+	err = global.GVA_DB.WithContext(ctx).Where("(type = ? OR id = ?) and status = ?", Type, Id, flag).Preload("SysDictionaryDetails", func(db *gorm.DB) *gorm.DB {
 		return db.Where("status = ?", true).Order("sort")
 	}).First(&sysDictionary).Error
 	return
@@ -105,8 +106,8 @@ func (dictionaryService *DictionaryService) GetSysDictionary(Type string, Id uin
 //@param: info request.SysDictionarySearch
 //@return: err error, list interface{}, total int64
 
-func (dictionaryService *DictionaryService) GetSysDictionaryInfoList() (list interface{}, err error) {
+func (dictionaryService *DictionaryService) GetSysDictionaryInfoList(ctx context.Context) (list interface{}, err error) {
 	var sysDictionarys []system.SysDictionary
-	err = global.GVA_DB.Find(&sysDictionarys).Error
+	err = global.GVA_DB.WithContext(ctx).Find(&sysDictionarys).Error
 	return sysDictionarys, err
 }
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"server/global"
 	"server/model/system"
 	"server/model/system/request"
@@ -16,8 +17,8 @@ type DictionaryDetailService struct{}
 
 var DictionaryDetailServiceApp = new(DictionaryDetailService)
 
-func (dictionaryDetailService *DictionaryDetailService) CreateSysDictionaryDetail(sysDictionaryDetail system.SysDictionaryDetail) (err error) {
-	err = global.GVA_DB.Create(&sysDictionaryDetail).Error
+func (dictionaryDetailService *DictionaryDetailService) CreateSysDictionaryDetail(ctx context.Context, sysDictionaryDetail system.SysDictionaryDetail) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Create(&sysDictionaryDetail).Error
 	return err
 }
 
@@ -27,8 +28,8 @@ func (dictionaryDetailService *DictionaryDetailService) CreateSysDictionaryDetai
 //@param: sysDictionaryDetail model.SysDictionaryDetail
 //@return: err error
 
-func (dictionaryDetailService *DictionaryDetailService) DeleteSysDictionaryDetail(sysDictionaryDetail system.SysDictionaryDetail) (err error) {
-	err = global.GVA_DB.Delete(&sysDictionaryDetail).Error
+func (dictionaryDetailService *DictionaryDetailService) DeleteSysDictionaryDetail(ctx context.Context, sysDictionaryDetail system.SysDictionaryDetail) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Delete(&sysDictionaryDetail).Error
 	return err
 }
 
@@ -38,8 +39,8 @@ func (dictionaryDetailService *DictionaryDetailService) DeleteSysDictionaryDetai
 //@param: sysDictionaryDetail *model.SysDictionaryDetail
 //@return: err error
 
-func (dictionaryDetailService *DictionaryDetailService) UpdateSysDictionaryDetail(sysDictionaryDetail *system.SysDictionaryDetail) (err error) {
-	err = global.GVA_DB.Save(sysDictionaryDetail).Error
+func (dictionaryDetailService *DictionaryDetailService) UpdateSysDictionaryDetail(ctx context.Context, sysDictionaryDetail *system.SysDictionaryDetail) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Save(sysDictionaryDetail).Error
 	return err
 }
 
@@ -49,8 +50,8 @@ func (dictionaryDetailService *DictionaryDetailService) UpdateSysDictionaryDetai
 //@param: id uint
 //@return: sysDictionaryDetail system.SysDictionaryDetail, err error
 
-func (dictionaryDetailService *DictionaryDetailService) GetSysDictionaryDetail(id uint) (sysDictionaryDetail system.SysDictionaryDetail, err error) {
-	err = global.GVA_DB.Where("id = ?", id).First(&sysDictionaryDetail).Error
+func (dictionaryDetailService *DictionaryDetailService) GetSysDictionaryDetail(ctx context.Context, id uint) (sysDictionaryDetail system.SysDictionaryDetail, err error) {
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", id).First(&sysDictionaryDetail).Error
 	return
 }
 
@@ -60,11 +61,11 @@ func (dictionaryDetailService *DictionaryDetailService) GetSysDictionaryDetail(i
 //@param: info request.SysDictionaryDetailSearch
 //@return: list interface{}, total int64, err error
 
-func (dictionaryDetailService *DictionaryDetailService) GetSysDictionaryDetailInfoList(info request.SysDictionaryDetailSearch) (list interface{}, total int64, err error) {
+func (dictionaryDetailService *DictionaryDetailService) GetSysDictionaryDetailInfoList(ctx context.Context, info request.SysDictionaryDetailSearch) (list interface{}, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	// 创建db
-	db := global.GVA_DB.Model(&system.SysDictionaryDetail{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysDictionaryDetail{})
 	var sysDictionaryDetails []system.SysDictionaryDetail
 	// 如果有条件搜索 下方会自动创建搜索语句
 	if info.Label != "" {
@@ -88,31 +89,31 @@ func (dictionaryDetailService *DictionaryDetailService) GetSysDictionaryDetailIn
 }
 
 // 按照字典id获取字典全部内容的方法
-func (dictionaryDetailService *DictionaryDetailService) GetDictionaryList(dictionaryID uint) (list []system.SysDictionaryDetail, err error) {
+func (dictionaryDetailService *DictionaryDetailService) GetDictionaryList(ctx context.Context, dictionaryID uint) (list []system.SysDictionaryDetail, err error) {
 	var sysDictionaryDetails []system.SysDictionaryDetail
-	err = global.GVA_DB.Find(&sysDictionaryDetails, "sys_dictionary_id = ?", dictionaryID).Error
+	err = global.GVA_DB.WithContext(ctx).Find(&sysDictionaryDetails, "sys_dictionary_id = ?", dictionaryID).Error
 	return sysDictionaryDetails, err
 }
 
 // 按照字典type获取字典全部内容的方法
-func (dictionaryDetailService *DictionaryDetailService) GetDictionaryListByType(t string) (list []system.SysDictionaryDetail, err error) {
+func (dictionaryDetailService *DictionaryDetailService) GetDictionaryListByType(ctx context.Context, t string) (list []system.SysDictionaryDetail, err error) {
 	var sysDictionaryDetails []system.SysDictionaryDetail
-	db := global.GVA_DB.Model(&system.SysDictionaryDetail{}).Joins("JOIN sys_dictionaries ON sys_dictionaries.id = sys_dictionary_details.sys_dictionary_id")
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysDictionaryDetail{}).Joins("JOIN sys_dictionaries ON sys_dictionaries.id = sys_dictionary_details.sys_dictionary_id")
 	err = db.Debug().Find(&sysDictionaryDetails, "type = ?", t).Error
 	return sysDictionaryDetails, err
 }
 
 // 按照字典id+字典内容value获取单条字典内容
-func (dictionaryDetailService *DictionaryDetailService) GetDictionaryInfoByValue(dictionaryID uint, value string) (detail system.SysDictionaryDetail, err error) {
+func (dictionaryDetailService *DictionaryDetailService) GetDictionaryInfoByValue(ctx context.Context, dictionaryID uint, value string) (detail system.SysDictionaryDetail, err error) {
 	var sysDictionaryDetail system.SysDictionaryDetail
-	err = global.GVA_DB.First(&sysDictionaryDetail, "sys_dictionary_id = ? and value = ?", dictionaryID, value).Error
+	err = global.GVA_DB.WithContext(ctx).First(&sysDictionaryDetail, "sys_dictionary_id = ? and value = ?", dictionaryID, value).Error
 	return sysDictionaryDetail, err
 }
 
 // 按照字典type+字典内容value获取单条字典内容
-func (dictionaryDetailService *DictionaryDetailService) GetDictionaryInfoByTypeValue(t string, value string) (detail system.SysDictionaryDetail, err error) {
+func (dictionaryDetailService *DictionaryDetailService) GetDictionaryInfoByTypeValue(ctx context.Context, t string, value string) (detail system.SysDictionaryDetail, err error) {
 	var sysDictionaryDetails system.SysDictionaryDetail
-	db := global.GVA_DB.Model(&system.SysDictionaryDetail{}).Joins("JOIN sys_dictionaries ON sys_dictionaries.id = sys_dictionary_details.sys_dictionary_id")
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysDictionaryDetail{}).Joins("JOIN sys_dictionaries ON sys_dictionaries.id = sys_dictionary_details.sys_dictionary_id")
 	err = db.First(&sysDictionaryDetails, "sys_dictionaries.type = ? and sys_dictionary_details.value = ?", t, value).Error
 	return sysDictionaryDetails, err
 }
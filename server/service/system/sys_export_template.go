@@ -2,6 +2,7 @@ package system
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,29 +28,29 @@ var SysExportTemplateServiceApp = new(SysExportTemplateService)
 
 // CreateSysExportTemplate 创建导出模板记录
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) CreateSysExportTemplate(sysExportTemplate *system.SysExportTemplate) (err error) {
-	err = global.GVA_DB.Create(sysExportTemplate).Error
+func (sysExportTemplateService *SysExportTemplateService) CreateSysExportTemplate(ctx context.Context, sysExportTemplate *system.SysExportTemplate) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Create(sysExportTemplate).Error
 	return err
 }
 
 // DeleteSysExportTemplate 删除导出模板记录
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) DeleteSysExportTemplate(sysExportTemplate system.SysExportTemplate) (err error) {
-	err = global.GVA_DB.Delete(&sysExportTemplate).Error
+func (sysExportTemplateService *SysExportTemplateService) DeleteSysExportTemplate(ctx context.Context, sysExportTemplate system.SysExportTemplate) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Delete(&sysExportTemplate).Error
 	return err
 }
 
 // DeleteSysExportTemplateByIds 批量删除导出模板记录
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) DeleteSysExportTemplateByIds(ids request.IdsReq) (err error) {
-	err = global.GVA_DB.Delete(&[]system.SysExportTemplate{}, "id in ?", ids.Ids).Error
+func (sysExportTemplateService *SysExportTemplateService) DeleteSysExportTemplateByIds(ctx context.Context, ids request.IdsReq) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Delete(&[]system.SysExportTemplate{}, "id in ?", ids.Ids).Error
 	return err
 }
 
 // UpdateSysExportTemplate 更新导出模板记录
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) UpdateSysExportTemplate(sysExportTemplate system.SysExportTemplate) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+func (sysExportTemplateService *SysExportTemplateService) UpdateSysExportTemplate(ctx context.Context, sysExportTemplate system.SysExportTemplate) (err error) {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		conditions := sysExportTemplate.Conditions
 		e := tx.Delete(&[]system.Condition{}, "template_id = ?", sysExportTemplate.TemplateID).Error
 		if e != nil {
@@ -86,18 +87,18 @@ func (sysExportTemplateService *SysExportTemplateService) UpdateSysExportTemplat
 
 // GetSysExportTemplate 根据id获取导出模板记录
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) GetSysExportTemplate(id uint) (sysExportTemplate system.SysExportTemplate, err error) {
-	err = global.GVA_DB.Where("id = ?", id).Preload("JoinTemplate").Preload("Conditions").First(&sysExportTemplate).Error
+func (sysExportTemplateService *SysExportTemplateService) GetSysExportTemplate(ctx context.Context, id uint) (sysExportTemplate system.SysExportTemplate, err error) {
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", id).Preload("JoinTemplate").Preload("Conditions").First(&sysExportTemplate).Error
 	return
 }
 
 // GetSysExportTemplateInfoList 分页获取导出模板记录
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) GetSysExportTemplateInfoList(info systemReq.SysExportTemplateSearch) (list []system.SysExportTemplate, total int64, err error) {
+func (sysExportTemplateService *SysExportTemplateService) GetSysExportTemplateInfoList(ctx context.Context, info systemReq.SysExportTemplateSearch) (list []system.SysExportTemplate, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	// 创建db
-	db := global.GVA_DB.Model(&system.SysExportTemplate{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysExportTemplate{})
 	var sysExportTemplates []system.SysExportTemplate
 	// 如果有条件搜索 下方会自动创建搜索语句
 	if info.StartCreatedAt != nil && info.EndCreatedAt != nil {
@@ -127,14 +128,13 @@ func (sysExportTemplateService *SysExportTemplateService) GetSysExportTemplateIn
 
 // ExportExcel 导出Excel
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID string, values url.Values) (file *bytes.Buffer, name string, err error) {
+func (sysExportTemplateService *SysExportTemplateService) ExportExcel(ctx context.Context, templateID string, values url.Values) (file *bytes.Buffer, name string, err error) {
 	var params = values.Get("params")
 	paramsValues, err := url.ParseQuery(params)
 	if err != nil {
 		return nil, "", fmt.Errorf("解析 params 参数失败: %v", err)
 	}
-	var template system.SysExportTemplate
-	err = global.GVA_DB.Preload("Conditions").Preload("JoinTemplate").First(&template, "template_id = ?", templateID).Error
+	template, rows, err := sysExportTemplateService.exportRows(ctx, templateID, paramsValues, nil)
 	if err != nil {
 		return nil, "", err
 	}
@@ -150,14 +150,79 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 		fmt.Println(err)
 		return
 	}
+	for i, row := range rows {
+		for j, colCell := range row {
+			cell := fmt.Sprintf("%s%d", getColumnName(j+1), i+1)
+
+ 			var sErr error
+ 			if v, err := strconv.ParseFloat(colCell, 64); err == nil {
+ 			    sErr = f.SetCellValue("Sheet1", cell, v)
+ 			} else if v, err := strconv.ParseInt(colCell, 10, 64); err == nil {
+ 			    sErr = f.SetCellValue("Sheet1", cell, v)
+ 			} else {
+ 			    sErr = f.SetCellValue("Sheet1", cell, colCell)
+ 			}
+
+			if sErr != nil {
+				return nil, "", sErr
+			}
+		}
+	}
+	f.SetActiveSheet(index)
+	file, err = f.WriteToBuffer()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return file, template.Name, nil
+}
+
+// ExportData 按角色的资源权限执行导出模板, 返回模板名称与首行为表头的数据
+// 主表含 created_by 或 sys_user_authority_id 列时只返回资源权限范围内用户的数据,
+// 两列都没有时无法限定范围, 仅拥有全部角色资源权限的角色可以导出
+func (sysExportTemplateService *SysExportTemplateService) ExportData(ctx context.Context, templateID string, paramsValues url.Values, authorityID uint) (name string, rows [][]string, err error) {
+	authorityIds, err := AuthorityServiceApp.GetDataAuthorityIds(ctx, authorityID)
+	if err != nil {
+		return "", nil, err
+	}
+	userIds, err := AuthorityServiceApp.GetDataAuthorityUserIds(ctx, authorityIds)
+	if err != nil {
+		return "", nil, err
+	}
+	scope := func(db *gorm.DB, table string) (*gorm.DB, error) {
+		switch {
+		case db.Migrator().HasColumn(table, "created_by"):
+			return db.Scopes(dataAuthorityScope(table+".created_by", userIds)), nil
+		case db.Migrator().HasColumn(table, "sys_user_authority_id"):
+			return db.Scopes(dataAuthorityScope(table+".sys_user_authority_id", authorityIds)), nil
+		}
+		all, err := AuthorityServiceApp.HasAllDataAuthority(ctx, authorityIds)
+		if err != nil {
+			return nil, err
+		}
+		if !all {
+			return nil, fmt.Errorf("表 %s 没有 created_by 或 sys_user_authority_id 列, 无法按资源权限限定导出范围", table)
+		}
+		return db, nil
+	}
+	template, rows, err := sysExportTemplateService.exportRows(ctx, templateID, paramsValues, scope)
+	return template.Name, rows, err
+}
+
+// exportRows 执行导出模板查询, 返回首行为表头的数据, scope 不为空时用于追加数据权限条件
+func (sysExportTemplateService *SysExportTemplateService) exportRows(ctx context.Context, templateID string, paramsValues url.Values, scope func(db *gorm.DB, table string) (*gorm.DB, error)) (template system.SysExportTemplate, rows [][]string, err error) {
+	err = global.GVA_DB.WithContext(ctx).Preload("Conditions").Preload("JoinTemplate").First(&template, "template_id = ?", templateID).Error
+	if err != nil {
+		return template, nil, err
+	}
 	var templateInfoMap = make(map[string]string)
 	columns, err := utils.GetJSONKeys(template.TemplateInfo)
 	if err != nil {
-		return nil, "", err
+		return template, nil, err
 	}
 	err = json.Unmarshal([]byte(template.TemplateInfo), &templateInfoMap)
 	if err != nil {
-		return nil, "", err
+		return template, nil, err
 	}
 	var tableTitle []string
 	var selectKeyFmt []string
@@ -172,6 +237,7 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 	if template.DBName != "" {
 		db = global.MustGetGlobalDBByDBName(template.DBName)
 	}
+	db = db.WithContext(ctx)
 
 	if len(template.JoinTemplate) > 0 {
 		for _, join := range template.JoinTemplate {
@@ -180,6 +246,11 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 	}
 
 	db = db.Select(selects).Table(template.TableName)
+	if scope != nil {
+		if db, err = scope(db, template.TableName); err != nil {
+			return template, nil, err
+		}
+	}
 
 	filterDeleted := false
 
@@ -196,7 +267,7 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 		if len(template.JoinTemplate) > 0 {
 			for _, join := range template.JoinTemplate {
 				// 检查关联表是否有deleted_at字段
-				hasDeletedAt := sysExportTemplateService.hasDeletedAtColumn(join.Table)
+				hasDeletedAt := sysExportTemplateService.hasDeletedAtColumn(ctx, join.Table)
 				if hasDeletedAt {
 					db = db.Where(fmt.Sprintf("%s.deleted_at IS NULL", join.Table))
 				}
@@ -247,7 +318,7 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 	table := template.TableName
 	orderColumns, err := db.Migrator().ColumnTypes(table)
 	if err != nil {
-		return nil, "", err
+		return template, nil, err
 	}
 
 	// 创建一个 map 来存储字段名
@@ -270,12 +341,12 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 		orderStr := ""
 		// 检查请求的排序字段是否在字段列表中
 		if _, ok := fields[checkOrderArr[0]]; !ok {
-			return nil, "", fmt.Errorf("order by %s is not in the fields", order)
+			return template, nil, fmt.Errorf("order by %s is not in the fields", order)
 		}
 		orderStr = checkOrderArr[0]
 		if len(checkOrderArr) > 1 {
 			if checkOrderArr[1] != "asc" && checkOrderArr[1] != "desc" {
-				return nil, "", fmt.Errorf("order by %s is not secure", order)
+				return template, nil, fmt.Errorf("order by %s is not secure", order)
 			}
 			orderStr = orderStr + " " + checkOrderArr[1]
 		}
@@ -284,9 +355,8 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 
 	err = db.Debug().Find(&tableMap).Error
 	if err != nil {
-		return nil, "", err
+		return template, nil, err
 	}
-	var rows [][]string
 	rows = append(rows, tableTitle)
 	for _, exTable := range tableMap {
 		var row []string
@@ -313,38 +383,14 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 		}
 		rows = append(rows, row)
 	}
-	for i, row := range rows {
-		for j, colCell := range row {
-			cell := fmt.Sprintf("%s%d", getColumnName(j+1), i+1)
-
- 			var sErr error
- 			if v, err := strconv.ParseFloat(colCell, 64); err == nil {
- 			    sErr = f.SetCellValue("Sheet1", cell, v)
- 			} else if v, err := strconv.ParseInt(colCell, 10, 64); err == nil {
- 			    sErr = f.SetCellValue("Sheet1", cell, v)
- 			} else {
- 			    sErr = f.SetCellValue("Sheet1", cell, colCell)
- 			}
-
-			if sErr != nil {
-				return nil, "", sErr
-			}
-		}
-	}
-	f.SetActiveSheet(index)
-	file, err = f.WriteToBuffer()
-	if err != nil {
-		return nil, "", err
-	}
-
-	return file, template.Name, nil
+	return template, rows, nil
 }
 
 // ExportTemplate 导出Excel模板
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) ExportTemplate(templateID string) (file *bytes.Buffer, name string, err error) {
+func (sysExportTemplateService *SysExportTemplateService) ExportTemplate(ctx context.Context, templateID string) (file *bytes.Buffer, name string, err error) {
 	var template system.SysExportTemplate
-	err = global.GVA_DB.First(&template, "template_id = ?", templateID).Error
+	err = global.GVA_DB.WithContext(ctx).First(&template, "template_id = ?", templateID).Error
 	if err != nil {
 		return nil, "", err
 	}
@@ -389,17 +435,17 @@ func (sysExportTemplateService *SysExportTemplateService) ExportTemplate(templat
 }
 
 // 辅助函数：检查表是否有deleted_at列
-func (s *SysExportTemplateService) hasDeletedAtColumn(tableName string) bool {
+func (s *SysExportTemplateService) hasDeletedAtColumn(ctx context.Context, tableName string) bool {
 	var count int64
-	global.GVA_DB.Raw("SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = 'deleted_at'", tableName).Count(&count)
+	global.GVA_DB.WithContext(ctx).Raw("SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = 'deleted_at'", tableName).Count(&count)
 	return count > 0
 }
 
 // ImportExcel 导入Excel
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) ImportExcel(templateID string, file *multipart.FileHeader) (err error) {
+func (sysExportTemplateService *SysExportTemplateService) ImportExcel(ctx context.Context, templateID string, file *multipart.FileHeader) (err error) {
 	var template system.SysExportTemplate
-	err = global.GVA_DB.First(&template, "template_id = ?", templateID).Error
+	err = global.GVA_DB.WithContext(ctx).First(&template, "template_id = ?", templateID).Error
 	if err != nil {
 		return err
 	}
@@ -434,9 +480,9 @@ func (sysExportTemplateService *SysExportTemplateService) ImportExcel(templateID
 		titleKeyMap[title] = key
 	}
 
-	db := global.GVA_DB
+	db := global.GVA_DB.WithContext(ctx)
 	if template.DBName != "" {
-		db = global.MustGetGlobalDBByDBName(template.DBName)
+		db = global.MustGetGlobalDBByDBName(template.DBName).WithContext(ctx)
 	}
 
 	return db.Transaction(func(tx *gorm.DB) error {
@@ -0,0 +1,48 @@
+package system
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"server/global"
+	"server/model/system"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestExportDataUnscopedTable(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.TempDir()+"/export.db"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysAuthority{}, &system.SysUserAuthority{}, &system.SysExportTemplate{}, &system.Condition{}, &system.JoinTemplate{}); err != nil {
+		t.Fatal(err)
+	}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+
+	admin := system.SysAuthority{AuthorityId: 888, AuthorityName: "管理员"}
+	user := system.SysAuthority{AuthorityId: 9528, AuthorityName: "用户"}
+	db.Create(&admin)
+	db.Create(&user)
+	db.Model(&admin).Association("DataAuthorityId").Append(&admin, &user)
+	db.Model(&user).Association("DataAuthorityId").Append(&user)
+	db.Exec("CREATE TABLE items (id integer, name text)")
+	db.Exec("INSERT INTO items VALUES (1, 'a')")
+	db.Create(&system.SysExportTemplate{TemplateID: "items", TableName: "items", TemplateInfo: `{"id":"ID","name":"名称"}`})
+
+	// 没有 created_by / sys_user_authority_id 列的表无法限定范围, 默认拒绝
+	if _, _, err = SysExportTemplateServiceApp.ExportData(context.Background(), "items", url.Values{}, 9528); err == nil {
+		t.Error("资源权限不完整的角色不应导出无法限定范围的表")
+	}
+	_, rows, err := SysExportTemplateServiceApp.ExportData(context.Background(), "items", url.Values{}, 888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("拥有全部资源权限的角色应导出全部数据, rows = %v", rows)
+	}
+}
@@ -26,10 +26,12 @@ func (h MssqlInitHandler) WriteConfig(ctx context.Context) error {
 	if !ok {
 		return errors.New("mssql config invalid")
 	}
-	global.GVA_CONFIG.System.DbType = "mssql"
-	global.GVA_CONFIG.Mssql = c
-	global.GVA_CONFIG.JWT.SigningKey = uuid.New().String()
-	cs := utils.StructToMap(global.GVA_CONFIG)
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.System.DbType = "mssql"
+		conf.Mssql = c
+		conf.JWT.SigningKey = uuid.New().String()
+	})
+	cs := utils.StructToMap(*global.GVA_CONFIG.Load())
 	for k, v := range cs {
 		global.GVA_VP.Set(k, v)
 	}
@@ -62,7 +64,8 @@ func (h MssqlInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (n
 		return nil, err
 	}
 
-	global.GVA_CONFIG.AutoCode.Root, _ = filepath.Abs("..")
+	root, _ := filepath.Abs("..")
+	global.UpdateConfig(func(server *config.Server) { server.AutoCode.Root = root })
 	next = context.WithValue(next, "db", db)
 	return next, err
 }
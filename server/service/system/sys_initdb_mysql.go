@@ -30,10 +30,12 @@ func (h MysqlInitHandler) WriteConfig(ctx context.Context) error {
 	if !ok {
 		return errors.New("mysql config invalid")
 	}
-	global.GVA_CONFIG.System.DbType = "mysql"
-	global.GVA_CONFIG.Mysql = c
-	global.GVA_CONFIG.JWT.SigningKey = uuid.New().String()
-	cs := utils.StructToMap(global.GVA_CONFIG)
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.System.DbType = "mysql"
+		conf.Mysql = c
+		conf.JWT.SigningKey = uuid.New().String()
+	})
+	cs := utils.StructToMap(*global.GVA_CONFIG.Load())
 	for k, v := range cs {
 		global.GVA_VP.Set(k, v)
 	}
@@ -67,7 +69,8 @@ func (h MysqlInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (n
 	}), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true}); err != nil {
 		return ctx, err
 	}
-	global.GVA_CONFIG.AutoCode.Root, _ = filepath.Abs("..")
+	root, _ := filepath.Abs("..")
+	global.UpdateConfig(func(server *config.Server) { server.AutoCode.Root = root })
 	next = context.WithValue(next, "db", db)
 	return next, err
 }
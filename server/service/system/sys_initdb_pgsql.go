@@ -30,10 +30,12 @@ func (h PgsqlInitHandler) WriteConfig(ctx context.Context) error {
 	if !ok {
 		return errors.New("postgresql config invalid")
 	}
-	global.GVA_CONFIG.System.DbType = "pgsql"
-	global.GVA_CONFIG.Pgsql = c
-	global.GVA_CONFIG.JWT.SigningKey = uuid.New().String()
-	cs := utils.StructToMap(global.GVA_CONFIG)
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.System.DbType = "pgsql"
+		conf.Pgsql = c
+		conf.JWT.SigningKey = uuid.New().String()
+	})
+	cs := utils.StructToMap(*global.GVA_CONFIG.Load())
 	for k, v := range cs {
 		global.GVA_VP.Set(k, v)
 	}
@@ -71,7 +73,8 @@ func (h PgsqlInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (n
 	}), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true}); err != nil {
 		return ctx, err
 	}
-	global.GVA_CONFIG.AutoCode.Root, _ = filepath.Abs("..")
+	root, _ := filepath.Abs("..")
+	global.UpdateConfig(func(server *config.Server) { server.AutoCode.Root = root })
 	next = context.WithValue(next, "db", db)
 	return next, err
 }
@@ -27,10 +27,12 @@ func (h SqliteInitHandler) WriteConfig(ctx context.Context) error {
 	if !ok {
 		return errors.New("sqlite config invalid")
 	}
-	global.GVA_CONFIG.System.DbType = "sqlite"
-	global.GVA_CONFIG.Sqlite = c
-	global.GVA_CONFIG.JWT.SigningKey = uuid.New().String()
-	cs := utils.StructToMap(global.GVA_CONFIG)
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.System.DbType = "sqlite"
+		conf.Sqlite = c
+		conf.JWT.SigningKey = uuid.New().String()
+	})
+	cs := utils.StructToMap(*global.GVA_CONFIG.Load())
 	for k, v := range cs {
 		global.GVA_VP.Set(k, v)
 	}
@@ -58,7 +60,8 @@ func (h SqliteInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (
 	}); err != nil {
 		return ctx, err
 	}
-	global.GVA_CONFIG.AutoCode.Root, _ = filepath.Abs("..")
+	root, _ := filepath.Abs("..")
+	global.UpdateConfig(func(server *config.Server) { server.AutoCode.Root = root })
 	next = context.WithValue(next, "db", db)
 	return next, err
 }
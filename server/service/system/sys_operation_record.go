@@ -1,6 +1,8 @@
 package system
 
 import (
+	"context"
+
 	"server/global"
 	"server/model/common/request"
 	"server/model/system"
@@ -24,8 +26,11 @@ var OperationRecordServiceApp = new(OperationRecordService)
 //@param: ids request.IdsReq
 //@return: err error
 
-func (operationRecordService *OperationRecordService) DeleteSysOperationRecordByIds(ids request.IdsReq) (err error) {
-	err = global.GVA_DB.Delete(&[]system.SysOperationRecord{}, "id in (?)", ids.Ids).Error
+func (operationRecordService *OperationRecordService) DeleteSysOperationRecordByIds(ctx context.Context, ids request.IdsReq) (err error) {
+	if global.GVA_CONFIG.Load().Audit.Enable {
+		return ErrAuditProtected
+	}
+	err = global.GVA_DB.WithContext(ctx).Delete(&[]system.SysOperationRecord{}, "id in (?)", ids.Ids).Error
 	return err
 }
 
@@ -35,8 +40,11 @@ func (operationRecordService *OperationRecordService) DeleteSysOperationRecordBy
 //@param: sysOperationRecord model.SysOperationRecord
 //@return: err error
 
-func (operationRecordService *OperationRecordService) DeleteSysOperationRecord(sysOperationRecord system.SysOperationRecord) (err error) {
-	err = global.GVA_DB.Delete(&sysOperationRecord).Error
+func (operationRecordService *OperationRecordService) DeleteSysOperationRecord(ctx context.Context, sysOperationRecord system.SysOperationRecord) (err error) {
+	if global.GVA_CONFIG.Load().Audit.Enable {
+		return ErrAuditProtected
+	}
+	err = global.GVA_DB.WithContext(ctx).Delete(&sysOperationRecord).Error
 	return err
 }
 
@@ -46,8 +54,8 @@ func (operationRecordService *OperationRecordService) DeleteSysOperationRecord(s
 //@param: id uint
 //@return: sysOperationRecord system.SysOperationRecord, err error
 
-func (operationRecordService *OperationRecordService) GetSysOperationRecord(id uint) (sysOperationRecord system.SysOperationRecord, err error) {
-	err = global.GVA_DB.Where("id = ?", id).First(&sysOperationRecord).Error
+func (operationRecordService *OperationRecordService) GetSysOperationRecord(ctx context.Context, id uint) (sysOperationRecord system.SysOperationRecord, err error) {
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", id).First(&sysOperationRecord).Error
 	return
 }
 
@@ -55,14 +63,14 @@ func (operationRecordService *OperationRecordService) GetSysOperationRecord(id u
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetSysOperationRecordInfoList
 //@description: 分页获取操作记录列表
-//@param: info systemReq.SysOperationRecordSearch
+//@param: ctx context.Context, info systemReq.SysOperationRecordSearch
 //@return: list interface{}, total int64, err error
 
-func (operationRecordService *OperationRecordService) GetSysOperationRecordInfoList(info systemReq.SysOperationRecordSearch) (list interface{}, total int64, err error) {
+func (operationRecordService *OperationRecordService) GetSysOperationRecordInfoList(ctx context.Context, info systemReq.SysOperationRecordSearch) (list interface{}, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	// 创建db
-	db := global.GVA_DB.Model(&system.SysOperationRecord{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysOperationRecord{})
 	var sysOperationRecords []system.SysOperationRecord
 	// 如果有条件搜索 下方会自动创建搜索语句
 	if info.Method != "" {
@@ -74,6 +82,12 @@ func (operationRecordService *OperationRecordService) GetSysOperationRecordInfoL
 	if info.Status != 0 {
 		db = db.Where("status = ?", info.Status)
 	}
+	if info.RequestID != "" {
+		db = db.Where("request_id = ?", info.RequestID)
+	}
+	if info.TraceID != "" {
+		db = db.Where("trace_id = ?", info.TraceID)
+	}
 	err = db.Count(&total).Error
 	if err != nil {
 		return
@@ -81,3 +95,26 @@ func (operationRecordService *OperationRecordService) GetSysOperationRecordInfoL
 	err = db.Order("id desc").Limit(limit).Offset(offset).Preload("User").Find(&sysOperationRecords).Error
 	return sysOperationRecords, total, err
 }
+
+//@function: GetSysOperationRecordListByAuthority
+//@description: 按筛选条件分页获取角色资源权限范围内用户的操作记录
+//@param: ctx context.Context, info systemReq.SysOperationRecordQuery, authorityID uint
+//@return: list []system.SysOperationRecord, total int64, err error
+
+func (operationRecordService *OperationRecordService) GetSysOperationRecordListByAuthority(ctx context.Context, info systemReq.SysOperationRecordQuery, authorityID uint) (list []system.SysOperationRecord, total int64, err error) {
+	authorityIds, err := AuthorityServiceApp.GetDataAuthorityIds(ctx, authorityID)
+	if err != nil {
+		return nil, 0, err
+	}
+	userIds, err := AuthorityServiceApp.GetDataAuthorityUserIds(ctx, authorityIds)
+	if err != nil {
+		return nil, 0, err
+	}
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysOperationRecord{}).
+		Scopes(operationRecordFilter(info.SysOperationRecordFilter), dataAuthorityScope(operationRecordTable+".user_id", userIds))
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Order("id desc").Scopes(info.Paginate()).Preload("User").Find(&list).Error
+	return list, total, err
+}
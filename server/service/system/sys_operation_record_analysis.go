@@ -0,0 +1,398 @@
+package system
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"server/global"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+const operationRecordTable = "sys_operation_records"
+
+// operationRecordFilter 生成筛选条件, 字段均带表名前缀以便与用户表关联查询
+func operationRecordFilter(info systemReq.SysOperationRecordFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if info.StartCreatedAt != nil && info.EndCreatedAt != nil {
+			db = db.Where(operationRecordTable+".created_at BETWEEN ? AND ?", info.StartCreatedAt, info.EndCreatedAt)
+		}
+		if info.Method != "" {
+			db = db.Where(operationRecordTable+".method = ?", info.Method)
+		}
+		if info.Path != "" {
+			db = db.Where(operationRecordTable+".path LIKE ?", "%"+info.Path+"%")
+		}
+		if info.Status != 0 {
+			db = db.Where(operationRecordTable+".status = ?", info.Status)
+		}
+		if info.UserID != 0 {
+			db = db.Where(operationRecordTable+".user_id = ?", info.UserID)
+		}
+		return db
+	}
+}
+
+//@function: GetSysOperationRecordStats
+//@description: 按时间粒度与维度统计请求数, 逐行读取所需列在内存中聚合, 不加载完整记录
+//@param: info systemReq.SysOperationRecordStats
+//@return: list []systemRes.OperationRecordBucketStat, err error
+
+func (operationRecordService *OperationRecordService) GetSysOperationRecordStats(ctx context.Context, info systemReq.SysOperationRecordStats) (list []systemRes.OperationRecordBucketStat, err error) {
+	layout := time.DateOnly
+	if info.Bucket == "hour" {
+		layout = "2006-01-02 15:00"
+	}
+	var column string
+	switch info.GroupBy {
+	case "":
+	case "user":
+		column = "user_id"
+	case "path":
+		column = "path"
+	case "status":
+		column = "status"
+	default:
+		return nil, fmt.Errorf("不支持的分组维度: %s", info.GroupBy)
+	}
+	selects := "created_at"
+	if column != "" {
+		selects += ", " + column
+	}
+	rows, err := global.GVA_DB.WithContext(ctx).Model(&system.SysOperationRecord{}).Scopes(operationRecordFilter(info.SysOperationRecordFilter)).Select(selects).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type bucketKey struct{ bucket, key string }
+	counts := make(map[bucketKey]int64)
+	for rows.Next() {
+		var createdAt time.Time
+		var key string
+		if column == "" {
+			err = rows.Scan(&createdAt)
+		} else {
+			err = rows.Scan(&createdAt, &key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		counts[bucketKey{createdAt.Local().Format(layout), key}]++
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	list = make([]systemRes.OperationRecordBucketStat, 0, len(counts))
+	userIds := make([]string, 0)
+	for k, v := range counts {
+		list = append(list, systemRes.OperationRecordBucketStat{Bucket: k.bucket, Key: k.key, Label: k.key, Count: v})
+		if column == "user_id" {
+			userIds = append(userIds, k.key)
+		}
+	}
+	if len(userIds) > 0 {
+		var users []system.SysUser
+		if err = global.GVA_DB.WithContext(ctx).Select("id, username").Where("id in ?", userIds).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		names := make(map[string]string, len(users))
+		for _, u := range users {
+			names[strconv.FormatUint(uint64(u.ID), 10)] = u.Username
+		}
+		for i := range list {
+			if name, ok := names[list[i].Key]; ok {
+				list[i].Label = name
+			}
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Bucket != list[j].Bucket {
+			return list[i].Bucket < list[j].Bucket
+		}
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Key < list[j].Key
+	})
+	return list, nil
+}
+
+//@function: GetTopErrorPaths
+//@description: 统计出错次数最多的路径, 出错指 http 状态码>=400、存在错误信息或业务响应码为7
+//@param: info systemReq.SysOperationRecordTop
+//@return: list []systemRes.OperationRecordErrorPath, err error
+
+func (operationRecordService *OperationRecordService) GetTopErrorPaths(ctx context.Context, info systemReq.SysOperationRecordTop) (list []systemRes.OperationRecordErrorPath, err error) {
+	var rows []struct {
+		Path   string
+		Method string
+		Count  int64
+		LastId uint
+	}
+	err = global.GVA_DB.WithContext(ctx).Model(&system.SysOperationRecord{}).
+		Scopes(operationRecordFilter(info.SysOperationRecordFilter)).
+		Select("path, method, COUNT(*) AS count, MAX(id) AS last_id").
+		Where("status >= ? OR error_message <> ? OR resp LIKE ?", 400, "", `{"code":7,%`).
+		Group("path, method").
+		Order("count DESC").
+		Limit(topLimit(info.Limit)).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r.LastId)
+	}
+	var last []system.SysOperationRecord
+	if err = global.GVA_DB.WithContext(ctx).Select("id, created_at").Where("id in ?", ids).Find(&last).Error; err != nil {
+		return nil, err
+	}
+	lastTime := make(map[uint]time.Time, len(last))
+	for _, r := range last {
+		lastTime[r.ID] = r.CreatedAt
+	}
+	list = make([]systemRes.OperationRecordErrorPath, 0, len(rows))
+	for _, r := range rows {
+		list = append(list, systemRes.OperationRecordErrorPath{Path: r.Path, Method: r.Method, Count: r.Count, LastTime: lastTime[r.LastId]})
+	}
+	return list, nil
+}
+
+//@function: GetSlowestPaths
+//@description: 按99分位耗时排序的最慢路径, 先分组统计数量, 再按路径与耗时顺序逐行扫描取分位值
+//@param: info systemReq.SysOperationRecordTop
+//@return: list []systemRes.OperationRecordLatency, err error
+
+func (operationRecordService *OperationRecordService) GetSlowestPaths(ctx context.Context, info systemReq.SysOperationRecordTop) (list []systemRes.OperationRecordLatency, err error) {
+	var groups []struct {
+		Path  string
+		Count int64
+		Avg   float64
+		Max   int64
+	}
+	err = global.GVA_DB.WithContext(ctx).Model(&system.SysOperationRecord{}).
+		Scopes(operationRecordFilter(info.SysOperationRecordFilter)).
+		Select("path, COUNT(*) AS count, AVG(latency) AS avg, MAX(latency) AS max").
+		Group("path").
+		Scan(&groups).Error
+	if err != nil || len(groups) == 0 {
+		return nil, err
+	}
+	stats := make(map[string]*systemRes.OperationRecordLatency, len(groups))
+	for _, g := range groups {
+		stats[g.Path] = &systemRes.OperationRecordLatency{Path: g.Path, Count: g.Count, Avg: durationMs(int64(g.Avg)), Max: durationMs(g.Max)}
+	}
+
+	rows, err := global.GVA_DB.WithContext(ctx).Model(&system.SysOperationRecord{}).
+		Scopes(operationRecordFilter(info.SysOperationRecordFilter)).
+		Select("path, latency").
+		Order("path, latency").
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var current string
+	var index int64
+	for rows.Next() {
+		var path string
+		var latency int64
+		if err = rows.Scan(&path, &latency); err != nil {
+			return nil, err
+		}
+		if path != current {
+			current, index = path, 0
+		}
+		s, ok := stats[path]
+		if !ok {
+			continue
+		}
+		if index == percentileIndex(s.Count, 0.5) {
+			s.P50 = durationMs(latency)
+		}
+		if index == percentileIndex(s.Count, 0.9) {
+			s.P90 = durationMs(latency)
+		}
+		if index == percentileIndex(s.Count, 0.99) {
+			s.P99 = durationMs(latency)
+		}
+		index++
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	list = make([]systemRes.OperationRecordLatency, 0, len(stats))
+	for _, s := range stats {
+		list = append(list, *s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].P99 != list[j].P99 {
+			return list[i].P99 > list[j].P99
+		}
+		return list[i].Path < list[j].Path
+	})
+	if limit := topLimit(info.Limit); len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// operationRecordXlsxMaxRows xlsx 导出的最大数据行数, 工作簿需在全部写入后整体输出, 超出时请缩小筛选范围或导出 csv
+var operationRecordXlsxMaxRows = 100000
+
+var operationRecordExportHeader = []string{"ID", "时间", "用户ID", "用户名", "请求IP", "请求方法", "请求路径", "状态", "延迟(ms)", "代理", "错误信息", "请求Body", "响应Body"}
+
+type operationRecordExportRow struct {
+	ID           uint
+	CreatedAt    time.Time
+	UserID       int
+	Username     string
+	Ip           string
+	Method       string
+	Path         string
+	Status       int
+	Latency      int64
+	Agent        string
+	ErrorMessage string
+	Body         string
+	Resp         string
+}
+
+func (r operationRecordExportRow) values() []interface{} {
+	return []interface{}{r.ID, r.CreatedAt.Local().Format(time.DateTime), r.UserID, escapeFormula(r.Username), escapeFormula(r.Ip), escapeFormula(r.Method),
+		escapeFormula(r.Path), r.Status, durationMs(r.Latency), escapeFormula(r.Agent), escapeFormula(r.ErrorMessage), escapeFormula(r.Body), escapeFormula(r.Resp)}
+}
+
+// escapeFormula 以 = + - @ 等开头的内容在表格软件中会被当作公式执行, 加 ' 前缀按文本显示
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+//@function: ExportSysOperationRecord
+//@description: 按筛选条件逐行导出操作记录为 csv 或 xlsx, xlsx 最多导出 operationRecordXlsxMaxRows 行
+//@param: info systemReq.SysOperationRecordExport, w io.Writer
+//@return: err error
+
+func (operationRecordService *OperationRecordService) ExportSysOperationRecord(ctx context.Context, info systemReq.SysOperationRecordExport, w io.Writer) (err error) {
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysOperationRecord{}).
+		Scopes(operationRecordFilter(info.SysOperationRecordFilter)).
+		Select(operationRecordTable + ".id, " + operationRecordTable + ".created_at, " + operationRecordTable + ".user_id, sys_users.username, ip, method, path, status, latency, agent, error_message, body, resp").
+		Joins("LEFT JOIN sys_users ON sys_users.id = " + operationRecordTable + ".user_id").
+		Order(operationRecordTable + ".id desc")
+	rows, err := db.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var writeRow func(values []interface{}) error
+	var finish func() error
+	switch info.Format {
+	case "", "csv":
+		// 写入 BOM 以便 Excel 正确识别中文
+		if _, err = w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+			return err
+		}
+		cw := csv.NewWriter(w)
+		writeRow = func(values []interface{}) error {
+			record := make([]string, len(values))
+			for i, v := range values {
+				record[i] = fmt.Sprint(v)
+			}
+			return cw.Write(record)
+		}
+		finish = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case "xlsx":
+		f := excelize.NewFile()
+		defer f.Close()
+		sw, err := f.NewStreamWriter("Sheet1")
+		if err != nil {
+			return err
+		}
+		line := 1
+		writeRow = func(values []interface{}) error {
+			// 含表头行
+			if line > operationRecordXlsxMaxRows+1 {
+				return fmt.Errorf("xlsx 最多导出 %d 条记录, 请缩小筛选范围或导出 csv", operationRecordXlsxMaxRows)
+			}
+			cell, err := excelize.CoordinatesToCellName(1, line)
+			if err != nil {
+				return err
+			}
+			line++
+			return sw.SetRow(cell, values)
+		}
+		finish = func() error {
+			if err := sw.Flush(); err != nil {
+				return err
+			}
+			return f.Write(w)
+		}
+	default:
+		return fmt.Errorf("不支持的导出格式: %s", info.Format)
+	}
+
+	header := make([]interface{}, len(operationRecordExportHeader))
+	for i, h := range operationRecordExportHeader {
+		header[i] = h
+	}
+	if err = writeRow(header); err != nil {
+		return err
+	}
+	for rows.Next() {
+		var row operationRecordExportRow
+		if err = global.GVA_DB.WithContext(ctx).ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err = writeRow(row.values()); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	return finish()
+}
+
+func topLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return 10
+	case limit > 100:
+		return 100
+	}
+	return limit
+}
+
+// percentileIndex 最近秩法计算分位值在有序序列中的下标
+func percentileIndex(count int64, p float64) int64 {
+	if count <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(p*float64(count))) - 1
+}
+
+func durationMs(d int64) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+}
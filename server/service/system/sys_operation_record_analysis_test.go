@@ -0,0 +1,139 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"server/global"
+	"server/model/system"
+	systemReq "server/model/system/request"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupOperationRecordDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysOperationRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	old := global.GVA_DB
+	global.GVA_DB = db
+	t.Cleanup(func() { global.GVA_DB = old })
+
+	db.Create(&system.SysUser{Username: "admin"})
+	day := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	var records []system.SysOperationRecord
+	for i := 1; i <= 100; i++ {
+		records = append(records, system.SysOperationRecord{Path: "/api/slow", Method: "POST", Status: 200, UserID: 1, Latency: time.Duration(i) * time.Millisecond, Resp: `{"code":0,"data":{},"msg":"ok"}`})
+	}
+	for i := 0; i < 3; i++ {
+		records = append(records, system.SysOperationRecord{Path: "/api/fail", Method: "POST", Status: 200, UserID: 2, Latency: time.Millisecond, Resp: `{"code":7,"data":{},"msg":"失败"}`})
+	}
+	records = append(records, system.SysOperationRecord{Path: "/api/500", Method: "GET", Status: 500, Latency: time.Millisecond})
+	for i := range records {
+		records[i].CreatedAt = day.Add(time.Duration(i%2) * time.Hour)
+	}
+	if err = db.Create(&records).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOperationRecordService_Analysis(t *testing.T) {
+	setupOperationRecordDB(t)
+	s := &OperationRecordService{}
+
+	stats, err := s.GetSysOperationRecordStats(context.Background(), systemReq.SysOperationRecordStats{Bucket: "hour", GroupBy: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var adminCount int64
+	for _, st := range stats {
+		if st.Label == "admin" {
+			adminCount += st.Count
+		}
+	}
+	if adminCount != 100 || len(stats) != 5 {
+		t.Errorf("GetSysOperationRecordStats() = %+v", stats)
+	}
+
+	errPaths, err := s.GetTopErrorPaths(context.Background(), systemReq.SysOperationRecordTop{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errPaths) != 2 || errPaths[0].Path != "/api/fail" || errPaths[0].Count != 3 || errPaths[0].LastTime.IsZero() {
+		t.Errorf("GetTopErrorPaths() = %+v", errPaths)
+	}
+
+	slow, err := s.GetSlowestPaths(context.Background(), systemReq.SysOperationRecordTop{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slow) != 1 || slow[0].Path != "/api/slow" || slow[0].P50 != 50 || slow[0].P90 != 90 || slow[0].P99 != 99 || slow[0].Max != 100 {
+		t.Errorf("GetSlowestPaths() = %+v", slow)
+	}
+}
+
+func TestOperationRecordService_Export(t *testing.T) {
+	setupOperationRecordDB(t)
+	s := &OperationRecordService{}
+
+	var buf bytes.Buffer
+	filter := systemReq.SysOperationRecordFilter{Path: "fail"}
+	if err := s.ExportSysOperationRecord(context.Background(), systemReq.SysOperationRecordExport{SysOperationRecordFilter: filter}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\xEF\xBB\xBF"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 4 || lines[1][6] != "/api/fail" {
+		t.Errorf("ExportSysOperationRecord() csv = %v", lines)
+	}
+
+	buf.Reset()
+	if err = s.ExportSysOperationRecord(context.Background(), systemReq.SysOperationRecordExport{Format: "xlsx"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("PK")) {
+		t.Errorf("ExportSysOperationRecord() xlsx 输出格式错误")
+	}
+
+	// 超过行数上限时不输出任何内容
+	old := operationRecordXlsxMaxRows
+	operationRecordXlsxMaxRows = 10
+	t.Cleanup(func() { operationRecordXlsxMaxRows = old })
+	buf.Reset()
+	if err = s.ExportSysOperationRecord(context.Background(), systemReq.SysOperationRecordExport{Format: "xlsx"}, &buf); err == nil || buf.Len() != 0 {
+		t.Errorf("超过 xlsx 行数上限应返回错误, err=%v len=%d", err, buf.Len())
+	}
+}
+
+func TestOperationRecordService_ExportEscapeFormula(t *testing.T) {
+	setupOperationRecordDB(t)
+	global.GVA_DB.Create(&system.SysOperationRecord{Path: "=HYPERLINK(\"http://x\")", Method: "GET", Body: "+1", Resp: "@SUM(A1)", ErrorMessage: "-2"})
+
+	var buf bytes.Buffer
+	filter := systemReq.SysOperationRecordFilter{Path: "HYPERLINK"}
+	if err := (&OperationRecordService{}).ExportSysOperationRecord(context.Background(), systemReq.SysOperationRecordExport{SysOperationRecordFilter: filter}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\xEF\xBB\xBF"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("ExportSysOperationRecord() csv = %v", lines)
+	}
+	row := lines[1]
+	if row[6] != `'=HYPERLINK("http://x")` || row[10] != "'-2" || row[11] != "'+1" || row[12] != "'@SUM(A1)" {
+		t.Errorf("公式内容应加 ' 前缀, 实际 %v", row)
+	}
+}
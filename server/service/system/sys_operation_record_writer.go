@@ -0,0 +1,304 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+	"server/utils/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	OperationRecordPolicyDrop  = "drop"
+	OperationRecordPolicyBlock = "block"
+)
+
+// OperationRecordSink 操作记录输出目标
+type OperationRecordSink interface {
+	Name() string
+	Write(ctx context.Context, records []system.SysOperationRecord) error
+}
+
+// dependentOperationRecordSink 依赖第一个输出目标写入结果(如记录id)的输出目标, 第一个输出目标失败时跳过
+type dependentOperationRecordSink interface {
+	dependsOnFirst()
+}
+
+// OperationRecordWriterStats 写入器计数
+type OperationRecordWriterStats struct {
+	Async    bool   `json:"async"`    // 是否异步
+	Queued   int    `json:"queued"`   // 当前排队数
+	Capacity int    `json:"capacity"` // 队列容量
+	Written  uint64 `json:"written"`  // 已写入条数
+	Dropped  uint64 `json:"dropped"`  // 队列满被丢弃的条数
+	Failed   uint64 `json:"failed"`   // 写入失败的条数
+	Batches  uint64 `json:"batches"`  // 已写入批次
+}
+
+// OperationRecordWriter 操作记录写入器, 异步模式下按条数或时间间隔批量写入各输出目标
+type OperationRecordWriter struct {
+	async     bool
+	block     bool
+	batchSize int
+	interval  time.Duration
+	sinks     []OperationRecordSink
+
+	queue    chan system.SysOperationRecord
+	done     chan struct{}
+	stopping chan struct{}
+	stopOnce sync.Once
+	inflight sync.WaitGroup
+	mu       sync.RWMutex
+	closed   bool
+
+	written atomic.Uint64
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+	batches atomic.Uint64
+}
+
+// OperationRecordWriterApp 全局操作记录写入器, 为 nil 时中间件直接同步写库
+var OperationRecordWriterApp *OperationRecordWriter
+
+//@function: NewOperationRecordWriter
+//@description: 创建操作记录写入器, 未指定输出目标时写入数据库
+//@param: conf config.OperationRecord, sinks ...OperationRecordSink
+//@return: *OperationRecordWriter
+
+func NewOperationRecordWriter(conf config.OperationRecord, sinks ...OperationRecordSink) *OperationRecordWriter {
+	w := &OperationRecordWriter{
+		async:     conf.Async,
+		block:     conf.Policy == OperationRecordPolicyBlock,
+		batchSize: conf.BatchSize,
+		sinks:     sinks,
+		done:      make(chan struct{}),
+		stopping:  make(chan struct{}),
+	}
+	if len(w.sinks) == 0 {
+		w.sinks = []OperationRecordSink{DBOperationRecordSink{}}
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = 100
+	}
+	w.interval, _ = time.ParseDuration(conf.FlushInterval)
+	if w.interval <= 0 {
+		w.interval = time.Second
+	}
+	if w.async {
+		size := conf.BufferSize
+		if size <= 0 {
+			size = 1024
+		}
+		w.queue = make(chan system.SysOperationRecord, size)
+		go w.run()
+	} else {
+		close(w.done)
+	}
+	return w
+}
+
+// Write 写入一条记录, 异步模式下入队, 队列已满且策略为 drop 时丢弃并返回 false
+func (w *OperationRecordWriter) Write(record system.SysOperationRecord) bool {
+	w.mu.RLock()
+	if !w.async || w.closed {
+		w.mu.RUnlock()
+		return w.flush([]system.SysOperationRecord{record})
+	}
+	// 入队时不持有锁, 由 inflight 保证 Stop 在所有入队结束后才关闭队列
+	w.inflight.Add(1)
+	w.mu.RUnlock()
+	defer w.inflight.Done()
+	if w.block {
+		// 阻塞期间 Stop 开始时改为同步写入
+		select {
+		case w.queue <- record:
+			return true
+		case <-w.stopping:
+			return w.flush([]system.SysOperationRecord{record})
+		}
+	}
+	select {
+	case w.queue <- record:
+		return true
+	default:
+		w.dropped.Add(1)
+		return false
+	}
+}
+
+// Stop 停止接收新记录并等待队列中的记录全部写出, ctx 结束时不再等待
+func (w *OperationRecordWriter) Stop(ctx context.Context) error {
+	w.stopOnce.Do(func() {
+		close(w.stopping)
+		w.mu.Lock()
+		w.closed = true
+		w.mu.Unlock()
+		if w.async {
+			go func() {
+				w.inflight.Wait()
+				close(w.queue)
+			}()
+		}
+	})
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	for _, sink := range w.sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				global.GVA_LOG.Named(logging.NameOperationRecord).Error("close operation record sink error:", zap.String("sink", sink.Name()), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+// Stats 获取写入器计数
+func (w *OperationRecordWriter) Stats() OperationRecordWriterStats {
+	return OperationRecordWriterStats{
+		Async:    w.async,
+		Queued:   len(w.queue),
+		Capacity: cap(w.queue),
+		Written:  w.written.Load(),
+		Dropped:  w.dropped.Load(),
+		Failed:   w.failed.Load(),
+		Batches:  w.batches.Load(),
+	}
+}
+
+func (w *OperationRecordWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	batch := make([]system.SysOperationRecord, 0, w.batchSize)
+	for {
+		select {
+		case record, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= w.batchSize {
+				w.flush(batch)
+				batch = make([]system.SysOperationRecord, 0, w.batchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				w.flush(batch)
+				batch = make([]system.SysOperationRecord, 0, w.batchSize)
+			}
+		}
+	}
+}
+
+// flush 将一批记录写入所有输出目标, 以第一个输出目标(通常为数据库)的结果计数
+// 第一个输出目标失败时其他输出目标照常写入, 只跳过审计等依赖其写入后生成的记录id的输出目标
+func (w *OperationRecordWriter) flush(batch []system.SysOperationRecord) bool {
+	if len(batch) == 0 {
+		return true
+	}
+	ok := true
+	for i, sink := range w.sinks {
+		if _, dependent := sink.(dependentOperationRecordSink); dependent && !ok {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := sink.Write(ctx, batch)
+		cancel()
+		if err != nil {
+			global.GVA_LOG.Named(logging.NameOperationRecord).Error("write operation record error:", zap.String("sink", sink.Name()), zap.Int("count", len(batch)), zap.Error(err))
+			if i == 0 {
+				ok = false
+			}
+		}
+	}
+	if ok {
+		w.written.Add(uint64(len(batch)))
+		w.batches.Add(1)
+	} else {
+		w.failed.Add(uint64(len(batch)))
+	}
+	return ok
+}
+
+// DBOperationRecordSink 写入 global.GVA_DB
+type DBOperationRecordSink struct{}
+
+func (DBOperationRecordSink) Name() string { return "db" }
+
+func (DBOperationRecordSink) Write(ctx context.Context, records []system.SysOperationRecord) error {
+	if global.GVA_DB == nil {
+		return errors.New("db not init")
+	}
+	return global.GVA_DB.WithContext(ctx).CreateInBatches(records, len(records)).Error
+}
+
+// MongoOperationRecordSink 写入 global.GVA_MONGO 的 sys_operation_records 集合
+type MongoOperationRecordSink struct{}
+
+func (MongoOperationRecordSink) Name() string { return "mongo" }
+
+func (MongoOperationRecordSink) Write(ctx context.Context, records []system.SysOperationRecord) error {
+	if global.GVA_MONGO == nil {
+		return errors.New("mongo not init")
+	}
+	_, err := global.GVA_MONGO.Database.Collection("sys_operation_records").InsertMany(ctx, records)
+	return err
+}
+
+// FileOperationRecordSink 以 JSON Lines 格式追加写入文件, 首次写入时打开文件并保持到 Close
+type FileOperationRecordSink struct {
+	Path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (s *FileOperationRecordSink) Name() string { return "file" }
+
+func (s *FileOperationRecordSink) Write(_ context.Context, records []system.SysOperationRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		if err := os.MkdirAll(filepath.Dir(s.Path), os.ModePerm); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		s.file = file
+	}
+	enc := json.NewEncoder(s.file)
+	for i := range records {
+		if err := enc.Encode(&records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 关闭文件, 之后的写入会重新打开
+func (s *FileOperationRecordSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,185 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+
+	"go.uber.org/zap"
+)
+
+type memoryOperationRecordSink struct {
+	mu      sync.Mutex
+	batches [][]system.SysOperationRecord
+	release chan struct{}
+}
+
+func (s *memoryOperationRecordSink) Name() string { return "memory" }
+
+func (s *memoryOperationRecordSink) Write(_ context.Context, records []system.SysOperationRecord) error {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]system.SysOperationRecord(nil), records...))
+	return nil
+}
+
+func (s *memoryOperationRecordSink) count() (batches, records int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.batches {
+		records += len(b)
+	}
+	return len(s.batches), records
+}
+
+func TestOperationRecordWriter_BatchAndStop(t *testing.T) {
+	sink := &memoryOperationRecordSink{}
+	w := NewOperationRecordWriter(config.OperationRecord{Async: true, BufferSize: 16, BatchSize: 3, FlushInterval: "1h"}, sink)
+	for i := 0; i < 7; i++ {
+		if !w.Write(system.SysOperationRecord{Path: "/test"}) {
+			t.Fatalf("Write() 第%d条失败", i)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := w.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	batches, records := sink.count()
+	if batches != 3 || records != 7 {
+		t.Errorf("期望3批7条, 实际%d批%d条", batches, records)
+	}
+	if stats := w.Stats(); stats.Written != 7 || stats.Dropped != 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	// 停止后退化为同步写入
+	w.Write(system.SysOperationRecord{Path: "/after-stop"})
+	if _, records = sink.count(); records != 8 {
+		t.Errorf("停止后应同步写入, 实际%d条", records)
+	}
+}
+
+func TestOperationRecordWriter_DropWhenFull(t *testing.T) {
+	sink := &memoryOperationRecordSink{release: make(chan struct{})}
+	w := NewOperationRecordWriter(config.OperationRecord{Async: true, BufferSize: 1, BatchSize: 1, Policy: OperationRecordPolicyDrop}, sink)
+	dropped := 0
+	for i := 0; i < 10; i++ {
+		if !w.Write(system.SysOperationRecord{}) {
+			dropped++
+		}
+	}
+	close(sink.release)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := w.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	stats := w.Stats()
+	if dropped == 0 || stats.Dropped != uint64(dropped) || stats.Written+stats.Dropped != 10 {
+		t.Errorf("dropped = %d, Stats() = %+v", dropped, stats)
+	}
+}
+
+type failingOperationRecordSink struct{}
+
+func (failingOperationRecordSink) Name() string { return "failing" }
+
+func (failingOperationRecordSink) Write(context.Context, []system.SysOperationRecord) error {
+	return errors.New("write failed")
+}
+
+type dependentMemorySink struct {
+	memoryOperationRecordSink
+}
+
+func (*dependentMemorySink) dependsOnFirst() {}
+
+func TestOperationRecordWriter_PrimaryFailure(t *testing.T) {
+	global.GVA_LOG = zap.NewNop()
+	independent := &memoryOperationRecordSink{}
+	dependent := &dependentMemorySink{}
+	w := NewOperationRecordWriter(config.OperationRecord{}, failingOperationRecordSink{}, independent, dependent)
+	if w.Write(system.SysOperationRecord{}) {
+		t.Fatal("第一个输出目标失败时 Write() 应返回 false")
+	}
+	if batches, _ := independent.count(); batches != 1 {
+		t.Errorf("第一个输出目标失败后仍应写入独立的输出目标, 实际%d批", batches)
+	}
+	if batches, _ := dependent.count(); batches != 0 {
+		t.Errorf("第一个输出目标失败后不应写入依赖它的输出目标, 实际%d批", batches)
+	}
+	if stats := w.Stats(); stats.Failed != 1 || stats.Written != 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestOperationRecordWriter_StopWhileBlocked(t *testing.T) {
+	sink := &memoryOperationRecordSink{release: make(chan struct{})}
+	w := NewOperationRecordWriter(config.OperationRecord{Async: true, BufferSize: 1, BatchSize: 1, Policy: OperationRecordPolicyBlock}, sink)
+	// 第一条被取出后阻塞在输出目标, 第二条占满队列, 第三条阻塞在入队
+	w.Write(system.SysOperationRecord{})
+	w.Write(system.SysOperationRecord{})
+	go w.Write(system.SysOperationRecord{})
+	time.Sleep(20 * time.Millisecond)
+
+	// 输出目标一直阻塞时 Stop 应在 ctx 结束时返回, 而不是卡在锁上
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	stopped := make(chan error)
+	go func() { stopped <- w.Stop(ctx) }()
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Stop() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stop() 被阻塞的 Write 卡住")
+	}
+
+	close(sink.release)
+	if err := w.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if _, records := sink.count(); records != 3 {
+		t.Errorf("期望写入3条, 实际%d条", records)
+	}
+}
+
+func TestFileOperationRecordSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log", "operation.jsonl")
+	sink := &FileOperationRecordSink{Path: path}
+	for i := 0; i < 2; i++ {
+		if err := sink.Write(context.Background(), []system.SysOperationRecord{{Path: "/test"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file := sink.file
+	if file == nil {
+		t.Fatal("写入后文件应保持打开")
+	}
+	if err := sink.Write(context.Background(), []system.SysOperationRecord{{Path: "/test"}}); err != nil || sink.file != file {
+		t.Fatalf("多批写入应复用同一文件, err = %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("期望3行, 实际%d行", lines)
+	}
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"server/global"
 	"server/model/system"
 	systemReq "server/model/system/request"
@@ -10,46 +11,46 @@ type SysParamsService struct{}
 
 // CreateSysParams 创建参数记录
 // Author [Mr.奇淼](https://github.com/pixelmaxQm)
-func (sysParamsService *SysParamsService) CreateSysParams(sysParams *system.SysParams) (err error) {
-	err = global.GVA_DB.Create(sysParams).Error
+func (sysParamsService *SysParamsService) CreateSysParams(ctx context.Context, sysParams *system.SysParams) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Create(sysParams).Error
 	return err
 }
 
 // DeleteSysParams 删除参数记录
 // Author [Mr.奇淼](https://github.com/pixelmaxQm)
-func (sysParamsService *SysParamsService) DeleteSysParams(ID string) (err error) {
-	err = global.GVA_DB.Delete(&system.SysParams{}, "id = ?", ID).Error
+func (sysParamsService *SysParamsService) DeleteSysParams(ctx context.Context, ID string) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Delete(&system.SysParams{}, "id = ?", ID).Error
 	return err
 }
 
 // DeleteSysParamsByIds 批量删除参数记录
 // Author [Mr.奇淼](https://github.com/pixelmaxQm)
-func (sysParamsService *SysParamsService) DeleteSysParamsByIds(IDs []string) (err error) {
-	err = global.GVA_DB.Delete(&[]system.SysParams{}, "id in ?", IDs).Error
+func (sysParamsService *SysParamsService) DeleteSysParamsByIds(ctx context.Context, IDs []string) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Delete(&[]system.SysParams{}, "id in ?", IDs).Error
 	return err
 }
 
 // UpdateSysParams 更新参数记录
 // Author [Mr.奇淼](https://github.com/pixelmaxQm)
-func (sysParamsService *SysParamsService) UpdateSysParams(sysParams system.SysParams) (err error) {
-	err = global.GVA_DB.Model(&system.SysParams{}).Where("id = ?", sysParams.ID).Updates(&sysParams).Error
+func (sysParamsService *SysParamsService) UpdateSysParams(ctx context.Context, sysParams system.SysParams) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Model(&system.SysParams{}).Where("id = ?", sysParams.ID).Updates(&sysParams).Error
 	return err
 }
 
 // GetSysParams 根据ID获取参数记录
 // Author [Mr.奇淼](https://github.com/pixelmaxQm)
-func (sysParamsService *SysParamsService) GetSysParams(ID string) (sysParams system.SysParams, err error) {
-	err = global.GVA_DB.Where("id = ?", ID).First(&sysParams).Error
+func (sysParamsService *SysParamsService) GetSysParams(ctx context.Context, ID string) (sysParams system.SysParams, err error) {
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", ID).First(&sysParams).Error
 	return
 }
 
 // GetSysParamsInfoList 分页获取参数记录
 // Author [Mr.奇淼](https://github.com/pixelmaxQm)
-func (sysParamsService *SysParamsService) GetSysParamsInfoList(info systemReq.SysParamsSearch) (list []system.SysParams, total int64, err error) {
+func (sysParamsService *SysParamsService) GetSysParamsInfoList(ctx context.Context, info systemReq.SysParamsSearch) (list []system.SysParams, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	// 创建db
-	db := global.GVA_DB.Model(&system.SysParams{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysParams{})
 	var sysParamss []system.SysParams
 	// 如果有条件搜索 下方会自动创建搜索语句
 	if info.StartCreatedAt != nil && info.EndCreatedAt != nil {
@@ -76,7 +77,7 @@ func (sysParamsService *SysParamsService) GetSysParamsInfoList(info systemReq.Sy
 
 // GetSysParam 根据key获取参数value
 // Author [Mr.奇淼](https://github.com/pixelmaxQm)
-func (sysParamsService *SysParamsService) GetSysParam(key string) (param system.SysParams, err error) {
-	err = global.GVA_DB.Where(system.SysParams{Key: key}).First(&param).Error
+func (sysParamsService *SysParamsService) GetSysParam(ctx context.Context, key string) (param system.SysParams, err error) {
+	err = global.GVA_DB.WithContext(ctx).Where(system.SysParams{Key: key}).First(&param).Error
 	return
 }
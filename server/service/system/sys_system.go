@@ -1,16 +1,25 @@
 package system
 
 import (
+	"context"
+	"errors"
+
 	"server/config"
 	"server/global"
 	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
 	"server/utils"
+	"server/utils/ctxlog"
+	"server/utils/logging"
+	"server/utils/secret"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetSystemConfig
-//@description: 读取配置文件
+//@description: 读取配置文件, 密码、密钥等字段以占位值返回
 //@return: conf config.Server, err error
 
 type SystemConfigService struct{}
@@ -18,18 +27,27 @@ type SystemConfigService struct{}
 var SystemConfigServiceApp = new(SystemConfigService)
 
 func (systemConfigService *SystemConfigService) GetSystemConfig() (conf config.Server, err error) {
-	return global.GVA_CONFIG, nil
+	return secret.Redact(*global.GVA_CONFIG.Load()), nil
 }
 
 // @description   set system config,
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: SetSystemConfig
-//@description: 设置配置文件
+//@description: 设置配置文件, 占位值与未修改的 ENC(...)、${ENV} 字段按配置文件原样写回
 //@param: system model.System
 //@return: err error
 
 func (systemConfigService *SystemConfigService) SetSystemConfig(system system.System) (err error) {
-	cs := utils.StructToMap(system.Config)
+	// 不经解析读取配置文件内容, 用于保留 ENC(...) 与 ${ENV} 的原始写法
+	var raw config.Server
+	if err = global.GVA_VP.Unmarshal(&raw); err != nil {
+		return err
+	}
+	conf := system.Config
+	if err = secret.Restore(&conf, *global.GVA_CONFIG.Load(), raw); err != nil {
+		return err
+	}
+	cs := utils.StructToMap(conf)
 	for k, v := range cs {
 		global.GVA_VP.Set(k, v)
 	}
@@ -40,23 +58,53 @@ func (systemConfigService *SystemConfigService) SetSystemConfig(system system.Sy
 //@author: [SliverHorn](https://github.com/SliverHorn)
 //@function: GetServerInfo
 //@description: 获取服务器信息
+//@param: ctx context.Context
 //@return: server *utils.Server, err error
 
-func (systemConfigService *SystemConfigService) GetServerInfo() (server *utils.Server, err error) {
+func (systemConfigService *SystemConfigService) GetServerInfo(ctx context.Context) (server *utils.Server, err error) {
 	var s utils.Server
 	s.Os = utils.InitOS()
 	if s.Cpu, err = utils.InitCPU(); err != nil {
-		global.GVA_LOG.Error("func utils.InitCPU() Failed", zap.String("err", err.Error()))
+		ctxlog.Logger(ctx).Error("func utils.InitCPU() Failed", zap.String("err", err.Error()))
 		return &s, err
 	}
 	if s.Ram, err = utils.InitRAM(); err != nil {
-		global.GVA_LOG.Error("func utils.InitRAM() Failed", zap.String("err", err.Error()))
+		ctxlog.Logger(ctx).Error("func utils.InitRAM() Failed", zap.String("err", err.Error()))
 		return &s, err
 	}
 	if s.Disk, err = utils.InitDisk(); err != nil {
-		global.GVA_LOG.Error("func utils.InitDisk() Failed", zap.String("err", err.Error()))
+		ctxlog.Logger(ctx).Error("func utils.InitDisk() Failed", zap.String("err", err.Error()))
 		return &s, err
 	}
 
 	return &s, nil
 }
+
+//@function: GetLogLevel
+//@description: 获取当前日志级别与日志投递目标状态
+//@return: res systemRes.SysLogLevelResponse
+
+func (systemConfigService *SystemConfigService) GetLogLevel() (res systemRes.SysLogLevelResponse) {
+	return systemRes.SysLogLevelResponse{LevelStatus: logging.Levels.Status(), Sinks: logging.Stats()}
+}
+
+//@function: SetLogLevel
+//@description: 运行时修改全局或指定名称的日志级别, 立即生效, 重启或重载后恢复为配置文件中的级别
+//@param: info systemReq.SysLogLevel
+//@return: err error
+
+func (systemConfigService *SystemConfigService) SetLogLevel(info systemReq.SysLogLevel) (err error) {
+	if info.Level == "" {
+		if info.Name == "" {
+			return errors.New("全局级别不能为空")
+		}
+		logging.Levels.Unset(info.Name)
+		return nil
+	}
+	level, err := zapcore.ParseLevel(info.Level)
+	if err != nil {
+		return err
+	}
+	logging.Levels.SetLevel(info.Name, level)
+	return nil
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -25,15 +26,15 @@ type UserService struct{}
 
 var UserServiceApp = new(UserService)
 
-func (userService *UserService) Register(u system.SysUser) (userInter system.SysUser, err error) {
+func (userService *UserService) Register(ctx context.Context, u system.SysUser) (userInter system.SysUser, err error) {
 	var user system.SysUser
-	if !errors.Is(global.GVA_DB.Where("username = ?", u.Username).First(&user).Error, gorm.ErrRecordNotFound) { // 判断用户名是否注册
+	if !errors.Is(global.GVA_DB.WithContext(ctx).Where("username = ?", u.Username).First(&user).Error, gorm.ErrRecordNotFound) { // 判断用户名是否注册
 		return userInter, errors.New("用户名已注册")
 	}
 	// 否则 附加uuid 密码hash加密 注册
 	u.Password = utils.BcryptHash(u.Password)
 	u.UUID = uuid.New()
-	err = global.GVA_DB.Create(&u).Error
+	err = global.GVA_DB.WithContext(ctx).Create(&u).Error
 	return u, err
 }
 
@@ -44,18 +45,18 @@ func (userService *UserService) Register(u system.SysUser) (userInter system.Sys
 //@param: u *model.SysUser
 //@return: err error, userInter *model.SysUser
 
-func (userService *UserService) Login(u *system.SysUser) (userInter *system.SysUser, err error) {
+func (userService *UserService) Login(ctx context.Context, u *system.SysUser) (userInter *system.SysUser, err error) {
 	if nil == global.GVA_DB {
 		return nil, fmt.Errorf("db not init")
 	}
 
 	var user system.SysUser
-	err = global.GVA_DB.Where("username = ?", u.Username).Preload("Authorities").Preload("Authority").First(&user).Error
+	err = global.GVA_DB.WithContext(ctx).Where("username = ?", u.Username).Preload("Authorities").Preload("Authority").First(&user).Error
 	if err == nil {
 		if ok := utils.BcryptCheck(u.Password, user.Password); !ok {
 			return nil, errors.New("密码错误")
 		}
-		MenuServiceApp.UserAuthorityDefaultRouter(&user)
+		MenuServiceApp.UserAuthorityDefaultRouter(ctx, &user)
 	}
 	return &user, err
 }
@@ -66,9 +67,9 @@ func (userService *UserService) Login(u *system.SysUser) (userInter *system.SysU
 //@param: u *model.SysUser, newPassword string
 //@return: err error
 
-func (userService *UserService) ChangePassword(u *system.SysUser, newPassword string) (err error) {
+func (userService *UserService) ChangePassword(ctx context.Context, u *system.SysUser, newPassword string) (err error) {
 	var user system.SysUser
-	err = global.GVA_DB.Select("id, password").Where("id = ?", u.ID).First(&user).Error
+	err = global.GVA_DB.WithContext(ctx).Select("id, password").Where("id = ?", u.ID).First(&user).Error
 	if err != nil {
 		return err
 	}
@@ -76,7 +77,7 @@ func (userService *UserService) ChangePassword(u *system.SysUser, newPassword st
 		return errors.New("原密码错误")
 	}
 	pwd := utils.BcryptHash(newPassword)
-	err = global.GVA_DB.Model(&user).Update("password", pwd).Error
+	err = global.GVA_DB.WithContext(ctx).Model(&user).Update("password", pwd).Error
 	return err
 }
 
@@ -86,10 +87,10 @@ func (userService *UserService) ChangePassword(u *system.SysUser, newPassword st
 //@param: info request.PageInfo
 //@return: err error, list interface{}, total int64
 
-func (userService *UserService) GetUserInfoList(info systemReq.GetUserList) (list interface{}, total int64, err error) {
+func (userService *UserService) GetUserInfoList(ctx context.Context, info systemReq.GetUserList) (list interface{}, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.GVA_DB.Model(&system.SysUser{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysUser{})
 	var userList []system.SysUser
 
 	if info.NickName != "" {
@@ -119,21 +120,21 @@ func (userService *UserService) GetUserInfoList(info systemReq.GetUserList) (lis
 //@param: uuid uuid.UUID, authorityId string
 //@return: err error
 
-func (userService *UserService) SetUserAuthority(id uint, authorityId uint) (err error) {
+func (userService *UserService) SetUserAuthority(ctx context.Context, id uint, authorityId uint) (err error) {
 
-	assignErr := global.GVA_DB.Where("sys_user_id = ? AND sys_authority_authority_id = ?", id, authorityId).First(&system.SysUserAuthority{}).Error
+	assignErr := global.GVA_DB.WithContext(ctx).Where("sys_user_id = ? AND sys_authority_authority_id = ?", id, authorityId).First(&system.SysUserAuthority{}).Error
 	if errors.Is(assignErr, gorm.ErrRecordNotFound) {
 		return errors.New("该用户无此角色")
 	}
 
 	var authority system.SysAuthority
-	err = global.GVA_DB.Where("authority_id = ?", authorityId).First(&authority).Error
+	err = global.GVA_DB.WithContext(ctx).Where("authority_id = ?", authorityId).First(&authority).Error
 	if err != nil {
 		return err
 	}
 	var authorityMenu []system.SysAuthorityMenu
 	var authorityMenuIDs []string
-	err = global.GVA_DB.Where("sys_authority_authority_id = ?", authorityId).Find(&authorityMenu).Error
+	err = global.GVA_DB.WithContext(ctx).Where("sys_authority_authority_id = ?", authorityId).Find(&authorityMenu).Error
 	if err != nil {
 		return err
 	}
@@ -143,7 +144,7 @@ func (userService *UserService) SetUserAuthority(id uint, authorityId uint) (err
 	}
 
 	var authorityMenus []system.SysBaseMenu
-	err = global.GVA_DB.Preload("Parameters").Where("id in (?)", authorityMenuIDs).Find(&authorityMenus).Error
+	err = global.GVA_DB.WithContext(ctx).Preload("Parameters").Where("id in (?)", authorityMenuIDs).Find(&authorityMenus).Error
 	if err != nil {
 		return err
 	}
@@ -158,7 +159,7 @@ func (userService *UserService) SetUserAuthority(id uint, authorityId uint) (err
 		return errors.New("找不到默认路由,无法切换本角色")
 	}
 
-	err = global.GVA_DB.Model(&system.SysUser{}).Where("id = ?", id).Update("authority_id", authorityId).Error
+	err = global.GVA_DB.WithContext(ctx).Model(&system.SysUser{}).Where("id = ?", id).Update("authority_id", authorityId).Error
 	return err
 }
 
@@ -168,8 +169,8 @@ func (userService *UserService) SetUserAuthority(id uint, authorityId uint) (err
 //@param: id uint, authorityIds []string
 //@return: err error
 
-func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, authorityIds []uint) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+func (userService *UserService) SetUserAuthorities(ctx context.Context, adminAuthorityID, id uint, authorityIds []uint) (err error) {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var user system.SysUser
 		TxErr := tx.Where("id = ?", id).First(&user).Error
 		if TxErr != nil {
@@ -182,7 +183,7 @@ func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, au
 		}
 		var useAuthority []system.SysUserAuthority
 		for _, v := range authorityIds {
-			e := AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, v)
+			e := AuthorityServiceApp.CheckAuthorityIDAuth(ctx, adminAuthorityID, v)
 			if e != nil {
 				return e
 			}
@@ -209,8 +210,8 @@ func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, au
 //@param: id float64
 //@return: err error
 
-func (userService *UserService) DeleteUser(id int) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+func (userService *UserService) DeleteUser(ctx context.Context, id int) (err error) {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Where("id = ?", id).Delete(&system.SysUser{}).Error; err != nil {
 			return err
 		}
@@ -227,8 +228,8 @@ func (userService *UserService) DeleteUser(id int) (err error) {
 //@param: reqUser model.SysUser
 //@return: err error, user model.SysUser
 
-func (userService *UserService) SetUserInfo(req system.SysUser) error {
-	return global.GVA_DB.Model(&system.SysUser{}).
+func (userService *UserService) SetUserInfo(ctx context.Context, req system.SysUser) error {
+	return global.GVA_DB.WithContext(ctx).Model(&system.SysUser{}).
 		Select("updated_at", "nick_name", "header_img", "phone", "email", "enable").
 		Where("id=?", req.ID).
 		Updates(map[string]interface{}{
@@ -247,8 +248,8 @@ func (userService *UserService) SetUserInfo(req system.SysUser) error {
 //@param: reqUser model.SysUser
 //@return: err error, user model.SysUser
 
-func (userService *UserService) SetSelfInfo(req system.SysUser) error {
-	return global.GVA_DB.Model(&system.SysUser{}).
+func (userService *UserService) SetSelfInfo(ctx context.Context, req system.SysUser) error {
+	return global.GVA_DB.WithContext(ctx).Model(&system.SysUser{}).
 		Where("id=?", req.ID).
 		Updates(req).Error
 }
@@ -259,8 +260,8 @@ func (userService *UserService) SetSelfInfo(req system.SysUser) error {
 //@param: req datatypes.JSON, uid uint
 //@return: err error
 
-func (userService *UserService) SetSelfSetting(req common.JSONMap, uid uint) error {
-	return global.GVA_DB.Model(&system.SysUser{}).Where("id = ?", uid).Update("origin_setting", req).Error
+func (userService *UserService) SetSelfSetting(ctx context.Context, req common.JSONMap, uid uint) error {
+	return global.GVA_DB.WithContext(ctx).Model(&system.SysUser{}).Where("id = ?", uid).Update("origin_setting", req).Error
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -270,13 +271,13 @@ func (userService *UserService) SetSelfSetting(req common.JSONMap, uid uint) err
 //@param: uuid uuid.UUID
 //@return: err error, user system.SysUser
 
-func (userService *UserService) GetUserInfo(uuid uuid.UUID) (user system.SysUser, err error) {
+func (userService *UserService) GetUserInfo(ctx context.Context, uuid uuid.UUID) (user system.SysUser, err error) {
 	var reqUser system.SysUser
-	err = global.GVA_DB.Preload("Authorities").Preload("Authority").First(&reqUser, "uuid = ?", uuid).Error
+	err = global.GVA_DB.WithContext(ctx).Preload("Authorities").Preload("Authority").First(&reqUser, "uuid = ?", uuid).Error
 	if err != nil {
 		return reqUser, err
 	}
-	MenuServiceApp.UserAuthorityDefaultRouter(&reqUser)
+	MenuServiceApp.UserAuthorityDefaultRouter(ctx, &reqUser)
 	return reqUser, err
 }
 
@@ -286,9 +287,9 @@ func (userService *UserService) GetUserInfo(uuid uuid.UUID) (user system.SysUser
 //@param: id int
 //@return: err error, user *model.SysUser
 
-func (userService *UserService) FindUserById(id int) (user *system.SysUser, err error) {
+func (userService *UserService) FindUserById(ctx context.Context, id int) (user *system.SysUser, err error) {
 	var u system.SysUser
-	err = global.GVA_DB.Where("id = ?", id).First(&u).Error
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", id).First(&u).Error
 	return &u, err
 }
 
@@ -298,9 +299,9 @@ func (userService *UserService) FindUserById(id int) (user *system.SysUser, err
 //@param: uuid string
 //@return: err error, user *model.SysUser
 
-func (userService *UserService) FindUserByUuid(uuid string) (user *system.SysUser, err error) {
+func (userService *UserService) FindUserByUuid(ctx context.Context, uuid string) (user *system.SysUser, err error) {
 	var u system.SysUser
-	if err = global.GVA_DB.Where("uuid = ?", uuid).First(&u).Error; err != nil {
+	if err = global.GVA_DB.WithContext(ctx).Where("uuid = ?", uuid).First(&u).Error; err != nil {
 		return &u, errors.New("用户不存在")
 	}
 	return &u, nil
@@ -312,7 +313,7 @@ func (userService *UserService) FindUserByUuid(uuid string) (user *system.SysUse
 //@param: ID uint
 //@return: err error
 
-func (userService *UserService) ResetPassword(ID uint, password string) (err error) {
-	err = global.GVA_DB.Model(&system.SysUser{}).Where("id = ?", ID).Update("password", utils.BcryptHash(password)).Error
+func (userService *UserService) ResetPassword(ctx context.Context, ID uint, password string) (err error) {
+	err = global.GVA_DB.WithContext(ctx).Model(&system.SysUser{}).Where("id = ?", ID).Update("password", utils.BcryptHash(password)).Error
 	return err
 }
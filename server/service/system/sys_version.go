@@ -13,28 +13,28 @@ type SysVersionService struct{}
 // CreateSysVersion 创建版本管理记录
 // Author [yourname](https://github.com/yourname)
 func (sysVersionService *SysVersionService) CreateSysVersion(ctx context.Context, sysVersion *system.SysVersion) (err error) {
-	err = global.GVA_DB.Create(sysVersion).Error
+	err = global.GVA_DB.WithContext(ctx).Create(sysVersion).Error
 	return err
 }
 
 // DeleteSysVersion 删除版本管理记录
 // Author [yourname](https://github.com/yourname)
 func (sysVersionService *SysVersionService) DeleteSysVersion(ctx context.Context, ID string) (err error) {
-	err = global.GVA_DB.Delete(&system.SysVersion{}, "id = ?", ID).Error
+	err = global.GVA_DB.WithContext(ctx).Delete(&system.SysVersion{}, "id = ?", ID).Error
 	return err
 }
 
 // DeleteSysVersionByIds 批量删除版本管理记录
 // Author [yourname](https://github.com/yourname)
 func (sysVersionService *SysVersionService) DeleteSysVersionByIds(ctx context.Context, IDs []string) (err error) {
-	err = global.GVA_DB.Where("id in ?", IDs).Delete(&system.SysVersion{}).Error
+	err = global.GVA_DB.WithContext(ctx).Where("id in ?", IDs).Delete(&system.SysVersion{}).Error
 	return err
 }
 
 // GetSysVersion 根据ID获取版本管理记录
 // Author [yourname](https://github.com/yourname)
 func (sysVersionService *SysVersionService) GetSysVersion(ctx context.Context, ID string) (sysVersion system.SysVersion, err error) {
-	err = global.GVA_DB.Where("id = ?", ID).First(&sysVersion).Error
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", ID).First(&sysVersion).Error
 	return
 }
 
@@ -44,7 +44,7 @@ func (sysVersionService *SysVersionService) GetSysVersionInfoList(ctx context.Co
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	// 创建db
-	db := global.GVA_DB.Model(&system.SysVersion{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysVersion{})
 	var sysVersions []system.SysVersion
 	// 如果有条件搜索 下方会自动创建搜索语句
 	if len(info.CreatedAtRange) == 2 {
@@ -76,19 +76,19 @@ func (sysVersionService *SysVersionService) GetSysVersionPublic(ctx context.Cont
 
 // GetMenusByIds 根据ID列表获取菜单数据
 func (sysVersionService *SysVersionService) GetMenusByIds(ctx context.Context, ids []uint) (menus []system.SysBaseMenu, err error) {
-	err = global.GVA_DB.Where("id in ?", ids).Preload("Parameters").Preload("MenuBtn").Find(&menus).Error
+	err = global.GVA_DB.WithContext(ctx).Where("id in ?", ids).Preload("Parameters").Preload("MenuBtn").Find(&menus).Error
 	return
 }
 
 // GetApisByIds 根据ID列表获取API数据
 func (sysVersionService *SysVersionService) GetApisByIds(ctx context.Context, ids []uint) (apis []system.SysApi, err error) {
-	err = global.GVA_DB.Where("id in ?", ids).Find(&apis).Error
+	err = global.GVA_DB.WithContext(ctx).Where("id in ?", ids).Find(&apis).Error
 	return
 }
 
 // ImportMenus 导入菜单数据
 func (sysVersionService *SysVersionService) ImportMenus(ctx context.Context, menus []system.SysBaseMenu) error {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 递归创建菜单
 		return sysVersionService.createMenusRecursively(tx, menus, 0)
 	})
@@ -169,8 +169,8 @@ func (sysVersionService *SysVersionService) createMenusRecursively(tx *gorm.DB,
 }
 
 // ImportApis 导入API数据
-func (sysVersionService *SysVersionService) ImportApis(apis []system.SysApi) error {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+func (sysVersionService *SysVersionService) ImportApis(ctx context.Context, apis []system.SysApi) error {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		for _, api := range apis {
 			// 检查API是否已存在
 			var existingApi system.SysApi
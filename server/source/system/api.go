@@ -62,6 +62,8 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "api", Method: "POST", Path: "/api/deleteApi", Description: "删除Api"},
 		{ApiGroup: "api", Method: "POST", Path: "/api/updateApi", Description: "更新Api"},
 		{ApiGroup: "api", Method: "POST", Path: "/api/getApiList", Description: "获取api列表"},
+		{ApiGroup: "api", Method: "GET", Path: "/api/checkOpenApi", Description: "检查路由一致性"},
+		{ApiGroup: "api", Method: "GET", Path: "/api/getSdk", Description: "生成接口客户端"},
 		{ApiGroup: "api", Method: "POST", Path: "/api/getAllApis", Description: "获取所有api"},
 		{ApiGroup: "api", Method: "POST", Path: "/api/getApiById", Description: "获取api详细信息"},
 		{ApiGroup: "api", Method: "DELETE", Path: "/api/deleteApisByIds", Description: "批量删除api"},
@@ -102,6 +104,8 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "文件上传与下载", Method: "POST", Path: "/fileUploadAndDownload/importURL", Description: "导入URL"},
 
 		{ApiGroup: "系统服务", Method: "POST", Path: "/system/getServerInfo", Description: "获取服务器信息"},
+		{ApiGroup: "系统服务", Method: "GET", Path: "/system/getLogLevel", Description: "获取日志级别"},
+		{ApiGroup: "系统服务", Method: "POST", Path: "/system/setLogLevel", Description: "设置日志级别"},
 		{ApiGroup: "系统服务", Method: "POST", Path: "/system/getSystemConfig", Description: "获取配置文件内容"},
 		{ApiGroup: "系统服务", Method: "POST", Path: "/system/setSystemConfig", Description: "设置配置文件内容"},
 
@@ -115,7 +119,17 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "代码生成器", Method: "GET", Path: "/autoCode/getTables", Description: "获取数据库表"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/createTemp", Description: "自动化代码"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/preview", Description: "预览自动化代码"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/previewInjection", Description: "预览注入代码"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/regenerate", Description: "重新生成并合并手工修改"},
+		{ApiGroup: "代码生成器", Method: "GET", Path: "/autoCode/getMigrations", Description: "获取字段变更迁移"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/applyMigration", Description: "执行迁移"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/rollbackMigration", Description: "回滚迁移"},
+		{ApiGroup: "代码生成器", Method: "GET", Path: "/autoCode/getTemplateSets", Description: "获取自定义模板集"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/createTemplateSet", Description: "注册自定义模板集"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/delTemplateSet", Description: "删除自定义模板集"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/validateTemplateSet", Description: "校验自定义模板集"},
 		{ApiGroup: "代码生成器", Method: "GET", Path: "/autoCode/getColumn", Description: "获取所选table的所有字段"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/getSpec", Description: "由数据表或建表语句生成代码生成器参数"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/installPlugin", Description: "安装插件"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/pubPlug", Description: "打包插件"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/mcp", Description: "自动生成 MCP Tool 模板"},
@@ -150,6 +164,14 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getSysOperationRecordList", Description: "获取操作记录列表"},
 		{ApiGroup: "操作记录", Method: "DELETE", Path: "/sysOperationRecord/deleteSysOperationRecord", Description: "删除操作记录"},
 		{ApiGroup: "操作记录", Method: "DELETE", Path: "/sysOperationRecord/deleteSysOperationRecordByIds", Description: "批量删除操作历史"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getOperationRecordWriterStats", Description: "获取操作记录写入器状态"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getSysOperationRecordStats", Description: "按时间粒度统计操作记录"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getTopErrorPaths", Description: "获取出错最多的路径"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getSlowestPaths", Description: "获取耗时分位最高的路径"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/exportSysOperationRecord", Description: "导出操作记录"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/verifyAuditLog", Description: "校验审计日志哈希链"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getAuditLogList", Description: "分页获取审计日志"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getChangeHistory", Description: "获取数据变更历史"},
 
 		{ApiGroup: "断点续传(插件版)", Method: "POST", Path: "/simpleUploader/upload", Description: "插件版分片上传"},
 		{ApiGroup: "断点续传(插件版)", Method: "GET", Path: "/simpleUploader/checkFileMd5", Description: "文件完整度验证"},
@@ -197,6 +219,18 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "版本控制", Method: "POST", Path: "/sysVersion/importVersion", Description: "同步版本"},
 		{ApiGroup: "版本控制", Method: "DELETE", Path: "/sysVersion/deleteSysVersion", Description: "删除版本"},
 		{ApiGroup: "版本控制", Method: "DELETE", Path: "/sysVersion/deleteSysVersionByIds", Description: "批量删除版本"},
+
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/create_api", Description: "MCP工具-创建API记录"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/create_menu", Description: "MCP工具-创建菜单记录"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/currentTime", Description: "MCP工具-获取当前时间"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/generate_dictionary_options", Description: "MCP工具-生成字典选项"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/getNickname", Description: "MCP工具-查询用户昵称"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/gva_auto_generate", Description: "MCP工具-执行代码生成"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/query_areas", Description: "MCP工具-查询区域"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/query_export_template", Description: "MCP工具-执行导出模板查询"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/query_dictionaries", Description: "MCP工具-查询字典"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/query_operation_records", Description: "MCP工具-查询操作记录"},
+		{ApiGroup: "mcp", Method: "POST", Path: "/mcp/tools/requirement_analyzer", Description: "MCP工具-分析需求"},
 	}
 	if err := db.Create(&entities).Error; err != nil {
 		return ctx, errors.Wrap(err, sysModel.SysApi{}.TableName()+"表数据初始化失败!")
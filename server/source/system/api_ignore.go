@@ -45,8 +45,12 @@ func (i *initApiIgnore) InitializeData(ctx context.Context) (context.Context, er
 	entities := []sysModel.SysIgnoreApi{
 		{Method: "GET", Path: "/swagger/*any"},
 		{Method: "GET", Path: "/api/freshCasbin"},
+		{Method: "GET", Path: "/api/openapi.json"},
 		{Method: "GET", Path: "/uploads/file/*filepath"},
 		{Method: "GET", Path: "/health"},
+		{Method: "GET", Path: "/livez"},
+		{Method: "GET", Path: "/readyz"},
+		{Method: "GET", Path: "/metrics"},
 		{Method: "HEAD", Path: "/uploads/file/*filepath"},
 		{Method: "POST", Path: "/autoCode/llmAuto"},
 		{Method: "POST", Path: "/system/reloadSystem"},
@@ -56,6 +60,11 @@ func (i *initApiIgnore) InitializeData(ctx context.Context) (context.Context, er
 		{Method: "POST", Path: "/init/checkdb"},
 		{Method: "GET", Path: "/info/getInfoDataSource"},
 		{Method: "GET", Path: "/info/getInfoPublic"},
+		{Method: "GET", Path: "/sse"},
+		{Method: "POST", Path: "/message"},
+		{Method: "GET", Path: "/mcp"},
+		{Method: "POST", Path: "/mcp"},
+		{Method: "DELETE", Path: "/mcp"},
 	}
 	if err := db.Create(&entities).Error; err != nil {
 		return ctx, errors.Wrap(err, sysModel.SysIgnoreApi{}.TableName()+"表数据初始化失败!")
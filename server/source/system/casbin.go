@@ -49,6 +49,8 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 
 		{Ptype: "p", V0: "888", V1: "/api/createApi", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/api/getApiList", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/api/checkOpenApi", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/api/getSdk", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/api/getApiById", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/api/deleteApi", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/api/updateApi", V2: "POST"},
@@ -106,6 +108,8 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/system/getSystemConfig", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/system/setSystemConfig", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/system/getServerInfo", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/system/getLogLevel", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/system/setLogLevel", V2: "POST"},
 
 		{Ptype: "p", V0: "888", V1: "/customer/customer", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/customer/customer", V2: "PUT"},
@@ -116,8 +120,18 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/autoCode/getDB", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/getMeta", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/preview", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/previewInjection", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/regenerate", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/getMigrations", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/applyMigration", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/rollbackMigration", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/getTemplateSets", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/createTemplateSet", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/delTemplateSet", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/validateTemplateSet", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/getTables", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/getColumn", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/getSpec", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/rollback", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/createTemp", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/delSysHistory", V2: "POST"},
@@ -152,6 +166,14 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getSysOperationRecordList", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/deleteSysOperationRecord", V2: "DELETE"},
 		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/deleteSysOperationRecordByIds", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getOperationRecordWriterStats", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getSysOperationRecordStats", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getTopErrorPaths", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getSlowestPaths", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/exportSysOperationRecord", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/verifyAuditLog", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getAuditLogList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getChangeHistory", V2: "GET"},
 
 		{Ptype: "p", V0: "888", V1: "/email/emailTest", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/email/sendEmail", V2: "POST"},
@@ -200,6 +222,18 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/sysVersion/deleteSysVersion", V2: "DELETE"},
 		{Ptype: "p", V0: "888", V1: "/sysVersion/deleteSysVersionByIds", V2: "DELETE"},
 
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/create_api", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/create_menu", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/currentTime", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/generate_dictionary_options", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/getNickname", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/gva_auto_generate", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/query_areas", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/query_export_template", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/query_dictionaries", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/query_operation_records", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/mcp/tools/requirement_analyzer", V2: "POST"},
+
 		{Ptype: "p", V0: "8881", V1: "/user/admin_register", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/createApi", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/getApiList", V2: "POST"},
@@ -11,18 +11,27 @@ import (
 
 //@author: [songzhibin97](https://github.com/songzhibin97)
 //@function: ClearTable
-//@description: 清理数据库表数据
+//@description: 清理数据库表数据, 保留已写入审计日志的操作记录
 //@param: db(数据库对象) *gorm.DB, tableName(表名) string, compareField(比较字段) string, interval(间隔) string
 //@return: error
 
 func ClearTable(db *gorm.DB) error {
 	var ClearTableDetail []common.ClearDB
 
-	ClearTableDetail = append(ClearTableDetail, common.ClearDB{
+	if db == nil {
+		return errors.New("db Cannot be empty")
+	}
+
+	operationRecords := common.ClearDB{
 		TableName:    "sys_operation_records",
 		CompareField: "created_at",
 		Interval:     "2160h",
-	})
+	}
+	// 已写入审计日志的操作记录需保留, 否则无法区分定时清理与恶意删除
+	if db.Migrator().HasTable("sys_audit_logs") {
+		operationRecords.Keep = "EXISTS (SELECT 1 FROM sys_audit_logs WHERE sys_audit_logs.record_id = sys_operation_records.id)"
+	}
+	ClearTableDetail = append(ClearTableDetail, operationRecords)
 
 	ClearTableDetail = append(ClearTableDetail, common.ClearDB{
 		TableName:    "jwt_blacklists",
@@ -30,10 +39,6 @@ func ClearTable(db *gorm.DB) error {
 		Interval:     "168h",
 	})
 
-	if db == nil {
-		return errors.New("db Cannot be empty")
-	}
-
 	for _, detail := range ClearTableDetail {
 		duration, err := time.ParseDuration(detail.Interval)
 		if err != nil {
@@ -42,7 +47,11 @@ func ClearTable(db *gorm.DB) error {
 		if duration < 0 {
 			return errors.New("parse duration < 0")
 		}
-		err = db.Debug().Exec(fmt.Sprintf("DELETE FROM %s WHERE %s < ?", detail.TableName, detail.CompareField), time.Now().Add(-duration)).Error
+		sql := fmt.Sprintf("DELETE FROM %s WHERE %s < ?", detail.TableName, detail.CompareField)
+		if detail.Keep != "" {
+			sql += " AND NOT " + detail.Keep
+		}
+		err = db.Debug().Exec(sql, time.Now().Add(-duration)).Error
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,39 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"server/model/system"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestClearTableKeepsAudited(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysOperationRecord{}, &system.SysAuditLog{}, &system.JwtBlacklist{}); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().AddDate(-1, 0, 0)
+	records := []system.SysOperationRecord{{Path: "/audited"}, {Path: "/plain"}, {Path: "/recent"}}
+	records[0].CreatedAt, records[1].CreatedAt = old, old
+	if err = db.Create(&records).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Create(&system.SysAuditLog{Seq: 1, RecordID: records[0].ID}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ClearTable(db); err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	db.Model(&system.SysOperationRecord{}).Order("id").Pluck("path", &paths)
+	if len(paths) != 2 || paths[0] != "/audited" || paths[1] != "/recent" {
+		t.Errorf("应保留已审计与未过期的记录, 实际 %v", paths)
+	}
+}
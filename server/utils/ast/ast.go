@@ -304,3 +304,44 @@ func VariableExistsInBlock(block *ast.BlockStmt, varName string) bool {
 	})
 	return exists
 }
+
+// HasMethodCall 判断代码块中是否已有 receiver.method(...) 调用
+func HasMethodCall(block *ast.BlockStmt, receiver string, method string) bool {
+	exists := false
+	ast.Inspect(block, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == method {
+				if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == receiver {
+					exists = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return exists
+}
+
+// HasMigrateModel 判断 AutoMigrate 参数中是否已有 packageName.structName, 支持 T{}、&T{} 和 new(T) 三种写法
+func HasMigrateModel(args []ast.Expr, packageName string, structName string) bool {
+	for _, arg := range args {
+		expr := arg
+		if unary, ok := expr.(*ast.UnaryExpr); ok {
+			expr = unary.X
+		}
+		if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "new" {
+				expr = call.Args[0]
+			}
+		}
+		if lit, ok := expr.(*ast.CompositeLit); ok {
+			expr = lit.Type
+		}
+		if sel, ok := expr.(*ast.SelectorExpr); ok && sel.Sel.Name == structName {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == packageName {
+				return true
+			}
+		}
+	}
+	return false
+}
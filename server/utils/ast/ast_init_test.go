@@ -1,11 +1,12 @@
 package ast
 
 import (
+	"server/config"
 	"server/global"
 	"path/filepath"
 )
 
 func init() {
-	global.GVA_CONFIG.AutoCode.Root, _ = filepath.Abs("../../../")
-	global.GVA_CONFIG.AutoCode.Server = "server"
+	global.GVA_CONFIG.Load().AutoCode.Root, _ = filepath.Abs("../../../")
+	global.UpdateConfig(func(conf *config.Server) { conf.AutoCode.Server = "server" })
 }
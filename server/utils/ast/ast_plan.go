@@ -0,0 +1,239 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+
+	"server/utils"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type ConflictReason string
+
+const (
+	ConflictMissing     ConflictReason = "missing"     // 目标文件不存在
+	ConflictUnparseable ConflictReason = "unparseable" // 目标文件无法解析
+	ConflictExists      ConflictReason = "exists"      // 注入的标识符已存在
+	ConflictNotFound    ConflictReason = "notFound"    // 回滚的标识符不存在
+	ConflictFailed      ConflictReason = "failed"      // 注入或回滚执行失败
+)
+
+// Conflict 预检发现的冲突
+type Conflict struct {
+	Path    string         `json:"path"`    // 相对 server 的路径
+	Type    Type           `json:"type"`    // 注入类型
+	Reason  ConflictReason `json:"reason"`  // 冲突原因
+	Message string         `json:"message"` // 说明
+}
+
+// Blocking 是否阻止写入, 标识符已存在/不存在时注入或回滚只是空操作, 不阻止写入
+func (c Conflict) Blocking() bool {
+	return c.Reason != ConflictExists && c.Reason != ConflictNotFound
+}
+
+func (c Conflict) Error() string {
+	return fmt.Sprintf("[filepath:%s][type:%s]%s", c.Path, c.Type, c.Message)
+}
+
+// Change 一个文件的注入结果
+type Change struct {
+	Filename string `json:"-"`     // 绝对路径
+	Path     string `json:"path"`  // 相对 server 的路径
+	Types    []Type `json:"types"` // 作用于该文件的注入类型
+	Diff     string `json:"diff"`  // unified diff, 无改动时为空
+	Before   string `json:"-"`
+	After    string `json:"-"`
+}
+
+// Plan 一组注入或回滚
+// 作用于同一文件的多个注入依次修改同一语法树, 每个文件只读写一次
+type Plan struct {
+	Base
+	rollback bool
+	asts     []Ast
+}
+
+// NewInjectionPlan 注入计划
+func NewInjectionPlan(asts ...Ast) *Plan {
+	return &Plan{asts: asts}
+}
+
+// NewRollbackPlan 回滚计划
+func NewRollbackPlan(asts ...Ast) *Plan {
+	return &Plan{rollback: true, asts: asts}
+}
+
+// Check 预检, 报告目标文件缺失、无法解析、标识符已存在等冲突, 不写入文件
+func (p *Plan) Check() []Conflict {
+	_, conflicts := p.Diff()
+	return conflicts
+}
+
+// Diff 计算每个文件注入前后的 unified diff, 不写入文件
+func (p *Plan) Diff() ([]Change, []Conflict) {
+	var (
+		paths     []string
+		groups    = make(map[string][]Ast)
+		changes   []Change
+		conflicts []Conflict
+	)
+	for _, value := range p.asts {
+		filename := value.Filepath()
+		if _, ok := groups[filename]; !ok {
+			paths = append(paths, filename)
+		}
+		groups[filename] = append(groups[filename], value)
+	}
+	for _, filename := range paths {
+		change, fileConflicts := p.change(filename, groups[filename])
+		conflicts = append(conflicts, fileConflicts...)
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes, conflicts
+}
+
+// Apply 预检通过后写入全部改动, 任一文件写入失败时恢复所有已写入的文件
+func (p *Plan) Apply() ([]Change, error) {
+	changes, conflicts := p.Diff()
+	if err := Conflicts(conflicts); err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte, len(changes))
+	for _, change := range changes {
+		if change.Diff != "" {
+			files[change.Filename] = []byte(change.After)
+		}
+	}
+	if err := utils.WriteFiles(files); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Conflicts 将阻止写入的冲突合并为一个错误, 没有时返回 nil
+func Conflicts(conflicts []Conflict) error {
+	var messages []string
+	for _, conflict := range conflicts {
+		if conflict.Blocking() {
+			messages = append(messages, conflict.Error())
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+// change 在内存中对一个文件依次执行注入或回滚, 无法处理的文件返回 nil
+func (p *Plan) change(filename string, asts []Ast) (*Change, []Conflict) {
+	relative := p.RelativePath(filename)
+	conflict := func(value Ast, reason ConflictReason, message string) Conflict {
+		return Conflict{Path: relative, Type: p.kind(value), Reason: reason, Message: message}
+	}
+	var conflicts []Conflict
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		reason := ConflictFailed
+		if os.IsNotExist(err) {
+			reason = ConflictMissing
+		}
+		for _, value := range asts {
+			conflicts = append(conflicts, conflict(value, reason, "读取文件失败: "+err.Error()))
+		}
+		return nil, conflicts
+	}
+	var file *ast.File
+	for _, value := range asts {
+		// 通过各注入自身的 Parse 解析, 同时补全 Path/RelativePath, 与直接注入时保存的历史一致
+		parsed, err := value.Parse("", nil)
+		if err != nil {
+			conflicts = append(conflicts, conflict(value, ConflictUnparseable, err.Error()))
+			continue
+		}
+		if file == nil {
+			file = parsed
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, conflicts
+	}
+	before, err := p.format(file)
+	if err != nil {
+		return nil, append(conflicts, conflict(asts[0], ConflictUnparseable, err.Error()))
+	}
+	change := &Change{Filename: filename, Path: relative, Before: string(src)}
+	for _, value := range asts {
+		change.Types = append(change.Types, p.kind(value))
+		previous := before
+		if err = p.execute(value, file); err != nil {
+			conflicts = append(conflicts, conflict(value, ConflictFailed, err.Error()))
+			continue
+		}
+		before, err = p.format(file)
+		if err != nil {
+			conflicts = append(conflicts, conflict(value, ConflictFailed, err.Error()))
+			continue
+		}
+		if bytes.Equal(previous, before) {
+			if p.rollback {
+				conflicts = append(conflicts, conflict(value, ConflictNotFound, "没有需要回滚的代码"))
+			} else {
+				conflicts = append(conflicts, conflict(value, ConflictExists, "注入的代码已存在"))
+			}
+		}
+	}
+	change.After = string(before)
+	if change.After != change.Before {
+		change.Diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(change.Before),
+			B:        difflib.SplitLines(change.After),
+			FromFile: "a/" + strings.TrimPrefix(relative, "/"),
+			ToFile:   "b/" + strings.TrimPrefix(relative, "/"),
+			Context:  3,
+		})
+	}
+	return change, conflicts
+}
+
+// execute 执行单个注入或回滚, 注入实现中的 panic 视为失败
+func (p *Plan) execute(value Ast, file *ast.File) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("执行失败: %v", r)
+		}
+	}()
+	if p.rollback {
+		return value.Rollback(file)
+	}
+	return value.Injection(file)
+}
+
+// format 与 Base.Format 一致的格式化输出
+func (p *Plan) format(file *ast.File) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := format.Node(&buffer, token.NewFileSet(), file); err != nil {
+		return nil, errors.Wrap(err, "格式化失败!")
+	}
+	return buffer.Bytes(), nil
+}
+
+// kind 注入类型, 各注入结构体的 Type 字段未在接口中暴露
+func (p *Plan) kind(value Ast) Type {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() == reflect.Struct {
+		if field := v.FieldByName("Type"); field.IsValid() && field.Type() == reflect.TypeOf(Type("")) {
+			return field.Interface().(Type)
+		}
+	}
+	return Type(fmt.Sprintf("%T", value))
+}
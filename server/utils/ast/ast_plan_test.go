@@ -0,0 +1,132 @@
+package ast
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const planGormBiz = `package initialize
+
+import (
+	"server/global"
+)
+
+func bizModel() error {
+	db := global.GVA_DB
+	err := db.AutoMigrate()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+`
+
+const planApiEnter = `package example
+
+import "server/service"
+
+type ApiGroup struct {
+	CustomerApi
+}
+
+var (
+	customerService = service.ServiceGroupApp.ExampleServiceGroup.CustomerService
+)
+`
+
+func planFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	filename := filepath.Join(dir, name)
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func planAsts(gorm string, enter string) []Ast {
+	return []Ast{
+		&PackageInitializeGorm{Type: TypePackageInitializeGorm, Path: gorm, ImportPath: `"server/model/example"`, StructName: "ExaFileUploadAndDownload", PackageName: "example", IsNew: true},
+		&PackageInitializeGorm{Type: TypePackageInitializeGorm, Path: gorm, ImportPath: `"server/model/example"`, StructName: "ExaCustomer", PackageName: "example", IsNew: true},
+		&PackageModuleEnter{Type: TypePackageApiModuleEnter, Path: enter, ImportPath: `"server/service"`, StructName: "FileUploadAndDownloadApi", AppName: "ServiceGroupApp", GroupName: "ExampleServiceGroup", ModuleName: "fileUploadAndDownloadService", PackageName: "service", ServiceName: "FileUploadAndDownloadService"},
+	}
+}
+
+func TestPlan_Diff(t *testing.T) {
+	dir := t.TempDir()
+	gorm := planFile(t, dir, "gorm_biz.go", planGormBiz)
+	enter := planFile(t, dir, "enter.go", planApiEnter)
+	changes, conflicts := NewInjectionPlan(planAsts(gorm, enter)...).Diff()
+	if len(conflicts) != 0 {
+		t.Fatalf("Diff() conflicts = %v", conflicts)
+	}
+	if len(changes) != 2 || len(changes[0].Types) != 2 {
+		t.Fatalf("Diff() changes = %+v", changes)
+	}
+	for _, want := range []string{
+		"+	\"server/model/example\"",
+		"+	err := db.AutoMigrate(example.ExaFileUploadAndDownload{}, example.ExaCustomer{})",
+	} {
+		if !strings.Contains(changes[0].Diff, want) {
+			t.Errorf("Diff() missing %q in\n%s", want, changes[0].Diff)
+		}
+	}
+	if !strings.Contains(changes[1].Diff, "+	fileUploadAndDownloadService") {
+		t.Errorf("Diff() enter.go =\n%s", changes[1].Diff)
+	}
+	if content, _ := os.ReadFile(gorm); string(content) != planGormBiz {
+		t.Errorf("Diff() should not write files")
+	}
+}
+
+func TestPlan_Check(t *testing.T) {
+	dir := t.TempDir()
+	gorm := planFile(t, dir, "gorm_biz.go", planGormBiz)
+	enter := planFile(t, dir, "enter.go", "package example\n\ntype ApiGroup struct {\n")
+	asts := planAsts(gorm, enter)
+	asts = append(asts, &PackageInitializeRouter{Type: TypePackageInitializeRouter, Path: filepath.Join(dir, "router_biz.go")})
+	conflicts := NewInjectionPlan(asts...).Check()
+	reasons := make(map[ConflictReason]int)
+	for _, conflict := range conflicts {
+		reasons[conflict.Reason]++
+	}
+	if reasons[ConflictUnparseable] != 1 || reasons[ConflictMissing] != 1 || len(conflicts) != 2 {
+		t.Fatalf("Check() = %v", conflicts)
+	}
+
+	if _, err := NewInjectionPlan(planAsts(gorm, planFile(t, dir, "enter.go", planApiEnter))...).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	conflicts = NewInjectionPlan(planAsts(gorm, enter)...).Check()
+	if len(conflicts) != 3 || conflicts[0].Reason != ConflictExists || conflicts[0].Blocking() {
+		t.Fatalf("Check() after apply = %v", conflicts)
+	}
+}
+
+func TestPlan_Apply(t *testing.T) {
+	dir := t.TempDir()
+	gorm := planFile(t, dir, "gorm_biz.go", planGormBiz)
+	enter := planFile(t, dir, "enter.go", planApiEnter)
+	if _, err := NewInjectionPlan(planAsts(gorm, enter)...).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(enter); !strings.Contains(string(content), "FileUploadAndDownloadApi") {
+		t.Fatalf("Apply() enter.go =\n%s", content)
+	}
+	if _, err := NewRollbackPlan(planAsts(gorm, enter)...).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(gorm); strings.Contains(string(content), "example") {
+		t.Errorf("Rollback() gorm_biz.go =\n%s", content)
+	}
+
+	missing := append(planAsts(gorm, enter), &PackageInitializeRouter{Type: TypePackageInitializeRouter, Path: filepath.Join(dir, "router_biz.go")})
+	before, _ := os.ReadFile(gorm)
+	if _, err := NewInjectionPlan(missing...).Apply(); err == nil {
+		t.Fatal("Apply() with missing file should fail")
+	}
+	if after, _ := os.ReadFile(gorm); string(after) != string(before) {
+		t.Errorf("Apply() should not touch files when preflight fails")
+	}
+}
@@ -14,4 +14,6 @@ type Ast interface {
 	Injection(file *ast.File) error
 	// Format 格式化输出
 	Format(filename string, writer io.Writer, file *ast.File) error
+	// Filepath 注入的目标文件绝对路径
+	Filepath() string
 }
@@ -54,9 +54,17 @@ func (a *Base) Format(filename string, writer io.Writer, file *ast.File) error {
 	return nil
 }
 
+// resolve 与 Parse 一致, 有相对路径时以相对路径为准, 否则使用绝对路径
+func (a *Base) resolve(filePath string, relativePath string) string {
+	if relativePath != "" {
+		return a.AbsolutePath(relativePath)
+	}
+	return filePath
+}
+
 // RelativePath 绝对路径转相对路径
 func (a *Base) RelativePath(filePath string) string {
-	server := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server)
+	server := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
 	hasServer := strings.Index(filePath, server)
 	if hasServer != -1 {
 		filePath = strings.TrimPrefix(filePath, server)
@@ -68,7 +76,7 @@ func (a *Base) RelativePath(filePath string) string {
 
 // AbsolutePath 相对路径转绝对路径
 func (a *Base) AbsolutePath(filePath string) string {
-	server := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server)
+	server := filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server)
 	keys := strings.Split(filePath, "/")
 	filePath = filepath.Join(keys...)
 	filePath = filepath.Join(server, filePath)
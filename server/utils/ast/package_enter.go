@@ -83,3 +83,7 @@ func (a *PackageEnter) Format(filename string, writer io.Writer, file *ast.File)
 	}
 	return a.Base.Format(filename, writer, file)
 }
+
+func (a *PackageEnter) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
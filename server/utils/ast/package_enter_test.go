@@ -24,7 +24,7 @@ func TestPackageEnter_Rollback(t *testing.T) {
 			name: "测试ExampleApiGroup回滚",
 			fields: fields{
 				Type:              TypePackageApiEnter,
-				Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "api", "v1", "enter.go"),
+				Path:              filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "api", "v1", "enter.go"),
 				ImportPath:        `"server/api/v1/example"`,
 				StructName:        "ExampleApiGroup",
 				PackageName:       "example",
@@ -36,7 +36,7 @@ func TestPackageEnter_Rollback(t *testing.T) {
 			name: "测试ExampleRouterGroup回滚",
 			fields: fields{
 				Type:              TypePackageRouterEnter,
-				Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "router", "enter.go"),
+				Path:              filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "router", "enter.go"),
 				ImportPath:        `"server/router/example"`,
 				StructName:        "Example",
 				PackageName:       "example",
@@ -48,7 +48,7 @@ func TestPackageEnter_Rollback(t *testing.T) {
 			name: "测试ExampleServiceGroup回滚",
 			fields: fields{
 				Type:              TypePackageServiceEnter,
-				Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service", "enter.go"),
+				Path:              filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service", "enter.go"),
 				ImportPath:        `"server/service/example"`,
 				StructName:        "ExampleServiceGroup",
 				PackageName:       "example",
@@ -98,7 +98,7 @@ func TestPackageEnter_Injection(t *testing.T) {
 			name: "测试ExampleApiGroup注入",
 			fields: fields{
 				Type:              TypePackageApiEnter,
-				Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "api", "v1", "enter.go"),
+				Path:              filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "api", "v1", "enter.go"),
 				ImportPath:        `"server/api/v1/example"`,
 				StructName:        "ExampleApiGroup",
 				PackageName:       "example",
@@ -109,7 +109,7 @@ func TestPackageEnter_Injection(t *testing.T) {
 			name: "测试ExampleRouterGroup注入",
 			fields: fields{
 				Type:              TypePackageRouterEnter,
-				Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "router", "enter.go"),
+				Path:              filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "router", "enter.go"),
 				ImportPath:        `"server/router/example"`,
 				StructName:        "Example",
 				PackageName:       "example",
@@ -121,7 +121,7 @@ func TestPackageEnter_Injection(t *testing.T) {
 			name: "测试ExampleServiceGroup注入",
 			fields: fields{
 				Type:              TypePackageServiceEnter,
-				Path:              filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service", "enter.go"),
+				Path:              filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service", "enter.go"),
 				ImportPath:        `"server/service/example"`,
 				StructName:        "ExampleServiceGroup",
 				PackageName:       "example",
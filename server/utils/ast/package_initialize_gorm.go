@@ -118,6 +118,11 @@ func (a *PackageInitializeGorm) Injection(file *ast.File) error {
 			return true
 		}
 
+		// 已注册的结构体不重复添加
+		if HasMigrateModel(callExpr.Args, a.PackageName, a.StructName) {
+			return true
+		}
+
 		// 添加结构体参数
 		callExpr.Args = append(callExpr.Args, &ast.CompositeLit{
 			Type: &ast.SelectorExpr{
@@ -194,3 +199,7 @@ func (a *PackageInitializeGorm) addDbVar(astBody *ast.BlockStmt) {
 	returnNode := astBody.List[len(astBody.List)-1]
 	astBody.List = append(astBody.List[:len(astBody.List)-1], assignNode, autoMigrateCall, returnNode)
 }
+
+func (a *PackageInitializeGorm) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
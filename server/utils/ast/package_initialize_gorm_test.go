@@ -24,7 +24,7 @@ func TestPackageInitializeGorm_Injection(t *testing.T) {
 			name: "测试 &example.ExaFileUploadAndDownload{} 注入",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaFileUploadAndDownload",
 				PackageName: "example",
@@ -35,7 +35,7 @@ func TestPackageInitializeGorm_Injection(t *testing.T) {
 			name: "测试 &example.ExaCustomer{} 注入",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaCustomer",
 				PackageName: "example",
@@ -46,7 +46,7 @@ func TestPackageInitializeGorm_Injection(t *testing.T) {
 			name: "测试 new(example.ExaFileUploadAndDownload) 注入",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaFileUploadAndDownload",
 				PackageName: "example",
@@ -57,7 +57,7 @@ func TestPackageInitializeGorm_Injection(t *testing.T) {
 			name: "测试 new(example.ExaCustomer) 注入",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaCustomer",
 				PackageName: "example",
@@ -106,7 +106,7 @@ func TestPackageInitializeGorm_Rollback(t *testing.T) {
 			name: "测试 &example.ExaFileUploadAndDownload{} 回滚",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaFileUploadAndDownload",
 				PackageName: "example",
@@ -117,7 +117,7 @@ func TestPackageInitializeGorm_Rollback(t *testing.T) {
 			name: "测试 &example.ExaCustomer{} 回滚",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaCustomer",
 				PackageName: "example",
@@ -128,7 +128,7 @@ func TestPackageInitializeGorm_Rollback(t *testing.T) {
 			name: "测试 new(example.ExaFileUploadAndDownload) 回滚",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaFileUploadAndDownload",
 				PackageName: "example",
@@ -139,7 +139,7 @@ func TestPackageInitializeGorm_Rollback(t *testing.T) {
 			name: "测试 new(example.ExaCustomer) 回滚",
 			fields: fields{
 				Type:        TypePackageInitializeGorm,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "gorm_biz.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "gorm_biz.go"),
 				ImportPath:  `"server/model/example"`,
 				StructName:  "ExaCustomer",
 				PackageName: "example",
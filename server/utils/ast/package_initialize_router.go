@@ -90,6 +90,9 @@ func (a *PackageInitializeRouter) Rollback(file *ast.File) error {
 
 func (a *PackageInitializeRouter) Injection(file *ast.File) error {
 	funcDecl := FindFunction(file, "initBizRouter")
+	if funcDecl == nil {
+		return fmt.Errorf("[filepath:%s]未找到initBizRouter函数", a.Path)
+	}
 	hasRouter := false
 	var varBlock *ast.BlockStmt
 	for i := range funcDecl.Body.List {
@@ -109,6 +112,9 @@ func (a *PackageInitializeRouter) Injection(file *ast.File) error {
 			},
 		}
 	}
+	if hasRouter && HasMethodCall(varBlock, a.ModuleName, a.FunctionName) {
+		return nil
+	} // 已注册的路由不重复添加
 	routerStmt := CreateStmt(fmt.Sprintf("%s.%s(%s,%s)", a.ModuleName, a.FunctionName, a.LeftRouterGroupName, a.RightRouterGroupName))
 	varBlock.List = append(varBlock.List, routerStmt)
 	if !hasRouter {
@@ -148,3 +154,7 @@ func (a *PackageInitializeRouter) CreateAssignStmt() *ast.AssignStmt {
 
 	return stmt
 }
+
+func (a *PackageInitializeRouter) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
@@ -27,7 +27,7 @@ func TestPackageInitializeRouter_Injection(t *testing.T) {
 			name: "测试 InitCustomerRouter 注入",
 			fields: fields{
 				Type:            TypePackageInitializeRouter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "router_biz.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "router_biz.go"),
 				ImportPath:      `"server/router"`,
 				AppName:         "RouterGroupApp",
 				GroupName:       "Example",
@@ -42,7 +42,7 @@ func TestPackageInitializeRouter_Injection(t *testing.T) {
 			name: "测试 InitFileUploadAndDownloadRouter 注入",
 			fields: fields{
 				Type:            TypePackageInitializeRouter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "router_biz.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "router_biz.go"),
 				ImportPath:      `"server/router"`,
 				AppName:         "RouterGroupApp",
 				GroupName:       "Example",
@@ -104,7 +104,7 @@ func TestPackageInitializeRouter_Rollback(t *testing.T) {
 			name: "测试 InitCustomerRouter 回滚",
 			fields: fields{
 				Type:            TypePackageInitializeRouter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "router_biz.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "router_biz.go"),
 				ImportPath:      `"server/router"`,
 				AppName:         "RouterGroupApp",
 				GroupName:       "Example",
@@ -119,7 +119,7 @@ func TestPackageInitializeRouter_Rollback(t *testing.T) {
 			name: "测试 InitFileUploadAndDownloadRouter 回滚",
 			fields: fields{
 				Type:            TypePackageInitializeRouter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize", "router_biz.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "initialize", "router_biz.go"),
 				ImportPath:      `"server/router"`,
 				AppName:         "RouterGroupApp",
 				GroupName:       "Example",
@@ -83,6 +83,17 @@ func (a *PackageModuleEnter) Injection(file *ast.File) error {
 	_ = NewImport(a.ImportPath).Injection(file)
 	var hasValue bool
 	var hasVariables bool
+	for i := 0; i < len(file.Decls); i++ {
+		v1, o1 := file.Decls[i].(*ast.GenDecl)
+		if o1 && v1.Tok == token.VAR {
+			for j := 0; j < len(v1.Specs); j++ {
+				v2, o2 := v1.Specs[j].(*ast.ValueSpec)
+				if o2 && len(v2.Names) == 1 && v2.Names[0].Name == a.ModuleName {
+					hasValue = true
+				}
+			}
+		}
+	} // 已注入的变量不再重复注入
 	for i := 0; i < len(file.Decls); i++ {
 		v1, o1 := file.Decls[i].(*ast.GenDecl)
 		if o1 {
@@ -178,3 +189,7 @@ func (a *PackageModuleEnter) Format(filename string, writer io.Writer, file *ast
 	}
 	return a.Base.Format(filename, writer, file)
 }
+
+func (a *PackageModuleEnter) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
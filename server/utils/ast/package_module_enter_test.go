@@ -27,7 +27,7 @@ func TestPackageModuleEnter_Rollback(t *testing.T) {
 			name: "测试 FileUploadAndDownloadRouter 回滚",
 			fields: fields{
 				Type:        TypePackageRouterModuleEnter,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "router", "example", "enter.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "router", "example", "enter.go"),
 				ImportPath:  `api "server/api/v1"`,
 				StructName:  "FileUploadAndDownloadRouter",
 				AppName:     "ApiGroupApp",
@@ -42,7 +42,7 @@ func TestPackageModuleEnter_Rollback(t *testing.T) {
 			name: "测试 FileUploadAndDownloadApi 回滚",
 			fields: fields{
 				Type:        TypePackageApiModuleEnter,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "api", "v1", "example", "enter.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "api", "v1", "example", "enter.go"),
 				ImportPath:  `"server/service"`,
 				StructName:  "FileUploadAndDownloadApi",
 				AppName:     "ServiceGroupApp",
@@ -57,7 +57,7 @@ func TestPackageModuleEnter_Rollback(t *testing.T) {
 			name: "测试 FileUploadAndDownloadService 回滚",
 			fields: fields{
 				Type:        TypePackageServiceModuleEnter,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service", "example", "enter.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service", "example", "enter.go"),
 				ImportPath:  ``,
 				StructName:  "FileUploadAndDownloadService",
 				AppName:     "",
@@ -116,7 +116,7 @@ func TestPackageModuleEnter_Injection(t *testing.T) {
 			name: "测试 FileUploadAndDownloadRouter 注入",
 			fields: fields{
 				Type:        TypePackageRouterModuleEnter,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "router", "example", "enter.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "router", "example", "enter.go"),
 				ImportPath:  `api "server/api/v1"`,
 				StructName:  "FileUploadAndDownloadRouter",
 				AppName:     "ApiGroupApp",
@@ -131,7 +131,7 @@ func TestPackageModuleEnter_Injection(t *testing.T) {
 			name: "测试 FileUploadAndDownloadApi 注入",
 			fields: fields{
 				Type:        TypePackageApiModuleEnter,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "api", "v1", "example", "enter.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "api", "v1", "example", "enter.go"),
 				ImportPath:  `"server/service"`,
 				StructName:  "FileUploadAndDownloadApi",
 				AppName:     "ServiceGroupApp",
@@ -146,7 +146,7 @@ func TestPackageModuleEnter_Injection(t *testing.T) {
 			name: "测试 FileUploadAndDownloadService 注入",
 			fields: fields{
 				Type:        TypePackageServiceModuleEnter,
-				Path:        filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "service", "example", "enter.go"),
+				Path:        filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "service", "example", "enter.go"),
 				ImportPath:  ``,
 				StructName:  "FileUploadAndDownloadService",
 				AppName:     "",
@@ -165,3 +165,7 @@ func (a *PluginEnter) Format(filename string, writer io.Writer, file *ast.File)
 	}
 	return a.Base.Format(filename, writer, file)
 }
+
+func (a *PluginEnter) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
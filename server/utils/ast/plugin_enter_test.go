@@ -27,7 +27,7 @@ func TestPluginEnter_Injection(t *testing.T) {
 			name: "测试 Gva插件UserApi 注入",
 			fields: fields{
 				Type:            TypePluginApiEnter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "api", "enter.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "api", "enter.go"),
 				ImportPath:      `"server/plugin/gva/service"`,
 				StructName:      "User",
 				StructCamelName: "user",
@@ -42,7 +42,7 @@ func TestPluginEnter_Injection(t *testing.T) {
 			name: "测试 Gva插件UserRouter 注入",
 			fields: fields{
 				Type:            TypePluginRouterEnter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "router", "enter.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "router", "enter.go"),
 				ImportPath:      `"server/plugin/gva/api"`,
 				StructName:      "User",
 				StructCamelName: "user",
@@ -57,7 +57,7 @@ func TestPluginEnter_Injection(t *testing.T) {
 			name: "测试 Gva插件UserService 注入",
 			fields: fields{
 				Type:            TypePluginServiceEnter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "service", "enter.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "service", "enter.go"),
 				ImportPath:      "",
 				StructName:      "User",
 				StructCamelName: "user",
@@ -72,7 +72,7 @@ func TestPluginEnter_Injection(t *testing.T) {
 			name: "测试 gva的User 注入",
 			fields: fields{
 				Type:            TypePluginServiceEnter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "service", "enter.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "service", "enter.go"),
 				ImportPath:      "",
 				StructName:      "User",
 				StructCamelName: "user",
@@ -131,7 +131,7 @@ func TestPluginEnter_Rollback(t *testing.T) {
 			name: "测试 Gva插件UserRouter 回滚",
 			fields: fields{
 				Type:            TypePluginRouterEnter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "router", "enter.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "router", "enter.go"),
 				ImportPath:      `"server/plugin/gva/api"`,
 				StructName:      "User",
 				StructCamelName: "user",
@@ -146,7 +146,7 @@ func TestPluginEnter_Rollback(t *testing.T) {
 			name: "测试 Gva插件UserApi 回滚",
 			fields: fields{
 				Type:            TypePluginApiEnter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "api", "enter.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "api", "enter.go"),
 				ImportPath:      `"server/plugin/gva/service"`,
 				StructName:      "User",
 				StructCamelName: "user",
@@ -161,7 +161,7 @@ func TestPluginEnter_Rollback(t *testing.T) {
 			name: "测试 Gva插件UserService 回滚",
 			fields: fields{
 				Type:            TypePluginServiceEnter,
-				Path:            filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "service", "enter.go"),
+				Path:            filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "service", "enter.go"),
 				ImportPath:      "",
 				StructName:      "User",
 				StructCamelName: "user",
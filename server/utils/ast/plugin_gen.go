@@ -187,3 +187,7 @@ func (a *PluginGen) Format(filename string, writer io.Writer, file *ast.File) er
 	}
 	return a.Base.Format(filename, writer, file)
 }
+
+func (a *PluginGen) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"fmt"
 	"go/ast"
 	"io"
 )
@@ -92,6 +93,13 @@ func (a *PluginInitializeGorm) Injection(file *ast.File) error {
 		return true
 	})
 
+	if call == nil {
+		return fmt.Errorf("[filepath:%s]未找到AutoMigrate调用", a.Path)
+	}
+	if HasMigrateModel(call.Args, a.PackageName, a.StructName) {
+		return nil
+	}
+
 	arg := &ast.CompositeLit{
 		Type: &ast.SelectorExpr{
 			X:   &ast.Ident{Name: a.PackageName},
@@ -109,3 +117,7 @@ func (a *PluginInitializeGorm) Format(filename string, writer io.Writer, file *a
 	}
 	return a.Base.Format(filename, writer, file)
 }
+
+func (a *PluginInitializeGorm) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
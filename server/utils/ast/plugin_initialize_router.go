@@ -122,3 +122,7 @@ func (a *PluginInitializeRouter) Format(filename string, writer io.Writer, file
 	}
 	return a.Base.Format(filename, writer, file)
 }
+
+func (a *PluginInitializeRouter) Filepath() string {
+	return a.Base.resolve(a.Path, a.RelativePath)
+}
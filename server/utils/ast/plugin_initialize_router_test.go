@@ -27,7 +27,7 @@ func TestPluginInitializeRouter_Injection(t *testing.T) {
 			name: "测试 Gva插件User 注入",
 			fields: fields{
 				Type:                 TypePluginInitializeRouter,
-				Path:                 filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
+				Path:                 filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
 				ImportPath:           `"server/plugin/gva/router"`,
 				AppName:              "Router",
 				GroupName:            "User",
@@ -42,7 +42,7 @@ func TestPluginInitializeRouter_Injection(t *testing.T) {
 			name: "测试 中文 注入",
 			fields: fields{
 				Type:                 TypePluginInitializeRouter,
-				Path:                 filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
+				Path:                 filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
 				ImportPath:           `"server/plugin/gva/router"`,
 				AppName:              "Router",
 				GroupName:            "U中文",
@@ -101,7 +101,7 @@ func TestPluginInitializeRouter_Rollback(t *testing.T) {
 			name: "测试 Gva插件User 回滚",
 			fields: fields{
 				Type:                 TypePluginInitializeRouter,
-				Path:                 filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
+				Path:                 filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
 				ImportPath:           `"server/plugin/gva/router"`,
 				AppName:              "Router",
 				GroupName:            "User",
@@ -116,7 +116,7 @@ func TestPluginInitializeRouter_Rollback(t *testing.T) {
 			name: "测试 中文 注入",
 			fields: fields{
 				Type:                 TypePluginInitializeRouter,
-				Path:                 filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
+				Path:                 filepath.Join(global.GVA_CONFIG.Load().AutoCode.Root, global.GVA_CONFIG.Load().AutoCode.Server, "plugin", "gva", "initialize", "router.go"),
 				ImportPath:           `"server/plugin/gva/router"`,
 				AppName:              "Router",
 				GroupName:            "U中文",
@@ -50,3 +50,7 @@ func (a *PluginInitializeV2) Format(filename string, writer io.Writer, file *ast
 	}
 	return a.Base.Format(filename, writer, file)
 }
+
+func (a *PluginInitializeV2) Filepath() string {
+	return a.Base.resolve(a.PluginPath, a.RelativePath)
+}
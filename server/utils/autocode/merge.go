@@ -0,0 +1,135 @@
+package autocode
+
+import (
+	"strings"
+)
+
+const (
+	MarkerCurrent   = "<<<<<<< current"
+	MarkerBase      = "||||||| generated"
+	MarkerSeparator = "======="
+	MarkerIncoming  = ">>>>>>> regenerated"
+)
+
+// MergeResult 三方合并结果
+type MergeResult struct {
+	Content   string // 合并后的内容, 冲突处带有冲突标记
+	Conflicts int    // 冲突块数量
+}
+
+// Merge 以上次生成的代码 base 为共同祖先, 按行合并磁盘上的代码 current 与重新生成的代码 incoming
+// 只有一方改动的块直接采用改动方, 双方改动相同的块采用任一方, 双方改动不同的块输出冲突标记
+func Merge(base, current, incoming string) MergeResult {
+	o, a, b := splitLines(base), splitLines(current), splitLines(incoming)
+	ma, mb := matchLines(o, a), matchLines(o, b)
+
+	var out strings.Builder
+	var result MergeResult
+	io, ia, ib := 0, 0, 0
+	for io < len(o) || ia < len(a) || ib < len(b) {
+		// 三方一致的行直接输出
+		if io < len(o) && ma[io] == ia && mb[io] == ib {
+			out.WriteString(o[io])
+			io, ia, ib = io+1, ia+1, ib+1
+			continue
+		}
+		// 找到下一处三方一致的行, 之前的部分为不稳定块
+		next := io
+		for next < len(o) && (ma[next] < 0 || mb[next] < 0) {
+			next++
+		}
+		ea, eb := len(a), len(b)
+		if next < len(o) {
+			ea, eb = ma[next], mb[next]
+		}
+		co, ca, cb := o[io:next], a[ia:ea], b[ib:eb]
+		switch {
+		case equalLines(ca, co):
+			writeLines(&out, cb)
+		case equalLines(cb, co), equalLines(ca, cb):
+			writeLines(&out, ca)
+		default:
+			result.Conflicts++
+			out.WriteString(MarkerCurrent + "\n")
+			writeBlock(&out, ca)
+			out.WriteString(MarkerBase + "\n")
+			writeBlock(&out, co)
+			out.WriteString(MarkerSeparator + "\n")
+			writeBlock(&out, cb)
+			out.WriteString(MarkerIncoming + "\n")
+		}
+		io, ia, ib = next, ea, eb
+	}
+	result.Content = out.String()
+	return result
+}
+
+// matchLines 基于最长公共子序列计算 base 每一行在 other 中对应的行号, 未匹配为 -1
+func matchLines(base, other []string) []int {
+	n, m := len(base), len(other)
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case base[i] == other[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case base[i] == other[j]:
+			match[i] = j
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.SplitAfter(s, "\n")
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// writeBlock 输出冲突块, 保证冲突标记独占一行
+func writeBlock(out *strings.Builder, lines []string) {
+	writeLines(out, lines)
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		out.WriteString("\n")
+	}
+}
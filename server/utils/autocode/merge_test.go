@@ -0,0 +1,61 @@
+package autocode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	base := "package a\n\ntype A struct {\n\tName string\n}\n\nfunc Get() {}\n"
+	tests := []struct {
+		name      string
+		current   string
+		incoming  string
+		want      string
+		conflicts int
+	}{
+		{
+			name:     "只有重新生成改动",
+			current:  base,
+			incoming: "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n",
+			want:     "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n",
+		},
+		{
+			name:     "手工修改与新增字段互不影响",
+			current:  "package a\n\ntype A struct {\n\tName string\n}\n\nfunc Get() {}\n\n// Custom 手写方法\nfunc Custom() {}\n",
+			incoming: "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n",
+			want:     "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n\n// Custom 手写方法\nfunc Custom() {}\n",
+		},
+		{
+			name:     "双方相同改动",
+			current:  "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n",
+			incoming: "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n",
+			want:     "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n",
+		},
+		{
+			name:      "同一处不同改动产生冲突",
+			current:   "package a\n\ntype A struct {\n\tTitle string\n}\n\nfunc Get() {}\n",
+			incoming:  "package a\n\ntype A struct {\n\tName string\n\tAge  int\n}\n\nfunc Get() {}\n",
+			want:      "package a\n\ntype A struct {\n" + MarkerCurrent + "\n\tTitle string\n" + MarkerBase + "\n\tName string\n" + MarkerSeparator + "\n\tName string\n\tAge  int\n" + MarkerIncoming + "\n}\n\nfunc Get() {}\n",
+			conflicts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Merge(base, tt.current, tt.incoming)
+			if got.Content != tt.want {
+				t.Errorf("Merge() content =\n%s\nwant\n%s", got.Content, tt.want)
+			}
+			if got.Conflicts != tt.conflicts {
+				t.Errorf("Merge() conflicts = %d, want %d", got.Conflicts, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestMerge_NoTrailingNewline(t *testing.T) {
+	got := Merge("a\nb", "a\nc", "a\nd")
+	if got.Conflicts != 1 || !strings.Contains(got.Content, "c\n"+MarkerBase+"\nb\n"+MarkerSeparator+"\nd\n"+MarkerIncoming) {
+		t.Errorf("Merge() = %q", got.Content)
+	}
+}
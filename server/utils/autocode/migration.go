@@ -0,0 +1,226 @@
+package autocode
+
+import (
+	"fmt"
+	"strings"
+
+	systemReq "server/model/system/request"
+)
+
+const (
+	MigrationAdd    = "add"
+	MigrationDrop   = "drop"
+	MigrationRename = "rename"
+	MigrationAlter  = "alter"
+)
+
+// Dialects 生成迁移的数据库方言, 与 system.db-type 取值一致
+var Dialects = []string{"mysql", "pgsql", "sqlite", "mssql", "oracle"}
+
+// ColumnChange 一次字段变更, Old/New 分别为变更前后的字段, 新增时 Old 为空, 删除时 New 为空
+type ColumnChange struct {
+	Op  string                   `json:"op"`
+	Old *systemReq.AutoCodeField `json:"old,omitempty"`
+	New *systemReq.AutoCodeField `json:"new,omitempty"`
+}
+
+// DiffFields 比对结构体字段变更, 以数据库列名关联前后字段, 仅修改 Go 字段名不产生变更
+// 新字段的 RenameFrom 指向旧列名, 或 Go 字段名未变而列名变化时视为重命名, 其余未关联的旧列视为删除
+// 类型或长度变化视为修改类型, 同一字段可能同时产生重命名与修改类型两项
+func DiffFields(oldFields, newFields []*systemReq.AutoCodeField) []ColumnChange {
+	olds := make(map[string]*systemReq.AutoCodeField, len(oldFields))
+	for _, field := range oldFields {
+		olds[field.ColumnName] = field
+	}
+	matched := make(map[string]bool, len(newFields))
+	pairs := make(map[*systemReq.AutoCodeField]*systemReq.AutoCodeField, len(newFields))
+	for _, field := range newFields {
+		if old, ok := olds[field.ColumnName]; ok {
+			pairs[field] = old
+			matched[old.ColumnName] = true
+		}
+	}
+	for _, field := range newFields {
+		if _, ok := pairs[field]; ok {
+			continue
+		}
+		for _, old := range oldFields {
+			if matched[old.ColumnName] {
+				continue
+			}
+			if (field.RenameFrom != "" && field.RenameFrom == old.ColumnName) || (field.RenameFrom == "" && field.FieldName == old.FieldName) {
+				pairs[field] = old
+				matched[old.ColumnName] = true
+				break
+			}
+		}
+	}
+	var changes, adds []ColumnChange
+	for _, field := range newFields {
+		old, ok := pairs[field]
+		if !ok {
+			adds = append(adds, ColumnChange{Op: MigrationAdd, New: field})
+			continue
+		}
+		if old.ColumnName != field.ColumnName {
+			changes = append(changes, ColumnChange{Op: MigrationRename, Old: old, New: field})
+		}
+		if old.FieldType != field.FieldType || old.DataTypeLong != field.DataTypeLong {
+			changes = append(changes, ColumnChange{Op: MigrationAlter, Old: old, New: field})
+		}
+	}
+	var drops []ColumnChange
+	for _, field := range oldFields {
+		if !matched[field.ColumnName] {
+			drops = append(drops, ColumnChange{Op: MigrationDrop, Old: field})
+		}
+	}
+	changes = append(changes, adds...)
+	return append(changes, drops...)
+}
+
+// Drops 变更中将被删除的列名
+func Drops(changes []ColumnChange) []string {
+	var columns []string
+	for _, change := range changes {
+		if change.Op == MigrationDrop {
+			columns = append(columns, change.Old.ColumnName)
+		}
+	}
+	return columns
+}
+
+// MigrationSQL 按方言生成升级与回滚SQL, 每行一条语句, 以 -- 开头的行为说明
+// 方言不支持某项变更时返回错误, 不生成无法完整执行的迁移
+func MigrationSQL(dialect, table string, changes []ColumnChange) (up, down string, err error) {
+	ups := make([]string, 0, len(changes))
+	downs := make([]string, 0, len(changes))
+	for _, change := range changes {
+		switch change.Op {
+		case MigrationAdd:
+			ups = append(ups, addColumn(dialect, table, change.New))
+			downs = append(downs, dropColumn(dialect, table, change.New.ColumnName))
+		case MigrationDrop:
+			ups = append(ups, dropColumn(dialect, table, change.Old.ColumnName))
+			downs = append(downs, addColumn(dialect, table, change.Old), "-- 回滚仅恢复列结构, 删除前的数据无法恢复")
+		case MigrationRename:
+			ups = append(ups, renameColumn(dialect, table, change.Old.ColumnName, change.New.ColumnName))
+			downs = append(downs, renameColumn(dialect, table, change.New.ColumnName, change.Old.ColumnName))
+		case MigrationAlter:
+			if dialect == "sqlite" {
+				return "", "", fmt.Errorf("sqlite 不支持修改列类型, 需手动重建表: %s.%s", table, change.New.ColumnName)
+			}
+			ups = append(ups, alterColumn(dialect, table, change.New.ColumnName, change.New))
+			downs = append(downs, alterColumn(dialect, table, change.New.ColumnName, change.Old))
+		}
+	}
+	// 回滚按相反顺序执行
+	for i, j := 0, len(downs)-1; i < j; i, j = i+1, j-1 {
+		downs[i], downs[j] = downs[j], downs[i]
+	}
+	return strings.Join(ups, "\n"), strings.Join(downs, "\n"), nil
+}
+
+// SplitStatements 拆分迁移SQL为可执行语句, 跳过空行与注释
+func SplitStatements(sql string) []string {
+	var statements []string
+	for _, line := range strings.Split(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		statements = append(statements, strings.TrimSuffix(line, ";"))
+	}
+	return statements
+}
+
+// ColumnType 字段在指定方言下的列类型, 与生成的 gorm 标签保持一致
+func ColumnType(dialect string, field *systemReq.AutoCodeField) string {
+	size := field.DataTypeLong
+	if size == "" {
+		size = "191"
+	}
+	switch field.FieldType {
+	case "int":
+		return map[string]string{"mysql": "bigint", "pgsql": "bigint", "sqlite": "integer", "mssql": "bigint", "oracle": "NUMBER(20)"}[dialect]
+	case "bool":
+		return map[string]string{"mysql": "tinyint(1)", "pgsql": "boolean", "sqlite": "numeric", "mssql": "bit", "oracle": "NUMBER(1)"}[dialect]
+	case "float64":
+		return map[string]string{"mysql": "double", "pgsql": "decimal", "sqlite": "real", "mssql": "float", "oracle": "BINARY_DOUBLE"}[dialect]
+	case "time.Time":
+		return map[string]string{"mysql": "datetime(3)", "pgsql": "timestamptz", "sqlite": "datetime", "mssql": "datetimeoffset", "oracle": "TIMESTAMP WITH TIME ZONE"}[dialect]
+	case "richtext":
+		return map[string]string{"mysql": "text", "pgsql": "text", "sqlite": "text", "mssql": "nvarchar(MAX)", "oracle": "CLOB"}[dialect]
+	case "file", "pictures", "array", "json":
+		return map[string]string{"mysql": "JSON", "pgsql": "JSONB", "sqlite": "JSON", "mssql": "NVARCHAR(MAX)", "oracle": "CLOB"}[dialect]
+	case "enum":
+		if dialect == "mysql" {
+			return fmt.Sprintf("enum(%s)", field.DataTypeLong)
+		}
+		size = "191"
+	}
+	switch dialect {
+	case "sqlite":
+		return "text"
+	case "mssql":
+		return fmt.Sprintf("nvarchar(%s)", size)
+	case "oracle":
+		return fmt.Sprintf("VARCHAR2(%s)", size)
+	default:
+		return fmt.Sprintf("varchar(%s)", size)
+	}
+}
+
+func quote(dialect, name string) string {
+	switch dialect {
+	case "mysql", "sqlite":
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case "mssql":
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+// literal 转义为 SQL 字符串字面量
+func literal(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func addColumn(dialect, table string, field *systemReq.AutoCodeField) string {
+	column := quote(dialect, field.ColumnName) + " " + ColumnType(dialect, field)
+	switch dialect {
+	case "mssql":
+		return fmt.Sprintf("ALTER TABLE %s ADD %s;", quote(dialect, table), column)
+	case "oracle":
+		return fmt.Sprintf("ALTER TABLE %s ADD (%s);", quote(dialect, table), column)
+	default:
+		return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", quote(dialect, table), column)
+	}
+}
+
+func dropColumn(dialect, table, column string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", quote(dialect, table), quote(dialect, column))
+}
+
+func renameColumn(dialect, table, from, to string) string {
+	if dialect == "mssql" {
+		// 原列名按对象名解析需加方括号, 新列名按字面使用不能加
+		return fmt.Sprintf("EXEC sp_rename %s, %s, 'COLUMN';", literal(quote(dialect, table)+"."+quote(dialect, from)), literal(to))
+	}
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", quote(dialect, table), quote(dialect, from), quote(dialect, to))
+}
+
+func alterColumn(dialect, table, column string, field *systemReq.AutoCodeField) string {
+	typ := ColumnType(dialect, field)
+	switch dialect {
+	case "mysql":
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s;", quote(dialect, table), quote(dialect, column), typ)
+	case "pgsql":
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;", quote(dialect, table), quote(dialect, column), typ, quote(dialect, column), typ)
+	case "mssql":
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", quote(dialect, table), quote(dialect, column), typ)
+	default:
+		return fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s);", quote(dialect, table), quote(dialect, column), typ)
+	}
+}
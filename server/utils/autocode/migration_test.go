@@ -0,0 +1,86 @@
+package autocode
+
+import (
+	"strings"
+	"testing"
+
+	systemReq "server/model/system/request"
+)
+
+func TestDiffFields(t *testing.T) {
+	oldFields := []*systemReq.AutoCodeField{
+		{FieldName: "Name", ColumnName: "name", FieldType: "string", DataTypeLong: "64"},
+		{FieldName: "Age", ColumnName: "age", FieldType: "int"},
+		{FieldName: "Remark", ColumnName: "remark", FieldType: "string"},
+	}
+	newFields := []*systemReq.AutoCodeField{
+		{FieldName: "Name", ColumnName: "name", FieldType: "string", DataTypeLong: "128"},
+		{FieldName: "Age", ColumnName: "user_age", FieldType: "int"},
+		{FieldName: "Email", ColumnName: "email", FieldType: "string"},
+	}
+	changes := DiffFields(oldFields, newFields)
+	var ops []string
+	for _, change := range changes {
+		ops = append(ops, change.Op)
+	}
+	if got := strings.Join(ops, ","); got != "alter,rename,add,drop" {
+		t.Fatalf("DiffFields() ops = %s", got)
+	}
+
+	up, down, err := MigrationSQL("mysql", "users", changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantUp := "ALTER TABLE `users` MODIFY COLUMN `name` varchar(128);\n" +
+		"ALTER TABLE `users` RENAME COLUMN `age` TO `user_age`;\n" +
+		"ALTER TABLE `users` ADD COLUMN `email` varchar(191);\n" +
+		"ALTER TABLE `users` DROP COLUMN `remark`;"
+	if up != wantUp {
+		t.Errorf("up =\n%s\nwant\n%s", up, wantUp)
+	}
+	if statements := SplitStatements(down); len(statements) != 4 || statements[0] != "ALTER TABLE `users` ADD COLUMN `remark` varchar(191)" {
+		t.Errorf("down = %q", statements)
+	}
+
+	for _, dialect := range Dialects {
+		up, down, err = MigrationSQL(dialect, "users", changes)
+		if dialect == "sqlite" {
+			// sqlite 不支持修改列类型, 不能生成只有注释的迁移
+			if err == nil {
+				t.Errorf("sqlite 修改列类型应返回错误, up = %s", up)
+			}
+			continue
+		}
+		if up == "" || down == "" {
+			t.Errorf("%s 未生成SQL", dialect)
+		}
+	}
+	if up, _, _ = MigrationSQL("mssql", "users", changes[1:2]); up != "EXEC sp_rename '[users].[age]', 'user_age', 'COLUMN';" {
+		t.Errorf("mssql rename = %s", up)
+	}
+	rename := []ColumnChange{{Op: MigrationRename, Old: &systemReq.AutoCodeField{ColumnName: "o'k]"}, New: &systemReq.AutoCodeField{ColumnName: "it's"}}}
+	if up, _, _ = MigrationSQL("mssql", "a]b", rename); up != "EXEC sp_rename '[a]]b].[o''k]]]', 'it''s', 'COLUMN';" {
+		t.Errorf("mssql rename 未转义: %s", up)
+	}
+}
+
+func TestDiffFieldsByColumn(t *testing.T) {
+	oldFields := []*systemReq.AutoCodeField{
+		{FieldName: "Name", ColumnName: "name", FieldType: "string"},
+		{FieldName: "Age", ColumnName: "age", FieldType: "int"},
+	}
+	newFields := []*systemReq.AutoCodeField{
+		{FieldName: "Title", ColumnName: "name", FieldType: "string"},
+		{FieldName: "Years", ColumnName: "years", FieldType: "int", RenameFrom: "age"},
+	}
+	changes := DiffFields(oldFields, newFields)
+	// 只改 Go 字段名不变更列, 指定 RenameFrom 时重命名而不是删除后新增
+	if len(changes) != 1 || changes[0].Op != MigrationRename || changes[0].Old.ColumnName != "age" || len(Drops(changes)) != 0 {
+		t.Fatalf("DiffFields() = %+v", changes)
+	}
+
+	newFields[1].RenameFrom = ""
+	if drops := Drops(DiffFields(oldFields, newFields)); len(drops) != 1 || drops[0] != "age" {
+		t.Errorf("Drops() = %v", drops)
+	}
+}
@@ -0,0 +1,121 @@
+package autocode
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/schema"
+	systemReq "server/model/system/request"
+)
+
+// naming 与 GORM 默认命名策略一致, 保证子查询列名与生成的表结构相同
+var naming = schema.NamingStrategy{}
+
+// 渲染Model中的关联字段, pkg 为当前模型所在包, 为空时关联模型均视为同包
+func GenerateRelationField(relation systemReq.AutoCodeRelation, pkg string) string {
+	model := relation.StructName
+	if pkg != "" && relation.Package != "" && relation.Package != pkg {
+		model = relation.Package + "." + relation.StructName
+	}
+	var result string
+	switch relation.Type {
+	case systemReq.RelationBelongsTo:
+		result = fmt.Sprintf("%s  *%s `json:\"%s\" form:\"%s\" gorm:\"foreignKey:%s;references:%s\"`",
+			relation.FieldName, model, relation.FieldJson, relation.FieldJson, relation.ForeignKey, relation.References)
+	case systemReq.RelationHasMany:
+		result = fmt.Sprintf("%s  []%s `json:\"%s\" form:\"%s\" gorm:\"foreignKey:%s;references:%s\"`",
+			relation.FieldName, model, relation.FieldJson, relation.FieldJson, relation.ForeignKey, relation.References)
+	case systemReq.RelationMany2Many:
+		// 中间表随 AutoMigrate 自动创建, 引用字段非默认值时写入标签
+		tag := "many2many:" + relation.JoinTable + ";"
+		if relation.ForeignKey != "" {
+			tag += "foreignKey:" + relation.ForeignKey + ";"
+		}
+		if relation.References != "" && relation.References != "ID" {
+			tag += "references:" + relation.References + ";"
+		}
+		result = fmt.Sprintf("%s  []%s `json:\"%s\" form:\"%s\" gorm:\"%s\"`",
+			relation.FieldName, model, relation.FieldJson, relation.FieldJson, tag)
+	}
+	if relation.FieldDesc != "" {
+		result += fmt.Sprintf("  //%s", relation.FieldDesc)
+	}
+	return result
+}
+
+// 渲染搜索结构体中按关联表搜索的字段
+func GenerateRelationSearchField(relation systemReq.AutoCodeRelation) string {
+	if relation.SearchColumn == "" {
+		return ""
+	}
+	name := relationSearchName(relation)
+	json := strings.ToLower(name[:1]) + name[1:]
+	return fmt.Sprintf("%s  *string `json:\"%s\" form:\"%s\"`", name, json, json)
+}
+
+// 渲染关联字段的预加载语句
+func GenerateRelationPreload(relations []*systemReq.AutoCodeRelation) string {
+	var builder strings.Builder
+	for _, relation := range relations {
+		if relation.Preload {
+			builder.WriteString(fmt.Sprintf(".Preload(%q)", relation.FieldName))
+		}
+	}
+	return builder.String()
+}
+
+// 渲染按关联表搜索的条件, 通过子查询过滤主表, db 为业务库表达式
+func GenerateRelationSearchConditions(info systemReq.AutoCode, db string) string {
+	var builder strings.Builder
+	primary, primaryField := "id", "ID"
+	if info.PrimaryField != nil && info.PrimaryField.ColumnName != "" {
+		primary, primaryField = info.PrimaryField.ColumnName, info.PrimaryField.FieldName
+	}
+	for _, relation := range info.Relations {
+		if relation.SearchColumn == "" {
+			continue
+		}
+		name := relationSearchName(*relation)
+		like := fmt.Sprintf(`%s.Table("%s").Select("%%s").Where("%s LIKE ?", "%%%%"+*info.%s+"%%%%")`, db, relation.RelatedTable, relation.SearchColumn, name)
+		var condition string
+		switch relation.Type {
+		case systemReq.RelationBelongsTo:
+			condition = fmt.Sprintf(`db = db.Where("%s IN (?)", %s)`, naming.ColumnName("", relation.ForeignKey), fmt.Sprintf(like, naming.ColumnName("", relation.References)))
+		case systemReq.RelationHasMany:
+			condition = fmt.Sprintf(`db = db.Where("%s IN (?)", %s)`, primary, fmt.Sprintf(like, naming.ColumnName("", relation.ForeignKey)))
+		case systemReq.RelationMany2Many:
+			// 与生成的 many2many 标签一致: 当前表以 foreignKey(默认主键) 关联, 关联表以 references 关联
+			// 中间表列名遵循 GORM 默认命名: 结构体名 + 关联字段名
+			owner, ownerField := primary, primaryField
+			if relation.ForeignKey != "" {
+				owner, ownerField = fieldColumn(info, relation.ForeignKey), relation.ForeignKey
+			}
+			join := fmt.Sprintf(`%s.Table("%s").Select("%s").Where("%s IN (?)", %s)`, db, relation.JoinTable,
+				naming.ColumnName("", info.StructName+ownerField), naming.ColumnName("", relation.StructName+relation.References),
+				fmt.Sprintf(like, naming.ColumnName("", relation.References)))
+			condition = fmt.Sprintf(`db = db.Where("%s IN (?)", %s)`, owner, join)
+		}
+		builder.WriteString(fmt.Sprintf("if info.%s != nil && *info.%s != \"\" {\n\t%s\n}\n", name, name, condition))
+	}
+	return builder.String()
+}
+
+// fieldColumn 当前结构体字段对应的列名, 字段未配置列名时按 GORM 默认命名
+func fieldColumn(info systemReq.AutoCode, fieldName string) string {
+	for _, field := range info.Fields {
+		if field.FieldName == fieldName && field.ColumnName != "" {
+			return field.ColumnName
+		}
+	}
+	return naming.ColumnName("", fieldName)
+}
+
+func relationSearchName(relation systemReq.AutoCodeRelation) string {
+	words := strings.Split(relation.SearchColumn, "_")
+	for i := range words {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return relation.FieldName + strings.Join(words, "")
+}
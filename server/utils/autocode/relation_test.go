@@ -0,0 +1,141 @@
+package autocode
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+	"text/template"
+
+	systemReq "server/model/system/request"
+)
+
+func relationAutoCode(t *testing.T) systemReq.AutoCode {
+	info := systemReq.AutoCode{
+		Package:      "blog",
+		StructName:   "Article",
+		Abbreviation: "article",
+		Description:  "文章",
+		GvaModel:     true,
+		Fields: []*systemReq.AutoCodeField{
+			{FieldName: "Title", FieldJson: "title", ColumnName: "title", FieldType: "string"},
+			{FieldName: "AuthorID", FieldJson: "authorID", ColumnName: "author_id", FieldType: "int"},
+		},
+		Relations: []*systemReq.AutoCodeRelation{
+			{Type: systemReq.RelationBelongsTo, FieldName: "Author", Package: "example", StructName: "ExaCustomer", ForeignKey: "AuthorID", RelatedTable: "exa_customers", SearchColumn: "customer_name", Preload: true},
+			{Type: systemReq.RelationHasMany, FieldName: "Comments", StructName: "Comment", ForeignKey: "ArticleID"},
+			{Type: systemReq.RelationMany2Many, FieldName: "Tags", StructName: "Tag", JoinTable: "article_tags", RelatedTable: "tags", SearchColumn: "name", Preload: true},
+		},
+	}
+	if err := info.Pretreatment(); err != nil {
+		t.Fatalf("Pretreatment() error = %v", err)
+	}
+	return info
+}
+
+func TestGenerateRelationField(t *testing.T) {
+	info := relationAutoCode(t)
+	if !info.HasNestedRelation || len(info.RelationPackages) != 1 || info.RelationPackages[0] != "example" {
+		t.Fatalf("HasNestedRelation = %v, RelationPackages = %v", info.HasNestedRelation, info.RelationPackages)
+	}
+	tests := []struct {
+		relation *systemReq.AutoCodeRelation
+		want     string
+	}{
+		{info.Relations[0], "Author  *example.ExaCustomer `json:\"author\" form:\"author\" gorm:\"foreignKey:AuthorID;references:ID\"`"},
+		{info.Relations[1], "Comments  []Comment `json:\"comments\" form:\"comments\" gorm:\"foreignKey:ArticleID;references:ID\"`"},
+		{info.Relations[2], "Tags  []Tag `json:\"tags\" form:\"tags\" gorm:\"many2many:article_tags;\"`"},
+	}
+	for _, tt := range tests {
+		if got := GenerateRelationField(*tt.relation, info.Package); got != tt.want {
+			t.Errorf("GenerateRelationField(%s) = %s, want %s", tt.relation.FieldName, got, tt.want)
+		}
+	}
+	if got := GenerateRelationPreload(info.Relations); got != `.Preload("Author").Preload("Tags")` {
+		t.Errorf("GenerateRelationPreload() = %s", got)
+	}
+}
+
+func TestGenerateRelationSearchConditions(t *testing.T) {
+	info := relationAutoCode(t)
+	got := GenerateRelationSearchConditions(info, "global.GVA_DB")
+	for _, want := range []string{
+		`if info.AuthorCustomerName != nil && *info.AuthorCustomerName != "" {`,
+		`db = db.Where("author_id IN (?)", global.GVA_DB.Table("exa_customers").Select("id").Where("customer_name LIKE ?", "%"+*info.AuthorCustomerName+"%"))`,
+		`db = db.Where("id IN (?)", global.GVA_DB.Table("article_tags").Select("article_id").Where("tag_id IN (?)", global.GVA_DB.Table("tags").Select("id").Where("name LIKE ?", "%"+*info.TagsName+"%")))`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GenerateRelationSearchConditions() missing %s in\n%s", want, got)
+		}
+	}
+}
+
+func TestRelationServiceTemplate(t *testing.T) {
+	info := relationAutoCode(t)
+	info.Module = "server"
+	info.PrimaryField = &systemReq.AutoCodeField{FieldName: "ID", FieldJson: "ID", ColumnName: "id", FieldType: "uint"}
+	files, err := template.New("service.go.tpl").Funcs(GetTemplateFuncMap()).ParseFiles("../../resource/package/server/service/service.go.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = files.Execute(&buf, info); err != nil {
+		t.Fatal(err)
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("format.Source() error = %v\n%s", err, buf.String())
+	}
+	for _, want := range []string{
+		`"gorm.io/gorm/clause"`,
+		`Omit(clause.Associations)`,
+		`tx.Model(&article).Association("Comments").Replace(article.Comments)`,
+		`tx.Model(&article).Association("Tags").Replace(article.Tags)`,
+		`db.Preload("Author").Preload("Tags").Find(&articles)`,
+	} {
+		if !bytes.Contains(code, []byte(want)) {
+			t.Errorf("service template missing %s", want)
+		}
+	}
+	if bytes.Contains(code, []byte(`Association("Author")`)) {
+		t.Errorf("belongsTo relation should not be replaced on update")
+	}
+}
+
+func TestRelationPluginModelTemplate(t *testing.T) {
+	info := relationAutoCode(t)
+	info.Module = "server"
+	files, err := template.New("model.go.tpl").Funcs(GetTemplateFuncMap()).ParseFiles("../../resource/plugin/server/model/model.go.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = files.Execute(&buf, info); err != nil {
+		t.Fatal(err)
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("format.Source() error = %v\n%s", err, buf.String())
+	}
+	// 插件模型引用其他包的模型时需限定包名并导入
+	for _, want := range []string{`"server/model/example"`, "*example.ExaCustomer", "[]Tag "} {
+		if !bytes.Contains(code, []byte(want)) {
+			t.Errorf("plugin model template missing %s in\n%s", want, code)
+		}
+	}
+}
+
+func TestGenerateRelationMany2ManyKeys(t *testing.T) {
+	info := relationAutoCode(t)
+	info.Fields = append(info.Fields, &systemReq.AutoCodeField{FieldName: "Code", ColumnName: "article_code", FieldType: "string"})
+	tags := info.Relations[2]
+	tags.ForeignKey, tags.References = "Code", "Name"
+	if got, want := GenerateRelationField(*tags, info.Package), "Tags  []Tag `json:\"tags\" form:\"tags\" gorm:\"many2many:article_tags;foreignKey:Code;references:Name;\"`"; got != want {
+		t.Errorf("GenerateRelationField() = %s, want %s", got, want)
+	}
+	// 搜索条件的列与标签中的引用字段一致
+	want := `db = db.Where("article_code IN (?)", global.GVA_DB.Table("article_tags").Select("article_code").Where("tag_name IN (?)", global.GVA_DB.Table("tags").Select("name").Where("name LIKE ?", "%"+*info.TagsName+"%")))`
+	if got := GenerateRelationSearchConditions(info, "global.GVA_DB"); !strings.Contains(got, want) {
+		t.Errorf("GenerateRelationSearchConditions() missing %s in\n%s", want, got)
+	}
+}
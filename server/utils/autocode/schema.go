@@ -0,0 +1,479 @@
+package autocode
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	systemReq "server/model/system/request"
+	"server/utils"
+
+	"github.com/pkg/errors"
+)
+
+// Column 数据库列的通用描述, 由数据库元数据或建表语句解析得到
+type Column struct {
+	Name          string // 列名
+	DataType      string // 小写的类型名, 如 varchar、bigint、timestamp with time zone
+	Length        string // 类型参数, 如 varchar(64) 的 64, decimal(10,2) 的 10,2, enum 的取值列表
+	Comment       string // 列注释
+	PrimaryKey    bool   // 是否主键
+	Nullable      bool   // 是否可为空
+	AutoIncrement bool   // 是否自增
+	HasDefault    bool   // 是否有默认值
+}
+
+var (
+	gvaModelColumns = []string{"id", "created_at", "updated_at", "deleted_at"}
+	resourceColumns = []string{"created_by", "updated_by", "deleted_by"}
+	dictHint        = regexp.MustCompile(`(?i)[(（\[【]?\s*(?:dict|字典)\s*[:：=]\s*([A-Za-z0-9_\-]+)\s*[)）\]】]?`)
+)
+
+// Spec 由表名、表注释和列生成完整的 AutoCode, 可直接用于预览或创建
+// 同时存在 id、created_at、updated_at、deleted_at 时使用 GVA_MODEL, 同时存在 created_by、updated_by、deleted_by 时开启资源标识
+func Spec(table string, comment string, columns []Column) systemReq.AutoCode {
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, strings.ToLower(column.Name))
+	}
+	hasAll := func(want []string) bool {
+		for _, name := range want {
+			if !slices.Contains(names, name) {
+				return false
+			}
+		}
+		return true
+	}
+	hump := camel(table)
+	info := systemReq.AutoCode{
+		TableName:           table,
+		StructName:          hump,
+		PackageName:         lowerFirst(hump),
+		Abbreviation:        lowerFirst(hump),
+		HumpPackageName:     utils.HumpToUnderscore(lowerFirst(hump)),
+		Description:         strings.TrimSpace(comment),
+		GvaModel:            hasAll(gvaModelColumns),
+		AutoCreateResource:  hasAll(resourceColumns),
+		AutoCreateApiToSql:  true,
+		AutoCreateMenuToSql: true,
+		GenerateWeb:         true,
+		GenerateServer:      true,
+	}
+	if info.Description == "" {
+		info.Description = hump + "表"
+	}
+	for _, column := range columns {
+		name := strings.ToLower(column.Name)
+		if info.GvaModel && slices.Contains(gvaModelColumns, name) || info.AutoCreateResource && slices.Contains(resourceColumns, name) {
+			continue
+		}
+		info.Fields = append(info.Fields, specField(column))
+	}
+	return info
+}
+
+func specField(column Column) *systemReq.AutoCodeField {
+	hump := camel(column.Name)
+	field := &systemReq.AutoCodeField{
+		FieldName:  hump,
+		FieldJson:  lowerFirst(hump),
+		FieldType:  FieldType(column.DataType, column.Length),
+		ColumnName: column.Name,
+		Comment:    column.Comment,
+		PrimaryKey: column.PrimaryKey,
+		Form:       !column.AutoIncrement,
+		Table:      true,
+		Desc:       true,
+		Clearable:  true,
+	}
+	desc := column.Comment
+	if match := dictHint.FindStringSubmatch(desc); match != nil {
+		field.DictType = match[1]
+		desc = dictHint.ReplaceAllString(desc, "")
+	} // 注释中的 dict:xxx 或 字典:xxx 作为字典类型
+	field.FieldDesc = strings.Trim(strings.TrimSpace(desc), ",，;；:：")
+	if field.FieldDesc == "" {
+		field.FieldDesc = field.FieldJson + "字段"
+	}
+	switch field.FieldType {
+	case "enum":
+		field.DataTypeLong = column.Length
+	case "string", "int":
+		if length, _, _ := strings.Cut(column.Length, ","); length != "" {
+			field.DataTypeLong = length
+		}
+	}
+	if !column.PrimaryKey {
+		switch field.FieldType {
+		case "string":
+			field.FieldSearchType = "LIKE"
+			if field.DictType != "" {
+				field.FieldSearchType = "="
+			}
+		case "int", "float64", "bool", "enum":
+			field.FieldSearchType = "="
+		case "time.Time":
+			field.FieldSearchType = "BETWEEN"
+		}
+	}
+	if !column.Nullable && !column.HasDefault && !column.PrimaryKey && !column.AutoIncrement {
+		field.Require = true
+		field.ErrorText = "请填写" + field.FieldDesc
+	}
+	return field
+}
+
+// FieldType 将数据库类型映射为代码生成器的字段类型
+func FieldType(dataType string, length string) string {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	switch {
+	case dataType == "tinyint" && length == "1", dataType == "bool", dataType == "boolean", dataType == "bit":
+		return "bool"
+	case dataType == "enum":
+		return "enum"
+	case strings.Contains(dataType, "int"), dataType == "serial", dataType == "bigserial", dataType == "smallserial":
+		return "int"
+	case slices.Contains([]string{"float", "double", "double precision", "real", "decimal", "numeric", "money", "number"}, dataType):
+		return "float64"
+	case strings.HasPrefix(dataType, "date"), strings.HasPrefix(dataType, "timestamp"), strings.HasPrefix(dataType, "time"):
+		return "time.Time"
+	case dataType == "json", dataType == "jsonb":
+		return "json"
+	case dataType == "longtext", dataType == "mediumtext":
+		return "richtext"
+	}
+	return "string"
+}
+
+// camel 下划线命名转为大驼峰
+func camel(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' || r == '.' })
+	var builder strings.Builder
+	for _, word := range words {
+		builder.WriteString(upperFirst(word))
+	}
+	if builder.Len() == 0 {
+		return name
+	}
+	return builder.String()
+}
+
+// upperFirst 首字母大写, 按 rune 处理以支持非 ASCII 名称
+func upperFirst(s string) string {
+	if s == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+// ParseDDL 解析 CREATE TABLE 语句, 支持 mysql、pgsql、sqlite、mssql 的常见写法
+// pgsql 的 COMMENT ON TABLE / COMMENT ON COLUMN 语句会合并到表和列的注释中
+func ParseDDL(ddl string) (table string, comment string, columns []Column, err error) {
+	tokens, err := tokenize(ddl)
+	if err != nil {
+		return "", "", nil, err
+	}
+	start := -1
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].is("CREATE") {
+			j := i + 1
+			for j < len(tokens) && (tokens[j].is("TEMPORARY") || tokens[j].is("TEMP") || tokens[j].is("UNLOGGED")) {
+				j++
+			}
+			if j < len(tokens) && tokens[j].is("TABLE") {
+				start = j + 1
+				break
+			}
+		}
+	}
+	if start == -1 {
+		return "", "", nil, errors.New("未找到 CREATE TABLE 语句!")
+	}
+	i := start
+	if i+2 < len(tokens) && tokens[i].is("IF") && tokens[i+1].is("NOT") && tokens[i+2].is("EXISTS") {
+		i += 3
+	}
+	for ; i < len(tokens) && tokens[i].kind != tokenGroup; i++ {
+		if tokens[i].kind == tokenWord || tokens[i].kind == tokenIdent {
+			table = tokens[i].text // schema.table 取最后一段
+		}
+	}
+	if table == "" || i >= len(tokens) {
+		return "", "", nil, errors.New("CREATE TABLE 语句缺少表名或列定义!")
+	}
+	var primary []string
+	definitions, err := tokenize(tokens[i].text)
+	if err != nil {
+		return "", "", nil, err
+	}
+	for _, definition := range splitDefinitions(definitions) {
+		if len(definition) == 0 {
+			continue
+		}
+		if primaryKey, ok := parseConstraint(definition); ok {
+			primary = append(primary, primaryKey...)
+			continue
+		}
+		column := parseColumn(definition)
+		if strings.TrimSpace(column.Name) == "" {
+			return "", "", nil, errors.Errorf("表[%s]第%d列缺少列名!", table, len(columns)+1)
+		}
+		columns = append(columns, column)
+	}
+	for j := i + 1; j < len(tokens) && !tokens[j].is(";"); j++ {
+		if tokens[j].is("COMMENT") {
+			k := j + 1
+			if k < len(tokens) && tokens[k].is("=") {
+				k++
+			}
+			if k < len(tokens) && tokens[k].kind == tokenString {
+				comment = tokens[k].text
+			}
+		}
+	} // mysql 表选项 COMMENT='...'
+	for j := 0; j+4 < len(tokens); j++ {
+		if !tokens[j].is("COMMENT") || !tokens[j+1].is("ON") {
+			continue
+		}
+		target := tokens[j+2]
+		k := j + 3
+		var path []string
+		for ; k < len(tokens) && !tokens[k].is("IS"); k++ {
+			if tokens[k].kind == tokenWord || tokens[k].kind == tokenIdent {
+				path = append(path, tokens[k].text)
+			}
+		}
+		if k+1 >= len(tokens) || tokens[k+1].kind != tokenString || len(path) == 0 {
+			continue
+		}
+		switch {
+		case target.is("TABLE") && strings.EqualFold(path[len(path)-1], table):
+			comment = tokens[k+1].text
+		case target.is("COLUMN") && len(path) >= 2 && strings.EqualFold(path[len(path)-2], table):
+			for c := range columns {
+				if strings.EqualFold(columns[c].Name, path[len(path)-1]) {
+					columns[c].Comment = tokens[k+1].text
+				}
+			}
+		}
+	} // pgsql COMMENT ON
+	for c := range columns {
+		if slices.ContainsFunc(primary, func(name string) bool { return strings.EqualFold(name, columns[c].Name) }) {
+			columns[c].PrimaryKey = true
+			columns[c].Nullable = false
+		}
+	}
+	if len(columns) == 0 {
+		return "", "", nil, errors.Errorf("表[%s]没有解析到任何列!", table)
+	}
+	return table, comment, columns, nil
+}
+
+// parseConstraint 解析表级约束, 返回主键列
+func parseConstraint(definition []token) ([]string, bool) {
+	i := 0
+	if definition[0].is("CONSTRAINT") {
+		i = 2
+	}
+	if i >= len(definition) || definition[i].kind != tokenWord {
+		return nil, i > 0
+	}
+	switch strings.ToUpper(definition[i].text) {
+	case "PRIMARY":
+		for _, t := range definition[i:] {
+			if t.kind == tokenGroup {
+				var columns []string
+				group, _ := tokenize(t.text) // 外层已校验括号
+				for _, column := range group {
+					if column.kind == tokenWord || column.kind == tokenIdent {
+						if column.is("ASC") || column.is("DESC") {
+							continue
+						}
+						columns = append(columns, column.text)
+					}
+				}
+				return columns, true
+			}
+		}
+		return nil, true
+	case "KEY", "INDEX", "UNIQUE", "FOREIGN", "CHECK", "FULLTEXT", "SPATIAL", "EXCLUDE":
+		return nil, true
+	}
+	return nil, i > 0
+}
+
+func parseColumn(definition []token) Column {
+	column := Column{Name: definition[0].text, Nullable: true}
+	i := 1
+	var types []string
+	for ; i < len(definition) && definition[i].kind == tokenWord; i++ {
+		if len(types) > 0 && !slices.Contains([]string{"varying", "precision", "with", "without", "time", "zone", "unsigned", "zerofill"}, strings.ToLower(definition[i].text)) {
+			break
+		}
+		types = append(types, strings.ToLower(definition[i].text))
+		if i+1 < len(definition) && definition[i+1].kind == tokenGroup {
+			i++
+			column.Length = strings.ReplaceAll(strings.TrimSpace(definition[i].text), " ", "")
+		}
+	}
+	types = slices.DeleteFunc(types, func(s string) bool { return s == "unsigned" || s == "zerofill" })
+	column.DataType = strings.Join(types, " ")
+	switch column.DataType {
+	case "character varying":
+		column.DataType = "varchar"
+	case "character":
+		column.DataType = "char"
+	case "serial", "bigserial", "smallserial":
+		column.AutoIncrement, column.Nullable = true, false
+	}
+	for ; i < len(definition); i++ {
+		switch {
+		case definition[i].is("NOT") && i+1 < len(definition) && definition[i+1].is("NULL"):
+			column.Nullable = false
+			i++
+		case definition[i].is("PRIMARY"):
+			column.PrimaryKey, column.Nullable = true, false
+		case definition[i].is("AUTO_INCREMENT"), definition[i].is("AUTOINCREMENT"), definition[i].is("IDENTITY"):
+			column.AutoIncrement = true
+		case definition[i].is("DEFAULT"):
+			column.HasDefault = true
+		case definition[i].is("COMMENT") && i+1 < len(definition) && definition[i+1].kind == tokenString:
+			column.Comment = definition[i+1].text
+			i++
+		}
+	}
+	return column
+}
+
+// splitDefinitions 按顶层逗号拆分列定义
+func splitDefinitions(tokens []token) [][]token {
+	var definitions [][]token
+	var current []token
+	for _, t := range tokens {
+		if t.is(",") {
+			definitions = append(definitions, current)
+			current = nil
+			continue
+		}
+		current = append(current, t)
+	}
+	return append(definitions, current)
+}
+
+const (
+	tokenWord   = iota // 关键字、未加引号的标识符、数字
+	tokenIdent         // 加引号的标识符
+	tokenString        // 字符串字面量, text 为去掉引号后的内容
+	tokenGroup         // 括号, text 为括号内的原始内容
+	tokenSymbol        // 其他符号
+)
+
+type token struct {
+	kind int
+	text string
+}
+
+func (t token) is(word string) bool {
+	return (t.kind == tokenWord || t.kind == tokenSymbol) && strings.EqualFold(t.text, word)
+}
+
+// tokenize 将 SQL 切分为词法单元, 忽略注释, 括号整体作为一个单元
+func tokenize(sql string) ([]token, error) {
+	var tokens []token
+	runes := []rune(sql)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '\'':
+			text, next := quoted(runes, i, '\'')
+			tokens = append(tokens, token{kind: tokenString, text: text})
+			i = next
+		case r == '`' || r == '"':
+			text, next := quoted(runes, i, r)
+			tokens = append(tokens, token{kind: tokenIdent, text: text})
+			i = next
+		case r == '[':
+			text, next := quoted(runes, i, ']')
+			tokens = append(tokens, token{kind: tokenIdent, text: text})
+			i = next
+		case r == '(':
+			depth, j := 0, i
+			for ; j < len(runes); j++ {
+				if runes[j] == '\'' || runes[j] == '"' || runes[j] == '`' {
+					_, next := quoted(runes, j, runes[j])
+					j = next - 1
+					continue
+				}
+				if runes[j] == '(' {
+					depth++
+				} else if runes[j] == ')' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if j >= len(runes) {
+				return nil, errors.New("建表语句括号不匹配(unbalanced parenthesis)!")
+			}
+			tokens = append(tokens, token{kind: tokenGroup, text: string(runes[i+1 : j])})
+			i = j + 1
+		case r == '_' || r == '$' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 127:
+			j := i
+			for j < len(runes) && (runes[j] == '_' || runes[j] == '$' || runes[j] >= '0' && runes[j] <= '9' || runes[j] >= 'a' && runes[j] <= 'z' || runes[j] >= 'A' && runes[j] <= 'Z' || runes[j] > 127) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[i:j])})
+			i = j
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// quoted 读取以 quote 结尾的引号内容, 连续两个结束符视为转义
+func quoted(runes []rune, start int, end rune) (string, int) {
+	var builder strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '\\' && end == '\'' && i+1 < len(runes) {
+			builder.WriteRune(runes[i+1])
+			i++
+			continue
+		}
+		if runes[i] == end {
+			if i+1 < len(runes) && runes[i+1] == end {
+				builder.WriteRune(end)
+				i++
+				continue
+			}
+			return builder.String(), i + 1
+		}
+		builder.WriteRune(runes[i])
+	}
+	return builder.String(), len(runes)
+}
@@ -0,0 +1,117 @@
+package autocode
+
+import (
+	"testing"
+)
+
+func TestParseDDL(t *testing.T) {
+	mysql := "CREATE TABLE IF NOT EXISTS `shop`.`orders` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `created_at` datetime(3) DEFAULT NULL,\n" +
+		"  `updated_at` datetime(3) DEFAULT NULL,\n" +
+		"  `deleted_at` datetime(3) DEFAULT NULL,\n" +
+		"  `order_no` varchar(64) NOT NULL COMMENT '订单号',\n" +
+		"  `status` varchar(20) NOT NULL DEFAULT 'new' COMMENT '状态 (dict:order_status)',\n" +
+		"  `amount` decimal(10,2) NOT NULL COMMENT '金额',\n" +
+		"  `paid` tinyint(1) DEFAULT NULL COMMENT '是否支付',\n" +
+		"  `channel` enum('web','app') DEFAULT NULL COMMENT '渠道, it''s',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_orders_deleted_at` (`deleted_at`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='订单';"
+	table, comment, columns, err := ParseDDL(mysql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table != "orders" || comment != "订单" || len(columns) != 9 {
+		t.Fatalf("ParseDDL() = %s, %s, %d columns", table, comment, len(columns))
+	}
+	if !columns[0].PrimaryKey || !columns[0].AutoIncrement || columns[0].DataType != "bigint" {
+		t.Errorf("id = %+v", columns[0])
+	}
+	if columns[6].DataType != "decimal" || columns[6].Length != "10,2" || columns[8].Length != "'web','app'" || columns[8].Comment != "渠道, it's" {
+		t.Errorf("amount = %+v, channel = %+v", columns[6], columns[8])
+	}
+
+	spec := Spec(table, comment, columns)
+	if !spec.GvaModel || spec.StructName != "Orders" || spec.Description != "订单" || len(spec.Fields) != 5 {
+		t.Fatalf("Spec() = %+v", spec)
+	}
+	tests := []struct {
+		name, fieldType, search, dict string
+		require                       bool
+	}{
+		{"OrderNo", "string", "LIKE", "", true},
+		{"Status", "string", "=", "order_status", false},
+		{"Amount", "float64", "=", "", true},
+		{"Paid", "bool", "=", "", false},
+		{"Channel", "enum", "=", "", false},
+	}
+	for i, tt := range tests {
+		field := spec.Fields[i]
+		if field.FieldName != tt.name || field.FieldType != tt.fieldType || field.FieldSearchType != tt.search || field.DictType != tt.dict || field.Require != tt.require {
+			t.Errorf("field %d = %+v, want %+v", i, field, tt)
+		}
+	}
+	if spec.Fields[1].FieldDesc != "状态" {
+		t.Errorf("status FieldDesc = %q", spec.Fields[1].FieldDesc)
+	}
+}
+
+func TestParseDDLPostgres(t *testing.T) {
+	pgsql := `CREATE TABLE public.article_tags (
+		article_id bigint NOT NULL,
+		tag_id bigint NOT NULL,
+		published_at timestamp with time zone,
+		title character varying(128) NOT NULL,
+		CONSTRAINT article_tags_pkey PRIMARY KEY (article_id, tag_id)
+	);
+	COMMENT ON TABLE public.article_tags IS '文章标签';
+	COMMENT ON COLUMN public.article_tags.title IS '标题';`
+	table, comment, columns, err := ParseDDL(pgsql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table != "article_tags" || comment != "文章标签" || len(columns) != 4 {
+		t.Fatalf("ParseDDL() = %s, %s, %+v", table, comment, columns)
+	}
+	if !columns[0].PrimaryKey || !columns[1].PrimaryKey || columns[2].DataType != "timestamp with time zone" {
+		t.Errorf("columns = %+v", columns)
+	}
+	if columns[3].DataType != "varchar" || columns[3].Length != "128" || columns[3].Comment != "标题" {
+		t.Errorf("title = %+v", columns[3])
+	}
+	spec := Spec(table, comment, columns)
+	if spec.GvaModel || spec.StructName != "ArticleTags" || spec.HumpPackageName != "article_tags" || spec.Fields[2].FieldSearchType != "BETWEEN" {
+		t.Errorf("Spec() = %+v", spec)
+	}
+	if _, _, _, err = ParseDDL("SELECT 1"); err == nil {
+		t.Error("ParseDDL() 非建表语句应返回错误")
+	}
+}
+
+func TestParseDDLInvalid(t *testing.T) {
+	for _, ddl := range []string{
+		"CREATE TABLE t (",
+		"CREATE TABLE t (a int",
+		"CREATE TABLE t (a varchar(10)",
+		`CREATE TABLE t ("" int)`,
+	} {
+		if _, _, _, err := ParseDDL(ddl); err == nil {
+			t.Errorf("ParseDDL(%q) 应返回错误", ddl)
+		}
+	}
+}
+
+func TestSpecNonASCII(t *testing.T) {
+	table, comment, columns, err := ParseDDL(`CREATE TABLE "élan_订单" ("ñame" varchar(10), "订单号" int)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := Spec(table, comment, columns)
+	if spec.StructName != "Élan订单" || spec.PackageName != "élan订单" {
+		t.Errorf("Spec() = %s, %s", spec.StructName, spec.PackageName)
+	}
+	if spec.Fields[0].FieldName != "Ñame" || spec.Fields[0].FieldJson != "ñame" || spec.Fields[1].FieldJson != "订单号" {
+		t.Errorf("fields = %+v, %+v", spec.Fields[0], spec.Fields[1])
+	}
+}
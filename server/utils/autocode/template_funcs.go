@@ -11,15 +11,23 @@ import (
 // GetTemplateFuncMap 返回模板函数映射，用于在模板中使用
 func GetTemplateFuncMap() template.FuncMap {
 	return template.FuncMap{
-		"title":                    strings.Title,
-		"GenerateField":            GenerateField,
-		"GenerateSearchField":      GenerateSearchField,
-		"GenerateSearchConditions": GenerateSearchConditions,
-		"GenerateSearchFormItem":   GenerateSearchFormItem,
-		"GenerateTableColumn":      GenerateTableColumn,
-		"GenerateFormItem":         GenerateFormItem,
-		"GenerateDescriptionItem":  GenerateDescriptionItem,
-		"GenerateDefaultFormValue": GenerateDefaultFormValue,
+		"title":                            strings.Title,
+		"GenerateField":                    GenerateField,
+		"GenerateSearchField":              GenerateSearchField,
+		"GenerateSearchConditions":         GenerateSearchConditions,
+		"GenerateSearchFormItem":           GenerateSearchFormItem,
+		"GenerateTableColumn":              GenerateTableColumn,
+		"GenerateFormItem":                 GenerateFormItem,
+		"GenerateDescriptionItem":          GenerateDescriptionItem,
+		"GenerateDefaultFormValue":         GenerateDefaultFormValue,
+		"GenerateRelationField":            GenerateRelationField,
+		"GenerateRelationSearchField":      GenerateRelationSearchField,
+		"GenerateRelationPreload":          GenerateRelationPreload,
+		"GenerateRelationSearchConditions": GenerateRelationSearchConditions,
+		"GenerateTestValue":                GenerateTestValue,
+		"GenerateTestSearch":               GenerateTestSearch,
+		"GenerateTestAssert":               GenerateTestAssert,
+		"GenerateTestPrimaryKey":           GenerateTestPrimaryKey,
 	}
 }
 
@@ -0,0 +1,104 @@
+package autocode
+
+import (
+	"fmt"
+	"strings"
+
+	systemReq "server/model/system/request"
+)
+
+// 生成测试用例中字段的示例值, updated 为 true 时返回更新用的值, ptr 为生成的取地址辅助函数名
+func GenerateTestValue(field systemReq.AutoCodeField, updated bool, ptr string) string {
+	switch field.FieldType {
+	case "string":
+		return fmt.Sprintf("%s(%q)", ptr, testValue(updated, "test", "updated"))
+	case "int":
+		return fmt.Sprintf("%s(%s)", ptr, testValue(updated, "1", "2"))
+	case "float64":
+		return fmt.Sprintf("%s(%s)", ptr, testValue(updated, "1.5", "2.5"))
+	case "bool":
+		return fmt.Sprintf("%s(%s)", ptr, testValue(updated, "true", "false"))
+	case "time.Time":
+		return fmt.Sprintf("%s(time.Date(2024, %s, 1, 0, 0, 0, 0, time.UTC))", ptr, testValue(updated, "1", "2"))
+	case "richtext":
+		return fmt.Sprintf("%s(%q)", ptr, testValue(updated, "<p>test</p>", "<p>updated</p>"))
+	case "picture", "video":
+		return fmt.Sprintf("%q", testValue(updated, "test.png", "updated.png"))
+	case "enum":
+		values := enumValues(field.DataTypeLong)
+		if len(values) == 0 {
+			return `""`
+		}
+		if updated && len(values) > 1 {
+			return fmt.Sprintf("%q", values[1])
+		}
+		return fmt.Sprintf("%q", values[0])
+	case "json":
+		return fmt.Sprintf("datatypes.JSON(%q)", testValue(updated, `{"key":"test"}`, `{"key":"updated"}`))
+	case "file", "pictures", "array":
+		return fmt.Sprintf("datatypes.JSON(%q)", testValue(updated, `["test"]`, `["updated"]`))
+	}
+	return ""
+}
+
+// 生成按字段搜索的测试条件, 条件与更新后的值匹配, 不支持的搜索方式返回空字符串
+func GenerateTestSearch(field systemReq.AutoCodeField, info string, ptr string) string {
+	switch field.FieldSearchType {
+	case "=", "LIKE", ">=", "<=":
+		switch field.FieldType {
+		case "string", "int", "float64", "bool", "time.Time", "enum":
+			return fmt.Sprintf("%s.%s = %s", info, field.FieldName, GenerateTestValue(field, true, ptr))
+		}
+	case "BETWEEN":
+		switch field.FieldType {
+		case "time.Time":
+			return fmt.Sprintf("%s.%sRange = []time.Time{time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)}", info, field.FieldName)
+		case "int", "float64":
+			return fmt.Sprintf("%s.Start%s, %s.End%s = %s(%s(0)), %s(%s(10))", info, field.FieldName, info, field.FieldName, ptr, field.FieldType, ptr, field.FieldType)
+		}
+	}
+	return ""
+}
+
+// 生成字段值与期望值一致的断言, 仅支持可直接比较的指针类型字段
+func GenerateTestAssert(field systemReq.AutoCodeField, got string, want string) string {
+	switch field.FieldType {
+	case "string", "int", "float64", "bool":
+		return fmt.Sprintf(`if %s.%s == nil || *%s.%s != *%s.%s {
+		t.Errorf("%s = %%v, want %%v", %s.%s, *%s.%s)
+	}`, got, field.FieldName, got, field.FieldName, want, field.FieldName,
+			field.FieldName, got, field.FieldName, want, field.FieldName)
+	}
+	return ""
+}
+
+// 生成主键转为字符串的表达式, 与生成的 service 中按字符串主键查询保持一致
+func GenerateTestPrimaryKey(info systemReq.AutoCode, value string) string {
+	if info.GvaModel || info.PrimaryField == nil {
+		return fmt.Sprintf("fmt.Sprint(%s.ID)", value)
+	}
+	switch info.PrimaryField.FieldType {
+	case "string", "int", "float64", "bool", "time.Time", "richtext":
+		return fmt.Sprintf("fmt.Sprint(*%s.%s)", value, info.PrimaryField.FieldName)
+	}
+	return fmt.Sprintf("fmt.Sprint(%s.%s)", value, info.PrimaryField.FieldName)
+}
+
+func testValue(updated bool, created string, update string) string {
+	if updated {
+		return update
+	}
+	return created
+}
+
+// enumValues 解析 'a','b' 形式的枚举值
+func enumValues(values string) []string {
+	var result []string
+	for _, value := range strings.Split(values, ",") {
+		value = strings.Trim(strings.TrimSpace(value), `'"`)
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,183 @@
+package autocode
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	gotoken "go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	systemReq "server/model/system/request"
+)
+
+func testcaseAutoCode(t *testing.T) systemReq.AutoCode {
+	info := systemReq.AutoCode{
+		Package:      "shop",
+		StructName:   "Product",
+		Abbreviation: "product",
+		Description:  "商品",
+		GvaModel:     true,
+		Fields: []*systemReq.AutoCodeField{
+			{FieldName: "Name", FieldJson: "name", ColumnName: "name", FieldType: "string", FieldSearchType: "LIKE"},
+			{FieldName: "Stock", FieldJson: "stock", ColumnName: "stock", FieldType: "int", FieldSearchType: "BETWEEN"},
+			{FieldName: "OnSale", FieldJson: "onSale", ColumnName: "on_sale", FieldType: "bool", FieldSearchType: "="},
+			{FieldName: "ReleasedAt", FieldJson: "releasedAt", ColumnName: "released_at", FieldType: "time.Time", FieldSearchType: "BETWEEN"},
+			{FieldName: "Level", FieldJson: "level", ColumnName: "level", FieldType: "enum", DataTypeLong: "'low','high'", FieldSearchType: "="},
+			{FieldName: "Images", FieldJson: "images", ColumnName: "images", FieldType: "pictures"},
+		},
+	}
+	if err := info.Pretreatment(); err != nil {
+		t.Fatalf("Pretreatment() error = %v", err)
+	}
+	info.Module = "server" // Pretreatment 从配置读取模块名
+	return info
+}
+
+func TestGenerateTestValue(t *testing.T) {
+	info := testcaseAutoCode(t)
+	tests := []struct {
+		field   *systemReq.AutoCodeField
+		updated bool
+		want    string
+	}{
+		{info.Fields[0], false, `ptr("test")`},
+		{info.Fields[1], true, `ptr(2)`},
+		{info.Fields[2], true, `ptr(false)`},
+		{info.Fields[3], false, `ptr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))`},
+		{info.Fields[4], true, `"high"`},
+		{info.Fields[5], false, `datatypes.JSON("[\"test\"]")`},
+	}
+	for _, tt := range tests {
+		if got := GenerateTestValue(*tt.field, tt.updated, "ptr"); got != tt.want {
+			t.Errorf("GenerateTestValue(%s, %v) = %s, want %s", tt.field.FieldName, tt.updated, got, tt.want)
+		}
+	}
+	if got := GenerateTestSearch(*info.Fields[1], "info", "ptr"); got != "info.StartStock, info.EndStock = ptr(int(0)), ptr(int(10))" {
+		t.Errorf("GenerateTestSearch(Stock) = %s", got)
+	}
+	if got := GenerateTestSearch(*info.Fields[5], "info", "ptr"); got != "" {
+		t.Errorf("GenerateTestSearch(Images) = %s, want empty", got)
+	}
+}
+
+func TestTestcaseTemplates(t *testing.T) {
+	info := testcaseAutoCode(t)
+	for _, path := range []string{
+		"../../resource/package/server/service/service_test.go.tpl",
+		"../../resource/package/server/api/api_test.go.tpl",
+	} {
+		src := renderTestcase(t, path, info)
+		code, err := format.Source(src)
+		if err != nil {
+			t.Fatalf("format.Source(%s) error = %v\n%s", path, err, src)
+		}
+		for _, want := range []string{
+			`func productTestDB(t *testing.T)`,
+			`product.Stock = productTestPtr(2)`,
+			`fmt.Sprint(created.ID)`,
+		} {
+			if !bytes.Contains(code, []byte(want)) {
+				t.Errorf("%s missing %s", path, want)
+			}
+		}
+	}
+}
+
+// testcasePackages 生成代码所在的包及其模板, 类型检查时代替仓库中不存在的包
+var testcasePackages = map[string][]string{
+	"server/model/shop":         {"model/model.go.tpl"},
+	"server/model/shop/request": {"model/request/request.go.tpl"},
+	"server/service/shop":       {"service/service.go.tpl", "service/service_test.go.tpl"},
+	"server/api/v1/shop":        {"api/api.go.tpl", "api/api_test.go.tpl"},
+}
+
+// testcaseApiEnter 代替注入到 api/v1/shop/enter.go 的服务变量
+const testcaseApiEnter = `package shop
+
+import service "server/service/shop"
+
+var productService service.ProductService
+`
+
+// testcaseImporter 从模板渲染生成的包, 其余包从源码导入
+type testcaseImporter struct {
+	t        *testing.T
+	fset     *gotoken.FileSet
+	info     systemReq.AutoCode
+	source   types.Importer
+	packages map[string]*types.Package
+}
+
+func (i *testcaseImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := i.packages[path]; ok {
+		return pkg, nil
+	}
+	if _, ok := testcasePackages[path]; !ok {
+		return i.source.Import(path)
+	}
+	return i.check(path), nil
+}
+
+// check 渲染并类型检查生成的包
+func (i *testcaseImporter) check(path string) *types.Package {
+	var files []*ast.File
+	for _, name := range testcasePackages[path] {
+		tpl := filepath.Join("../../resource/package/server", name)
+		files = append(files, i.parse(tpl, renderTestcase(i.t, tpl, i.info)))
+	}
+	if path == "server/api/v1/shop" {
+		files = append(files, i.parse("enter.go", []byte(testcaseApiEnter)))
+	}
+	conf := types.Config{Importer: i}
+	pkg, err := conf.Check(path, i.fset, files, nil)
+	if err != nil {
+		i.t.Fatalf("types.Check(%s) error = %v", path, err)
+	}
+	i.packages[path] = pkg
+	return pkg
+}
+
+func (i *testcaseImporter) parse(name string, src []byte) *ast.File {
+	file, err := parser.ParseFile(i.fset, name, src, parser.AllErrors)
+	if err != nil {
+		i.t.Fatalf("parser.ParseFile(%s) error = %v\n%s", name, err, src)
+	}
+	return file
+}
+
+func renderTestcase(t *testing.T, path string, info systemReq.AutoCode) []byte {
+	files, err := template.New(filepath.Base(path)).Funcs(GetTemplateFuncMap()).ParseFiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = files.Execute(&buf, info); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// TestTestcaseTemplatesTypeCheck 对生成的服务与接口测试做类型检查, 模板改动导致生成代码无法编译时失败
+func TestTestcaseTemplatesTypeCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("从源码导入依赖较慢")
+	}
+	fset := gotoken.NewFileSet()
+	imp := &testcaseImporter{
+		t:        t,
+		fset:     fset,
+		info:     testcaseAutoCode(t),
+		source:   importer.ForCompiler(fset, "source", nil),
+		packages: make(map[string]*types.Package),
+	}
+	for _, path := range []string{"server/service/shop", "server/api/v1/shop"} {
+		if _, err := imp.Import(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
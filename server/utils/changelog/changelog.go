@@ -0,0 +1,366 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+
+	beforeKey = "gva:change_history:before"
+	maskValue = "******"
+)
+
+// DefaultTables 未配置 tables 时记录变更的系统表
+var DefaultTables = []string{
+	"sys_users",
+	"sys_authorities",
+	"sys_base_menus",
+	"sys_apis",
+	"sys_dictionaries",
+	"sys_dictionary_details",
+	"sys_area",
+}
+
+// Plugin 数据变更历史插件, 在登记表的新增/修改/删除前后读取整行快照, 比对后写入 sys_change_histories
+// 变更历史与业务语句使用同一连接, 处于事务中时随事务一并提交或回滚
+type Plugin struct {
+	tables map[string]bool
+	mask   map[string]bool
+	ignore map[string]bool
+}
+
+//@function: New
+//@description: 按配置创建数据变更历史插件
+//@param: conf config.ChangeHistory
+//@return: *Plugin
+
+func New(conf config.ChangeHistory) *Plugin {
+	tables := conf.Tables
+	if len(tables) == 0 {
+		tables = DefaultTables
+	}
+	return &Plugin{
+		tables: toSet(tables),
+		mask:   toSet(conf.MaskFields),
+		ignore: toSet(conf.IgnoreFields),
+	}
+}
+
+func (p *Plugin) Name() string {
+	return "gva:change_history"
+}
+
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:create").Register("gva:change_history:after_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("gva:change_history:before_update", p.before); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("gva:change_history:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("gva:change_history:before_delete", p.before); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:delete").Register("gva:change_history:after_delete", p.afterDelete)
+}
+
+func (p *Plugin) tracked(db *gorm.DB) bool {
+	return db.Error == nil && !db.Statement.DryRun && p.tables[db.Statement.Table]
+}
+
+// before 修改/删除前按语句条件读取将受影响的行
+func (p *Plugin) before(db *gorm.DB) {
+	if !p.tracked(db) {
+		return
+	}
+	var exprs []clause.Expression
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			exprs = append(exprs, where.Exprs...)
+		}
+	}
+	if ids := primaryValues(db); len(ids) > 0 {
+		exprs = append(exprs, clause.IN{Column: clause.Column{Name: primaryKey(db)}, Values: ids})
+	}
+	if len(exprs) == 0 {
+		return
+	}
+	if !db.Statement.Unscoped && db.Statement.Schema != nil {
+		if field := db.Statement.Schema.LookUpField("deleted_at"); field != nil {
+			exprs = append(exprs, clause.Eq{Column: clause.Column{Name: field.DBName}, Value: nil})
+		}
+	}
+	rows, err := p.find(db, exprs...)
+	if err != nil {
+		global.GVA_LOG.Error("change history snapshot failed", zap.String("table", db.Statement.Table), zap.Error(err))
+		return
+	}
+	db.InstanceSet(beforeKey, rows)
+}
+
+func (p *Plugin) afterCreate(db *gorm.DB) {
+	if !p.tracked(db) {
+		return
+	}
+	ids := primaryValues(db)
+	if len(ids) == 0 {
+		return
+	}
+	pk := primaryKey(db)
+	rows, err := p.find(db, clause.IN{Column: clause.Column{Name: pk}, Values: ids})
+	if err != nil {
+		global.GVA_LOG.Error("change history snapshot failed", zap.String("table", db.Statement.Table), zap.Error(err))
+		return
+	}
+	for _, row := range rows {
+		p.record(db, ActionCreate, pk, nil, row)
+	}
+}
+
+func (p *Plugin) afterUpdate(db *gorm.DB) {
+	if !p.tracked(db) {
+		return
+	}
+	before := beforeRows(db)
+	if len(before) == 0 {
+		return
+	}
+	pk := primaryKey(db)
+	ids := make([]interface{}, 0, len(before))
+	for _, row := range before {
+		ids = append(ids, row[pk])
+	}
+	rows, err := p.find(db, clause.IN{Column: clause.Column{Name: pk}, Values: ids})
+	if err != nil {
+		global.GVA_LOG.Error("change history snapshot failed", zap.String("table", db.Statement.Table), zap.Error(err))
+		return
+	}
+	after := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		after[fmt.Sprint(row[pk])] = row
+	}
+	for _, row := range before {
+		p.record(db, ActionUpdate, pk, row, after[fmt.Sprint(row[pk])])
+	}
+}
+
+func (p *Plugin) afterDelete(db *gorm.DB) {
+	if !p.tracked(db) {
+		return
+	}
+	pk := primaryKey(db)
+	for _, row := range beforeRows(db) {
+		p.record(db, ActionDelete, pk, row, nil)
+	}
+}
+
+// find 在当前连接(含事务)上按条件读取整行
+func (p *Plugin) find(db *gorm.DB, exprs ...clause.Expression) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Table(db.Statement.Table).
+		Clauses(clause.Where{Exprs: exprs}).
+		Find(&rows).Error
+	for _, row := range rows {
+		for k, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[k] = string(b)
+			}
+		}
+	}
+	return rows, err
+}
+
+// record 比对前后快照并写入一条变更历史, 修改前后无差异时不写入
+func (p *Plugin) record(db *gorm.DB, action, pk string, before, after map[string]interface{}) {
+	diff := p.Diff(before, after)
+	if action == ActionUpdate && len(diff) == 0 {
+		return
+	}
+	id := after[pk]
+	if before != nil {
+		id = before[pk]
+	}
+	history := system.SysChangeHistory{
+		Table:    db.Statement.Table,
+		RecordID: fmt.Sprint(id),
+		Action:   action,
+		Before:   toJSON(p.masked(before)),
+		After:    toJSON(p.masked(after)),
+		Diff:     toJSON(diff),
+	}
+	history.OperatorID, history.OperatorName = Operator(db.Statement.Context)
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&history).Error
+	if err != nil {
+		global.GVA_LOG.Error("write change history failed", zap.String("table", history.Table), zap.String("recordId", history.RecordID), zap.Error(err))
+	}
+}
+
+// Diff 逐字段比对前后快照, 忽略字段不参与比对, 脱敏字段只标记变化不输出原值
+func (p *Plugin) Diff(before, after map[string]interface{}) map[string]map[string]interface{} {
+	diff := make(map[string]map[string]interface{})
+	keys := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	for k := range keys {
+		if p.ignore[strings.ToLower(k)] {
+			continue
+		}
+		b, a := before[k], after[k]
+		if before != nil && after != nil && equal(b, a) {
+			continue
+		}
+		if (before == nil && a == nil) || (after == nil && b == nil) {
+			continue
+		}
+		if p.mask[strings.ToLower(k)] {
+			b, a = maskOf(b), maskOf(a)
+		}
+		diff[k] = map[string]interface{}{"before": b, "after": a}
+	}
+	return diff
+}
+
+func (p *Plugin) masked(row map[string]interface{}) map[string]interface{} {
+	if row == nil || len(p.mask) == 0 {
+		return row
+	}
+	out := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		if p.mask[strings.ToLower(k)] {
+			v = maskOf(v)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+type operatorKey struct{}
+
+// operator 记录变更的操作人
+type operator struct {
+	ID   uint
+	Name string
+}
+
+//@function: WithOperator
+//@description: 将操作人写入请求上下文, 由 JWTAuth / McpAuth 在鉴权通过后调用
+//@param: ctx context.Context, id uint, name string
+//@return: context.Context
+
+func WithOperator(ctx context.Context, id uint, name string) context.Context {
+	return context.WithValue(ctx, operatorKey{}, operator{ID: id, Name: name})
+}
+
+//@function: Operator
+//@description: 从上下文中读取 WithOperator 写入的操作人, 没有时视为系统操作
+//@param: ctx context.Context
+//@return: uint, string
+
+func Operator(ctx context.Context) (uint, string) {
+	if ctx == nil {
+		return 0, ""
+	}
+	if op, ok := ctx.Value(operatorKey{}).(operator); ok {
+		return op.ID, op.Name
+	}
+	return 0, ""
+}
+
+func beforeRows(db *gorm.DB) []map[string]interface{} {
+	if v, ok := db.InstanceGet(beforeKey); ok {
+		rows, _ := v.([]map[string]interface{})
+		return rows
+	}
+	return nil
+}
+
+func primaryKey(db *gorm.DB) string {
+	if db.Statement.Schema != nil && db.Statement.Schema.PrioritizedPrimaryField != nil {
+		return db.Statement.Schema.PrioritizedPrimaryField.DBName
+	}
+	return "id"
+}
+
+// primaryValues 读取语句模型上非零的主键值
+func primaryValues(db *gorm.DB) []interface{} {
+	stmt := db.Statement
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil || !stmt.ReflectValue.IsValid() {
+		return nil
+	}
+	field := stmt.Schema.PrioritizedPrimaryField
+	var values []interface{}
+	add := func(rv reflect.Value) {
+		rv = reflect.Indirect(rv)
+		if rv.Kind() != reflect.Struct || rv.Type() != stmt.Schema.ModelType {
+			return
+		}
+		if v, zero := field.ValueOf(stmt.Context, rv); !zero {
+			values = append(values, v)
+		}
+	}
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			add(stmt.ReflectValue.Index(i))
+		}
+	case reflect.Struct:
+		add(stmt.ReflectValue)
+	}
+	return values
+}
+
+func equal(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func maskOf(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return maskValue
+}
+
+func toSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		set[strings.ToLower(s)] = true
+	}
+	return set
+}
+
+func toJSON(v interface{}) string {
+	if v == nil || reflect.ValueOf(v).IsNil() {
+		return ""
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
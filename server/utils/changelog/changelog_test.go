@@ -0,0 +1,99 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"server/config"
+	"server/global"
+	"server/model/system"
+
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysChangeHistory{}); err != nil {
+		t.Fatal(err)
+	}
+	if global.GVA_LOG == nil {
+		global.GVA_LOG = zap.NewNop()
+	}
+	err = db.Use(New(config.ChangeHistory{MaskFields: []string{"password"}, IgnoreFields: []string{"updated_at"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func histories(t *testing.T, db *gorm.DB) []system.SysChangeHistory {
+	var list []system.SysChangeHistory
+	if err := db.Order("id").Find(&list).Error; err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
+
+func TestPlugin_CreateUpdateDelete(t *testing.T) {
+	db := setupDB(t)
+	ctx := WithOperator(context.Background(), 7, "admin")
+	tx := db.WithContext(ctx)
+
+	user := system.SysUser{Username: "alice", NickName: "A", Password: "secret"}
+	if err := tx.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	tx.Model(&user).Updates(map[string]interface{}{"nick_name": "B", "password": "changed"})
+	tx.Model(&user).Update("nick_name", "B") // 无差异不记录
+	tx.Where("username = ?", "alice").Delete(&system.SysUser{})
+
+	list := histories(t, db)
+	if len(list) != 3 {
+		t.Fatalf("期望3条变更历史, 实际%d条", len(list))
+	}
+	for i, action := range []string{ActionCreate, ActionUpdate, ActionDelete} {
+		h := list[i]
+		if h.Action != action || h.Table != "sys_users" || h.OperatorID != 7 || h.OperatorName != "admin" {
+			t.Errorf("第%d条 = %+v", i, h)
+		}
+	}
+
+	var diff map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(list[1].Diff), &diff); err != nil {
+		t.Fatal(err)
+	}
+	if len(diff) != 2 || diff["nick_name"]["before"] != "A" || diff["nick_name"]["after"] != "B" {
+		t.Errorf("update diff = %v", diff)
+	}
+	if diff["password"]["before"] != maskValue || diff["password"]["after"] != maskValue {
+		t.Errorf("password 未脱敏: %v", diff["password"])
+	}
+	if list[2].After != "" || list[2].Before == "" {
+		t.Errorf("delete 快照 = %+v", list[2])
+	}
+}
+
+func TestPlugin_Rollback(t *testing.T) {
+	db := setupDB(t)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&system.SysUser{Username: "bob"}).Error; err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	if err == nil {
+		t.Fatal("期望事务回滚")
+	}
+	if list := histories(t, db); len(list) != 0 {
+		t.Errorf("回滚后不应保留变更历史, 实际%d条", len(list))
+	}
+}
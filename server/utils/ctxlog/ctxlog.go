@@ -0,0 +1,89 @@
+package ctxlog
+
+import (
+	"context"
+	"sync/atomic"
+
+	"server/global"
+	"server/utils/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type requestKey struct{}
+
+// RequestInfo 请求级日志上下文, 由 RequestID 中间件创建, 鉴权通过后补充用户ID
+type RequestInfo struct {
+	RequestID string
+	Route     string
+	userID    atomic.Uint64
+}
+
+// UserID 当前请求的用户ID, 未登录时为0
+func (r *RequestInfo) UserID() uint {
+	return uint(r.userID.Load())
+}
+
+// WithRequest 将请求信息写入 ctx
+func WithRequest(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestKey{}, info)
+}
+
+// Request 读取 ctx 中的请求信息, 支持直接传入 *gin.Context
+func Request(ctx context.Context) *RequestInfo {
+	if ctx == nil {
+		return nil
+	}
+	if c, ok := ctx.(*gin.Context); ok {
+		if c.Request == nil {
+			return nil
+		}
+		ctx = c.Request.Context()
+	}
+	info, _ := ctx.Value(requestKey{}).(*RequestInfo)
+	return info
+}
+
+// RequestID 返回 ctx 中的请求ID, 没有时返回空字符串
+func RequestID(ctx context.Context) string {
+	if info := Request(ctx); info != nil {
+		return info.RequestID
+	}
+	return ""
+}
+
+// SetUserID 记录当前请求的用户ID, 之后通过 Logger 输出的日志都会带上 user_id
+func SetUserID(ctx context.Context, userID uint) {
+	if info := Request(ctx); info != nil {
+		info.userID.Store(uint64(userID))
+	}
+}
+
+// Fields 返回 ctx 中的请求ID、用户ID、路由与链路追踪日志字段
+func Fields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if info := Request(ctx); info != nil {
+		fields = append(fields, zap.String("request_id", info.RequestID))
+		if userID := info.UserID(); userID != 0 {
+			fields = append(fields, zap.Uint("user_id", userID))
+		}
+		if info.Route != "" {
+			fields = append(fields, zap.String("route", info.Route))
+		}
+	}
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil {
+		ctx = c.Request.Context()
+	}
+	return append(fields, tracing.ZapFields(ctx)...)
+}
+
+// Logger 返回带有请求上下文字段的 global.GVA_LOG, 在接口与服务中代替 global.GVA_LOG 使用
+//
+//	ctxlog.Logger(ctx).Error("创建失败!", zap.Error(err))
+func Logger(ctx context.Context) *zap.Logger {
+	if fields := Fields(ctx); len(fields) > 0 {
+		return global.GVA_LOG.With(fields...)
+	}
+	return global.GVA_LOG
+}
@@ -0,0 +1,47 @@
+package ctxlog
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"server/global"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	oldLog := global.GVA_LOG
+	global.GVA_LOG = zap.New(core)
+	t.Cleanup(func() {
+		global.GVA_LOG = oldLog
+	})
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/user/1", nil)
+	info := &RequestInfo{RequestID: "req-1", Route: "/user/:id"}
+	c.Request = c.Request.WithContext(WithRequest(c.Request.Context(), info))
+	SetUserID(c, 7)
+
+	// 服务中通常传入 c.Request.Context(), 接口中也可以直接传入 *gin.Context
+	Logger(c.Request.Context()).Info("service")
+	Logger(c).Info("api")
+	Logger(context.Background()).Info("background")
+
+	entries := logs.All()
+	for _, entry := range entries[:2] {
+		fields := entry.ContextMap()
+		if fields["request_id"] != "req-1" || fields["user_id"] != uint64(7) || fields["route"] != "/user/:id" {
+			t.Errorf("%s 日志字段 = %v", entry.Message, fields)
+		}
+	}
+	if fields := entries[2].ContextMap(); len(fields) != 0 {
+		t.Errorf("没有请求上下文时不应附加字段, 实际 %v", fields)
+	}
+	if RequestID(context.Background()) != "" {
+		t.Error("没有请求上下文时请求ID应为空")
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -122,3 +123,54 @@ func FileExist(path string) bool {
 	}
 	return !os.IsNotExist(err)
 }
+
+// WriteFiles 批量写入文件, 任一文件写入失败时恢复已写入文件的原内容并删除新建的文件
+func WriteFiles(files map[string][]byte) error {
+	type backup struct {
+		content []byte
+		mode    os.FileMode
+		exist   bool
+	}
+	backups := make(map[string]backup, len(files))
+	for path := range files {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			backups[path] = backup{}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("[filepath:%s]读取文件失败: %w", path, err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("[filepath:%s]读取文件失败: %w", path, err)
+		}
+		backups[path] = backup{content: content, mode: info.Mode().Perm(), exist: true}
+	}
+	written := make([]string, 0, len(files))
+	restore := func() {
+		for _, path := range written {
+			if b := backups[path]; b.exist {
+				_ = os.WriteFile(path, b.content, b.mode)
+			} else {
+				_ = os.Remove(path)
+			}
+		}
+	}
+	for path, content := range files {
+		mode := os.FileMode(0o666)
+		if b := backups[path]; b.exist {
+			mode = b.mode
+		}
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			restore()
+			return fmt.Errorf("[filepath:%s]创建文件夹失败: %w", path, err)
+		}
+		written = append(written, path)
+		if err := os.WriteFile(path, content, mode); err != nil {
+			restore()
+			return fmt.Errorf("[filepath:%s]写入文件失败: %w", path, err)
+		}
+	}
+	return nil
+}
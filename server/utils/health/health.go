@@ -0,0 +1,168 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"server/global"
+	"server/utils/upload"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm"
+)
+
+const (
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusSkipped      = "skipped"
+	StatusShuttingDown = "shutting_down"
+
+	defaultTimeout = 3 * time.Second
+)
+
+var shuttingDown atomic.Bool
+
+// CheckResult 单项依赖的检查结果
+type CheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Report 就绪检查结果, Status 为 up 时表示可以接收流量
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Ready 是否可以接收流量
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+type check struct {
+	name string
+	ping func(ctx context.Context) error
+}
+
+// SetShuttingDown 进入优雅关闭阶段, 之后就绪检查始终返回未就绪
+func SetShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+// ShuttingDown 是否处于优雅关闭阶段
+func ShuttingDown() bool {
+	return shuttingDown.Load()
+}
+
+//@function: Readiness
+//@description: 并发检查数据库、Redis、Mongo 与对象存储, 每项检查使用独立超时
+//@param: ctx context.Context, timeout time.Duration 单项超时, 不大于0时使用默认值
+//@return: Report
+
+func Readiness(ctx context.Context, timeout time.Duration) Report {
+	if ShuttingDown() {
+		return Report{Status: StatusShuttingDown, Checks: []CheckResult{}}
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	checks := dependencies()
+	report := Report{Status: StatusUp, Checks: make([]CheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, c, timeout)
+		}(i, c)
+	}
+	wg.Wait()
+	for _, result := range report.Checks {
+		if result.Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, c check, timeout time.Duration) CheckResult {
+	result := CheckResult{Name: c.name, Status: StatusUp}
+	if c.ping == nil {
+		result.Status = StatusSkipped
+		return result
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	err := c.ping(ctx)
+	result.Duration = time.Since(start).String()
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// dependencies 按当前配置列出需要检查的依赖, 尚未配置数据库(等待初始化)时跳过数据库检查以便完成初始化
+func dependencies() []check {
+	checks := []check{{name: "db", ping: pingGorm(global.GVA_DB)}}
+	if global.GVA_DB == nil && awaitingInit() {
+		checks[0].ping = nil
+	}
+	for name, db := range global.GVA_DBList {
+		checks = append(checks, check{name: "db:" + name, ping: pingGorm(db)})
+	}
+	if global.GVA_CONFIG.Load().System.UseRedis {
+		client := global.GVA_REDIS
+		checks = append(checks, check{name: "redis", ping: func(ctx context.Context) error {
+			if client == nil {
+				return errors.New("redis 未初始化")
+			}
+			return client.Ping(ctx).Err()
+		}})
+		for name, client := range global.GVA_REDISList {
+			checks = append(checks, check{name: "redis:" + name, ping: func(ctx context.Context) error {
+				return client.Ping(ctx).Err()
+			}})
+		}
+	}
+	if global.GVA_CONFIG.Load().System.UseMongo {
+		client := global.GVA_MONGO
+		checks = append(checks, check{name: "mongo", ping: func(ctx context.Context) error {
+			if client == nil {
+				return errors.New("mongo 未初始化")
+			}
+			// client.Ping 只支持整秒超时, 改用 ping 命令以使用 ctx 的截止时间
+			return client.RunCommand(ctx, bson.D{{Key: "ping", Value: 1}}).Err()
+		}})
+	}
+	ossType := global.GVA_CONFIG.Load().System.OssType
+	if ossType == "" {
+		ossType = "local"
+	}
+	checks = append(checks, check{name: "oss:" + ossType, ping: upload.Ping})
+	return checks
+}
+
+// awaitingInit 是否处于初始化向导阶段, 即配置中尚未填写数据库名
+func awaitingInit() bool {
+	return global.GVA_ACTIVE_DBNAME == nil || *global.GVA_ACTIVE_DBNAME == ""
+}
+
+func pingGorm(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if db == nil {
+			return errors.New("数据库未初始化")
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
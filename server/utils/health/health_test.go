@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"server/config"
+	"server/global"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestReadiness(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldDB, oldConfig, oldDBName := global.GVA_DB, global.GVA_CONFIG.Load(), global.GVA_ACTIVE_DBNAME
+	t.Cleanup(func() {
+		global.GVA_DB, global.GVA_ACTIVE_DBNAME = oldDB, oldDBName
+		global.GVA_CONFIG.Store(oldConfig)
+	})
+	global.GVA_DB = db
+	global.UpdateConfig(func(conf *config.Server) {
+		conf.System.OssType = "local"
+		conf.Local.StorePath = t.TempDir()
+	})
+
+	report := Readiness(context.Background(), time.Second)
+	if !report.Ready() || len(report.Checks) != 2 {
+		t.Fatalf("数据库与本地存储可用时应就绪, 实际 %+v", report)
+	}
+
+	global.UpdateConfig(func(conf *config.Server) { conf.System.UseRedis = true })
+	report = Readiness(context.Background(), time.Second)
+	if report.Ready() {
+		t.Fatalf("Redis 未初始化时不应就绪, 实际 %+v", report)
+	}
+	if last := report.Checks[len(report.Checks)-1]; last.Name != "oss:local" || last.Status != StatusUp {
+		t.Errorf("oss 检查结果 %+v", last)
+	}
+
+	global.UpdateConfig(func(conf *config.Server) { conf.System.UseRedis = false })
+	// 已配置数据库但连接不存在时不应就绪, 只有等待初始化时跳过数据库检查
+	dbName := "gva"
+	global.GVA_DB, global.GVA_ACTIVE_DBNAME = nil, &dbName
+	if report = Readiness(context.Background(), time.Second); report.Ready() || report.Checks[0].Status != StatusDown {
+		t.Errorf("数据库未连接时不应就绪, 实际 %+v", report)
+	}
+	dbName = ""
+	if report = Readiness(context.Background(), time.Second); !report.Ready() || report.Checks[0].Status != StatusSkipped {
+		t.Errorf("等待初始化时应跳过数据库检查, 实际 %+v", report)
+	}
+
+	SetShuttingDown()
+	if report = Readiness(context.Background(), time.Second); report.Status != StatusShuttingDown {
+		t.Errorf("关闭阶段应返回 %s, 实际 %+v", StatusShuttingDown, report)
+	}
+}
@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// 各子系统使用的日志名称, 可通过 /system/setLogLevel 按名称单独调整级别
+const (
+	NameGorm            = "gorm"
+	NameMcp             = "mcp"
+	NameOperationRecord = "operation_record"
+)
+
+// Levels 系统日志级别, core.Zap 使用它包装所有输出, 修改后立即生效, 无需重载系统
+var Levels = NewLevelRegistry(zapcore.InfoLevel)
+
+// LevelRegistry 全局与按名称(logger.Named)覆盖的日志级别
+// 名称按 "." 分级匹配, 为 a 设置的级别同样作用于 a.b, 更具体的名称优先
+type LevelRegistry struct {
+	mu     sync.RWMutex
+	global zapcore.Level
+	named  map[string]zapcore.Level
+	// min 所有级别中的最低级别, 供 Enabled 快速判断
+	min atomic.Int32
+}
+
+// LevelStatus 当前生效的日志级别
+type LevelStatus struct {
+	Global string            `json:"global"` // 全局级别
+	Named  map[string]string `json:"named"`  // 按名称覆盖的级别
+}
+
+func NewLevelRegistry(level zapcore.Level) *LevelRegistry {
+	r := &LevelRegistry{global: level, named: map[string]zapcore.Level{}}
+	r.min.Store(int32(level))
+	return r
+}
+
+// Reset 恢复为配置文件中的级别并清除所有按名称覆盖的级别
+func (r *LevelRegistry) Reset(level zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.global = level
+	r.named = map[string]zapcore.Level{}
+	r.updateMin()
+}
+
+// SetLevel 设置日志级别, name 为空时设置全局级别
+func (r *LevelRegistry) SetLevel(name string, level zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name == "" {
+		r.global = level
+	} else {
+		r.named[name] = level
+	}
+	r.updateMin()
+}
+
+// Unset 移除 name 的级别覆盖, 之后该名称使用上级名称或全局级别
+func (r *LevelRegistry) Unset(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.named, name)
+	r.updateMin()
+}
+
+// Level 返回 name 实际生效的级别
+func (r *LevelRegistry) Level(name string) zapcore.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for name != "" {
+		if level, ok := r.named[name]; ok {
+			return level
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return r.global
+}
+
+// Status 返回当前的全局级别与按名称覆盖的级别
+func (r *LevelRegistry) Status() LevelStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	status := LevelStatus{Global: r.global.String(), Named: make(map[string]string, len(r.named))}
+	for name, level := range r.named {
+		status.Named[name] = level.String()
+	}
+	return status
+}
+
+func (r *LevelRegistry) updateMin() {
+	min := r.global
+	for _, level := range r.named {
+		if level < min {
+			min = level
+		}
+	}
+	r.min.Store(int32(min))
+}
+
+// Wrap 使用级别表过滤 core 的输出
+func (r *LevelRegistry) Wrap(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: r}
+}
+
+type levelCore struct {
+	zapcore.Core
+	levels *LevelRegistry
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return int32(level) >= c.levels.min.Load() && c.Core.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < c.levels.Level(entry.LoggerName) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
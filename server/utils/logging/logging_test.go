@@ -0,0 +1,165 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"server/config"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevelRegistry(t *testing.T) {
+	levels := NewLevelRegistry(zapcore.InfoLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(levels.Wrap(core))
+
+	logger.Debug("global debug")
+	logger.Named("mcp").Debug("named debug")
+	if logs.Len() != 0 {
+		t.Fatalf("全局级别为 info 时不应输出 debug, 实际 %d 条", logs.Len())
+	}
+
+	levels.SetLevel("mcp", zapcore.DebugLevel)
+	logger.Debug("global debug")
+	logger.Named("mcp").Named("auth").Debug("named debug")
+	if entries := logs.TakeAll(); len(entries) != 1 || entries[0].LoggerName != "mcp.auth" {
+		t.Fatalf("只有 mcp 及其子名称应输出 debug, 实际 %+v", entries)
+	}
+
+	levels.SetLevel("", zapcore.ErrorLevel)
+	logger.Info("global info")
+	logger.Named("mcp").Info("named info")
+	if entries := logs.TakeAll(); len(entries) != 1 || entries[0].LoggerName != "mcp" {
+		t.Fatalf("全局级别调整为 error 后只有 mcp 应输出 info, 实际 %+v", entries)
+	}
+
+	levels.Unset("mcp")
+	logger.Named("mcp").Warn("named warn")
+	if logs.Len() != 0 {
+		t.Fatal("移除覆盖后 mcp 应使用全局级别")
+	}
+	if status := levels.Status(); status.Global != "error" || len(status.Named) != 0 {
+		t.Errorf("Status() = %+v", status)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var mu sync.Mutex
+	var received []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var batch []map[string]any
+		if err := json.Unmarshal(body, &batch); err != nil {
+			t.Errorf("请求体应为 JSON 数组: %v", err)
+		}
+		mu.Lock()
+		received = append(received, batch...)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	cores, err := NewSinkCores([]config.ZapSink{{Type: "http", Address: server.URL, Level: "info", FlushInterval: "10ms"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := zap.New(cores[0])
+	logger.Debug("ignored")
+	logger.Info("shipped", zap.String("request_id", "abc"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err = Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0]["msg"] != "shipped" || received[0]["request_id"] != "abc" {
+		t.Errorf("received = %+v", received)
+	}
+}
+
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(ctx context.Context, records []Record) error {
+	<-w.release
+	return nil
+}
+
+func (w *blockingWriter) Close() error {
+	return nil
+}
+
+func TestSinkDropsWhenFull(t *testing.T) {
+	writer := &blockingWriter{release: make(chan struct{})}
+	sink := NewSink(config.ZapSink{Name: "test", BufferSize: 2, BatchSize: 1}, writer)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			sink.Push(Record{Time: time.Now(), Line: []byte(`{}`)})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("队列已满时写日志不应阻塞")
+	}
+	// 后台协程最多取走 1 条, 队列最多容纳 2 条
+	if stats := sink.Stats(); stats.Dropped < 7 {
+		t.Errorf("Stats() = %+v, 至少应丢弃 7 条", stats)
+	}
+	close(writer.release)
+	if err := sink.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSinkCoresReplaced(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var batch []map[string]any
+		_ = json.Unmarshal(body, &batch)
+		mu.Lock()
+		for _, record := range batch {
+			received[r.URL.Path] = append(received[r.URL.Path], record["msg"].(string))
+		}
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	cores, err := NewSinkCores([]config.ZapSink{{Type: "http", Address: server.URL + "/old", FlushInterval: "10ms"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 重建投递目标前创建的 logger 应写入新的投递目标, 而不是已关闭的旧目标
+	logger := zap.New(cores[0]).Named("mcp")
+	if _, err = NewSinkCores([]config.ZapSink{{Type: "http", Address: server.URL + "/new", FlushInterval: "10ms"}}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("after reload")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err = Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received["/old"]) != 0 || len(received["/new"]) != 1 || received["/new"][0] != "after reload" {
+		t.Errorf("received = %+v", received)
+	}
+}
@@ -0,0 +1,345 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"server/config"
+	"server/utils/metrics"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultBufferSize    = 1024
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	defaultTimeout       = 5 * time.Second
+)
+
+// Record 一条已编码的日志
+type Record struct {
+	Time  time.Time
+	Level zapcore.Level
+	Line  []byte // JSON 编码的日志内容, 不含换行
+}
+
+// Writer 日志投递目标的发送实现, 由后台协程串行调用
+type Writer interface {
+	Write(ctx context.Context, records []Record) error
+	Close() error
+}
+
+// SinkStats 投递目标的运行统计
+type SinkStats struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Buffered int    `json:"buffered"` // 队列中等待发送的条数
+	Sent     uint64 `json:"sent"`     // 已发送条数
+	Dropped  uint64 `json:"dropped"`  // 队列满时丢弃的条数
+	Failed   uint64 `json:"failed"`   // 发送失败的条数
+}
+
+var (
+	sinksMu sync.Mutex
+	sinks   []*Sink
+)
+
+// Sink 带缓冲的异步投递目标
+type Sink struct {
+	name, typ string
+	writer    Writer
+	queue     chan Record
+	batchSize int
+	interval  time.Duration
+	timeout   time.Duration
+
+	sent, dropped, failed atomic.Uint64
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+//@function: NewSink
+//@description: 创建异步投递目标并启动后台发送协程
+//@param: conf config.ZapSink, writer Writer
+//@return: *Sink
+
+func NewSink(conf config.ZapSink, writer Writer) *Sink {
+	s := &Sink{
+		name:      conf.Name,
+		typ:       conf.Type,
+		writer:    writer,
+		batchSize: conf.BatchSize,
+		interval:  parseDuration(conf.FlushInterval, defaultFlushInterval),
+		timeout:   parseDuration(conf.Timeout, defaultTimeout),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if s.name == "" {
+		s.name = conf.Type
+	}
+	bufferSize := conf.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultBatchSize
+	}
+	s.queue = make(chan Record, bufferSize)
+	go s.run()
+	return s
+}
+
+// Push 将日志放入队列, 队列已满时丢弃并计数, 不阻塞调用方
+func (s *Sink) Push(record Record) {
+	select {
+	case s.queue <- record:
+	default:
+		s.dropped.Add(1)
+		metrics.ObserveLogDropped(s.name)
+	}
+}
+
+// Stats 返回运行统计
+func (s *Sink) Stats() SinkStats {
+	return SinkStats{
+		Name:     s.name,
+		Type:     s.typ,
+		Buffered: len(s.queue),
+		Sent:     s.sent.Load(),
+		Dropped:  s.dropped.Load(),
+		Failed:   s.failed.Load(),
+	}
+}
+
+// Close 发送队列中剩余的日志后关闭, ctx 结束时放弃等待
+func (s *Sink) Close(ctx context.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	select {
+	case <-s.done:
+		return s.writer.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	batch := make([]Record, 0, s.batchSize)
+	for {
+		select {
+		case record := <-s.queue:
+			batch = append(batch, record)
+			if len(batch) >= s.batchSize {
+				batch = s.flush(batch)
+			}
+		case <-ticker.C:
+			batch = s.flush(batch)
+		case <-s.stop:
+			for {
+				select {
+				case record := <-s.queue:
+					batch = append(batch, record)
+					if len(batch) >= s.batchSize {
+						batch = s.flush(batch)
+					}
+				default:
+					s.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Sink) flush(batch []Record) []Record {
+	if len(batch) == 0 {
+		return batch
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.writer.Write(ctx, batch); err != nil {
+		s.failed.Add(uint64(len(batch)))
+		// 不能写回 zap, 避免投递失败时循环写日志
+		fmt.Fprintf(os.Stderr, "log sink %s: %v\n", s.name, err)
+	} else {
+		s.sent.Add(uint64(len(batch)))
+	}
+	return batch[:0]
+}
+
+// sinkCore 将日志编码为 JSON 后放入当前所有投递目标的队列
+// 投递目标在写入时从 current 读取, 重建投递目标后仍持有旧 logger 的调用方也会写入新的投递目标
+type sinkCore struct {
+	encoder zapcore.Encoder
+}
+
+// levelSink 投递目标及其级别
+type levelSink struct {
+	level zapcore.Level
+	sink  *Sink
+}
+
+// current 当前的投递目标, 由 NewSinkCores 整体替换
+var current atomic.Pointer[[]levelSink]
+
+func (c *sinkCore) Enabled(level zapcore.Level) bool {
+	list := current.Load()
+	if list == nil {
+		return false
+	}
+	for _, s := range *list {
+		if s.level.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	encoder := c.encoder.Clone()
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+	return &sinkCore{encoder: encoder}
+}
+
+func (c *sinkCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *sinkCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	list := current.Load()
+	if list == nil {
+		return nil
+	}
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	line := make([]byte, 0, buf.Len())
+	line = append(line, buf.Bytes()...)
+	buf.Free()
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+	}
+	for _, s := range *list {
+		if s.level.Enabled(entry.Level) {
+			s.sink.Push(Record{Time: entry.Time, Level: entry.Level, Line: line})
+		}
+	}
+	return nil
+}
+
+func (c *sinkCore) Sync() error {
+	return nil
+}
+
+//@function: NewSinkCores
+//@description: 按配置创建日志投递目标并替换之前创建的投递目标, 之前的投递目标发送完剩余日志后关闭; 单个目标创建失败时跳过并返回错误
+//@param: confs []config.ZapSink
+//@return: cores []zapcore.Core, err error
+
+func NewSinkCores(confs []config.ZapSink) (cores []zapcore.Core, err error) {
+	created := make([]levelSink, 0, len(confs))
+	var errs []error
+	for _, conf := range confs {
+		writer, e := newWriter(conf)
+		if e != nil {
+			errs = append(errs, fmt.Errorf("log sink %s: %w", conf.Type, e))
+			continue
+		}
+		level := zapcore.DebugLevel
+		if conf.Level != "" {
+			if level, e = zapcore.ParseLevel(conf.Level); e != nil {
+				errs = append(errs, fmt.Errorf("log sink %s: %w", conf.Type, e))
+				_ = writer.Close()
+				continue
+			}
+		}
+		created = append(created, levelSink{level: level, sink: NewSink(conf, writer)})
+	}
+
+	sinksMu.Lock()
+	previous := sinks
+	sinks = make([]*Sink, 0, len(created))
+	for _, s := range created {
+		sinks = append(sinks, s.sink)
+	}
+	current.Store(&created)
+	sinksMu.Unlock()
+	// 替换后不再有日志写入之前的投递目标
+	for _, sink := range previous {
+		go sink.Close(context.Background())
+	}
+	return []zapcore.Core{&sinkCore{encoder: newEncoder()}}, errors.Join(errs...)
+}
+
+// Stats 返回所有投递目标的运行统计
+func Stats() []SinkStats {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	stats := make([]SinkStats, 0, len(sinks))
+	for _, sink := range sinks {
+		stats = append(stats, sink.Stats())
+	}
+	return stats
+}
+
+// Close 发送所有投递目标中剩余的日志, 在服务退出前调用
+func Close(ctx context.Context) error {
+	sinksMu.Lock()
+	closing := sinks
+	sinks = nil
+	current.Store(nil)
+	sinksMu.Unlock()
+	var errs []error
+	for _, sink := range closing {
+		if err := sink.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func newWriter(conf config.ZapSink) (Writer, error) {
+	switch conf.Type {
+	case "syslog":
+		return newSyslogWriter(conf)
+	case "http":
+		return newHTTPWriter(conf)
+	case "loki":
+		return newLokiWriter(conf)
+	default:
+		return nil, errors.New("不支持的类型 " + conf.Type)
+	}
+}
+
+func newEncoder() zapcore.Encoder {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "time"
+	encoderConfig.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	return zapcore.NewJSONEncoder(encoderConfig)
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
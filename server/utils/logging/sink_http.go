@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"server/config"
+)
+
+// httpWriter 以 JSON 数组的形式将日志 POST 到收集服务
+type httpWriter struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newHTTPWriter(conf config.ZapSink) (Writer, error) {
+	if conf.Address == "" {
+		return nil, errors.New("address 不能为空")
+	}
+	return &httpWriter{url: conf.Address, headers: conf.Headers, client: &http.Client{}}, nil
+}
+
+func (w *httpWriter) Write(ctx context.Context, records []Record) error {
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, record := range records {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(record.Line)
+	}
+	body.WriteByte(']')
+	return post(ctx, w.client, w.url, w.headers, &body)
+}
+
+func (w *httpWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"server/config"
+)
+
+// lokiWriter 通过 Loki push API 发送日志, 按级别拆分为不同的流
+type lokiWriter struct {
+	url     string
+	headers map[string]string
+	labels  map[string]string
+	client  *http.Client
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func newLokiWriter(conf config.ZapSink) (Writer, error) {
+	if conf.Address == "" {
+		return nil, errors.New("address 不能为空")
+	}
+	labels := map[string]string{"service": "gin-vue-admin"}
+	for key, value := range conf.Labels {
+		labels[key] = value
+	}
+	return &lokiWriter{
+		url:     strings.TrimRight(conf.Address, "/") + "/loki/api/v1/push",
+		headers: conf.Headers,
+		labels:  labels,
+		client:  &http.Client{},
+	}, nil
+}
+
+func (w *lokiWriter) Write(ctx context.Context, records []Record) error {
+	streams := make(map[string]*lokiStream)
+	order := make([]string, 0, 2)
+	for _, record := range records {
+		level := record.Level.String()
+		stream, ok := streams[level]
+		if !ok {
+			labels := make(map[string]string, len(w.labels)+1)
+			for key, value := range w.labels {
+				labels[key] = value
+			}
+			labels["level"] = level
+			stream = &lokiStream{Stream: labels}
+			streams[level] = stream
+			order = append(order, level)
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(record.Time.UnixNano(), 10), string(record.Line)})
+	}
+	payload := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{Streams: make([]*lokiStream, 0, len(order))}
+	for _, level := range order {
+		payload.Streams = append(payload.Streams, streams[level])
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return post(ctx, w.client, w.url, w.headers, bytes.NewReader(body))
+}
+
+func (w *lokiWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
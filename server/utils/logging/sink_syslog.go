@@ -0,0 +1,68 @@
+//go:build !windows && !plan9
+
+package logging
+
+import (
+	"context"
+	"log/syslog"
+	"net/url"
+
+	"server/config"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// syslogWriter 将日志写入本机或远程 syslog
+type syslogWriter struct {
+	writer *syslog.Writer
+}
+
+func newSyslogWriter(conf config.ZapSink) (Writer, error) {
+	tag := conf.Tag
+	if tag == "" {
+		tag = "gin-vue-admin"
+	}
+	var network, addr string
+	if conf.Address != "" {
+		u, err := url.Parse(conf.Address)
+		if err != nil {
+			return nil, err
+		}
+		network, addr = u.Scheme, u.Host
+	}
+	writer, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_LOCAL0, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogWriter{writer: writer}, nil
+}
+
+func (w *syslogWriter) Write(ctx context.Context, records []Record) error {
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := string(record.Line)
+		var err error
+		switch {
+		case record.Level >= zapcore.DPanicLevel:
+			err = w.writer.Crit(line)
+		case record.Level == zapcore.ErrorLevel:
+			err = w.writer.Err(line)
+		case record.Level == zapcore.WarnLevel:
+			err = w.writer.Warning(line)
+		case record.Level == zapcore.InfoLevel:
+			err = w.writer.Info(line)
+		default:
+			err = w.writer.Debug(line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *syslogWriter) Close() error {
+	return w.writer.Close()
+}
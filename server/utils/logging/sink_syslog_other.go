@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logging
+
+import (
+	"errors"
+
+	"server/config"
+)
+
+func newSyslogWriter(conf config.ZapSink) (Writer, error) {
+	return nil, errors.New("当前系统不支持 syslog")
+}
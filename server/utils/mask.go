@@ -0,0 +1,298 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"server/config"
+	"server/global"
+
+	"go.uber.org/zap"
+)
+
+const defaultMaskReplacement = "******"
+
+// Masker 按配置对请求体、查询参数、响应体与请求头进行脱敏, nil 值表示不脱敏
+type Masker struct {
+	replacement string
+	fields      [][]string
+	headers     map[string]struct{}
+	regexes     []maskRegex
+	paths       []maskPath
+}
+
+type maskRegex struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+type maskPath struct {
+	path    string
+	prefix  bool
+	fields  [][]string
+	regexes []maskRegex
+}
+
+var (
+	maskerMu     sync.RWMutex
+	maskerLoaded bool
+	masker       *Masker
+)
+
+//@function: NewMasker
+//@description: 根据配置构建脱敏器, 无法编译的正则会被跳过并在 err 中返回
+//@param: conf config.Mask
+//@return: *Masker, error
+
+func NewMasker(conf config.Mask) (*Masker, error) {
+	m := &Masker{
+		replacement: conf.Replacement,
+		fields:      parseMaskFields(conf.Fields),
+		headers:     make(map[string]struct{}, len(conf.Headers)),
+	}
+	if m.replacement == "" {
+		m.replacement = defaultMaskReplacement
+	}
+	for _, h := range conf.Headers {
+		m.headers[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+	var errs []error
+	m.regexes = m.compileRegexes(conf.Regexes, &errs)
+	for _, p := range conf.Paths {
+		if p.Path == "" {
+			continue
+		}
+		rule := maskPath{
+			path:    strings.TrimSuffix(p.Path, "*"),
+			prefix:  strings.HasSuffix(p.Path, "*"),
+			fields:  parseMaskFields(p.Fields),
+			regexes: m.compileRegexes(p.Regexes, &errs),
+		}
+		m.paths = append(m.paths, rule)
+	}
+	return m, errors.Join(errs...)
+}
+
+// GetMasker 获取依据 global.GVA_CONFIG.Load().Mask 构建的脱敏器, 未开启时返回 nil
+func GetMasker() *Masker {
+	maskerMu.RLock()
+	if maskerLoaded {
+		defer maskerMu.RUnlock()
+		return masker
+	}
+	maskerMu.RUnlock()
+
+	maskerMu.Lock()
+	defer maskerMu.Unlock()
+	if maskerLoaded {
+		return masker
+	}
+	masker = nil
+	if global.GVA_CONFIG.Load().Mask.Enable {
+		var err error
+		masker, err = NewMasker(global.GVA_CONFIG.Load().Mask)
+		if err != nil && global.GVA_LOG != nil {
+			global.GVA_LOG.Error("脱敏规则配置有误, 已跳过无效规则", zap.Error(err))
+		}
+	}
+	maskerLoaded = true
+	return masker
+}
+
+// ResetMasker 清除缓存的脱敏器, 下次调用 GetMasker 时按最新配置重建
+func ResetMasker() {
+	maskerMu.Lock()
+	defer maskerMu.Unlock()
+	masker = nil
+	maskerLoaded = false
+}
+
+// MaskBody 对请求体或响应体脱敏, 支持JSON与表单格式, 其他格式仅应用正则规则
+func (m *Masker) MaskBody(path, body string) string {
+	if m == nil || body == "" {
+		return body
+	}
+	fields, regexes := m.rules(path)
+	trimmed := strings.TrimSpace(body)
+	switch {
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		if masked, ok := m.maskJSON(trimmed, fields); ok {
+			body = masked
+		}
+	case strings.Contains(trimmed, "="):
+		if masked, ok := m.maskForm(trimmed, fields); ok {
+			body = masked
+		}
+	}
+	return m.applyRegexes(body, regexes)
+}
+
+// MaskQuery 对 url 查询字符串脱敏
+func (m *Masker) MaskQuery(path, rawQuery string) string {
+	if m == nil || rawQuery == "" {
+		return rawQuery
+	}
+	fields, regexes := m.rules(path)
+	if masked, ok := m.maskForm(rawQuery, fields); ok {
+		rawQuery = masked
+	}
+	return m.applyRegexes(rawQuery, regexes)
+}
+
+// MaskHeader 返回脱敏后的请求头副本
+func (m *Masker) MaskHeader(header http.Header) http.Header {
+	masked := header.Clone()
+	if m == nil {
+		return masked
+	}
+	for k, v := range masked {
+		if _, ok := m.headers[http.CanonicalHeaderKey(k)]; ok {
+			for i := range v {
+				v[i] = m.replacement
+			}
+		}
+	}
+	return masked
+}
+
+// rules 合并全局规则与命中路径的规则
+func (m *Masker) rules(path string) (fields [][]string, regexes []maskRegex) {
+	fields, regexes = m.fields, m.regexes
+	for _, p := range m.paths {
+		if p.path == path || (p.prefix && strings.HasPrefix(path, p.path)) {
+			fields = append(fields[:len(fields):len(fields)], p.fields...)
+			regexes = append(regexes[:len(regexes):len(regexes)], p.regexes...)
+		}
+	}
+	return fields, regexes
+}
+
+func (m *Masker) maskJSON(body string, fields [][]string) (string, bool) {
+	if len(fields) == 0 {
+		return body, true
+	}
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return body, false
+	}
+	v = m.walk(v, nil, fields)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return body, false
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), true
+}
+
+// walk 递归替换命中字段, 数组不占用路径层级
+func (m *Masker) walk(v interface{}, path []string, fields [][]string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			p := append(path[:len(path):len(path)], k)
+			if matchMaskField(p, fields) {
+				val[k] = m.replacement
+				continue
+			}
+			val[k] = m.walk(child, p, fields)
+		}
+	case []interface{}:
+		for i := range val {
+			val[i] = m.walk(val[i], path, fields)
+		}
+	}
+	return v
+}
+
+func (m *Masker) maskForm(raw string, fields [][]string) (string, bool) {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw, false
+	}
+	if len(fields) == 0 {
+		return raw, true
+	}
+	changed := false
+	for k, v := range values {
+		if matchMaskField([]string{k}, fields) {
+			for i := range v {
+				v[i] = m.replacement
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return raw, true
+	}
+	return values.Encode(), true
+}
+
+func (m *Masker) applyRegexes(s string, regexes []maskRegex) string {
+	for _, r := range regexes {
+		s = r.re.ReplaceAllString(s, r.replacement)
+	}
+	return s
+}
+
+func (m *Masker) compileRegexes(rules []config.MaskRegex, errs *[]error) []maskRegex {
+	regexes := make([]maskRegex, 0, len(rules))
+	for _, r := range rules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("脱敏正则 %q 无效: %w", r.Pattern, err))
+			continue
+		}
+		replacement := r.Replacement
+		if replacement == "" {
+			replacement = m.replacement
+		}
+		regexes = append(regexes, maskRegex{re: re, replacement: replacement})
+	}
+	return regexes
+}
+
+func parseMaskFields(fields []string) [][]string {
+	parsed := make([][]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			parsed = append(parsed, strings.Split(f, "."))
+		}
+	}
+	return parsed
+}
+
+// matchMaskField 单段规则匹配任意层级的同名字段, 多段规则按完整路径匹配, 字段名忽略大小写
+func matchMaskField(path []string, fields [][]string) bool {
+	for _, f := range fields {
+		if len(f) == 1 {
+			if strings.EqualFold(f[0], path[len(path)-1]) {
+				return true
+			}
+			continue
+		}
+		if len(f) != len(path) {
+			continue
+		}
+		matched := true
+		for i := range f {
+			if f[i] != "*" && !strings.EqualFold(f[i], path[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+
+	"server/config"
+)
+
+func TestMaskerMaskBody(t *testing.T) {
+	m, err := NewMasker(config.Mask{
+		Enable:  true,
+		Fields:  []string{"password", "data.token", "list.*.secret"},
+		Regexes: []config.MaskRegex{{Pattern: `\b(1[3-9]\d)\d{4}(\d{4})\b`, Replacement: "$1****$2"}},
+		Paths:   []config.MaskPath{{Path: "/base/*", Fields: []string{"captcha"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		path string
+		body string
+		want string
+	}{
+		{"任意层级字段", "/user/changePassword", `{"passWord":"123","user":{"password":"456"}}`, `{"passWord":"******","user":{"password":"******"}}`},
+		{"路径字段", "/user/getUserInfo", `{"token":"t","data":{"token":"t"}}`, `{"data":{"token":"******"},"token":"t"}`},
+		{"通配与数组", "/user/getUserInfo", `{"list":{"a":[{"secret":"s"}]}}`, `{"list":{"a":[{"secret":"******"}]}}`},
+		{"接口规则", "/base/login", `{"captcha":"1234"}`, `{"captcha":"******"}`},
+		{"接口规则不命中", "/user/login", `{"captcha":"1234"}`, `{"captcha":"1234"}`},
+		{"表单", "/user/login", `password=1&name=a`, `name=a&password=%2A%2A%2A%2A%2A%2A`},
+		{"正则", "/user/login", `手机 13812345678 时间 1760000000000`, `手机 138****5678 时间 1760000000000`},
+		{"数字保持原样", "/user/login", `{"id":12345678901234567890}`, `{"id":12345678901234567890}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.MaskBody(tt.path, tt.body); got != tt.want {
+				t.Errorf("MaskBody() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskerMaskHeader(t *testing.T) {
+	m, _ := NewMasker(config.Mask{Enable: true, Headers: []string{"x-token"}})
+	h := http.Header{}
+	h.Set("X-Token", "abc")
+	h.Set("Content-Type", "application/json")
+	masked := m.MaskHeader(h)
+	if masked.Get("X-Token") != "******" || masked.Get("Content-Type") != "application/json" {
+		t.Errorf("MaskHeader() = %v", masked)
+	}
+	if h.Get("X-Token") != "abc" {
+		t.Errorf("MaskHeader() 不应修改原请求头")
+	}
+	var nilMasker *Masker
+	if got := nilMasker.MaskBody("/", `{"password":"1"}`); got != `{"password":"1"}` {
+		t.Errorf("nil Masker 不应脱敏, got %v", got)
+	}
+}
+
+func TestNewMaskerInvalidRegex(t *testing.T) {
+	m, err := NewMasker(config.Mask{Regexes: []config.MaskRegex{{Pattern: "("}, {Pattern: "a"}}})
+	if err == nil {
+		t.Fatal("期望返回正则编译错误")
+	}
+	if got := m.MaskBody("/", "abc"); got != "******bc" {
+		t.Errorf("有效规则仍应生效, got %v", got)
+	}
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const startKey = "gva:metrics:start"
+
+// GormPlugin 数据库语句耗时插件, 按表与操作(create/query/update/delete/row/raw)记录
+type GormPlugin struct {
+	name string
+}
+
+//@function: NewGormPlugin
+//@description: 创建数据库指标插件
+//@param: name string 指标中的数据库标签
+//@return: *GormPlugin
+
+func NewGormPlugin(name string) *GormPlugin {
+	return &GormPlugin{name: name}
+}
+
+func (p *GormPlugin) Name() string {
+	return "gva:metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register("gva:metrics:before_create", p.before); err != nil {
+		return err
+	}
+	if err := callback.Create().After("gorm:create").Register("gva:metrics:after_create", p.after("create")); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("gorm:query").Register("gva:metrics:before_query", p.before); err != nil {
+		return err
+	}
+	if err := callback.Query().After("gorm:query").Register("gva:metrics:after_query", p.after("query")); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("gva:metrics:before_update", p.before); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("gva:metrics:after_update", p.after("update")); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("gva:metrics:before_delete", p.before); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("gorm:delete").Register("gva:metrics:after_delete", p.after("delete")); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("gorm:row").Register("gva:metrics:before_row", p.before); err != nil {
+		return err
+	}
+	if err := callback.Row().After("gorm:row").Register("gva:metrics:after_row", p.after("row")); err != nil {
+		return err
+	}
+	if err := callback.Raw().Before("gorm:raw").Register("gva:metrics:before_raw", p.before); err != nil {
+		return err
+	}
+	return callback.Raw().After("gorm:raw").Register("gva:metrics:after_raw", p.after("raw"))
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func (p *GormPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		dbDuration.WithLabelValues(p.name, table, operation).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			dbErrors.WithLabelValues(p.name, table, operation).Inc()
+		}
+	}
+}
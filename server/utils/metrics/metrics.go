@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gva"
+
+// Registry 系统指标注册表, 与默认注册表隔离, 只输出本系统登记的指标
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP 请求数, 按请求方法、路由模板与状态码统计",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP 请求耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "数据库语句耗时, 按数据库、表与操作统计",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"db", "table", "operation"})
+	dbErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "数据库语句错误数, 不含记录不存在",
+	}, []string{"db", "table", "operation"})
+
+	redisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "redis",
+		Name:      "command_duration_seconds",
+		Help:      "Redis 命令耗时, 管道按 pipeline 统计",
+		Buckets:   []float64{.0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"name", "command"})
+	redisErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "redis",
+		Name:      "command_errors_total",
+		Help:      "Redis 命令错误数, 不含键不存在",
+	}, []string{"name", "command"})
+
+	casbinEnforce = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "casbin",
+		Name:      "enforce_total",
+		Help:      "权限校验次数, 按来源与结果(allow/deny)统计",
+	}, []string{"source", "result"})
+
+	timerRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "timer",
+		Name:      "job_runs_total",
+		Help:      "定时任务执行次数, 按结果(success/panic)统计",
+	}, []string{"cron", "task", "result"})
+	logDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "log",
+		Name:      "sink_dropped_total",
+		Help:      "日志投递目标队列已满时丢弃的日志条数",
+	}, []string{"sink"})
+
+	timerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "timer",
+		Name:      "job_duration_seconds",
+		Help:      "定时任务执行耗时",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"cron", "task"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration,
+		dbDuration, dbErrors,
+		redisDuration, redisErrors,
+		casbinEnforce,
+		timerRuns, timerDuration,
+		logDropped,
+	)
+}
+
+// Handler 输出 Prometheus 文本格式的指标
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTP 记录一次 HTTP 请求, route 为路由模板, 未匹配路由时为 unmatched
+func ObserveHTTP(method, route string, status int, elapsed time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveCasbin 记录一次权限校验, source 为校验来源, 如 http、mcp
+func ObserveCasbin(source string, allowed bool) {
+	result := "deny"
+	if allowed {
+		result = "allow"
+	}
+	casbinEnforce.WithLabelValues(source, result).Inc()
+}
+
+// ObserveLogDropped 记录一条因投递队列已满而丢弃的日志
+func ObserveLogDropped(sink string) {
+	logDropped.WithLabelValues(sink).Inc()
+}
+
+// WrapJob 包装定时任务, 记录执行耗时与结果; 任务 panic 时记录后继续向上抛出, 不改变原有行为
+func WrapJob(cronName, taskName string, fun func()) func() {
+	return func() {
+		start := time.Now()
+		result := "panic"
+		defer func() {
+			timerRuns.WithLabelValues(cronName, taskName, result).Inc()
+			timerDuration.WithLabelValues(cronName, taskName).Observe(time.Since(start).Seconds())
+		}()
+		fun()
+		result = "success"
+	}
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/gorm"
+)
+
+func TestGormPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Use(NewGormPlugin("test")); err != nil {
+		t.Fatal(err)
+	}
+	type metricsItem struct {
+		ID   uint
+		Name string
+	}
+	if err = db.AutoMigrate(&metricsItem{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&metricsItem{Name: "a"})
+	var item metricsItem
+	db.First(&item)
+	db.First(&item, "name = ?", "missing")
+
+	if got := testutil.CollectAndCount(dbDuration, "gva_db_query_duration_seconds"); got < 2 {
+		t.Errorf("应至少记录 create 与 query 两组耗时, 实际 %d", got)
+	}
+	if got := testutil.ToFloat64(dbErrors.WithLabelValues("test", "metrics_items", "query")); got != 0 {
+		t.Errorf("记录不存在不应计为错误, 实际 %v", got)
+	}
+}
+
+func TestWrapJob(t *testing.T) {
+	WrapJob("test", "ok", func() {})()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("任务 panic 应继续向上抛出")
+			}
+		}()
+		WrapJob("test", "fail", func() { panic("boom") })()
+	}()
+	if got := testutil.ToFloat64(timerRuns.WithLabelValues("test", "ok", "success")); got != 1 {
+		t.Errorf("success = %v", got)
+	}
+	if got := testutil.ToFloat64(timerRuns.WithLabelValues("test", "fail", "panic")); got != 1 {
+		t.Errorf("panic = %v", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ObserveHTTP(http.MethodGet, "/user/:id", http.StatusOK, time.Millisecond)
+	ObserveHTTP(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+	ObserveCasbin("http", false)
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`gva_http_requests_total{method="GET",route="/user/:id",status="200"} 1`,
+		`gva_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`gva_casbin_enforce_total{result="deny",source="http"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("指标输出缺少 %s", want)
+		}
+	}
+}
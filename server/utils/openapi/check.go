@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MismatchKind 路由与注释不一致的类型
+type MismatchKind string
+
+const (
+	MismatchAnnotation   MismatchKind = "annotation"   // 缺少 @Router 注释
+	MismatchRoute        MismatchKind = "route"        // @Router 与实际注册的路径或方法不一致
+	MismatchRequest      MismatchKind = "request"      // @Param 与实际绑定的请求类型不一致
+	MismatchResponse     MismatchKind = "response"     // @Success 与实际返回的数据类型不一致
+	MismatchUnregistered MismatchKind = "unregistered" // 有 @Router 注释但没有注册路由
+)
+
+// Mismatch 路由与 swagger 注释不一致的地方
+type Mismatch struct {
+	Method  string       `json:"method"`
+	Path    string       `json:"path"`
+	Handler string       `json:"handler"`
+	Kind    MismatchKind `json:"kind"`
+	Message string       `json:"message"`
+}
+
+// Mismatches 对比已注册的路由与处理函数上的 swagger 注释
+// prefix 为路由前缀, swag 注释中的路径不包含前缀
+func (s *Source) Mismatches(routes gin.RoutesInfo, prefix string) []Mismatch {
+	var mismatches []Mismatch
+	registered := make(map[*Handler]bool)
+	packages := make(map[string]bool)
+	for _, route := range sortRoutes(routes) {
+		handler := s.Handler(route.Handler)
+		if handler == nil {
+			continue
+		}
+		registered[handler] = true
+		packages[handler.Package] = true
+		add := func(kind MismatchKind, message string) {
+			mismatches = append(mismatches, Mismatch{Method: route.Method, Path: route.Path, Handler: handler.Name, Kind: kind, Message: message})
+		}
+		annotation := handler.Annotation
+		if annotation.Router == "" {
+			add(MismatchAnnotation, "缺少 @Router 注释")
+			continue
+		}
+		path, _ := convertPath(strings.TrimPrefix(route.Path, prefix))
+		if annotation.Router != path || annotation.Method != route.Method {
+			add(MismatchRoute, "@Router "+annotation.Router+" ["+strings.ToLower(annotation.Method)+"] 与注册的路由不一致")
+		}
+		if handler.Bind != nil {
+			documented := annotation.Body
+			if handler.BindKind == "query" || (handler.BindKind == "form" && (route.Method == "GET" || route.Method == "DELETE")) {
+				documented = annotation.Query
+			}
+			if message := s.compare(handler.Bind, documented, "@Param"); message != "" {
+				add(MismatchRequest, message)
+			}
+		}
+		if handler.Data != nil && annotation.Data != nil {
+			if message := s.compare(handler.Data, annotation.Data, "@Success data"); message != "" {
+				add(MismatchResponse, message)
+			}
+		}
+	}
+	var unregistered []Mismatch
+	for _, handler := range s.Handlers {
+		// 只检查已注册了其他路由的包, 未安装的插件等不做报告
+		if registered[handler] || !packages[handler.Package] || handler.Annotation.Router == "" {
+			continue
+		}
+		unregistered = append(unregistered, Mismatch{
+			Method:  handler.Annotation.Method,
+			Path:    prefix + handler.Annotation.Router,
+			Handler: handler.Name,
+			Kind:    MismatchUnregistered,
+			Message: "有 @Router 注释但没有注册路由",
+		})
+	}
+	sort.Slice(unregistered, func(i, j int) bool {
+		return unregistered[i].Path < unregistered[j].Path
+	})
+	return append(mismatches, unregistered...)
+}
+
+// compare 对比代码中的类型与注释中的类型, 只比较本模块的具名类型
+func (s *Source) compare(actual *TypeRef, documented *TypeRef, annotation string) string {
+	actualName := actual.Name
+	if actualName == "" {
+		return ""
+	}
+	if documented == nil {
+		return "缺少 " + annotation + " 注释, 代码中为 " + s.shortName(actualName)
+	}
+	documentedName := documented.Name
+	if documentedName == actualName {
+		return ""
+	}
+	if documentedName == "" {
+		documentedName = "未知类型"
+	}
+	return annotation + " 为 " + s.shortName(documentedName) + ", 代码中为 " + s.shortName(actualName)
+}
+
+// shortName 去掉模块名前缀
+func (s *Source) shortName(name string) string {
+	return strings.ReplaceAll(name, s.Module+"/", "")
+}
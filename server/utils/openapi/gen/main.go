@@ -0,0 +1,24 @@
+// gen 分析服务端源码生成接口定义, 运行环境没有源码时据此生成 OpenAPI 文档
+// 在 server 目录执行 go generate ./docs, 新增或修改接口后需要重新生成
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"server/utils/openapi"
+)
+
+func main() {
+	root := flag.String("root", ".", "go.mod 所在目录")
+	output := flag.String("output", "openapi.json", "输出文件")
+	flag.Parse()
+	data, err := openapi.Generate(*root)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(*output, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
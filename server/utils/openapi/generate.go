@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecuritySchemeName 与 main.go 中 swag 的 securityDefinitions 一致
+const SecuritySchemeName = "ApiKeyAuth"
+
+// Document 根据 gin 路由表生成 OpenAPI 文档
+// 请求与响应结构来自处理函数中实际绑定与返回的类型, 无法从代码推断时使用 swagger 注释中的类型
+func (s *Source) Document(routes gin.RoutesInfo, info Info) *Document {
+	document := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]*SecurityScheme{
+				SecuritySchemeName: {Type: "apiKey", In: "header", Name: "x-token"},
+			},
+		},
+	}
+	routes = sortRoutes(routes)
+	operationIDs := make(map[string]bool)
+	for _, route := range routes {
+		openapiPath, pathParams := convertPath(route.Path)
+		item, ok := document.Paths[openapiPath]
+		if !ok {
+			item = &PathItem{}
+			document.Paths[openapiPath] = item
+		}
+		slot := item.Operation(route.Method)
+		if slot == nil {
+			continue
+		}
+		operation := &Operation{Responses: make(map[string]*Response)}
+		for _, name := range pathParams {
+			operation.Parameters = append(operation.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		var data *Schema
+		if handler := s.Handler(route.Handler); handler != nil {
+			operation.fill(route.Method, handler)
+			operation.OperationID = operationID(operationIDs, handler.Name, route)
+			if handler.Data != nil {
+				data = handler.Data.Schema
+			} else if handler.Annotation.Data != nil {
+				data = handler.Annotation.Data.Schema
+			}
+			if data != nil && handler.Page != nil {
+				// 分页结果的 list 为 interface{}, 使用 service 实际返回的列表类型
+				data = &Schema{
+					AllOf:      []*Schema{data},
+					Properties: map[string]*Schema{"list": {Type: "array", Items: handler.Page.Schema}},
+				}
+			}
+		}
+		operation.Responses["200"] = &Response{
+			Description: "OK",
+			Content: map[string]*MediaType{
+				"application/json": {Schema: envelope(data)},
+			},
+		}
+		*slot = operation
+		s.components(document.Components.Schemas, operation)
+	}
+	return document
+}
+
+// components 收集接口引用的组件, 包括组件之间的引用
+func (s *Source) components(schemas map[string]*Schema, operation *Operation) {
+	var walk func(schema *Schema)
+	walk = func(schema *Schema) {
+		if schema == nil {
+			return
+		}
+		if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+			if _, ok = schemas[name]; !ok && s.Schemas[name] != nil {
+				schemas[name] = s.Schemas[name]
+				walk(schemas[name])
+			}
+		}
+		for _, item := range schema.AllOf {
+			walk(item)
+		}
+		for _, property := range schema.Properties {
+			walk(property)
+		}
+		walk(schema.Items)
+		walk(schema.AdditionalProperties)
+	}
+	for _, parameter := range operation.Parameters {
+		walk(parameter.Schema)
+	}
+	if operation.RequestBody != nil {
+		for _, media := range operation.RequestBody.Content {
+			walk(media.Schema)
+		}
+	}
+	for _, response := range operation.Responses {
+		for _, media := range response.Content {
+			walk(media.Schema)
+		}
+	}
+}
+
+// fill 填充处理函数的注释信息、请求参数与请求体
+func (operation *Operation) fill(method string, handler *Handler) {
+	annotation := handler.Annotation
+	operation.Tags = annotation.Tags
+	operation.Summary = annotation.Summary
+	if annotation.Security {
+		operation.Security = []map[string][]string{{SecuritySchemeName: {}}}
+	}
+	body, query := handler.Bind, (*TypeRef)(nil)
+	switch handler.BindKind {
+	case "query":
+		body, query = nil, handler.Bind
+	case "form":
+		if method == "GET" || method == "DELETE" {
+			body, query = nil, handler.Bind
+		}
+	}
+	if body == nil && query == nil {
+		body, query = annotation.Body, annotation.Query
+	}
+	if query != nil {
+		operation.Parameters = append(operation.Parameters, query.Parameters...)
+	}
+	for _, name := range handler.Queries {
+		if !hasParameter(operation.Parameters, name) {
+			operation.Parameters = append(operation.Parameters, &Parameter{Name: name, In: "query", Schema: &Schema{Type: "string"}})
+		}
+	}
+	switch {
+	case len(handler.FormFiles) > 0:
+		form := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, name := range handler.FormFiles {
+			form.Properties[name] = &Schema{Type: "string", Format: "binary"}
+		}
+		operation.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{"multipart/form-data": {Schema: form}}}
+	case body != nil:
+		operation.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{"application/json": {Schema: body.Schema}}}
+	}
+}
+
+// envelope 统一响应结构 response.Response
+func envelope(data *Schema) *Schema {
+	if data == nil {
+		data = &Schema{}
+	}
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code": {Type: "integer"},
+			"data": data,
+			"msg":  {Type: "string"},
+		},
+	}
+}
+
+// convertPath 将 gin 路径参数 :id 与 *any 转换为 {id} 与 {any}
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID 默认使用处理函数名, 重名时追加请求方法与路径
+func operationID(used map[string]bool, name string, route gin.RouteInfo) string {
+	id := lowerFirst(name)
+	if used[id] {
+		words := strings.FieldsFunc(strings.ToLower(route.Method)+"/"+route.Path, func(r rune) bool {
+			return r == '/' || r == ':' || r == '*'
+		})
+		for _, word := range words {
+			id += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	used[id] = true
+	return id
+}
+
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func hasParameter(parameters []*Parameter, name string) bool {
+	for _, parameter := range parameters {
+		if parameter.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortRoutes(routes gin.RoutesInfo) gin.RoutesInfo {
+	sorted := make(gin.RoutesInfo, len(routes))
+	copy(sorted, routes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+	return sorted
+}
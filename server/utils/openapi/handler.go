@@ -0,0 +1,275 @@
+package openapi
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// bindKinds gin 绑定方法 => 绑定位置
+var bindKinds = map[string]string{
+	"ShouldBindJSON":  "json",
+	"BindJSON":        "json",
+	"ShouldBindQuery": "query",
+	"BindQuery":       "query",
+	"ShouldBind":      "form",
+	"Bind":            "form",
+}
+
+// analyze 分析处理函数体, 找出绑定的请求类型、读取的参数与返回的数据类型
+func analyze(handler *Handler, body *ast.BlockStmt, ctx string, f *sourceFile, module string) {
+	vars := make(map[string]ast.Expr)
+	calls := make(map[string]*callResult)
+	typeOf := func(expr ast.Expr) ast.Expr {
+		switch expr := expr.(type) {
+		case *ast.UnaryExpr:
+			if expr.Op == token.AND {
+				return typeOfValue(expr.X, vars)
+			}
+		case *ast.Ident:
+			return vars[expr.Name]
+		}
+		return typeOfValue(expr, vars)
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if n.Type != nil {
+					vars[name.Name] = n.Type
+				} else if i < len(n.Values) {
+					if expr := typeOfValue(n.Values[i], vars); expr != nil {
+						vars[name.Name] = expr
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				return true
+			}
+			if len(n.Rhs) == 1 && len(n.Lhs) > 1 {
+				if call, ok := n.Rhs[0].(*ast.CallExpr); ok {
+					if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+						for i, lhs := range n.Lhs {
+							if ident, ok := lhs.(*ast.Ident); ok {
+								calls[ident.Name] = &callResult{method: selector.Sel.Name, index: i}
+							}
+						}
+					}
+				}
+			}
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if expr := typeOfValue(n.Rhs[i], vars); expr != nil {
+					vars[ident.Name] = expr
+				}
+			}
+		case *ast.CallExpr:
+			selector, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			receiver, ok := selector.X.(*ast.Ident)
+			if !ok {
+				// c.Request.FormFile("file")
+				if request, ok := selector.X.(*ast.SelectorExpr); ok && selector.Sel.Name == "FormFile" && len(n.Args) == 1 {
+					if ident, ok := request.X.(*ast.Ident); ok && ident.Name == ctx && request.Sel.Name == "Request" {
+						if lit, ok := n.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							name, _ := strconv.Unquote(lit.Value)
+							handler.FormFiles = appendUnique(handler.FormFiles, name)
+						}
+					}
+				}
+				return true
+			}
+			method := selector.Sel.Name
+			switch {
+			case receiver.Name == ctx:
+				if kind, ok := bindKinds[method]; ok && handler.Bind == nil && len(n.Args) == 1 {
+					if expr := typeOf(n.Args[0]); expr != nil {
+						handler.Bind, handler.BindKind = &TypeRef{Expr: expr, file: f}, kind
+					}
+					return true
+				}
+				if len(n.Args) == 0 {
+					return true
+				}
+				lit, ok := n.Args[0].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				name, _ := strconv.Unquote(lit.Value)
+				switch method {
+				case "Query", "DefaultQuery", "GetQuery", "QueryArray":
+					handler.Queries = appendUnique(handler.Queries, name)
+				case "FormFile":
+					handler.FormFiles = appendUnique(handler.FormFiles, name)
+				}
+			case f.imports[receiver.Name] == module+"/model/common/response":
+				if (method == "OkWithData" || method == "OkWithDetailed") && handler.Data == nil && len(n.Args) > 1 {
+					if expr := typeOf(n.Args[0]); expr != nil {
+						handler.Data = &TypeRef{Expr: expr, file: f}
+					}
+					if list := pageList(n.Args[0], f, module); list != nil {
+						if expr, ok := vars[list.Name]; ok {
+							if array, ok := expr.(*ast.ArrayType); ok {
+								handler.Page = &TypeRef{Expr: array.Elt, file: f}
+							}
+						} else {
+							handler.pageCall = calls[list.Name]
+						}
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// pageList 返回 response.PageResult{List: list} 中的 list 变量
+func pageList(expr ast.Expr, f *sourceFile, module string) *ast.Ident {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	selector, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "PageResult" {
+		return nil
+	}
+	if ident, ok := selector.X.(*ast.Ident); !ok || f.imports[ident.Name] != module+"/model/common/response" {
+		return nil
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "List" {
+			list, _ := kv.Value.(*ast.Ident)
+			return list
+		}
+	}
+	return nil
+}
+
+// typeOfValue 推断表达式的类型, 仅支持字面量与已知类型的变量
+func typeOfValue(expr ast.Expr, vars map[string]ast.Expr) ast.Expr {
+	switch expr := expr.(type) {
+	case *ast.CompositeLit:
+		return expr.Type
+	case *ast.UnaryExpr:
+		if expr.Op == token.AND {
+			return typeOfValue(expr.X, vars)
+		}
+	case *ast.CallExpr:
+		if ident, ok := expr.Fun.(*ast.Ident); ok && ident.Name == "new" && len(expr.Args) == 1 {
+			return expr.Args[0]
+		}
+	case *ast.Ident:
+		return vars[expr.Name]
+	}
+	return nil
+}
+
+// parseAnnotation 解析 swag 注释
+func parseAnnotation(doc *ast.CommentGroup, f *sourceFile) (annotation Annotation) {
+	if doc == nil {
+		return
+	}
+	for _, comment := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rest := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+		switch fields[0] {
+		case "@Tags":
+			for _, tag := range strings.Split(rest, ",") {
+				annotation.Tags = append(annotation.Tags, strings.TrimSpace(tag))
+			}
+		case "@Summary":
+			annotation.Summary = rest
+		case "@Security":
+			annotation.Security = true
+		case "@Router":
+			if len(fields) > 2 {
+				annotation.Router = fields[1]
+				annotation.Method = strings.ToUpper(strings.Trim(fields[2], "[]"))
+			}
+		case "@Param":
+			if len(fields) < 4 {
+				continue
+			}
+			switch fields[2] {
+			case "body":
+				annotation.Body = parseTypeRef(fields[3], f)
+			case "query":
+				if expr := parseTypeRef(fields[3], f); expr != nil {
+					if _, ok := expr.Expr.(*ast.SelectorExpr); ok {
+						annotation.Query = expr
+					}
+				}
+			}
+		case "@Success":
+			if len(fields) > 3 && annotation.Data == nil {
+				annotation.Data = parseTypeRef(responseData(fields[3]), f)
+			}
+		}
+	}
+	return
+}
+
+// responseData 取出 response.Response{data=类型,msg=string} 中的 data 类型
+func responseData(value string) string {
+	index := strings.Index(value, "data=")
+	if index < 0 {
+		return ""
+	}
+	value = value[index+len("data="):]
+	depth := 0
+	for i, r := range value {
+		switch r {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return value[:i]
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return value[:i]
+			}
+		}
+	}
+	return value
+}
+
+func parseTypeRef(value string, f *sourceFile) *TypeRef {
+	if value == "" {
+		return nil
+	}
+	expr, err := parser.ParseExpr(value)
+	if err != nil {
+		return nil
+	}
+	return &TypeRef{Expr: expr, file: f}
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,252 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+var openapiFiles = map[string]string{
+	"go.mod": "module demo\n\ngo 1.22\n",
+	"model/shop/request/product.go": `package request
+
+type ProductSearch struct {
+	Name     string ` + "`json:\"name\" form:\"name\"`" + ` // 名称
+	PageInfo
+}
+
+type PageInfo struct {
+	Page int ` + "`json:\"page\" form:\"page\" binding:\"required\"`" + `
+}
+`,
+	"model/shop/product.go": `package shop
+
+import "time"
+
+type Product struct {
+	ID        uint      ` + "`json:\"ID\"`" + `
+	Name      string    ` + "`json:\"name\" binding:\"required\"`" + `
+	Tags      []string  ` + "`json:\"tags\"`" + `
+	Parent    *Product  ` + "`json:\"parent\"`" + `
+	CreatedAt time.Time ` + "`json:\"createdAt\"`" + `
+	secret    string
+}
+`,
+	"api/shop/product.go": `package shop
+
+import (
+	"demo/model/common/response"
+	"demo/model/shop"
+	shopReq "demo/model/shop/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductApi struct{}
+
+// CreateProduct
+// @Tags     Product
+// @Summary  创建商品
+// @Security ApiKeyAuth
+// @Param    data  body      shop.Product  true  "商品"
+// @Success  200   {object}  response.Response{msg=string}
+// @Router   /product/createProduct [post]
+func (a *ProductApi) CreateProduct(c *gin.Context) {
+	var product shop.Product
+	_ = c.ShouldBindJSON(&product)
+	response.OkWithData(product, c)
+}
+
+// GetProductList
+// @Tags     Product
+// @Param    data  query     shop.Product  true  "查询"
+// @Success  200   {object}  response.Response{data=[]shop.Product,msg=string}
+// @Router   /product/productList [get]
+func (a *ProductApi) GetProductList(c *gin.Context) {
+	var search shopReq.ProductSearch
+	_ = c.ShouldBindQuery(&search)
+	list := []shop.Product{}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// FindProduct
+// @Router   /product/findProduct/{id} [get]
+func (a *ProductApi) FindProduct(c *gin.Context) {
+	_ = c.Query("name")
+}
+
+// DeleteProduct
+// @Router   /product/deleteProduct [delete]
+func (a *ProductApi) DeleteProduct(c *gin.Context) {}
+`,
+	"model/common/response/response.go": `package response
+
+func OkWithData(data interface{}, c interface{}) {}
+
+func OkWithDetailed(data interface{}, message string, c interface{}) {}
+`,
+}
+
+func openapiSource(t *testing.T) *Source {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range openapiFiles {
+		filename := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	source, err := Parse(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return source
+}
+
+var openapiRoutes = gin.RoutesInfo{
+	{Method: "POST", Path: "/product/createProduct", Handler: "demo/api/shop.(*ProductApi).CreateProduct-fm"},
+	{Method: "GET", Path: "/product/getProductList", Handler: "demo/api/shop.(*ProductApi).GetProductList-fm"},
+	{Method: "GET", Path: "/product/findProduct/:id", Handler: "demo/api/shop.(*ProductApi).FindProduct-fm"},
+	{Method: "GET", Path: "/health", Handler: "demo/initialize.Routers.func1"},
+}
+
+func TestSource_Document(t *testing.T) {
+	source := openapiSource(t)
+	document := source.Document(openapiRoutes, Info{Title: "demo", Version: "v1"})
+	if document.OpenAPI != Version || len(document.Paths) != 4 {
+		t.Fatalf("Document() = %+v", document)
+	}
+
+	create := document.Paths["/product/createProduct"].Post
+	if create == nil || create.OperationID != "createProduct" || len(create.Security) != 1 {
+		t.Fatalf("createProduct = %+v", create)
+	}
+	if ref := create.RequestBody.Content["application/json"].Schema.Ref; ref != "#/components/schemas/shop.Product" {
+		t.Errorf("createProduct requestBody = %s", ref)
+	}
+	product := document.Components.Schemas["shop.Product"]
+	if product == nil || len(product.Properties) != 5 || product.Properties["createdAt"].Format != "date-time" ||
+		product.Properties["parent"].Ref != "#/components/schemas/shop.Product" || len(product.Required) != 1 {
+		t.Errorf("shop.Product = %+v", product)
+	}
+
+	list := document.Paths["/product/getProductList"].Get
+	if len(list.Parameters) != 2 || list.Parameters[0].Name != "name" || !list.Parameters[1].Required || list.RequestBody != nil {
+		t.Errorf("getProductList parameters = %+v", list.Parameters)
+	}
+	if data := list.Responses["200"].Content["application/json"].Schema.Properties["data"]; data.Type != "array" || data.Items.Ref == "" {
+		t.Errorf("getProductList data = %+v", data)
+	}
+
+	find := document.Paths["/product/findProduct/{id}"].Get
+	if len(find.Parameters) != 2 || find.Parameters[0].In != "path" || find.Parameters[1].Name != "name" {
+		t.Errorf("findProduct parameters = %+v", find.Parameters)
+	}
+	if health := document.Paths["/health"].Get; health == nil || health.OperationID != "" {
+		t.Errorf("health = %+v", health)
+	}
+}
+
+func TestSource_Mismatches(t *testing.T) {
+	source := openapiSource(t)
+	got := make(map[MismatchKind]string)
+	for _, mismatch := range source.Mismatches(openapiRoutes, "") {
+		got[mismatch.Kind] = mismatch.Handler
+	}
+	want := map[MismatchKind]string{
+		MismatchRoute:        "GetProductList",
+		MismatchRequest:      "GetProductList",
+		MismatchUnregistered: "DeleteProduct",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Mismatches() = %v, want %v", got, want)
+	}
+	for kind, handler := range want {
+		if got[kind] != handler {
+			t.Errorf("Mismatches()[%s] = %s, want %s", kind, got[kind], handler)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	source := openapiSource(t)
+	data, err := json.Marshal(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 序列化后不再依赖源码, 生成的文档与检查结果应与直接解析一致
+	for _, pair := range [][2]any{
+		{source.Document(openapiRoutes, Info{}), loaded.Document(openapiRoutes, Info{})},
+		{source.Mismatches(openapiRoutes, ""), loaded.Mismatches(openapiRoutes, "")},
+	} {
+		want, _ := json.Marshal(pair[0])
+		got, _ := json.Marshal(pair[1])
+		if !bytes.Equal(got, want) {
+			t.Errorf("Load() = %s, want %s", got, want)
+		}
+	}
+	want, _ := GenerateGo(source.Document(openapiRoutes, Info{}), "democlient")
+	got, _ := GenerateGo(loaded.Document(openapiRoutes, Info{}), "democlient")
+	if !bytes.Equal(got, want) {
+		t.Errorf("GenerateGo() = %s, want %s", got, want)
+	}
+}
+
+// TestGeneratedSource 嵌入的接口定义需要与源码一致, 修改接口后执行 go generate ./docs
+func TestGeneratedSource(t *testing.T) {
+	want, err := Generate("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../docs/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("docs/openapi.json 已过期, 请在 server 目录执行 go generate ./docs")
+	}
+}
+
+func TestGenerateSdk(t *testing.T) {
+	document := openapiSource(t).Document(openapiRoutes, Info{Title: "demo", Version: "v1"})
+
+	code, err := GenerateGo(document, "democlient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package democlient",
+		"type Product struct",
+		"Parent    *Product",
+		"func (c *Client) CreateProduct(ctx context.Context, body Product) (out Product, err error)",
+		"func (c *Client) FindProduct(ctx context.Context, id string, params *FindProductParams)",
+		`"new-token"`,
+	} {
+		if !strings.Contains(string(code), want) {
+			t.Errorf("GenerateGo() missing %q", want)
+		}
+	}
+
+	ts := string(GenerateTypeScript(document))
+	for _, want := range []string{
+		"export type Product = {",
+		"  createProduct(body: Product): Promise<Product> {",
+		"return this.request('GET', `/product/findProduct/${encodeURIComponent(id)}`, { query })",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("GenerateTypeScript() missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package openapi
+
+import (
+	"go/ast"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// basicSchemas go 基础类型 => JSON Schema 类型
+var basicSchemas = map[string]Schema{
+	"string":  {Type: "string"},
+	"bool":    {Type: "boolean"},
+	"int":     {Type: "integer"},
+	"int8":    {Type: "integer"},
+	"int16":   {Type: "integer"},
+	"int32":   {Type: "integer", Format: "int32"},
+	"int64":   {Type: "integer", Format: "int64"},
+	"uint":    {Type: "integer"},
+	"uint8":   {Type: "integer"},
+	"uint16":  {Type: "integer"},
+	"uint32":  {Type: "integer", Format: "int32"},
+	"uint64":  {Type: "integer", Format: "int64"},
+	"byte":    {Type: "integer"},
+	"rune":    {Type: "integer"},
+	"float32": {Type: "number", Format: "float"},
+	"float64": {Type: "number", Format: "double"},
+	"any":     {},
+}
+
+// externalSchemas 常用第三方类型 => JSON Schema, 未列出的第三方类型不限制结构
+var externalSchemas = map[string]Schema{
+	"time.Time":                             {Type: "string", Format: "date-time"},
+	"time.Duration":                         {Type: "integer", Format: "int64"},
+	"gorm.io/gorm.DeletedAt":                {Type: []string{"string", "null"}, Format: "date-time"},
+	"encoding/json.RawMessage":              {},
+	"gorm.io/datatypes.JSON":                {},
+	"mime/multipart.FileHeader":             {Type: "string", Format: "binary"},
+	"github.com/gin-gonic/gin.H":            {Type: "object"},
+	"github.com/google/uuid.UUID":           {Type: "string", Format: "uuid"},
+	"github.com/gofrs/uuid/v5.UUID":         {Type: "string", Format: "uuid"},
+	"github.com/shopspring/decimal.Decimal": {Type: "string"},
+}
+
+// field 展开匿名嵌入后的结构体字段
+type field struct {
+	name        string
+	expr        ast.Expr
+	file        *sourceFile
+	required    bool
+	description string
+}
+
+// generator 将源码中的类型转换为 JSON Schema, 具名结构体收集到 schemas 中通过 $ref 引用
+type generator struct {
+	source  *Source
+	schemas map[string]*Schema
+}
+
+func newGenerator(source *Source) *generator {
+	return &generator{source: source, schemas: make(map[string]*Schema)}
+}
+
+func (g *generator) schema(expr ast.Expr, f *sourceFile) *Schema {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if basic, ok := basicSchemas[expr.Name]; ok {
+			return &basic
+		}
+		return g.named(f.pkg, expr.Name)
+	case *ast.SelectorExpr:
+		importPath, name := g.source.qualify(expr, f)
+		if external, ok := externalSchemas[importPath+"."+name]; ok {
+			return &external
+		}
+		return g.named(importPath, name)
+	case *ast.StarExpr:
+		return g.schema(expr.X, f)
+	case *ast.ParenExpr:
+		return g.schema(expr.X, f)
+	case *ast.IndexExpr:
+		return g.schema(expr.X, f)
+	case *ast.IndexListExpr:
+		return g.schema(expr.X, f)
+	case *ast.ArrayType:
+		if ident, ok := expr.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(expr.Elt, f)}
+	case *ast.MapType:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(expr.Value, f)}
+	case *ast.StructType:
+		return g.object(expr, f)
+	}
+	return &Schema{}
+}
+
+// named 具名类型, 结构体生成组件并返回引用, 其他类型返回底层类型的结构
+func (g *generator) named(pkg string, name string) *Schema {
+	decl, ok := g.source.types[pkg+"."+name]
+	if !ok {
+		return &Schema{}
+	}
+	if _, ok = decl.spec.Type.(*ast.StructType); !ok {
+		return g.schema(decl.spec.Type, decl.file)
+	}
+	component := g.source.componentName(pkg, name)
+	if _, ok = g.schemas[component]; !ok {
+		// 先占位, 避免自引用的结构体无限递归
+		g.schemas[component] = &Schema{}
+		*g.schemas[component] = *g.object(decl.spec.Type.(*ast.StructType), decl.file)
+	}
+	return &Schema{Ref: "#/components/schemas/" + component}
+}
+
+func (g *generator) object(st *ast.StructType, f *sourceFile) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, item := range g.source.fields(st, f, "json", 0) {
+		property := g.schema(item.expr, item.file)
+		if item.description != "" && property.Ref == "" {
+			property.Description = item.description
+		}
+		schema.Properties[item.name] = property
+		if item.required {
+			schema.Required = append(schema.Required, item.name)
+		}
+	}
+	return schema
+}
+
+// parameters 将 query 绑定的结构体展开为查询参数
+func (g *generator) parameters(ref *TypeRef) []*Parameter {
+	st, f := g.source.structOf(ref.Expr, ref.file)
+	if st == nil {
+		return nil
+	}
+	var parameters []*Parameter
+	for _, item := range g.source.fields(st, f, "form", 0) {
+		parameters = append(parameters, &Parameter{
+			Name:        item.name,
+			In:          "query",
+			Description: item.description,
+			Required:    item.required,
+			Schema:      g.schema(item.expr, item.file),
+		})
+	}
+	return parameters
+}
+
+// fields 按 tag 取字段名, 匿名嵌入的结构体字段提升到外层, 与 encoding/json 与 gin 的绑定规则一致
+func (s *Source) fields(st *ast.StructType, f *sourceFile, key string, depth int) (fields []field) {
+	if depth > 8 {
+		return nil
+	}
+	for _, item := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if item.Tag != nil {
+			value, _ := strconv.Unquote(item.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		name, _, _ := strings.Cut(tag.Get(key), ",")
+		if name == "-" {
+			continue
+		}
+		required := strings.Contains(tag.Get("binding"), "required")
+		description := strings.TrimSpace(item.Comment.Text())
+		if len(item.Names) == 0 {
+			if name == "" {
+				if embedded, embeddedFile := s.structOf(item.Type, f); embedded != nil {
+					fields = append(fields, s.fields(embedded, embeddedFile, key, depth+1)...)
+				}
+				continue
+			}
+			fields = append(fields, field{name: name, expr: item.Type, file: f, required: required, description: description})
+			continue
+		}
+		for _, ident := range item.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			fieldName := name
+			if fieldName == "" {
+				fieldName = ident.Name
+			}
+			fields = append(fields, field{name: fieldName, expr: item.Type, file: f, required: required, description: description})
+		}
+	}
+	return fields
+}
+
+// structOf 解析到结构体定义, 非本模块的类型返回 nil
+func (s *Source) structOf(expr ast.Expr, f *sourceFile) (*ast.StructType, *sourceFile) {
+	for i := 0; i < 8; i++ {
+		switch e := expr.(type) {
+		case *ast.StructType:
+			return e, f
+		case *ast.StarExpr:
+			expr = e.X
+			continue
+		case *ast.IndexExpr:
+			expr = e.X
+			continue
+		case *ast.IndexListExpr:
+			expr = e.X
+			continue
+		}
+		pkg, name := s.qualify(expr, f)
+		decl, ok := s.types[pkg+"."+name]
+		if !ok {
+			return nil, nil
+		}
+		expr, f = decl.spec.Type, decl.file
+	}
+	return nil, nil
+}
+
+// qualify 返回类型的导入路径与类型名
+func (s *Source) qualify(expr ast.Expr, f *sourceFile) (pkg string, name string) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if _, ok := basicSchemas[expr.Name]; ok {
+			return "", expr.Name
+		}
+		return f.pkg, expr.Name
+	case *ast.SelectorExpr:
+		ident, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return "", ""
+		}
+		pkg, name = f.imports[ident.Name], expr.Sel.Name
+		if _, ok = s.types[pkg+"."+name]; ok {
+			return pkg, name
+		}
+		// swag 注释按包名而不是导入别名引用类型, 如 systemReq 导入的包在注释中写作 request
+		for _, importPath := range f.imports {
+			if _, ok = s.types[importPath+"."+name]; ok && path.Base(importPath) == ident.Name {
+				return importPath, name
+			}
+		}
+		return pkg, name
+	}
+	return "", ""
+}
+
+// typeName 本模块具名类型的完整名称, 忽略指针, 其他类型返回空
+func (s *Source) typeName(ref *TypeRef) string {
+	expr := ref.Expr
+	prefix := ""
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+			continue
+		case *ast.ArrayType:
+			prefix += "[]"
+			expr = e.Elt
+			continue
+		}
+		break
+	}
+	pkg, name := s.qualify(expr, ref.file)
+	if _, ok := s.types[pkg+"."+name]; !ok {
+		return ""
+	}
+	return prefix + pkg + "." + name
+}
+
+// componentName 组件名, 由模块内路径组成, 如 system.request.Login、common.response.PageResult
+func (s *Source) componentName(pkg string, name string) string {
+	relative := strings.TrimPrefix(strings.TrimPrefix(pkg, s.Module), "/")
+	relative = strings.TrimPrefix(relative, "model/")
+	if relative == "" {
+		return name
+	}
+	return strings.ReplaceAll(relative, "/", ".") + "." + name
+}
@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	componentPrefix     = "#/components/schemas/"
+	pageResultComponent = "common.response.PageResult"
+	responseComponent   = "common.response.Response"
+)
+
+// sdkOperation 生成客户端方法所需的接口信息
+type sdkOperation struct {
+	Name       string // 方法名, 首字母大写
+	Method     string
+	Path       string
+	Summary    string
+	PathParams []*Parameter
+	Query      []*Parameter
+	Body       *Schema
+	Files      []string
+	Data       *Schema
+}
+
+// sdkOperations 按路径与请求方法排序的接口, 无法定位处理函数的路由没有 operationId, 不生成方法
+func sdkOperations(document *Document) []sdkOperation {
+	var operations []sdkOperation
+	for path, item := range document.Paths {
+		for _, method := range []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH"} {
+			operation := *item.Operation(method)
+			if operation == nil || operation.OperationID == "" {
+				continue
+			}
+			op := sdkOperation{Name: upperFirst(operation.OperationID), Method: method, Path: path, Summary: operation.Summary}
+			for _, parameter := range operation.Parameters {
+				switch parameter.In {
+				case "path":
+					op.PathParams = append(op.PathParams, parameter)
+				case "query":
+					op.Query = append(op.Query, parameter)
+				}
+			}
+			if operation.RequestBody != nil {
+				if media, ok := operation.RequestBody.Content["application/json"]; ok {
+					op.Body = media.Schema
+				}
+				if media, ok := operation.RequestBody.Content["multipart/form-data"]; ok {
+					op.Files = sortedKeys(media.Schema.Properties)
+				}
+			}
+			if response, ok := operation.Responses["200"]; ok {
+				if media, ok := response.Content["application/json"]; ok && media.Schema != nil {
+					op.Data = media.Schema.Properties["data"]
+				}
+			}
+			operations = append(operations, op)
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].Path != operations[j].Path {
+			return operations[i].Path < operations[j].Path
+		}
+		return operations[i].Method < operations[j].Method
+	})
+	return operations
+}
+
+// sdkTypeNames 组件名 => 客户端中的类型名, 默认取最后一段, 重名时使用完整路径
+// 统一响应与分页结果由客户端内置的泛型类型表示, 不单独生成
+func sdkTypeNames(schemas map[string]*Schema) map[string]string {
+	count := make(map[string]int)
+	for component := range schemas {
+		count[lastSegment(component)]++
+	}
+	names := make(map[string]string, len(schemas))
+	for component := range schemas {
+		if component == pageResultComponent || component == responseComponent {
+			continue
+		}
+		name := exportedName(lastSegment(component))
+		if count[lastSegment(component)] > 1 || name == "PageResult" || name == "Response" || name == "Error" || name == "Client" || name == "File" {
+			name = ""
+			for _, segment := range strings.Split(component, ".") {
+				name += exportedName(segment)
+			}
+		}
+		names[component] = name
+	}
+	return names
+}
+
+// pageItem 分页结果返回列表元素的结构, 不是分页结果时 ok 为 false
+func pageItem(schema *Schema) (item *Schema, ok bool) {
+	if schema == nil {
+		return nil, false
+	}
+	if schema.Ref == componentPrefix+pageResultComponent {
+		return nil, true
+	}
+	if len(schema.AllOf) == 1 && schema.AllOf[0].Ref == componentPrefix+pageResultComponent {
+		if list, ok := schema.Properties["list"]; ok {
+			return list.Items, true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// schemaType 去掉 null 后的类型, 以及是否可以为 null
+func schemaType(schema *Schema) (string, bool) {
+	switch value := schema.Type.(type) {
+	case string:
+		return value, false
+	case []string:
+		kind, nullable := "", false
+		for _, v := range value {
+			if v == "null" {
+				nullable = true
+			} else if kind == "" {
+				kind = v
+			}
+		}
+		return kind, nullable
+	}
+	return "", false
+}
+
+// exportedName 转换为导出的 go 标识符, 如 page_size => PageSize
+func exportedName(name string) string {
+	var builder strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	result := builder.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "X" + result
+	}
+	return result
+}
+
+// identifier 转换为首字母小写的标识符, 用于参数名
+func identifier(name string) string {
+	return lowerFirst(exportedName(name))
+}
+
+func upperFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func lastSegment(component string) string {
+	return component[strings.LastIndex(component, ".")+1:]
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,418 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GenerateGo 生成 go 客户端, packageName 为生成代码的包名
+func GenerateGo(document *Document, packageName string) ([]byte, error) {
+	if !token.IsIdentifier(packageName) {
+		return nil, errors.Errorf("包名 %q 不合法", packageName)
+	}
+	g := &goSDK{names: sdkTypeNames(document.Components.Schemas)}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, goSDKHeader, document.Info.Title, packageName)
+	for _, component := range sortedKeys(g.names) {
+		schema := document.Components.Schemas[component]
+		fmt.Fprintf(&buf, "\n// %s %s\ntype %s %s\n", g.names[component], component, g.names[component], g.typeOf(schema, false))
+	}
+	for _, operation := range sdkOperations(document) {
+		g.operation(&buf, operation)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "格式化go客户端失败")
+	}
+	return source, nil
+}
+
+type goSDK struct {
+	names map[string]string
+}
+
+// typeOf schema 对应的 go 类型, field 为 true 时引用其他结构体使用指针, 以支持自引用
+func (g *goSDK) typeOf(schema *Schema, field bool) string {
+	if schema == nil {
+		return "json.RawMessage"
+	}
+	if item, ok := pageItem(schema); ok {
+		return "PageResult[" + g.typeOf(item, false) + "]"
+	}
+	if schema.Ref != "" {
+		name, ok := g.names[strings.TrimPrefix(schema.Ref, componentPrefix)]
+		if !ok {
+			return "json.RawMessage"
+		}
+		if field {
+			return "*" + name
+		}
+		return name
+	}
+	kind, nullable := schemaType(schema)
+	var result string
+	switch kind {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			result = "time.Time"
+		case "byte":
+			result = "[]byte"
+		default:
+			result = "string"
+		}
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			result = "int32"
+		case "int64":
+			result = "int64"
+		default:
+			result = "int"
+		}
+	case "number":
+		result = "float64"
+		if schema.Format == "float" {
+			result = "float32"
+		}
+	case "boolean":
+		result = "bool"
+	case "array":
+		result = "[]" + g.typeOf(schema.Items, false)
+	case "object":
+		switch {
+		case schema.AdditionalProperties != nil:
+			result = "map[string]" + g.typeOf(schema.AdditionalProperties, false)
+		case len(schema.Properties) > 0:
+			result = g.structOf(schema)
+		default:
+			result = "map[string]any"
+		}
+	default:
+		return "json.RawMessage"
+	}
+	if nullable {
+		return "*" + result
+	}
+	return result
+}
+
+func (g *goSDK) structOf(schema *Schema) string {
+	var buf strings.Builder
+	buf.WriteString("struct {\n")
+	used := make(map[string]bool)
+	for _, property := range sortedKeys(schema.Properties) {
+		name := exportedName(property)
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+		value := schema.Properties[property]
+		if value.Description != "" {
+			fmt.Fprintf(&buf, "// %s %s\n", name, oneLine(value.Description))
+		}
+		fmt.Fprintf(&buf, "%s %s `json:%q`\n", name, g.typeOf(value, true), property)
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+func (g *goSDK) operation(buf *bytes.Buffer, op sdkOperation) {
+	params := []string{"ctx context.Context"}
+	used := map[string]bool{"ctx": true, "c": true, "query": true, "out": true, "err": true, "body": true, "params": true}
+	path := fmt.Sprintf("%q", op.Path)
+	for _, parameter := range op.PathParams {
+		name := goParam(parameter.Name, used)
+		params = append(params, name+" string")
+		path = strings.Replace(path, "{"+parameter.Name+"}", `"+url.PathEscape(`+name+`)+"`, 1)
+	}
+	path = strings.TrimSuffix(strings.TrimPrefix(path, `""+`), `+""`)
+
+	if len(op.Query) > 0 {
+		fmt.Fprintf(buf, "\n// %sParams %s 的查询参数\ntype %sParams struct {\n", op.Name, op.Name, op.Name)
+		for _, parameter := range op.Query {
+			if parameter.Description != "" {
+				fmt.Fprintf(buf, "// %s %s\n", exportedName(parameter.Name), oneLine(parameter.Description))
+			}
+			fmt.Fprintf(buf, "%s %s\n", exportedName(parameter.Name), g.typeOf(parameter.Schema, false))
+		}
+		buf.WriteString("}\n")
+		params = append(params, "params *"+op.Name+"Params")
+	}
+	body := "nil"
+	if op.Body != nil {
+		params = append(params, "body "+g.typeOf(op.Body, false))
+		body = "body"
+	}
+	files := "nil"
+	if len(op.Files) > 0 {
+		var entries []string
+		for _, file := range op.Files {
+			name := goParam(file, used)
+			params = append(params, name+" File")
+			entries = append(entries, fmt.Sprintf("%q: %s", file, name))
+		}
+		files = "map[string]File{" + strings.Join(entries, ", ") + "}"
+	}
+
+	data := g.typeOf(op.Data, false)
+	summary := op.Summary
+	if summary == "" {
+		summary = op.Method + " " + op.Path
+	}
+	fmt.Fprintf(buf, "\n// %s %s\n// %s %s\n", op.Name, oneLine(summary), op.Method, op.Path)
+	fmt.Fprintf(buf, "func (c *Client) %s(%s) (out %s, err error) {\n", op.Name, strings.Join(params, ", "), data)
+	query := "nil"
+	if len(op.Query) > 0 {
+		query = "query"
+		buf.WriteString("query := url.Values{}\nif params != nil {\n")
+		for _, parameter := range op.Query {
+			fmt.Fprintf(buf, "addQuery(query, %q, params.%s)\n", parameter.Name, exportedName(parameter.Name))
+		}
+		buf.WriteString("}\n")
+	}
+	fmt.Fprintf(buf, "err = c.do(ctx, %q, %s, %s, %s, %s, &out)\nreturn\n}\n", op.Method, path, query, body, files)
+}
+
+// goParam 生成不与关键字及已有参数冲突的参数名
+func goParam(name string, used map[string]bool) string {
+	result := identifier(name)
+	for token.IsKeyword(result) || used[result] {
+		result += "Param"
+	}
+	used[result] = true
+	return result
+}
+
+func oneLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+const goSDKHeader = `// Code generated by gin-vue-admin openapi. DO NOT EDIT.
+
+// Package %[2]s %[1]s 的接口客户端, 由服务端路由表生成
+package %[2]s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	CodeSuccess = 0 // 业务成功
+	CodeError   = 7 // 业务失败
+)
+
+// Response 统一响应结构
+type Response[T any] struct {
+	Code int    ` + "`json:\"code\"`" + `
+	Data T      ` + "`json:\"data\"`" + `
+	Msg  string ` + "`json:\"msg\"`" + `
+}
+
+// PageResult 分页结果
+type PageResult[T any] struct {
+	List     []T   ` + "`json:\"list\"`" + `
+	Total    int64 ` + "`json:\"total\"`" + `
+	Page     int   ` + "`json:\"page\"`" + `
+	PageSize int   ` + "`json:\"pageSize\"`" + `
+}
+
+// Error HTTP 状态码不是 200 或业务码不是 CodeSuccess 时返回的错误
+type Error struct {
+	StatusCode int
+	Code       int
+	Msg        string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("gva: status=%%d code=%%d msg=%%s", e.StatusCode, e.Code, e.Msg)
+}
+
+// Unauthorized 未登录、令牌过期或已失效, 需要重新登录
+func (e *Error) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// File 上传的文件
+type File struct {
+	Name   string
+	Reader io.Reader
+}
+
+// Client 接口客户端, 可以并发使用
+type Client struct {
+	baseURL        string
+	httpClient     *http.Client
+	onTokenRefresh func(token string, expiresAt time.Time)
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Option 客户端选项
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的 http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithToken 设置 x-token
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithTokenRefresh 服务端通过 new-token 响应头续期令牌时回调, 客户端已自动使用新令牌
+func WithTokenRefresh(fn func(token string, expiresAt time.Time)) Option {
+	return func(c *Client) { c.onTokenRefresh = fn }
+}
+
+// NewClient 创建客户端, baseURL 包含路由前缀, 如 http://127.0.0.1:8888
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetToken 设置 x-token, 通常在登录后调用
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// Token 当前使用的 x-token
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body any, files map[string]File, out any) error {
+	var (
+		reader      io.Reader
+		contentType string
+	)
+	switch {
+	case files != nil:
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		for field, file := range files {
+			part, err := writer.CreateFormFile(field, file.Name)
+			if err != nil {
+				return err
+			}
+			if _, err = io.Copy(part, file.Reader); err != nil {
+				return err
+			}
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		reader, contentType = &buf, writer.FormDataContentType()
+	case body != nil:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader, contentType = bytes.NewReader(data), "application/json"
+	}
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token := c.Token(); token != "" {
+		req.Header.Set("x-token", token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if token := resp.Header.Get("new-token"); token != "" {
+		c.SetToken(token)
+		if c.onTokenRefresh != nil {
+			var expiresAt time.Time
+			if unix, err := strconv.ParseInt(resp.Header.Get("new-expires-at"), 10, 64); err == nil {
+				expiresAt = time.Unix(unix, 0)
+			}
+			c.onTokenRefresh(token, expiresAt)
+		}
+	}
+
+	var result Response[json.RawMessage]
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return &Error{StatusCode: resp.StatusCode, Code: CodeError, Msg: resp.Status}
+		}
+		return fmt.Errorf("gva: 解析响应失败: %%w", err)
+	}
+	if resp.StatusCode != http.StatusOK || result.Code != CodeSuccess {
+		return &Error{StatusCode: resp.StatusCode, Code: result.Code, Msg: result.Msg}
+	}
+	if out == nil || len(result.Data) == 0 || string(result.Data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(result.Data, out)
+}
+
+// addQuery 添加查询参数, 零值不传递, 切片按同名参数重复传递
+func addQuery(query url.Values, key string, value any) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.IsZero() {
+		return
+	}
+	switch value := v.Interface().(type) {
+	case time.Time:
+		query.Add(key, value.Format(time.RFC3339))
+		return
+	case json.RawMessage:
+		query.Add(key, string(value))
+		return
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			addQuery(query, key, v.Index(i).Interface())
+		}
+	case reflect.Struct, reflect.Map:
+		data, _ := json.Marshal(v.Interface())
+		query.Add(key, string(data))
+	default:
+		query.Add(key, fmt.Sprint(v.Interface()))
+	}
+}
+`
@@ -0,0 +1,297 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tsIdentifier 合法的 TypeScript 属性名, 其他属性名需要加引号
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsReserved TypeScript 保留字, 不能作为参数名
+var tsReserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true, "debugger": true,
+	"default": true, "delete": true, "do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true, "import": true, "in": true,
+	"instanceof": true, "new": true, "null": true, "return": true, "super": true, "switch": true, "this": true,
+	"throw": true, "true": true, "try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+}
+
+// GenerateTypeScript 生成 TypeScript 客户端, 仅依赖 fetch
+func GenerateTypeScript(document *Document) []byte {
+	t := &tsSDK{names: sdkTypeNames(document.Components.Schemas)}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, tsSDKHeader, document.Info.Title)
+	for _, component := range sortedKeys(t.names) {
+		schema := document.Components.Schemas[component]
+		fmt.Fprintf(&buf, "\n/** %s */\nexport type %s = %s\n", component, t.names[component], t.typeOf(schema, 0))
+	}
+	buf.WriteString(tsClientHeader)
+	for _, operation := range sdkOperations(document) {
+		t.operation(&buf, operation)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+type tsSDK struct {
+	names map[string]string
+}
+
+func (t *tsSDK) typeOf(schema *Schema, indent int) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if item, ok := pageItem(schema); ok {
+		return "PageResult<" + t.typeOf(item, indent) + ">"
+	}
+	if schema.Ref != "" {
+		name, ok := t.names[strings.TrimPrefix(schema.Ref, componentPrefix)]
+		if !ok {
+			return "unknown"
+		}
+		return name
+	}
+	kind, nullable := schemaType(schema)
+	var result string
+	switch kind {
+	case "string":
+		result = "string"
+	case "integer", "number":
+		result = "number"
+	case "boolean":
+		result = "boolean"
+	case "array":
+		item := t.typeOf(schema.Items, indent)
+		if strings.ContainsAny(item, " |") {
+			item = "(" + item + ")"
+		}
+		result = item + "[]"
+	case "object":
+		switch {
+		case schema.AdditionalProperties != nil:
+			result = "Record<string, " + t.typeOf(schema.AdditionalProperties, indent) + ">"
+		case len(schema.Properties) > 0:
+			result = t.objectOf(schema, indent)
+		default:
+			result = "Record<string, unknown>"
+		}
+	default:
+		return "unknown"
+	}
+	if nullable {
+		return result + " | null"
+	}
+	return result
+}
+
+func (t *tsSDK) objectOf(schema *Schema, indent int) string {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	pad := strings.Repeat("  ", indent+1)
+	var buf strings.Builder
+	buf.WriteString("{\n")
+	for _, property := range sortedKeys(schema.Properties) {
+		value := schema.Properties[property]
+		if value.Description != "" {
+			fmt.Fprintf(&buf, "%s/** %s */\n", pad, oneLine(value.Description))
+		}
+		optional := "?"
+		if required[property] {
+			optional = ""
+		}
+		fmt.Fprintf(&buf, "%s%s%s: %s\n", pad, tsProperty(property), optional, t.typeOf(value, indent+1))
+	}
+	buf.WriteString(strings.Repeat("  ", indent) + "}")
+	return buf.String()
+}
+
+func (t *tsSDK) operation(buf *bytes.Buffer, op sdkOperation) {
+	var params []string
+	used := map[string]bool{"query": true, "body": true, "files": true}
+	path := "'" + op.Path + "'"
+	for _, parameter := range op.PathParams {
+		name := tsParam(parameter.Name, used)
+		params = append(params, name+": string")
+		path = strings.Replace(path, "{"+parameter.Name+"}", "${encodeURIComponent("+name+")}", 1)
+	}
+	if len(op.PathParams) > 0 {
+		path = "`" + strings.Trim(path, "'") + "`"
+	}
+	var options []string
+	if op.Body != nil {
+		params = append(params, "body: "+t.typeOf(op.Body, 1))
+		options = append(options, "body")
+	}
+	if len(op.Files) > 0 {
+		var entries []string
+		for _, file := range op.Files {
+			name := tsParam(file, used)
+			params = append(params, name+": Blob")
+			entries = append(entries, tsProperty(file)+": "+name)
+		}
+		options = append(options, "files: { "+strings.Join(entries, ", ")+" }")
+	}
+	if len(op.Query) > 0 {
+		query := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, parameter := range op.Query {
+			query.Properties[parameter.Name] = parameter.Schema
+			if parameter.Required {
+				query.Required = append(query.Required, parameter.Name)
+			}
+		}
+		params = append(params, "query?: "+t.objectOf(query, 1))
+		options = append(options, "query")
+	}
+	summary := op.Summary
+	if summary == "" {
+		summary = op.Method + " " + op.Path
+	}
+	arguments := path
+	if len(options) > 0 {
+		arguments += ", { " + strings.Join(options, ", ") + " }"
+	}
+	fmt.Fprintf(buf, "\n  /** %s\n   * %s %s\n   */\n", oneLine(summary), op.Method, op.Path)
+	fmt.Fprintf(buf, "  %s(%s): Promise<%s> {\n", lowerFirst(op.Name), strings.Join(params, ", "), t.typeOf(op.Data, 1))
+	fmt.Fprintf(buf, "    return this.request('%s', %s)\n  }\n", op.Method, arguments)
+}
+
+// tsParam 生成不与保留字及已有参数冲突的参数名
+func tsParam(name string, used map[string]bool) string {
+	result := identifier(name)
+	for tsReserved[result] || used[result] {
+		result += "Param"
+	}
+	used[result] = true
+	return result
+}
+
+func tsProperty(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "\\'") + "'"
+}
+
+const tsSDKHeader = `// Code generated by gin-vue-admin openapi. DO NOT EDIT.
+// %s 的接口客户端, 由服务端路由表生成
+
+export const CodeSuccess = 0
+export const CodeError = 7
+
+/** 统一响应结构 */
+export interface Response<T> {
+  code: number
+  data: T
+  msg: string
+}
+
+/** 分页结果 */
+export interface PageResult<T> {
+  list: T[]
+  total: number
+  page: number
+  pageSize: number
+}
+
+/** HTTP 状态码不是 200 或业务码不是 CodeSuccess 时抛出的错误 */
+export class GvaError extends Error {
+  constructor(
+    public readonly status: number,
+    public readonly code: number,
+    message: string
+  ) {
+    super(message)
+    this.name = 'GvaError'
+  }
+
+  /** 未登录、令牌过期或已失效, 需要重新登录 */
+  get unauthorized(): boolean {
+    return this.status === 401
+  }
+}
+
+export interface GvaClientOptions {
+  /** 包含路由前缀, 如 http://127.0.0.1:8888 */
+  baseURL: string
+  token?: string
+  fetch?: typeof fetch
+  /** 服务端通过 new-token 响应头续期令牌时回调, 客户端已自动使用新令牌 */
+  onTokenRefresh?: (token: string, expiresAt?: Date) => void
+}
+
+interface RequestOptions {
+  query?: Record<string, unknown>
+  body?: unknown
+  files?: Record<string, Blob>
+}
+`
+
+const tsClientHeader = `
+export class GvaClient {
+  private readonly baseURL: string
+  private readonly fetcher: typeof fetch
+  private readonly onTokenRefresh?: (token: string, expiresAt?: Date) => void
+  private token?: string
+
+  constructor(options: GvaClientOptions) {
+    this.baseURL = options.baseURL.replace(/\/$/, '')
+    this.fetcher = options.fetch ?? fetch.bind(globalThis)
+    this.onTokenRefresh = options.onTokenRefresh
+    this.token = options.token
+  }
+
+  /** 设置 x-token, 通常在登录后调用 */
+  setToken(token?: string): void {
+    this.token = token
+  }
+
+  getToken(): string | undefined {
+    return this.token
+  }
+
+  protected async request<T>(method: string, path: string, options: RequestOptions = {}): Promise<T> {
+    const search = new URLSearchParams()
+    for (const [key, value] of Object.entries(options.query ?? {})) {
+      for (const item of Array.isArray(value) ? value : [value]) {
+        if (item === undefined || item === null || item === '') continue
+        search.append(key, typeof item === 'object' ? JSON.stringify(item) : String(item))
+      }
+    }
+    const headers: Record<string, string> = {}
+    if (this.token) headers['x-token'] = this.token
+    let body: BodyInit | undefined
+    if (options.files) {
+      const form = new FormData()
+      for (const [field, file] of Object.entries(options.files)) form.append(field, file)
+      body = form
+    } else if (options.body !== undefined) {
+      headers['Content-Type'] = 'application/json'
+      body = JSON.stringify(options.body)
+    }
+    const query = search.toString()
+    const response = await this.fetcher(this.baseURL + path + (query ? '?' + query : ''), { method, headers, body })
+
+    const token = response.headers.get('new-token')
+    if (token) {
+      this.token = token
+      const expiresAt = Number(response.headers.get('new-expires-at'))
+      this.onTokenRefresh?.(token, expiresAt ? new Date(expiresAt * 1000) : undefined)
+    }
+
+    let result: Response<T>
+    try {
+      result = (await response.json()) as Response<T>
+    } catch {
+      throw new GvaError(response.status, CodeError, response.statusText || '解析响应失败')
+    }
+    if (!response.ok || result.code !== CodeSuccess) {
+      throw new GvaError(response.status, result.code, result.msg)
+    }
+    return result.data
+  }
+`
@@ -0,0 +1,311 @@
+package openapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// skipDirs 不参与解析的目录
+var skipDirs = map[string]bool{
+	"resource": true,
+	"docs":     true,
+	"log":      true,
+	"uploads":  true,
+	"vendor":   true,
+	"testdata": true,
+}
+
+// Source 服务端源码的静态分析结果, 类型解析为 JSON Schema 后可以序列化, 通过 Load 读取时不再依赖源码
+type Source struct {
+	Module   string              `json:"module"`   // go.mod 中的模块名
+	Handlers map[string]*Handler `json:"handlers"` // 与 gin 路由表中的 Handler 名称一致, 如 server/api/v1/system.(*BaseApi).Login
+	Schemas  map[string]*Schema  `json:"schemas"`  // 处理函数引用的具名结构体, 文档中只输出已注册路由用到的部分
+	types    map[string]*typeDecl
+	methods  map[string][]*methodDecl // service 方法名 => 方法
+}
+
+// Handler 接口处理函数
+type Handler struct {
+	Name       string     `json:"name"`                // 处理函数名
+	Package    string     `json:"package"`             // 导入路径
+	Annotation Annotation `json:"annotation"`          // swagger 注释
+	Bind       *TypeRef   `json:"bind,omitempty"`      // ShouldBind 系列绑定的请求类型
+	BindKind   string     `json:"bindKind,omitempty"`  // json/query/form, form 由请求方法决定绑定位置
+	Queries    []string   `json:"queries,omitempty"`   // c.Query 读取的查询参数
+	FormFiles  []string   `json:"formFiles,omitempty"` // c.FormFile 读取的上传文件
+	Data       *TypeRef   `json:"data,omitempty"`      // response.OkWithData/OkWithDetailed 返回的数据类型
+	Page       *TypeRef   `json:"page,omitempty"`      // 返回 response.PageResult 时 List 的元素类型
+	pageCall   *callResult
+}
+
+// callResult 变量来自方法调用的第几个返回值, 用于推断 service 返回的列表类型
+type callResult struct {
+	method string
+	index  int
+}
+
+// Annotation swagger 注释
+type Annotation struct {
+	Tags     []string `json:"tags,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Security bool     `json:"security,omitempty"`
+	Router   string   `json:"router,omitempty"` // @Router 路径
+	Method   string   `json:"method,omitempty"` // @Router 请求方法, 大写
+	Body     *TypeRef `json:"body,omitempty"`   // @Param ... body 类型
+	Query    *TypeRef `json:"query,omitempty"`  // @Param ... query 结构体类型
+	Data     *TypeRef `json:"data,omitempty"`   // @Success ... response.Response{data=类型}
+}
+
+// TypeRef 源码中的类型表达式及其所在文件, 解析后记录类型名与对应的 JSON Schema
+type TypeRef struct {
+	Name       string       `json:"name,omitempty"`       // 本模块具名类型的完整名称, 其他类型为空
+	Schema     *Schema      `json:"schema"`               // 类型对应的结构
+	Parameters []*Parameter `json:"parameters,omitempty"` // 作为查询参数绑定时展开的字段
+	Expr       ast.Expr     `json:"-"`
+	file       *sourceFile
+}
+
+type sourceFile struct {
+	pkg     string            // 导入路径
+	imports map[string]string // 包名 => 导入路径
+}
+
+type typeDecl struct {
+	file *sourceFile
+	spec *ast.TypeSpec
+}
+
+type methodDecl struct {
+	file *sourceFile
+	decl *ast.FuncDecl
+}
+
+// Parse 解析 root 下的全部 go 源码, root 为 go.mod 所在目录
+func Parse(root string) (*Source, error) {
+	module, err := moduleName(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	s := &Source{Module: module, Handlers: make(map[string]*Handler), types: make(map[string]*typeDecl), methods: make(map[string][]*methodDecl)}
+	fset := token.NewFileSet()
+	err = filepath.WalkDir(root, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if filename != root && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(filename, ".go") || strings.HasSuffix(filename, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+		if err != nil {
+			// 自动化代码生成过程中可能存在暂时无法解析的文件, 跳过即可
+			return nil
+		}
+		relative, _ := filepath.Rel(root, filepath.Dir(filename))
+		pkg := module
+		if relative != "." {
+			pkg = path.Join(module, filepath.ToSlash(relative))
+		}
+		s.add(file, pkg)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "解析源码失败")
+	}
+	for _, handler := range s.Handlers {
+		if handler.Page == nil && handler.pageCall != nil {
+			handler.Page = s.resultType(handler.pageCall)
+		}
+	}
+	s.resolve()
+	return s, nil
+}
+
+// Generate 解析 root 下的源码并序列化分析结果, 由 go generate 写入 docs/openapi.json 后嵌入二进制
+func Generate(root string) ([]byte, error) {
+	s, err := Parse(root)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "序列化接口定义失败")
+	}
+	return append(data, '\n'), nil
+}
+
+// Load 读取序列化的分析结果, 运行环境没有源码时使用 go generate 生成并嵌入的结果
+func Load(data []byte) (*Source, error) {
+	s := new(Source)
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrap(err, "读取接口定义失败")
+	}
+	return s, nil
+}
+
+// resolve 将处理函数引用的类型解析为 JSON Schema, 之后生成文档与检查注释只使用解析结果
+func (s *Source) resolve() {
+	g := newGenerator(s)
+	for _, handler := range s.Handlers {
+		annotation := handler.Annotation
+		for _, ref := range []*TypeRef{handler.Bind, handler.Data, handler.Page, annotation.Body, annotation.Query, annotation.Data} {
+			if ref != nil {
+				ref.Name = s.typeName(ref)
+				ref.Schema = g.schema(ref.Expr, ref.file)
+			}
+		}
+		if handler.Bind != nil && handler.BindKind != "json" {
+			handler.Bind.Parameters = g.parameters(handler.Bind)
+		}
+		if annotation.Query != nil {
+			annotation.Query.Parameters = g.parameters(annotation.Query)
+		}
+	}
+	s.Schemas = g.schemas
+}
+
+// Handler 根据 gin 路由表中的处理函数名查找处理函数, 闭包等无法定位的返回 nil
+func (s *Source) Handler(name string) *Handler {
+	return s.Handlers[strings.TrimSuffix(name, "-fm")]
+}
+
+func (s *Source) add(file *ast.File, pkg string) {
+	f := &sourceFile{pkg: pkg, imports: make(map[string]string)}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name != "_" && name != "." {
+			f.imports[name] = importPath
+		}
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				s.types[pkg+"."+typeSpec.Name.Name] = &typeDecl{file: f, spec: typeSpec}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv != nil && strings.Contains(pkg, "/service") {
+				s.methods[decl.Name.Name] = append(s.methods[decl.Name.Name], &methodDecl{file: f, decl: decl})
+			}
+			ctx := contextParam(decl, f)
+			if ctx == "" || decl.Body == nil {
+				continue
+			}
+			handler := &Handler{Name: decl.Name.Name, Package: pkg}
+			handler.Annotation = parseAnnotation(decl.Doc, f)
+			analyze(handler, decl.Body, ctx, f, s.Module)
+			s.Handlers[handlerKey(pkg, decl)] = handler
+		}
+	}
+}
+
+// resultType 方法名唯一时返回对应返回值切片的元素类型
+func (s *Source) resultType(call *callResult) *TypeRef {
+	methods := s.methods[call.method]
+	if len(methods) != 1 || methods[0].decl.Type.Results == nil {
+		return nil
+	}
+	index := 0
+	for _, result := range methods[0].decl.Type.Results.List {
+		count := len(result.Names)
+		if count == 0 {
+			count = 1
+		}
+		if call.index < index+count {
+			if array, ok := result.Type.(*ast.ArrayType); ok {
+				return &TypeRef{Expr: array.Elt, file: methods[0].file}
+			}
+			return nil
+		}
+		index += count
+	}
+	return nil
+}
+
+// handlerKey 与 runtime.FuncForPC 的函数名格式一致
+func handlerKey(pkg string, decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return pkg + "." + decl.Name.Name
+	}
+	recv := decl.Recv.List[0].Type
+	pointer := false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		pointer = true
+		recv = star.X
+	}
+	switch expr := recv.(type) {
+	case *ast.IndexExpr:
+		recv = expr.X
+	case *ast.IndexListExpr:
+		recv = expr.X
+	}
+	name := ""
+	if ident, ok := recv.(*ast.Ident); ok {
+		name = ident.Name
+	}
+	if pointer {
+		return pkg + ".(*" + name + ")." + decl.Name.Name
+	}
+	return pkg + "." + name + "." + decl.Name.Name
+}
+
+// contextParam 返回 *gin.Context 参数名, 不是 gin 处理函数时返回空
+func contextParam(decl *ast.FuncDecl, f *sourceFile) string {
+	params := decl.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) != 1 {
+		return ""
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	selector, ok := star.X.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Context" {
+		return ""
+	}
+	if ident, ok := selector.X.(*ast.Ident); !ok || f.imports[ident.Name] != "github.com/gin-gonic/gin" {
+		return ""
+	}
+	return params[0].Names[0].Name
+}
+
+// moduleName 读取 go.mod 中的模块名
+func moduleName(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", errors.Wrap(err, "读取go.mod失败")
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+	return "", errors.Errorf("%s 中没有 module 声明", filename)
+}
@@ -0,0 +1,178 @@
+package secret
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Redacted 返回给前端时替换密钥字段的占位值, 保存配置时原样传回表示不修改
+const Redacted = "******"
+
+// SensitiveKeys 需要隐藏的配置字段, 按配置文件中的键匹配; map 类型的字段隐藏全部取值
+var SensitiveKeys = map[string]bool{
+	"password":          true,
+	"secret":            true,
+	"secret-key":        true,
+	"secret-access-key": true,
+	"access-key-secret": true,
+	"signing-key":       true,
+	"key":               true,
+	"headers":           true,
+}
+
+//@function: Redact
+//@description: 返回隐藏了密钥字段的配置副本, 不修改传入的配置
+//@param: conf T
+//@return: T
+
+func Redact[T any](conf T) T {
+	return redact(reflect.ValueOf(conf), false).Interface().(T)
+}
+
+func redact(v reflect.Value, sensitive bool) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		if sensitive && v.Len() > 0 {
+			return reflect.ValueOf(Redacted).Convert(v.Type())
+		}
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			out.Field(i).Set(redact(v.Field(i), sensitive || isSensitive(v.Type().Field(i))))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redact(v.Index(i), sensitive))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redact(iter.Value(), sensitive))
+		}
+		return out
+	}
+	return v
+}
+
+//@function: Restore
+//@description: 保存配置前处理前端传回的配置: 占位值还原为当前值; 未修改的字段还原为配置文件中的原始写法(ENC(...) 或 ${ENV}), 避免明文写回
+//@param: conf *T 前端传回的配置, current T 当前生效的配置, raw T 未解析的配置文件内容
+//@return: error 列表长度变化等原因导致占位值无法还原时返回错误
+
+func Restore[T any](conf *T, current, raw T) error {
+	if !restore(reflect.ValueOf(conf).Elem(), reflect.ValueOf(current), reflect.ValueOf(raw)) {
+		return errors.New("无法确定被隐藏的密钥字段的原值, 请重新填写")
+	}
+	return nil
+}
+
+// restore 返回 false 表示存在无法还原的占位值
+func restore(dst, current, raw reflect.Value) bool {
+	switch dst.Kind() {
+	case reflect.String:
+		value := restoreString(dst.String(), current, raw)
+		dst.SetString(value)
+		return value != Redacted
+	case reflect.Struct:
+		ok := true
+		for i := 0; i < dst.NumField(); i++ {
+			if dst.Type().Field(i).IsExported() {
+				ok = restore(dst.Field(i), current.Field(i), raw.Field(i)) && ok
+			}
+		}
+		return ok
+	case reflect.Slice:
+		ok := true
+		// 按下标对应, 增删元素后无法确定对应关系, 只检查是否残留占位值
+		matched := current.Len() == dst.Len() && raw.Len() == dst.Len()
+		for i := 0; i < dst.Len(); i++ {
+			if matched {
+				ok = restore(dst.Index(i), current.Index(i), raw.Index(i)) && ok
+			} else {
+				ok = !containsRedacted(dst.Index(i)) && ok
+			}
+		}
+		return ok
+	case reflect.Map:
+		if dst.Type().Elem().Kind() != reflect.String {
+			return !containsRedacted(dst)
+		}
+		ok := true
+		iter := dst.MapRange()
+		for iter.Next() {
+			value := restoreString(iter.Value().String(), current.MapIndex(iter.Key()), raw.MapIndex(iter.Key()))
+			dst.SetMapIndex(iter.Key(), reflect.ValueOf(value).Convert(dst.Type().Elem()))
+			ok = value != Redacted && ok
+		}
+		return ok
+	}
+	return true
+}
+
+// restoreString current 与 raw 可能是无效值(map 中不存在的键)
+func restoreString(value string, current, raw reflect.Value) string {
+	if !current.IsValid() {
+		return value
+	}
+	if value == Redacted {
+		value = current.String()
+	}
+	if raw.IsValid() && value == current.String() && raw.String() != value {
+		return raw.String()
+	}
+	return value
+}
+
+func containsRedacted(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String() == Redacted
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && containsRedacted(v.Field(i)) {
+				return true
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if containsRedacted(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if containsRedacted(iter.Value()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSensitive headers 只有 map 类型(请求头与取值)需要隐藏, 脱敏配置中的请求头名称列表不需要
+func isSensitive(field reflect.StructField) bool {
+	name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	if name == "headers" {
+		return field.Type.Kind() == reflect.Map
+	}
+	return SensitiveKeys[name]
+}
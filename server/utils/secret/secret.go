@@ -0,0 +1,173 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+const (
+	// MasterKeyEnv 主密钥环境变量
+	MasterKeyEnv = "GVA_MASTER_KEY"
+	// MasterKeyFileEnv 主密钥文件路径环境变量, 文件内容为主密钥, 适用于 docker/k8s secret 挂载
+	MasterKeyFileEnv = "GVA_MASTER_KEY_FILE"
+
+	encPrefix = "ENC("
+	encSuffix = ")"
+)
+
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+//@function: MasterKey
+//@description: 读取主密钥, 优先使用环境变量 GVA_MASTER_KEY, 其次读取 GVA_MASTER_KEY_FILE 指向的文件
+//@return: string, error
+
+func MasterKey() (string, error) {
+	if key := os.Getenv(MasterKeyEnv); key != "" {
+		return key, nil
+	}
+	if path := os.Getenv(MasterKeyFileEnv); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("读取主密钥文件失败: %w", err)
+		}
+		if key := strings.TrimSpace(string(data)); key != "" {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("配置中包含 ENC() 加密值, 请通过 %s 或 %s 提供主密钥", MasterKeyEnv, MasterKeyFileEnv)
+}
+
+func newAEAD(masterKey string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(masterKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+//@function: Encrypt
+//@description: 使用主密钥加密明文, 返回可直接写入配置文件的 ENC(...) 值
+//@param: plaintext string, masterKey string
+//@return: string, error
+
+func Encrypt(plaintext, masterKey string) (string, error) {
+	aead, err := newAEAD(masterKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encPrefix + base64.StdEncoding.EncodeToString(sealed) + encSuffix, nil
+}
+
+// IsEncrypted 判断是否为 ENC(...) 加密值
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encPrefix) && strings.HasSuffix(value, encSuffix)
+}
+
+//@function: Decrypt
+//@description: 使用主密钥解密 ENC(...) 值
+//@param: value string, masterKey string
+//@return: string, error
+
+func Decrypt(value, masterKey string) (string, error) {
+	if !IsEncrypted(value) {
+		return "", errors.New("不是 ENC(...) 加密值")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, encPrefix), encSuffix))
+	if err != nil {
+		return "", fmt.Errorf("加密值格式错误: %w", err)
+	}
+	aead, err := newAEAD(masterKey)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("加密值格式错误")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("解密失败, 请检查主密钥")
+	}
+	return string(plaintext), nil
+}
+
+// ExpandEnv 替换 ${NAME} 与 ${NAME:-默认值}, 环境变量未设置且没有默认值时返回错误
+func ExpandEnv(value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+	var missing []string
+	result := envPattern.ReplaceAllStringFunc(value, func(match string) string {
+		groups := envPattern.FindStringSubmatch(match)
+		if env, ok := os.LookupEnv(groups[1]); ok {
+			return env
+		}
+		if groups[2] != "" {
+			return groups[3]
+		}
+		missing = append(missing, groups[1])
+		return match
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("环境变量 %s 未设置", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// Resolver 解析配置中的 ${ENV} 与 ENC(...) 值, 主密钥在首次遇到加密值时读取
+type Resolver struct {
+	once sync.Once
+	key  string
+	err  error
+}
+
+// Resolve 先替换环境变量, 结果为 ENC(...) 时再解密, 因此环境变量中也可以保存加密值
+func (r *Resolver) Resolve(value string) (string, error) {
+	value, err := ExpandEnv(value)
+	if err != nil {
+		return "", err
+	}
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	r.once.Do(func() {
+		r.key, r.err = MasterKey()
+	})
+	if r.err != nil {
+		return "", r.err
+	}
+	return Decrypt(value, r.key)
+}
+
+// DecodeHook 在 viper 解析配置时解析所有字符串字段, 并保留 viper 默认的时长与切片转换
+func DecodeHook() viper.DecoderConfigOption {
+	resolver := &Resolver{}
+	return viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		func(from, to reflect.Type, data interface{}) (interface{}, error) {
+			if from.Kind() != reflect.String || to.Kind() != reflect.String {
+				return data, nil
+			}
+			return resolver.Resolve(reflect.ValueOf(data).String())
+		},
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))
+}
@@ -0,0 +1,112 @@
+package secret
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	value, err := Encrypt("p@ssw0rd", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(value) {
+		t.Fatalf("加密结果应为 ENC(...), 实际 %s", value)
+	}
+	plaintext, err := Decrypt(value, "master")
+	if err != nil || plaintext != "p@ssw0rd" {
+		t.Fatalf("解密结果错误: %q, %v", plaintext, err)
+	}
+	if _, err = Decrypt(value, "other"); err == nil {
+		t.Fatal("主密钥错误时应解密失败")
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GVA_TEST_HOST", "db")
+	value, err := ExpandEnv("${GVA_TEST_HOST}:${GVA_TEST_PORT:-3306}")
+	if err != nil || value != "db:3306" {
+		t.Fatalf("环境变量替换错误: %q, %v", value, err)
+	}
+	if _, err = ExpandEnv("${GVA_TEST_MISSING}"); err == nil || !strings.Contains(err.Error(), "GVA_TEST_MISSING") {
+		t.Fatalf("未设置的环境变量应返回错误, 实际 %v", err)
+	}
+}
+
+func TestDecodeHook(t *testing.T) {
+	encrypted, err := Encrypt("secret-value", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(MasterKeyEnv, "master")
+	t.Setenv("GVA_TEST_USER", "root")
+
+	v := viper.New()
+	v.Set("user", "${GVA_TEST_USER}")
+	v.Set("password", encrypted)
+	v.Set("timeout", "5s")
+	var conf struct {
+		User     string        `mapstructure:"user"`
+		Password string        `mapstructure:"password"`
+		Timeout  time.Duration `mapstructure:"timeout"`
+	}
+	if err = v.Unmarshal(&conf, DecodeHook()); err != nil {
+		t.Fatal(err)
+	}
+	if conf.User != "root" || conf.Password != "secret-value" || conf.Timeout != 5*time.Second {
+		t.Fatalf("配置解析错误: %+v", conf)
+	}
+}
+
+type testConfig struct {
+	Name     string            `mapstructure:"name"`
+	Password string            `mapstructure:"password"`
+	Headers  map[string]string `mapstructure:"headers"`
+	DBList   []testDB          `mapstructure:"db-list"`
+}
+
+type testDB struct {
+	Alias    string `mapstructure:"alias"`
+	Password string `mapstructure:"password"`
+}
+
+func TestRedactRestore(t *testing.T) {
+	current := testConfig{
+		Name:     "gva",
+		Password: "plain",
+		Headers:  map[string]string{"Authorization": "token"},
+		DBList:   []testDB{{Alias: "a", Password: "pa"}},
+	}
+	raw := current
+	raw.Password = "ENC(xxx)"
+	raw.Headers = map[string]string{"Authorization": "${TOKEN}"}
+	raw.DBList = []testDB{{Alias: "a", Password: "pa"}}
+
+	redacted := Redact(current)
+	if redacted.Password != Redacted || redacted.Headers["Authorization"] != Redacted || redacted.DBList[0].Password != Redacted || redacted.Name != "gva" {
+		t.Fatalf("脱敏结果错误: %+v", redacted)
+	}
+	if current.Headers["Authorization"] != "token" || current.DBList[0].Password != "pa" {
+		t.Fatal("脱敏不应修改原配置")
+	}
+
+	// 未修改的字段还原为原始写法, 修改过的字段使用新值
+	redacted.Name = "new"
+	redacted.DBList[0].Password = "changed"
+	if err := Restore(&redacted, current, raw); err != nil {
+		t.Fatal(err)
+	}
+	if redacted.Password != "ENC(xxx)" || redacted.Headers["Authorization"] != "${TOKEN}" || redacted.DBList[0].Password != "changed" || redacted.Name != "new" {
+		t.Fatalf("还原结果错误: %+v", redacted)
+	}
+
+	// 列表长度变化后无法对应占位值
+	conf := Redact(current)
+	conf.DBList = append(conf.DBList, testDB{Alias: "b"})
+	if err := Restore(&conf, current, raw); err == nil {
+		t.Fatal("无法还原的占位值应返回错误")
+	}
+}
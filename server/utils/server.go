@@ -107,8 +107,8 @@ func InitRAM() (r Ram, err error) {
 //@return: d Disk, err error
 
 func InitDisk() (d []Disk, err error) {
-	for i := range global.GVA_CONFIG.DiskList {
-		mp := global.GVA_CONFIG.DiskList[i].MountPoint
+	for i := range global.GVA_CONFIG.Load().DiskList {
+		mp := global.GVA_CONFIG.Load().DiskList[i].MountPoint
 		if u, err := disk.Usage(mp); err != nil {
 			return d, err
 		} else {
@@ -1,13 +1,36 @@
 package utils
 
 import (
+	"fmt"
 	"sync"
+
+	"server/config"
+	"server/global"
+
+	"go.uber.org/zap"
 )
 
 // SystemEvents 定义系统级事件处理
 type SystemEvents struct {
 	reloadHandlers []func() error
+	configHandlers []configHandler
 	mu             sync.RWMutex
+	// applyMu 保证同一时间只应用一份配置
+	applyMu sync.Mutex
+}
+
+// ConfigReload 配置项变更的处理结果, 所有处理函数都校验通过后才会替换全局配置
+type ConfigReload struct {
+	Apply func() // 新配置生效后调用, 用于切换到已创建的资源
+	Abort func() // 其他配置项校验失败、放弃本次变更时调用, 用于释放已创建的资源
+}
+
+// ConfigReloadHandler 配置项变更处理函数, 在新配置生效前调用, 返回错误时放弃本次变更
+type ConfigReloadHandler func(oldConf, newConf *config.Server) (ConfigReload, error)
+
+type configHandler struct {
+	sections []string
+	handler  ConfigReloadHandler
 }
 
 // 全局事件管理器
@@ -22,13 +45,90 @@ func (e *SystemEvents) RegisterReloadHandler(handler func() error) {
 
 // TriggerReload 触发所有注册的重载处理函数
 func (e *SystemEvents) TriggerReload() error {
+	// 复制后释放锁, 处理函数中会调用 ApplyConfig 再次加锁
 	e.mu.RLock()
-	defer e.mu.RUnlock()
-	
-	for _, handler := range e.reloadHandlers {
+	handlers := make([]func() error, len(e.reloadHandlers))
+	copy(handlers, e.reloadHandlers)
+	e.mu.RUnlock()
+
+	for _, handler := range handlers {
 		if err := handler(); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// RegisterConfigHandler 注册配置项变更处理函数, sections 中任一配置项变化时调用一次
+func (e *SystemEvents) RegisterConfigHandler(handler ConfigReloadHandler, sections ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.configHandlers = append(e.configHandlers, configHandler{sections: sections, handler: handler})
+}
+
+//@function: ApplyConfig
+//@description: 对比新旧配置, 调用变化配置项的处理函数, 全部通过后替换全局配置; 任一处理函数失败时保持原配置
+//@param: newConf config.Server
+//@return: changed []string, err error
+
+func (e *SystemEvents) ApplyConfig(newConf config.Server) (changed []string, err error) {
+	e.applyMu.Lock()
+	defer e.applyMu.Unlock()
+
+	oldConf := *global.GVA_CONFIG.Load()
+	changed = config.ChangedSections(oldConf, newConf)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	changedSet := make(map[string]bool, len(changed))
+	for _, section := range changed {
+		changedSet[section] = true
+	}
+
+	e.mu.RLock()
+	handlers := make([]configHandler, len(e.configHandlers))
+	copy(handlers, e.configHandlers)
+	e.mu.RUnlock()
+
+	handled := make(map[string]bool, len(changed))
+	reloads := make([]ConfigReload, 0, len(handlers))
+	for _, h := range handlers {
+		matched := false
+		for _, section := range h.sections {
+			if changedSet[section] {
+				matched, handled[section] = true, true
+			}
+		}
+		if !matched {
+			continue
+		}
+		reload, err := h.handler(&oldConf, &newConf)
+		if err != nil {
+			for _, r := range reloads {
+				if r.Abort != nil {
+					r.Abort()
+				}
+			}
+			return changed, fmt.Errorf("配置项 %v 校验失败: %w", h.sections, err)
+		}
+		reloads = append(reloads, reload)
+	}
+
+	global.GVA_CONFIG.Store(&newConf)
+	for _, r := range reloads {
+		if r.Apply != nil {
+			r.Apply()
+		}
+	}
+
+	var restart []string
+	for _, section := range changed {
+		if !handled[section] {
+			restart = append(restart, section)
+		}
+	}
+	if len(restart) > 0 && global.GVA_LOG != nil {
+		global.GVA_LOG.Warn("以下配置项不支持热加载, 需重启后完全生效", zap.Strings("sections", restart))
+	}
+	return changed, nil
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"server/config"
+	"server/global"
+)
+
+func TestSystemEventsApplyConfig(t *testing.T) {
+	origin := global.GVA_CONFIG.Load()
+	t.Cleanup(func() { global.GVA_CONFIG.Store(origin) })
+	global.GVA_CONFIG.Store(&config.Server{JWT: config.JWT{ExpiresTime: "7d"}, Captcha: config.Captcha{KeyLong: 6}})
+
+	events := &SystemEvents{}
+	var calls []string
+	var applied, aborted bool
+	events.RegisterConfigHandler(func(oldConf, newConf *config.Server) (ConfigReload, error) {
+		calls = append(calls, "jwt")
+		if oldConf.JWT.ExpiresTime != "7d" || newConf.JWT.ExpiresTime != "1d" {
+			t.Errorf("old=%s new=%s", oldConf.JWT.ExpiresTime, newConf.JWT.ExpiresTime)
+		}
+		return ConfigReload{
+			Apply: func() { applied = global.GVA_CONFIG.Load().JWT.ExpiresTime == "1d" },
+			Abort: func() { aborted = true },
+		}, nil
+	}, "jwt")
+	events.RegisterConfigHandler(func(_, newConf *config.Server) (ConfigReload, error) {
+		calls = append(calls, "captcha")
+		if newConf.Captcha.KeyLong <= 0 {
+			return ConfigReload{}, errors.New("invalid key-long")
+		}
+		return ConfigReload{}, nil
+	}, "captcha", "cors")
+
+	// 任一配置项校验失败时保持原配置, 并释放已创建的资源
+	newConf := *global.GVA_CONFIG.Load()
+	newConf.JWT.ExpiresTime = "1d"
+	newConf.Captcha.KeyLong = 0
+	changed, err := events.ApplyConfig(newConf)
+	if err == nil || !reflect.DeepEqual(changed, []string{"jwt", "captcha"}) {
+		t.Fatalf("ApplyConfig() = %v, %v", changed, err)
+	}
+	if global.GVA_CONFIG.Load().JWT.ExpiresTime != "7d" || !aborted || applied {
+		t.Fatalf("校验失败后不应替换配置: expires=%s aborted=%v applied=%v", global.GVA_CONFIG.Load().JWT.ExpiresTime, aborted, applied)
+	}
+
+	// 只调用发生变化的配置项的处理函数
+	calls = nil
+	newConf.Captcha.KeyLong = 6
+	if changed, err = events.ApplyConfig(newConf); err != nil || !reflect.DeepEqual(changed, []string{"jwt"}) {
+		t.Fatalf("ApplyConfig() = %v, %v", changed, err)
+	}
+	if !reflect.DeepEqual(calls, []string{"jwt"}) || !applied {
+		t.Errorf("calls=%v applied=%v", calls, applied)
+	}
+
+	// 配置未变化时不调用任何处理函数
+	calls = nil
+	if changed, err = events.ApplyConfig(newConf); err != nil || len(changed) != 0 || len(calls) != 0 {
+		t.Errorf("ApplyConfig() = %v, %v, calls=%v", changed, err, calls)
+	}
+}
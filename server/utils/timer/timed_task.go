@@ -1,7 +1,9 @@
 package timer
 
 import (
+	"context"
 	"github.com/robfig/cron/v3"
+	"server/utils/metrics"
 	"sync"
 )
 
@@ -32,6 +34,8 @@ type Timer interface {
 	Clear(cronName string)
 	// 停止所有的cron
 	Close()
+	// 停止所有的cron并等待执行中的任务结束, ctx 结束时不再等待
+	Shutdown(ctx context.Context) error
 }
 
 type task struct {
@@ -62,7 +66,7 @@ func (t *timer) AddTaskByFunc(cronName string, spec string, fun func(), taskName
 			tasks: tasks,
 		}
 	}
-	id, err := t.cronList[cronName].corn.AddFunc(spec, fun)
+	id, err := t.cronList[cronName].corn.AddFunc(spec, metrics.WrapJob(cronName, taskName, fun))
 	t.cronList[cronName].corn.Start()
 	t.cronList[cronName].tasks[id] = &task{
 		EntryID:  id,
@@ -84,7 +88,7 @@ func (t *timer) AddTaskByFuncWithSecond(cronName string, spec string, fun func()
 			tasks: tasks,
 		}
 	}
-	id, err := t.cronList[cronName].corn.AddFunc(spec, fun)
+	id, err := t.cronList[cronName].corn.AddFunc(spec, metrics.WrapJob(cronName, taskName, fun))
 	t.cronList[cronName].corn.Start()
 	t.cronList[cronName].tasks[id] = &task{
 		EntryID:  id,
@@ -105,7 +109,7 @@ func (t *timer) AddTaskByJob(cronName string, spec string, job interface{ Run()
 			tasks: tasks,
 		}
 	}
-	id, err := t.cronList[cronName].corn.AddJob(spec, job)
+	id, err := t.cronList[cronName].corn.AddJob(spec, cron.FuncJob(metrics.WrapJob(cronName, taskName, job.Run)))
 	t.cronList[cronName].corn.Start()
 	t.cronList[cronName].tasks[id] = &task{
 		EntryID:  id,
@@ -127,7 +131,7 @@ func (t *timer) AddTaskByJobWithSeconds(cronName string, spec string, job interf
 			tasks: tasks,
 		}
 	}
-	id, err := t.cronList[cronName].corn.AddJob(spec, job)
+	id, err := t.cronList[cronName].corn.AddJob(spec, cron.FuncJob(metrics.WrapJob(cronName, taskName, job.Run)))
 	t.cronList[cronName].corn.Start()
 	t.cronList[cronName].tasks[id] = &task{
 		EntryID:  id,
@@ -224,6 +228,24 @@ func (t *timer) Close() {
 	}
 }
 
+// Shutdown 停止调度并等待执行中的任务结束
+func (t *timer) Shutdown(ctx context.Context) error {
+	t.Lock()
+	running := make([]context.Context, 0, len(t.cronList))
+	for _, v := range t.cronList {
+		running = append(running, v.corn.Stop())
+	}
+	t.Unlock()
+	for _, done := range running {
+		select {
+		case <-done.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 func NewTimerTask() Timer {
 	return &timer{cronList: make(map[string]*taskManager)}
 }
@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
+)
+
+// GormPlugin 数据库链路追踪插件, 不记录 SQL 参数以免泄露敏感数据
+func GormPlugin(dbName string) gorm.Plugin {
+	return otelgorm.NewPlugin(otelgorm.WithDBName(dbName), otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())
+}
+
+// InstrumentRedis 为 Redis 客户端添加链路追踪钩子
+func InstrumentRedis(client redis.UniversalClient) error {
+	return redisotel.InstrumentTracing(client)
+}
+
+// GormLogger 在 SQL 日志前追加 trace_id 注释, 便于从日志定位链路; 不影响实际执行的语句
+type GormLogger struct {
+	logger.Interface
+}
+
+func (l GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return GormLogger{Interface: l.Interface.LogMode(level)}
+}
+
+func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	traceID := TraceID(ctx)
+	if traceID == "" {
+		l.Interface.Trace(ctx, begin, fc, err)
+		return
+	}
+	l.Interface.Trace(ctx, begin, func() (string, int64) {
+		sql, rows := fc()
+		return "/* trace_id=" + traceID + " */ " + sql, rows
+	}, err)
+}
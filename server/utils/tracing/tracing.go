@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"server/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultServiceName 未配置服务名时上报的服务名
+	DefaultServiceName = "gin-vue-admin"
+
+	instrumentationName = "server"
+)
+
+var (
+	provider *sdktrace.TracerProvider
+	mu       sync.Mutex
+)
+
+//@function: Init
+//@description: 按配置创建 OTLP 导出器并设置全局 TracerProvider 与 W3C 传播器
+//@param: conf config.Trace
+//@return: error
+
+func Init(conf config.Trace) error {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch conf.Exporter {
+	case "otlp-http":
+		options := []otlptracehttp.Option{otlptracehttp.WithHeaders(conf.Headers)}
+		if conf.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), options...)
+	case "", "otlp-grpc":
+		options := []otlptracegrpc.Option{otlptracegrpc.WithHeaders(conf.Headers)}
+		if conf.Endpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), options...)
+	default:
+		return errors.New("不支持的链路追踪导出协议: " + conf.Exporter)
+	}
+	if err != nil {
+		return err
+	}
+	Setup(conf, sdktrace.WithBatcher(exporter))
+	return nil
+}
+
+//@function: Setup
+//@description: 使用指定的 span 处理器设置全局 TracerProvider, 测试中可传入 sdktrace.WithSyncer(tracetest.NewInMemoryExporter())
+//@param: conf config.Trace, options ...sdktrace.TracerProviderOption
+//@return: *sdktrace.TracerProvider
+
+func Setup(conf config.Trace, options ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	serviceName := conf.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	sampler := sdktrace.AlwaysSample()
+	if conf.SampleRatio > 0 && conf.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(conf.SampleRatio)
+	}
+	options = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+	}, options...)
+	tp := sdktrace.NewTracerProvider(options...)
+
+	mu.Lock()
+	previous := provider
+	provider = tp
+	mu.Unlock()
+	// 重载系统时关闭旧的 TracerProvider, 导出其缓冲中的 span
+	if previous != nil {
+		go previous.Shutdown(context.Background())
+	}
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp
+}
+
+// Shutdown 导出缓冲中的 span 并关闭 TracerProvider, 未开启链路追踪时直接返回
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	tp := provider
+	provider = nil
+	mu.Unlock()
+	if tp == nil {
+		return nil
+	}
+	return tp.Shutdown(ctx)
+}
+
+// Start 以 ctx 中的 span 为父节点创建子 span, 未开启链路追踪时返回不记录的 span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End 结束 span, err 不为空时记录错误并将状态置为 Error
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID 返回 ctx 中的 trace id, 没有有效 span 时返回空字符串
+func TraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// ZapFields 返回 ctx 中的 trace_id 与 span_id 日志字段, 没有有效 span 时返回 nil
+func ZapFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", spanContext.TraceID().String()),
+		zap.String("span_id", spanContext.SpanID().String()),
+	}
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"server/config"
+
+	"github.com/glebarez/sqlite"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	Setup(config.Trace{ServiceName: "test"}, sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() {
+		_ = Shutdown(context.Background())
+	})
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Use(GormPlugin("test")); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, span := Start(context.Background(), "request")
+	traceID := TraceID(ctx)
+	if traceID == "" || len(ZapFields(ctx)) != 2 {
+		t.Fatalf("TraceID() = %q, ZapFields() = %v", traceID, ZapFields(ctx))
+	}
+	var n int
+	db.WithContext(ctx).Raw("SELECT 1").Scan(&n)
+	_, child := Start(ctx, "email.send")
+	End(child, errors.New("smtp down"))
+	End(span, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("应导出 3 个 span, 实际 %d", len(spans))
+	}
+	for _, s := range spans {
+		if s.SpanContext.TraceID().String() != traceID {
+			t.Errorf("span %s 不在同一链路中", s.Name)
+		}
+		if s.Name == "email.send" && s.Status.Code != codes.Error {
+			t.Errorf("出错的 span 状态应为 Error, 实际 %v", s.Status)
+		}
+	}
+	if TraceID(context.Background()) != "" || ZapFields(context.Background()) != nil {
+		t.Error("没有 span 时不应返回 trace id")
+	}
+}
@@ -28,7 +28,7 @@ func (*AliyunOSS) UploadFile(file *multipart.FileHeader) (string, string, error)
 	defer f.Close() // 创建文件 defer 关闭
 	// 上传阿里云路径 文件名格式 自己可以改 建议保证唯一性
 	// yunFileTmpPath := filepath.Join("uploads", time.Now().Format("2006-01-02")) + "/" + file.Filename
-	yunFileTmpPath := global.GVA_CONFIG.AliyunOSS.BasePath + "/" + "uploads" + "/" + time.Now().Format("2006-01-02") + "/" + file.Filename
+	yunFileTmpPath := global.GVA_CONFIG.Load().AliyunOSS.BasePath + "/" + "uploads" + "/" + time.Now().Format("2006-01-02") + "/" + file.Filename
 
 	// 上传文件流。
 	err = bucket.PutObject(yunFileTmpPath, f)
@@ -37,7 +37,7 @@ func (*AliyunOSS) UploadFile(file *multipart.FileHeader) (string, string, error)
 		return "", "", errors.New("function formUploader.Put() Failed, err:" + err.Error())
 	}
 
-	return global.GVA_CONFIG.AliyunOSS.BucketUrl + "/" + yunFileTmpPath, yunFileTmpPath, nil
+	return global.GVA_CONFIG.Load().AliyunOSS.BucketUrl + "/" + yunFileTmpPath, yunFileTmpPath, nil
 }
 
 func (*AliyunOSS) DeleteFile(key string) error {
@@ -60,13 +60,13 @@ func (*AliyunOSS) DeleteFile(key string) error {
 
 func NewBucket() (*oss.Bucket, error) {
 	// 创建OSSClient实例。
-	client, err := oss.New(global.GVA_CONFIG.AliyunOSS.Endpoint, global.GVA_CONFIG.AliyunOSS.AccessKeyId, global.GVA_CONFIG.AliyunOSS.AccessKeySecret)
+	client, err := oss.New(global.GVA_CONFIG.Load().AliyunOSS.Endpoint, global.GVA_CONFIG.Load().AliyunOSS.AccessKeyId, global.GVA_CONFIG.Load().AliyunOSS.AccessKeySecret)
 	if err != nil {
 		return nil, err
 	}
 
 	// 获取存储空间。
-	bucket, err := client.Bucket(global.GVA_CONFIG.AliyunOSS.BucketName)
+	bucket, err := client.Bucket(global.GVA_CONFIG.Load().AliyunOSS.BucketName)
 	if err != nil {
 		return nil, err
 	}
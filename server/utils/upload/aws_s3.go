@@ -30,7 +30,7 @@ func (*AwsS3) UploadFile(file *multipart.FileHeader) (string, string, error) {
 	uploader := s3manager.NewUploader(session)
 
 	fileKey := fmt.Sprintf("%d%s", time.Now().Unix(), file.Filename)
-	filename := global.GVA_CONFIG.AwsS3.PathPrefix + "/" + fileKey
+	filename := global.GVA_CONFIG.Load().AwsS3.PathPrefix + "/" + fileKey
 	f, openError := file.Open()
 	if openError != nil {
 		global.GVA_LOG.Error("function file.Open() failed", zap.Any("err", openError.Error()))
@@ -39,7 +39,7 @@ func (*AwsS3) UploadFile(file *multipart.FileHeader) (string, string, error) {
 	defer f.Close() // 创建文件 defer 关闭
 
 	_, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(global.GVA_CONFIG.AwsS3.Bucket),
+		Bucket: aws.String(global.GVA_CONFIG.Load().AwsS3.Bucket),
 		Key:    aws.String(filename),
 		Body:   f,
 	})
@@ -48,7 +48,7 @@ func (*AwsS3) UploadFile(file *multipart.FileHeader) (string, string, error) {
 		return "", "", err
 	}
 
-	return global.GVA_CONFIG.AwsS3.BaseURL + "/" + filename, fileKey, nil
+	return global.GVA_CONFIG.Load().AwsS3.BaseURL + "/" + filename, fileKey, nil
 }
 
 //@author: [WqyJh](https://github.com/WqyJh)
@@ -61,8 +61,8 @@ func (*AwsS3) UploadFile(file *multipart.FileHeader) (string, string, error) {
 func (*AwsS3) DeleteFile(key string) error {
 	session := newSession()
 	svc := s3.New(session)
-	filename := global.GVA_CONFIG.AwsS3.PathPrefix + "/" + key
-	bucket := global.GVA_CONFIG.AwsS3.Bucket
+	filename := global.GVA_CONFIG.Load().AwsS3.PathPrefix + "/" + key
+	bucket := global.GVA_CONFIG.Load().AwsS3.Bucket
 
 	_, err := svc.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -83,13 +83,13 @@ func (*AwsS3) DeleteFile(key string) error {
 // newSession Create S3 session
 func newSession() *session.Session {
 	sess, _ := session.NewSession(&aws.Config{
-		Region:           aws.String(global.GVA_CONFIG.AwsS3.Region),
-		Endpoint:         aws.String(global.GVA_CONFIG.AwsS3.Endpoint), //minio在这里设置地址,可以兼容
-		S3ForcePathStyle: aws.Bool(global.GVA_CONFIG.AwsS3.S3ForcePathStyle),
-		DisableSSL:       aws.Bool(global.GVA_CONFIG.AwsS3.DisableSSL),
+		Region:           aws.String(global.GVA_CONFIG.Load().AwsS3.Region),
+		Endpoint:         aws.String(global.GVA_CONFIG.Load().AwsS3.Endpoint), //minio在这里设置地址,可以兼容
+		S3ForcePathStyle: aws.Bool(global.GVA_CONFIG.Load().AwsS3.S3ForcePathStyle),
+		DisableSSL:       aws.Bool(global.GVA_CONFIG.Load().AwsS3.DisableSSL),
 		Credentials: credentials.NewStaticCredentials(
-			global.GVA_CONFIG.AwsS3.SecretID,
-			global.GVA_CONFIG.AwsS3.SecretKey,
+			global.GVA_CONFIG.Load().AwsS3.SecretID,
+			global.GVA_CONFIG.Load().AwsS3.SecretKey,
 			"",
 		),
 	})
@@ -22,7 +22,7 @@ func (c *CloudflareR2) UploadFile(file *multipart.FileHeader) (fileUrl string, f
 	client := s3manager.NewUploader(session)
 
 	fileKey := fmt.Sprintf("%d_%s", time.Now().Unix(), file.Filename)
-	fileName = fmt.Sprintf("%s/%s", global.GVA_CONFIG.CloudflareR2.Path, fileKey)
+	fileName = fmt.Sprintf("%s/%s", global.GVA_CONFIG.Load().CloudflareR2.Path, fileKey)
 	f, openError := file.Open()
 	if openError != nil {
 		global.GVA_LOG.Error("function file.Open() failed", zap.Any("err", openError.Error()))
@@ -31,7 +31,7 @@ func (c *CloudflareR2) UploadFile(file *multipart.FileHeader) (fileUrl string, f
 	defer f.Close() // 创建文件 defer 关闭
 
 	input := &s3manager.UploadInput{
-		Bucket: aws.String(global.GVA_CONFIG.CloudflareR2.Bucket),
+		Bucket: aws.String(global.GVA_CONFIG.Load().CloudflareR2.Bucket),
 		Key:    aws.String(fileName),
 		Body:   f,
 	}
@@ -42,7 +42,7 @@ func (c *CloudflareR2) UploadFile(file *multipart.FileHeader) (fileUrl string, f
 		return "", "", err
 	}
 
-	return fmt.Sprintf("%s/%s", global.GVA_CONFIG.CloudflareR2.BaseURL,
+	return fmt.Sprintf("%s/%s", global.GVA_CONFIG.Load().CloudflareR2.BaseURL,
 			fileName),
 		fileKey,
 		nil
@@ -51,8 +51,8 @@ func (c *CloudflareR2) UploadFile(file *multipart.FileHeader) (fileUrl string, f
 func (c *CloudflareR2) DeleteFile(key string) error {
 	session := newSession()
 	svc := s3.New(session)
-	filename := global.GVA_CONFIG.CloudflareR2.Path + "/" + key
-	bucket := global.GVA_CONFIG.CloudflareR2.Bucket
+	filename := global.GVA_CONFIG.Load().CloudflareR2.Path + "/" + key
+	bucket := global.GVA_CONFIG.Load().CloudflareR2.Bucket
 
 	_, err := svc.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -71,14 +71,14 @@ func (c *CloudflareR2) DeleteFile(key string) error {
 }
 
 func (*CloudflareR2) newSession() *session.Session {
-	endpoint := fmt.Sprintf("%s.r2.cloudflarestorage.com", global.GVA_CONFIG.CloudflareR2.AccountID)
+	endpoint := fmt.Sprintf("%s.r2.cloudflarestorage.com", global.GVA_CONFIG.Load().CloudflareR2.AccountID)
 
 	return session.Must(session.NewSession(&aws.Config{
 		Region:   aws.String("auto"),
 		Endpoint: aws.String(endpoint),
 		Credentials: credentials.NewStaticCredentials(
-			global.GVA_CONFIG.CloudflareR2.AccessKeyID,
-			global.GVA_CONFIG.CloudflareR2.SecretAccessKey,
+			global.GVA_CONFIG.Load().CloudflareR2.AccessKeyID,
+			global.GVA_CONFIG.Load().CloudflareR2.SecretAccessKey,
 			"",
 		),
 	}))
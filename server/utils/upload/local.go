@@ -37,14 +37,14 @@ func (*Local) UploadFile(file *multipart.FileHeader) (string, string, error) {
 	// 拼接新文件名
 	filename := name + "_" + time.Now().Format("20060102150405") + ext
 	// 尝试创建此路径
-	mkdirErr := os.MkdirAll(global.GVA_CONFIG.Local.StorePath, os.ModePerm)
+	mkdirErr := os.MkdirAll(global.GVA_CONFIG.Load().Local.StorePath, os.ModePerm)
 	if mkdirErr != nil {
 		global.GVA_LOG.Error("function os.MkdirAll() failed", zap.Any("err", mkdirErr.Error()))
 		return "", "", errors.New("function os.MkdirAll() failed, err:" + mkdirErr.Error())
 	}
 	// 拼接路径和文件名
-	p := global.GVA_CONFIG.Local.StorePath + "/" + filename
-	filepath := global.GVA_CONFIG.Local.Path + "/" + filename
+	p := global.GVA_CONFIG.Load().Local.StorePath + "/" + filename
+	filepath := global.GVA_CONFIG.Load().Local.Path + "/" + filename
 
 	f, openError := file.Open() // 读取文件
 	if openError != nil {
@@ -89,7 +89,7 @@ func (*Local) DeleteFile(key string) error {
 		return errors.New("非法的key")
 	}
 
-	p := filepath.Join(global.GVA_CONFIG.Local.StorePath, key)
+	p := filepath.Join(global.GVA_CONFIG.Load().Local.StorePath, key)
 
 	// 检查文件是否存在
 	if _, err := os.Stat(p); os.IsNotExist(err) {
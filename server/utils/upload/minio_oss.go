@@ -70,10 +70,10 @@ func (m *Minio) UploadFile(file *multipart.FileHeader) (filePathres, key string,
 	// 对文件名进行加密存储
 	ext := filepath.Ext(file.Filename)
 	filename := utils.MD5V([]byte(strings.TrimSuffix(file.Filename, ext))) + ext
-	if global.GVA_CONFIG.Minio.BasePath == "" {
+	if global.GVA_CONFIG.Load().Minio.BasePath == "" {
 		filePathres = "uploads" + "/" + time.Now().Format("2006-01-02") + "/" + filename
 	} else {
-		filePathres = global.GVA_CONFIG.Minio.BasePath + "/" + time.Now().Format("2006-01-02") + "/" + filename
+		filePathres = global.GVA_CONFIG.Load().Minio.BasePath + "/" + time.Now().Format("2006-01-02") + "/" + filename
 	}
 
 	// 设置超时10分钟
@@ -81,12 +81,12 @@ func (m *Minio) UploadFile(file *multipart.FileHeader) (filePathres, key string,
 	defer cancel()
 
 	// Upload the file with PutObject   大文件自动切换为分片上传
-	info, err := m.Client.PutObject(ctx, global.GVA_CONFIG.Minio.BucketName, filePathres, &filecontent, file.Size, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	info, err := m.Client.PutObject(ctx, global.GVA_CONFIG.Load().Minio.BucketName, filePathres, &filecontent, file.Size, minio.PutObjectOptions{ContentType: "application/octet-stream"})
 	if err != nil {
 		global.GVA_LOG.Error("上传文件到minio失败", zap.Any("err", err.Error()))
 		return "", "", errors.New("上传文件到minio失败, err:" + err.Error())
 	}
-	return global.GVA_CONFIG.Minio.BucketUrl + "/" + info.Key, filePathres, nil
+	return global.GVA_CONFIG.Load().Minio.BucketUrl + "/" + info.Key, filePathres, nil
 }
 
 func (m *Minio) DeleteFile(key string) error {
@@ -13,7 +13,7 @@ var HuaWeiObs = new(Obs)
 type Obs struct{}
 
 func NewHuaWeiObsClient() (client *obs.ObsClient, err error) {
-	return obs.New(global.GVA_CONFIG.HuaWeiObs.AccessKey, global.GVA_CONFIG.HuaWeiObs.SecretKey, global.GVA_CONFIG.HuaWeiObs.Endpoint)
+	return obs.New(global.GVA_CONFIG.Load().HuaWeiObs.AccessKey, global.GVA_CONFIG.Load().HuaWeiObs.SecretKey, global.GVA_CONFIG.Load().HuaWeiObs.Endpoint)
 }
 
 func (o *Obs) UploadFile(file *multipart.FileHeader) (string, string, error) {
@@ -27,7 +27,7 @@ func (o *Obs) UploadFile(file *multipart.FileHeader) (string, string, error) {
 	input := &obs.PutObjectInput{
 		PutObjectBasicInput: obs.PutObjectBasicInput{
 			ObjectOperationInput: obs.ObjectOperationInput{
-				Bucket: global.GVA_CONFIG.HuaWeiObs.Bucket,
+				Bucket: global.GVA_CONFIG.Load().HuaWeiObs.Bucket,
 				Key:    filename,
 			},
 			HttpHeader: obs.HttpHeader{
@@ -47,7 +47,7 @@ func (o *Obs) UploadFile(file *multipart.FileHeader) (string, string, error) {
 	if err != nil {
 		return "", "", errors.Wrap(err, "文件上传失败!")
 	}
-	filepath := global.GVA_CONFIG.HuaWeiObs.Path + "/" + filename
+	filepath := global.GVA_CONFIG.Load().HuaWeiObs.Path + "/" + filename
 	return filepath, filename, err
 }
 
@@ -57,7 +57,7 @@ func (o *Obs) DeleteFile(key string) error {
 		return errors.Wrap(err, "获取华为对象存储对象失败!")
 	}
 	input := &obs.DeleteObjectInput{
-		Bucket: global.GVA_CONFIG.HuaWeiObs.Bucket,
+		Bucket: global.GVA_CONFIG.Load().HuaWeiObs.Bucket,
 		Key:    key,
 	}
 	var output *obs.DeleteObjectOutput
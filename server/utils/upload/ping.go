@@ -0,0 +1,87 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"server/global"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/qiniu/go-sdk/v7/auth/qbox"
+	"github.com/qiniu/go-sdk/v7/storage"
+)
+
+//@function: Ping
+//@description: 探测当前配置的对象存储是否可用, 供就绪检查使用; 不支持 context 的 SDK 在超时后直接返回, 不等待请求结束
+//@param: ctx context.Context
+//@return: error
+
+func Ping(ctx context.Context) error {
+	conf := global.GVA_CONFIG.Load()
+	switch conf.System.OssType {
+	case "qiniu":
+		return withContext(ctx, func() error {
+			mac := qbox.NewMac(conf.Qiniu.AccessKey, conf.Qiniu.SecretKey)
+			_, err := storage.NewBucketManager(mac, qiniuConfig()).GetBucketInfo(conf.Qiniu.Bucket)
+			return err
+		})
+	case "tencent-cos":
+		_, err := NewClient().Bucket.Head(ctx)
+		return err
+	case "aliyun-oss":
+		return withContext(ctx, func() error {
+			client, err := oss.New(conf.AliyunOSS.Endpoint, conf.AliyunOSS.AccessKeyId, conf.AliyunOSS.AccessKeySecret)
+			if err != nil {
+				return err
+			}
+			_, err = client.GetBucketInfo(conf.AliyunOSS.BucketName)
+			return err
+		})
+	case "huawei-obs":
+		return withContext(ctx, func() error {
+			client, err := NewHuaWeiObsClient()
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			_, err = client.HeadBucket(conf.HuaWeiObs.Bucket)
+			return err
+		})
+	case "aws-s3":
+		_, err := s3.New(newSession()).HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(conf.AwsS3.Bucket)})
+		return err
+	case "cloudflare-r2":
+		_, err := s3.New((&CloudflareR2{}).newSession()).HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(conf.CloudflareR2.Bucket)})
+		return err
+	case "minio":
+		client, err := GetMinio(conf.Minio.Endpoint, conf.Minio.AccessKeyId, conf.Minio.AccessKeySecret, conf.Minio.BucketName, conf.Minio.UseSSL)
+		if err != nil {
+			return err
+		}
+		exists, err := client.Client.BucketExists(ctx, conf.Minio.BucketName)
+		if err == nil && !exists {
+			err = errors.New("bucket " + conf.Minio.BucketName + " 不存在")
+		}
+		return err
+	default:
+		// 与上传时一致, 存储目录不存在时创建
+		return os.MkdirAll(conf.Local.StorePath, os.ModePerm)
+	}
+}
+
+// withContext 在 ctx 结束时放弃等待 fn 的结果
+func withContext(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
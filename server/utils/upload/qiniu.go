@@ -25,8 +25,8 @@ type Qiniu struct{}
 //@return: string, string, error
 
 func (*Qiniu) UploadFile(file *multipart.FileHeader) (string, string, error) {
-	putPolicy := storage.PutPolicy{Scope: global.GVA_CONFIG.Qiniu.Bucket}
-	mac := qbox.NewMac(global.GVA_CONFIG.Qiniu.AccessKey, global.GVA_CONFIG.Qiniu.SecretKey)
+	putPolicy := storage.PutPolicy{Scope: global.GVA_CONFIG.Load().Qiniu.Bucket}
+	mac := qbox.NewMac(global.GVA_CONFIG.Load().Qiniu.AccessKey, global.GVA_CONFIG.Load().Qiniu.SecretKey)
 	upToken := putPolicy.UploadToken(mac)
 	cfg := qiniuConfig()
 	formUploader := storage.NewFormUploader(cfg)
@@ -46,7 +46,7 @@ func (*Qiniu) UploadFile(file *multipart.FileHeader) (string, string, error) {
 		global.GVA_LOG.Error("function formUploader.Put() failed", zap.Any("err", putErr.Error()))
 		return "", "", errors.New("function formUploader.Put() failed, err:" + putErr.Error())
 	}
-	return global.GVA_CONFIG.Qiniu.ImgPath + "/" + ret.Key, ret.Key, nil
+	return global.GVA_CONFIG.Load().Qiniu.ImgPath + "/" + ret.Key, ret.Key, nil
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -59,10 +59,10 @@ func (*Qiniu) UploadFile(file *multipart.FileHeader) (string, string, error) {
 //@return: error
 
 func (*Qiniu) DeleteFile(key string) error {
-	mac := qbox.NewMac(global.GVA_CONFIG.Qiniu.AccessKey, global.GVA_CONFIG.Qiniu.SecretKey)
+	mac := qbox.NewMac(global.GVA_CONFIG.Load().Qiniu.AccessKey, global.GVA_CONFIG.Load().Qiniu.SecretKey)
 	cfg := qiniuConfig()
 	bucketManager := storage.NewBucketManager(mac, cfg)
-	if err := bucketManager.Delete(global.GVA_CONFIG.Qiniu.Bucket, key); err != nil {
+	if err := bucketManager.Delete(global.GVA_CONFIG.Load().Qiniu.Bucket, key); err != nil {
 		global.GVA_LOG.Error("function bucketManager.Delete() failed", zap.Any("err", err.Error()))
 		return errors.New("function bucketManager.Delete() failed, err:" + err.Error())
 	}
@@ -77,10 +77,10 @@ func (*Qiniu) DeleteFile(key string) error {
 
 func qiniuConfig() *storage.Config {
 	cfg := storage.Config{
-		UseHTTPS:      global.GVA_CONFIG.Qiniu.UseHTTPS,
-		UseCdnDomains: global.GVA_CONFIG.Qiniu.UseCdnDomains,
+		UseHTTPS:      global.GVA_CONFIG.Load().Qiniu.UseHTTPS,
+		UseCdnDomains: global.GVA_CONFIG.Load().Qiniu.UseCdnDomains,
 	}
-	switch global.GVA_CONFIG.Qiniu.Zone { // 根据配置文件进行初始化空间对应的机房
+	switch global.GVA_CONFIG.Load().Qiniu.Zone { // 根据配置文件进行初始化空间对应的机房
 	case "ZoneHuadong":
 		cfg.Zone = &storage.ZoneHuadong
 	case "ZoneHuabei":
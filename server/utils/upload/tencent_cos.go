@@ -28,17 +28,17 @@ func (*TencentCOS) UploadFile(file *multipart.FileHeader) (string, string, error
 	defer f.Close() // 创建文件 defer 关闭
 	fileKey := fmt.Sprintf("%d%s", time.Now().Unix(), file.Filename)
 
-	_, err := client.Object.Put(context.Background(), global.GVA_CONFIG.TencentCOS.PathPrefix+"/"+fileKey, f, nil)
+	_, err := client.Object.Put(context.Background(), global.GVA_CONFIG.Load().TencentCOS.PathPrefix+"/"+fileKey, f, nil)
 	if err != nil {
 		panic(err)
 	}
-	return global.GVA_CONFIG.TencentCOS.BaseURL + "/" + global.GVA_CONFIG.TencentCOS.PathPrefix + "/" + fileKey, fileKey, nil
+	return global.GVA_CONFIG.Load().TencentCOS.BaseURL + "/" + global.GVA_CONFIG.Load().TencentCOS.PathPrefix + "/" + fileKey, fileKey, nil
 }
 
 // DeleteFile delete file form COS
 func (*TencentCOS) DeleteFile(key string) error {
 	client := NewClient()
-	name := global.GVA_CONFIG.TencentCOS.PathPrefix + "/" + key
+	name := global.GVA_CONFIG.Load().TencentCOS.PathPrefix + "/" + key
 	_, err := client.Object.Delete(context.Background(), name)
 	if err != nil {
 		global.GVA_LOG.Error("function bucketManager.Delete() failed", zap.Any("err", err.Error()))
@@ -49,12 +49,12 @@ func (*TencentCOS) DeleteFile(key string) error {
 
 // NewClient init COS client
 func NewClient() *cos.Client {
-	urlStr, _ := url.Parse("https://" + global.GVA_CONFIG.TencentCOS.Bucket + ".cos." + global.GVA_CONFIG.TencentCOS.Region + ".myqcloud.com")
+	urlStr, _ := url.Parse("https://" + global.GVA_CONFIG.Load().TencentCOS.Bucket + ".cos." + global.GVA_CONFIG.Load().TencentCOS.Region + ".myqcloud.com")
 	baseURL := &cos.BaseURL{BucketURL: urlStr}
 	client := cos.NewClient(baseURL, &http.Client{
 		Transport: &cos.AuthorizationTransport{
-			SecretID:  global.GVA_CONFIG.TencentCOS.SecretID,
-			SecretKey: global.GVA_CONFIG.TencentCOS.SecretKey,
+			SecretID:  global.GVA_CONFIG.Load().TencentCOS.SecretID,
+			SecretKey: global.GVA_CONFIG.Load().TencentCOS.SecretKey,
 		},
 	})
 	return client